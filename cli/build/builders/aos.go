@@ -2,11 +2,14 @@ package builders
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/fs"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
+	"runtime"
 	"strings"
 	"time"
 
@@ -14,10 +17,16 @@ import (
 	harlequinConfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
 	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
 	harlequinLuaUtils "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/lua_utils"
+	harlequinWasm "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/wasm"
+	"golang.org/x/sync/errgroup"
 )
 
 const (
 	AOSRepoURL = "https://github.com/permaweb/aos.git"
+
+	// AOSSqliteRepoURL is the sqlite-enabled AOS variant, selected via the
+	// "aos-sqlite" flavour in the build config
+	AOSSqliteRepoURL = "https://github.com/permaweb/aos-sqlite.git"
 )
 
 /*
@@ -81,6 +90,7 @@ type AOSBuilder struct {
 	configFilePath string
 	config         *harlequinConfig.Config
 	runner         *harlequinBuild.BuildRunner
+	commands       CommandRunner
 	callbacks      *BuildCallbacks
 }
 
@@ -111,6 +121,7 @@ func NewAOSBuilder(params AOSBuilderParams) *AOSBuilder {
 		config:         params.Config,
 		workspaceDir:   workspaceDir,
 		runner:         runner,
+		commands:       ExecCommandRunner{},
 		callbacks:      callbacks,
 	}
 }
@@ -145,10 +156,17 @@ func newAOSBuilderWithWorkspace(params AOSBuilderParams, workspaceDir string) *A
 		config:         params.Config,
 		workspaceDir:   workspaceDir,
 		runner:         runner,
+		commands:       ExecCommandRunner{},
 		callbacks:      callbacks,
 	}
 }
 
+// SetCommandRunner substitutes the runner used for docker and wasm-opt
+// invocations; tests use it to install a FakeCommandRunner
+func (b *AOSBuilder) SetCommandRunner(commands CommandRunner) {
+	b.commands = commands
+}
+
 // NewAOSBuilderWithDefaultCallbacks creates an AOSBuilder with default logging callbacks (convenience function)
 func NewAOSBuilderWithDefaultCallbacks(config *harlequinConfig.Config, configFilePath, entrypoint, outputDir string) *AOSBuilder {
 	return NewAOSBuilder(AOSBuilderParams{
@@ -172,7 +190,7 @@ func NewAOSBuilderSilent(config *harlequinConfig.Config, configFilePath, entrypo
 }
 
 // executeStep runs a build step and calls the appropriate callback
-func (b *AOSBuilder) executeStep(ctx context.Context, stepName string, callback func(ctx context.Context, info BuildStepInfo), stepFunc func() error) error {
+func executeStep(ctx context.Context, stepName string, callback func(ctx context.Context, info BuildStepInfo), stepFunc func() error) error {
 	startTime := time.Now()
 
 	var err error
@@ -192,6 +210,7 @@ func (b *AOSBuilder) executeStep(ctx context.Context, stepName string, callback
 			Metadata:  make(map[string]interface{}),
 		}
 
+		logStep(info)
 		if callback != nil {
 			callback(ctx, info)
 		}
@@ -202,32 +221,134 @@ func (b *AOSBuilder) executeStep(ctx context.Context, stepName string, callback
 	return err
 }
 
+// logStep emits a structured record for a completed build step
+func logStep(info BuildStepInfo) {
+	if info.Error != nil {
+		slog.Error("build step failed", "step", info.StepName, "duration", info.Duration, "error", info.Error)
+		return
+	}
+	slog.Info("build step completed", "step", info.StepName, "duration", info.Duration)
+}
+
+// executeStepWithMetadata runs a build step whose stepFunc can record metadata
+// that is passed through to the callback (e.g. before/after sizes)
+func executeStepWithMetadata(ctx context.Context, stepName string, callback func(ctx context.Context, info BuildStepInfo), stepFunc func(metadata map[string]interface{}) error) error {
+	startTime := time.Now()
+	metadata := make(map[string]interface{})
+
+	var err error
+	var success bool
+
+	defer func() {
+		endTime := time.Now()
+		duration := endTime.Sub(startTime)
+
+		info := BuildStepInfo{
+			StepName:  stepName,
+			StartTime: startTime,
+			EndTime:   endTime,
+			Duration:  duration,
+			Success:   success,
+			Error:     err,
+			Metadata:  metadata,
+		}
+
+		logStep(info)
+		if callback != nil {
+			callback(ctx, info)
+		}
+	}()
+
+	err = stepFunc(metadata)
+	success = err == nil
+	return err
+}
+
 // Build performs the complete AOS build process: prepares workspace, bundles Lua, injects code, and builds WASM
 func (b *AOSBuilder) Build(ctx context.Context) error {
-	// Step 1: Prepare AOS workspace (clone AOS repo and copy files)
-	if err := b.executeStep(ctx, "CopyAOSFiles", b.callbacks.OnCopyAOSFiles, func() error {
-		// Check if config file actually exists before trying to copy it
-		configFilePath := b.configFilePath
-		if _, err := os.Stat(configFilePath); os.IsNotExist(err) {
-			// Config file doesn't exist, don't try to copy it
-			configFilePath = ""
+	// The temp workspace must not outlive the build, even when a step fails
+	// or the context is cancelled mid-way; the visible Cleanup step below
+	// still reports the happy path
+	defer os.RemoveAll(b.workspaceDir)
+
+	// Offline builds fail fast, listing everything that would be needed
+	if Offline {
+		if missing := MissingOfflineArtifacts(ctx, b.config, b.workspaceDir, b.runner.GetImageName()); len(missing) > 0 {
+			return fmt.Errorf("offline mode: missing cached artifacts:\n  - %s\nrun 'harlequin prefetch' while online to populate the cache", strings.Join(missing, "\n  - "))
+		}
+	}
+
+	// Pre-pull the build image when requested, so a slow or failing pull
+	// shows docker's progress instead of stalling silently inside docker run
+	if PullImages {
+		if err := PullBuildImage(ctx, b.runner.GetImageName(), b.config.ModuleFormat); err != nil {
+			return err
 		}
-		return b.CopyAOSFiles(ctx, b.workspaceDir, configFilePath)
-	}); err != nil {
-		return fmt.Errorf("failed to prepare workspace: %w", err)
 	}
 
-	// Step 2: Bundle the Lua project
+	// Steps 1+2 run concurrently: preparing the AOS workspace is network
+	// bound (git clone) while transpiling and bundling the Lua project is
+	// CPU/IO bound, and neither depends on the other
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	// Step 1: Prepare AOS workspace (clone AOS repo and copy files)
+	group.Go(func() error {
+		if err := executeStep(groupCtx, "CopyAOSFiles", b.callbacks.OnCopyAOSFiles, func() error {
+			// Check if config file actually exists before trying to copy it
+			configFilePath := b.configFilePath
+			if _, err := os.Stat(configFilePath); os.IsNotExist(err) {
+				// Config file doesn't exist, don't try to copy it
+				configFilePath = ""
+			}
+			return b.CopyAOSFiles(groupCtx, b.workspaceDir, configFilePath)
+		}); err != nil {
+			return fmt.Errorf("failed to prepare workspace: %w", err)
+		}
+		return nil
+	})
+
+	entrypoint := b.entrypoint
 	var bundledCode string
-	if err := b.executeStep(ctx, "BundleLua", b.callbacks.OnBundleLua, func() error {
-		var err error
-		bundledCode, err = harlequinLuaUtils.Bundle(b.entrypoint)
+	var genDir string
+	defer func() {
+		if genDir != "" {
+			os.RemoveAll(genDir)
+		}
+	}()
+	group.Go(func() error {
+		// Step 1b: Transpile Teal sources to Lua when the entrypoint is a .tl file
+		if IsTealEntrypoint(entrypoint) {
+			if err := executeStep(groupCtx, "TranspileTeal", b.callbacks.OnTranspileTeal, func() error {
+				var err error
+				entrypoint, genDir, err = TranspileTealProject(groupCtx, b.entrypoint)
+				return err
+			}); err != nil {
+				return fmt.Errorf("failed to transpile Teal project: %w", err)
+			}
+		}
+
+		// Step 2: Bundle the Lua project
+		if err := executeStep(groupCtx, "BundleLua", b.callbacks.OnBundleLua, func() error {
+			var err error
+			bundledCode, err = harlequinLuaUtils.Bundle(entrypoint)
+			return err
+		}); err != nil {
+			return fmt.Errorf("failed to bundle Lua project: %w", err)
+		}
+		return nil
+	})
+
+	if err := group.Wait(); err != nil {
 		return err
-	}); err != nil {
-		return fmt.Errorf("failed to bundle Lua project: %w", err)
 	}
 
-	// Step 3: Write bundled code to workspace
+	// Step 3: Write bundled code to workspace. Parse it first, so a syntax
+	// error surfaces in milliseconds with a file/line message instead of
+	// dying cryptically inside the container during the WASM step. The
+	// check is advisory: the embedded parser speaks Lua 5.1, while AOS
+	// runs Lua 5.3, so 5.3-only syntax (bitwise operators, //, goto) must
+	// not block the build.
+	warnLuaSyntax("bundled.lua", bundledCode)
 	processDir := filepath.Join(b.workspaceDir, "aos-process")
 	bundledFilePath := filepath.Join(processDir, "bundled.lua")
 	if err := os.WriteFile(bundledFilePath, []byte(bundledCode), 0644); err != nil {
@@ -235,22 +356,49 @@ func (b *AOSBuilder) Build(ctx context.Context) error {
 	}
 
 	// Step 4: Inject the bundled code into the AOS process
-	if err := b.executeStep(ctx, "InjectLua", b.callbacks.OnInjectLua, func() error {
+	if err := executeStep(ctx, "InjectLua", b.callbacks.OnInjectLua, func() error {
 		options := NewDefaultBuildInjectionOptions(processDir, bundledFilePath, ".bundled")
-		return InjectBundledCode(options)
+		if b.config.Patches != nil {
+			options.Point = b.config.Patches.InjectionPoint
+			options.Marker = b.config.Patches.Marker
+		}
+		if err := InjectBundledCode(options); err != nil {
+			return err
+		}
+		// Apply the config's process patches on top of the injected require
+		return ApplyProcessPatches(options.ProcessFilePath, b.config.Patches, filepath.Dir(b.configFilePath))
 	}); err != nil {
 		return fmt.Errorf("failed to inject bundled code: %w", err)
 	}
 
+	// Step 4b: Stage declared native (C) modules so the container build
+	// compiles and links them into the wasm
+	if len(b.config.NativeModules) > 0 {
+		if err := executeStep(ctx, "PrepareNativeModules", b.callbacks.OnPrepareNative, func() error {
+			return PrepareNativeModules(b.config, filepath.Dir(b.configFilePath), processDir)
+		}); err != nil {
+			return fmt.Errorf("failed to prepare native modules: %w", err)
+		}
+	}
+
 	// Step 5: Build the project using Docker
-	if err := b.executeStep(ctx, "WasmCompile", b.callbacks.OnWasmCompile, func() error {
+	if err := executeStep(ctx, "WasmCompile", b.callbacks.OnWasmCompile, func() error {
 		return b.buildWithDocker(ctx, processDir)
 	}); err != nil {
 		return fmt.Errorf("failed to build WASM: %w", err)
 	}
 
+	// Step 5b: Optionally run wasm-opt on the built module
+	if b.config.Optimize {
+		if err := executeStepWithMetadata(ctx, "OptimizeWasm", b.callbacks.OnOptimizeWasm, func(metadata map[string]interface{}) error {
+			return optimizeWasm(ctx, b.commands, b.runner.GetImageName(), b.config.DockerUser, processDir, metadata)
+		}); err != nil {
+			return fmt.Errorf("failed to optimize WASM: %w", err)
+		}
+	}
+
 	// Step 6: Copy outputs to the specified directory
-	if err := b.executeStep(ctx, "CopyOutputs", b.callbacks.OnCopyOutputs, func() error {
+	if err := executeStep(ctx, "CopyOutputs", b.callbacks.OnCopyOutputs, func() error {
 		if err := b.CopyBuildOutputs(processDir, b.outputDir); err != nil {
 			return err
 		}
@@ -260,13 +408,24 @@ func (b *AOSBuilder) Build(ctx context.Context) error {
 		if err := copyFile(bundledFilePath, outputBundledPath); err != nil {
 			return fmt.Errorf("failed to copy bundled Lua file: %w", err)
 		}
-		return nil
+
+		// Write the build manifest so the artifacts can be verified later
+		return b.writeBuildManifest(ctx)
 	}); err != nil {
 		return fmt.Errorf("failed to copy build outputs: %w", err)
 	}
 
+	// Step 6b: Optionally analyze the metered module and write a report
+	if b.config.MeteringReport {
+		if err := executeStepWithMetadata(ctx, "MeteringReport", b.callbacks.OnMeteringReport, func(metadata map[string]interface{}) error {
+			return generateMeteringReport(b.outputDir, metadata)
+		}); err != nil {
+			return fmt.Errorf("failed to generate metering report: %w", err)
+		}
+	}
+
 	// Clean up workspace automatically
-	if err := b.executeStep(ctx, "Cleanup", b.callbacks.OnCleanup, func() error {
+	if err := executeStep(ctx, "Cleanup", b.callbacks.OnCleanup, func() error {
 		return b.CleanWorkspace(b.workspaceDir)
 	}); err != nil {
 		debug.Printf("Warning: failed to clean workspace: %v\n", err)
@@ -296,7 +455,8 @@ func (b *AOSBuilder) CleanWorkspace(workspaceDir string) error {
 // AOSCopyOptions holds configuration for copying AOS process files
 type AOSCopyOptions struct {
 	RepoURL          string
-	CommitHash       string
+	CommitHash       string // Git ref to check out: commit hash, branch, or tag
+	ProcessSubDir    string // Subdirectory of the repo holding the process sources
 	TempRepoDir      string
 	ProcessTargetDir string
 	ConfigSourceFile string
@@ -309,9 +469,26 @@ func NewAOSCopyOptions(config *harlequinConfig.Config, targetDir string) *AOSCop
 	processTargetDir := filepath.Join(targetDir, "aos-process")
 	configDestFile := filepath.Join(processTargetDir, "config.yml")
 
+	// The sqlite flavour builds from the sqlite-enabled AOS repository, and a
+	// config-specified fork overrides either upstream repo
+	repoURL := AOSRepoURL
+	if config.Flavour == harlequinConfig.FlavourAOSSqlite {
+		repoURL = AOSSqliteRepoURL
+	}
+	if config.AOSRepoURL != "" {
+		repoURL = config.AOSRepoURL
+	}
+
+	// Forks may keep the process sources somewhere other than process/
+	processSubDir := "process"
+	if config.AOSSubdir != "" {
+		processSubDir = config.AOSSubdir
+	}
+
 	return &AOSCopyOptions{
-		RepoURL:          AOSRepoURL,
+		RepoURL:          repoURL,
 		CommitHash:       config.AOSGitHash,
+		ProcessSubDir:    processSubDir,
 		TempRepoDir:      tempRepoDir,
 		ProcessTargetDir: processTargetDir,
 		ConfigSourceFile: "", // Will be set based on where config is found
@@ -329,34 +506,48 @@ func CopyAOSProcess(ctx context.Context, options *AOSCopyOptions) error {
 		return fmt.Errorf("failed to remove existing directory: %w", err)
 	}
 
-	// Step 2: Clean up any existing temp directory and clone the repository
-	debug.Printf("Removing any existing temp directory: %s\n", options.TempRepoDir)
-	if err := os.RemoveAll(options.TempRepoDir); err != nil {
-		debug.Printf("Warning: failed to remove existing temp directory: %v\n", err)
-	}
-
-	// Cleanup temp repo on exit
-	defer func() {
-		debug.Printf("Removing temporary directory: %s\n", options.TempRepoDir)
-		_ = os.RemoveAll(options.TempRepoDir)
-	}()
-
-	debug.Printf("Cloning repository: %s\n", options.RepoURL)
-	cloneCmd := exec.CommandContext(ctx, "git", "clone", options.RepoURL, options.TempRepoDir)
-	if err := cloneCmd.Run(); err != nil {
-		return fmt.Errorf("failed to clone repository: %w", err)
-	}
+	// Step 2: Materialize the repository at the pinned ref, preferring the
+	// local cache over a fresh clone
+	repoDir := options.TempRepoDir
+	if hasCachedAOSRepo(options.CommitHash) {
+		cacheDir, err := aosCacheDir(options.CommitHash)
+		if err != nil {
+			return err
+		}
+		debug.Printf("Using cached AOS checkout: %s\n", cacheDir)
+		repoDir = cacheDir
+	} else if Offline {
+		return fmt.Errorf("offline mode: AOS repository %s@%s is not cached - run 'harlequin prefetch' while online", options.RepoURL, options.CommitHash)
+	} else {
+		// Cleanup temp repo on exit
+		defer func() {
+			debug.Printf("Removing temporary directory: %s\n", options.TempRepoDir)
+			_ = os.RemoveAll(options.TempRepoDir)
+		}()
+
+		// Step 3: Clone the repository and checkout the specific commit hash
+		if err := cloneAOSRepo(ctx, options.RepoURL, options.CommitHash, options.TempRepoDir); err != nil {
+			return err
+		}
 
-	// Step 3: Checkout the specific commit hash
-	debug.Printf("Checking out commit: %s\n", options.CommitHash)
-	checkoutCmd := exec.CommandContext(ctx, "git", "checkout", options.CommitHash)
-	checkoutCmd.Dir = options.TempRepoDir
-	if err := checkoutCmd.Run(); err != nil {
-		return fmt.Errorf("failed to checkout commit %s: %w", options.CommitHash, err)
+		// Cache immutable refs for later offline builds; a failed cache write
+		// never fails the build
+		if commitHashPattern.MatchString(options.CommitHash) {
+			if err := cacheAOSCheckout(options.TempRepoDir, options.CommitHash); err != nil {
+				debug.Printf("Warning: failed to cache AOS checkout: %v\n", err)
+			}
+		}
 	}
 
 	// Step 4: Move the process directory to the target location
-	processDir := filepath.Join(options.TempRepoDir, "process")
+	processSubDir := options.ProcessSubDir
+	if processSubDir == "" {
+		processSubDir = "process"
+	}
+	processDir := filepath.Join(repoDir, processSubDir)
+	if _, err := os.Stat(processDir); os.IsNotExist(err) {
+		return fmt.Errorf("repository has no %s directory at ref %s", processSubDir, options.CommitHash)
+	}
 	debug.Printf("Moving %s to %s\n", processDir, options.ProcessTargetDir)
 
 	// Ensure target directory exists
@@ -410,42 +601,46 @@ func copyFile(src, dst string) error {
 
 // copyDirectory recursively copies a directory from src to dst
 func copyDirectory(src, dst string) error {
-	// Get source directory info
-	srcInfo, err := os.Stat(src)
-	if err != nil {
-		return fmt.Errorf("failed to stat source directory: %w", err)
-	}
-
-	// Create destination directory
-	if err := os.MkdirAll(dst, srcInfo.Mode()); err != nil {
-		return fmt.Errorf("failed to create destination directory: %w", err)
-	}
+	// Walk the tree first, creating directories and collecting the files;
+	// directories must all exist before files are copied in parallel
+	var files [][2]string
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
 
-	// Read source directory
-	entries, err := os.ReadDir(src)
+		if d.IsDir() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			return os.MkdirAll(target, info.Mode())
+		}
+		files = append(files, [2]string{path, target})
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to read source directory: %w", err)
+		return fmt.Errorf("failed to walk source directory: %w", err)
 	}
 
-	// Copy each entry
-	for _, entry := range entries {
-		srcPath := filepath.Join(src, entry.Name())
-		dstPath := filepath.Join(dst, entry.Name())
-
-		if entry.IsDir() {
-			// Recursively copy subdirectory
-			if err := copyDirectory(srcPath, dstPath); err != nil {
-				return fmt.Errorf("failed to copy subdirectory %s: %w", entry.Name(), err)
+	// AOS checkouts are many small files; copying them with a worker pool is
+	// markedly faster than one at a time on large trees
+	group := new(errgroup.Group)
+	group.SetLimit(runtime.NumCPU())
+	for _, pair := range files {
+		group.Go(func() error {
+			if err := copyFile(pair[0], pair[1]); err != nil {
+				return fmt.Errorf("failed to copy file %s: %w", pair[0], err)
 			}
-		} else {
-			// Copy file
-			if err := copyFile(srcPath, dstPath); err != nil {
-				return fmt.Errorf("failed to copy file %s: %w", entry.Name(), err)
-			}
-		}
+			return nil
+		})
 	}
-
-	return nil
+	return group.Wait()
 }
 
 // PrepareAOSWorkspace prepares a workspace for AOS building by copying necessary files (DEPRECATED: integrated into Build())
@@ -487,11 +682,15 @@ func InjectBundledCode(options *BuildInjectionOptions) error {
 	fileContent := string(content)
 
 	// Inject the bundled code require statement
-	fileContent, err = injectRequireStatement(fileContent, options.RequireName)
+	fileContent, err = injectRequireStatement(fileContent, options.RequireName, options.Point, options.Marker)
 	if err != nil {
 		return fmt.Errorf("failed to inject require statement: %w", err)
 	}
 
+	// Flag an injection that left the file unparseable, without failing the
+	// build: process.lua is Lua 5.3 and the parser only speaks 5.1
+	warnLuaSyntax("injected process.lua", fileContent)
+
 	// Write the updated content back to the file
 	if err := os.WriteFile(options.ProcessFilePath, []byte(fileContent), 0644); err != nil {
 		return fmt.Errorf("failed to write updated process file: %w", err)
@@ -501,8 +700,9 @@ func InjectBundledCode(options *BuildInjectionOptions) error {
 	return nil
 }
 
-// injectRequireStatement injects the bundled code require after the last Handlers.append
-func injectRequireStatement(content, requireName string) (string, error) {
+// injectRequireStatement injects the bundled code require at the configured
+// injection point (after the last Handlers.append by default)
+func injectRequireStatement(content, requireName, point, marker string) (string, error) {
 	// Check if the require statement is already present
 	existingRequire := fmt.Sprintf("require('%s')", requireName)
 	if strings.Contains(content, existingRequire) {
@@ -510,23 +710,13 @@ func injectRequireStatement(content, requireName string) (string, error) {
 		return content, nil
 	}
 
-	// Find all Handlers.append statements
-	handlersAppendRegex := regexp.MustCompile(`(Handlers\.append.*)`)
-
-	// Find the last occurrence of Handlers.append
-	lastMatchIndices := handlersAppendRegex.FindAllStringIndex(content, -1)
-	if len(lastMatchIndices) == 0 {
-		return "", fmt.Errorf("no Handlers.append found in process.lua")
+	injectionCode := fmt.Sprintf("require('%s');", requireName)
+	result, err := insertAtPoint(content, injectionCode, point, marker)
+	if err != nil {
+		return "", err
 	}
 
-	lastMatch := lastMatchIndices[len(lastMatchIndices)-1]
-	position := lastMatch[1] // End of the last match
-
-	// Inject the require statement after the last Handlers.append
-	injectionCode := fmt.Sprintf("\nrequire('%s');", requireName)
-	result := content[:position] + injectionCode + content[position:]
-
-	debug.Printf("Injected require('%s') after the last Handlers.append\n", requireName)
+	debug.Printf("Injected require('%s') at injection point %q\n", requireName, point)
 	return result, nil
 }
 
@@ -568,23 +758,28 @@ func (b *AOSBuilder) buildWithDocker(ctx context.Context, processDir string) err
 	// Get the Docker image name from the runner or use default
 	imageName := b.runner.GetImageName()
 
+	// Load the image from the offline cache if the daemon does not have it
+	if err := EnsureDockerImage(ctx, b.commands, imageName); err != nil {
+		return err
+	}
+
 	debug.Printf("Using absolute path for Docker mount: %s\n", absProcessDir)
 
 	// Docker command: docker run --platform linux/amd64 -v ${pwd}:/src p3rmaw3b/ao:${VERSION} ao-build-module
-	cmd := exec.CommandContext(ctx,
-		"docker", "run",
-		"--platform", "linux/amd64",
+	args := []string{"run", "--platform", "linux/amd64"}
+	args = append(args, dockerUserArgs(b.config.DockerUser)...)
+	args = append(args,
 		"-v", fmt.Sprintf("%s:/src", absProcessDir),
 		imageName,
 		"ao-build-module",
 	)
-
-	// Set working directory and capture output
-	cmd.Dir = processDir
-	output, err := cmd.CombinedOutput()
+	output, err := b.commands.Run(ctx, processDir, "docker", args...)
 
 	if err != nil {
 		debug.Printf("Docker build failed with output:\n%s\n", string(output))
+		if missingErr := missingImageError(imageName, b.config.ModuleFormat, string(output)); missingErr != nil {
+			return missingErr
+		}
 		return fmt.Errorf("docker build failed: %w", err)
 	}
 
@@ -596,10 +791,162 @@ func (b *AOSBuilder) buildWithDocker(ctx context.Context, processDir string) err
 		return fmt.Errorf("process.wasm was not created by the build process")
 	}
 
+	// Validate that AO can actually load the module before it is shipped anywhere
+	wasmBinary, err := os.ReadFile(wasmPath)
+	if err != nil {
+		return fmt.Errorf("failed to read built process.wasm: %w", err)
+	}
+	wasmInfo, err := harlequinWasm.ParseWasmBinary(wasmBinary)
+	if err != nil {
+		return fmt.Errorf("failed to parse built process.wasm: %w", err)
+	}
+	if err := harlequinWasm.ValidateForAO(wasmInfo, b.config.ModuleFormat); err != nil {
+		return err
+	}
+
 	debug.Printf("✅ WASM module successfully built: %s\n", wasmPath)
 	return nil
 }
 
+// dockerUserArgs returns the --user mapping for docker run. On Linux the
+// container runs as the invoking user's uid:gid by default, so files written
+// into the mounted workspace are not root-owned; docker_user in the config
+// overrides the mapping, and "root" keeps the container's default user.
+func dockerUserArgs(dockerUser string) []string {
+	if dockerUser == "root" {
+		return nil
+	}
+	if dockerUser == "" {
+		// Docker Desktop on macOS and Windows already maps file ownership to
+		// the invoking user
+		if runtime.GOOS != "linux" {
+			return nil
+		}
+		dockerUser = fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid())
+	}
+	return []string{"--user", dockerUser}
+}
+
+// optimizeWasm runs binaryen's wasm-opt -Oz over process.wasm, preferring a
+// local wasm-opt binary and falling back to the build container image. The
+// before/after sizes are recorded in the step metadata.
+func optimizeWasm(ctx context.Context, commands CommandRunner, imageName, dockerUser, processDir string, metadata map[string]interface{}) error {
+	wasmPath := filepath.Join(processDir, "process.wasm")
+
+	beforeInfo, err := os.Stat(wasmPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat process.wasm: %w", err)
+	}
+	metadata["size_before"] = beforeInfo.Size()
+
+	var output []byte
+	if wasmOptPath, lookErr := exec.LookPath("wasm-opt"); lookErr == nil {
+		debug.Printf("Optimizing with local wasm-opt: %s\n", wasmOptPath)
+		output, err = commands.Run(ctx, "", wasmOptPath, "-Oz", wasmPath, "-o", wasmPath)
+	} else {
+		// Fall back to the build container, which ships binaryen
+		absProcessDir, absErr := filepath.Abs(processDir)
+		if absErr != nil {
+			return fmt.Errorf("failed to get absolute path: %w", absErr)
+		}
+		debug.Printf("Optimizing with containerized wasm-opt in %s\n", imageName)
+		args := []string{"run", "--platform", "linux/amd64"}
+		args = append(args, dockerUserArgs(dockerUser)...)
+		args = append(args,
+			"-v", fmt.Sprintf("%s:/src", absProcessDir),
+			imageName,
+			"wasm-opt", "-Oz", "/src/process.wasm", "-o", "/src/process.wasm",
+		)
+		output, err = commands.Run(ctx, "", "docker", args...)
+	}
+
+	if err != nil {
+		debug.Printf("wasm-opt failed with output:\n%s\n", string(output))
+		return fmt.Errorf("wasm-opt failed: %w", err)
+	}
+
+	afterInfo, err := os.Stat(wasmPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat optimized process.wasm: %w", err)
+	}
+	metadata["size_after"] = afterInfo.Size()
+
+	debug.Printf("✅ WASM optimized: %d bytes -> %d bytes\n", beforeInfo.Size(), afterInfo.Size())
+	return nil
+}
+
+// generateMeteringReport analyzes the built module and writes
+// metering-report.json next to the other build outputs. The rendered report
+// is recorded in the step metadata for the callbacks to display.
+func generateMeteringReport(outputDir string, metadata map[string]interface{}) error {
+	wasmPath := filepath.Join(outputDir, "process.wasm")
+	wasmBinary, err := os.ReadFile(wasmPath)
+	if err != nil {
+		return fmt.Errorf("failed to read process.wasm: %w", err)
+	}
+
+	wasmInfo, err := harlequinWasm.ParseWasmBinary(wasmBinary)
+	if err != nil {
+		return fmt.Errorf("failed to parse process.wasm: %w", err)
+	}
+
+	report := harlequinWasm.AnalyzeMetering(wasmInfo, len(wasmBinary))
+
+	reportJSON, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal metering report: %w", err)
+	}
+
+	reportPath := filepath.Join(outputDir, "metering-report.json")
+	if err := os.WriteFile(reportPath, reportJSON, 0644); err != nil {
+		return fmt.Errorf("failed to write metering report: %w", err)
+	}
+
+	metadata["report"] = harlequinWasm.FormatMeteringReport(report)
+	metadata["recommended_compute_limit"] = report.RecommendedComputeLimit
+
+	debug.Printf("Metering report written to: %s\n", reportPath)
+	return nil
+}
+
+// writeBuildManifest records the build attestation (config snapshot, AOS
+// sources, image digest, and artifact hashes) next to the other outputs
+func (b *AOSBuilder) writeBuildManifest(ctx context.Context) error {
+	bundledHash, err := FileSHA256(filepath.Join(b.outputDir, "bundled.lua"))
+	if err != nil {
+		return fmt.Errorf("failed to hash bundled.lua: %w", err)
+	}
+	wasmHash, err := FileSHA256(filepath.Join(b.outputDir, "process.wasm"))
+	if err != nil {
+		return fmt.Errorf("failed to hash process.wasm: %w", err)
+	}
+
+	// NewAOSCopyOptions resolves the effective repo URL for the configured
+	// flavour and fork settings
+	copyOptions := NewAOSCopyOptions(b.config, b.workspaceDir)
+
+	manifest := &BuildManifest{
+		ToolkitVersion:    ToolkitVersion,
+		CreatedAt:         time.Now().UTC(),
+		Entrypoint:        b.entrypoint,
+		ConfigYAML:        harlequinConfig.ToYAML(b.config),
+		AOSRepoURL:        copyOptions.RepoURL,
+		AOSGitHash:        b.config.AOSGitHash,
+		DockerImage:       b.runner.GetImageName(),
+		DockerImageDigest: dockerImageDigest(ctx, b.commands, b.runner.GetImageName()),
+		BundledLuaSHA256:  bundledHash,
+		WasmSHA256:        wasmHash,
+	}
+
+	manifestPath := filepath.Join(b.outputDir, ManifestFileName)
+	if err := WriteBuildManifest(manifestPath, manifest); err != nil {
+		return err
+	}
+
+	debug.Printf("Build manifest written to: %s\n", manifestPath)
+	return nil
+}
+
 // copyBuildOutputs copies build artifacts to the output directory
 func (b *AOSBuilder) CopyBuildOutputs(processDir, outputDir string) error {
 	// Ensure output directory exists
@@ -615,6 +962,13 @@ func (b *AOSBuilder) CopyBuildOutputs(processDir, outputDir string) error {
 			return fmt.Errorf("failed to copy process.wasm: %w", err)
 		}
 		debug.Printf("Copied process.wasm to %s\n", outputWasm)
+
+		// Builds that ran as root inside the container (docker_user: root)
+		// can leave the output unwritable for the invoking user; fix that up
+		// best-effort
+		if err := os.Chmod(outputWasm, 0644); err != nil {
+			debug.Printf("Could not fix permissions on %s: %v\n", outputWasm, err)
+		}
 	}
 
 	return nil
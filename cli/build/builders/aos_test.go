@@ -2,8 +2,10 @@ package builders
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -21,7 +23,7 @@ Handlers.append("second", function() end)
 print("done")
 `
 
-	result, err := injectRequireStatement(content, ".bundled")
+	result, err := injectRequireStatement(content, ".bundled", "", "")
 	if err != nil {
 		t.Fatalf("injectRequireStatement failed: %v", err)
 	}
@@ -66,7 +68,7 @@ Handlers.append("second", function() end)
 print("done")
 `
 
-	result, err := injectRequireStatement(content, ".bundled")
+	result, err := injectRequireStatement(content, ".bundled", "", "")
 	if err != nil {
 		t.Fatalf("injectRequireStatement failed: %v", err)
 	}
@@ -89,7 +91,7 @@ local something = "test"
 print("no handlers here")
 `
 
-	_, err := injectRequireStatement(content, ".bundled")
+	_, err := injectRequireStatement(content, ".bundled", "", "")
 	if err == nil {
 		t.Error("Expected error when no Handlers.append found")
 	}
@@ -664,3 +666,64 @@ func TestAOSBuilderNonExistentConfigPath(t *testing.T) {
 
 	t.Log("✅ Non-existent config path behavior verified successfully!")
 }
+
+func TestNewAOSCopyOptionsRepoSelection(t *testing.T) {
+	// Vanilla config clones upstream AOS with the default process subdirectory
+	vanilla := harlequinConfig.NewConfig(nil)
+	options := NewAOSCopyOptions(vanilla, t.TempDir())
+	if options.RepoURL != AOSRepoURL {
+		t.Errorf("Expected upstream repo %q, got %q", AOSRepoURL, options.RepoURL)
+	}
+	if options.ProcessSubDir != "process" {
+		t.Errorf("Expected default process subdirectory, got %q", options.ProcessSubDir)
+	}
+
+	// The sqlite flavour switches to the sqlite-enabled variant
+	sqlite := harlequinConfig.NewConfig(nil)
+	sqlite.ApplyFlavour(harlequinConfig.FlavourAOSSqlite)
+	options = NewAOSCopyOptions(sqlite, t.TempDir())
+	if options.RepoURL != AOSSqliteRepoURL {
+		t.Errorf("Expected sqlite repo %q, got %q", AOSSqliteRepoURL, options.RepoURL)
+	}
+
+	// A config-specified fork overrides both, including the subdirectory and ref
+	fork := harlequinConfig.NewConfig(nil)
+	fork.AOSRepoURL = "https://github.com/example/aos-fork.git"
+	fork.AOSGitHash = "my-branch"
+	fork.AOSSubdir = "src/process"
+	options = NewAOSCopyOptions(fork, t.TempDir())
+	if options.RepoURL != fork.AOSRepoURL {
+		t.Errorf("Expected fork repo %q, got %q", fork.AOSRepoURL, options.RepoURL)
+	}
+	if options.CommitHash != "my-branch" {
+		t.Errorf("Expected branch ref to pass through, got %q", options.CommitHash)
+	}
+	if options.ProcessSubDir != "src/process" {
+		t.Errorf("Expected fork subdirectory, got %q", options.ProcessSubDir)
+	}
+}
+
+func TestDockerUserArgs(t *testing.T) {
+	// "root" keeps the container's default user
+	if args := dockerUserArgs("root"); args != nil {
+		t.Errorf("Expected no args for root, got %v", args)
+	}
+
+	// An explicit mapping is passed through verbatim
+	args := dockerUserArgs("1000:1000")
+	if len(args) != 2 || args[0] != "--user" || args[1] != "1000:1000" {
+		t.Errorf("Expected --user 1000:1000, got %v", args)
+	}
+
+	// The default depends on the host OS: the invoking user on Linux, no
+	// mapping elsewhere
+	args = dockerUserArgs("")
+	if runtime.GOOS == "linux" {
+		expected := fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid())
+		if len(args) != 2 || args[1] != expected {
+			t.Errorf("Expected --user %s on linux, got %v", expected, args)
+		}
+	} else if args != nil {
+		t.Errorf("Expected no args on %s, got %v", runtime.GOOS, args)
+	}
+}
@@ -0,0 +1,257 @@
+package builders
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	harlequinBuild "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/build"
+	harlequinConfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	harlequinWasm "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/wasm"
+)
+
+/*
+C builder is a builder for C/C++ AO processes
+It runs the project's CMake/emcc pipeline inside the AO build container,
+honoring the stack and memory settings from the harlequin config, and emits
+the same artifacts (process.wasm in the output directory) and callbacks as
+the AOS builder.
+
+Steps:
+ 1. Copy the project into a temporary workspace (OnCopyAOSFiles callback)
+ 2. Resolve Conan dependencies when a conanfile is present, configure with
+    emcmake, and compile with emcc inside the container (OnWasmCompile)
+ 3. Optionally optimize with wasm-opt (OnOptimizeWasm)
+ 4. Copy process.wasm to the output directory (OnCopyOutputs)
+ 5. Optionally generate a metering report (OnMeteringReport)
+ 6. Clean up the workspace (OnCleanup)
+
+Usage:
+
+	config := &harlequinConfig.Config{...}
+	builder := NewCBuilder(CBuilderParams{
+		Config:     config,
+		ProjectDir: ".",
+		OutputDir:  "./dist",
+		Callbacks:  CallbacksDefault, // or nil for default, CallbacksSilent for quiet
+	})
+	err := builder.Build(ctx)
+*/
+type CBuilder struct {
+	projectDir   string
+	outputDir    string
+	workspaceDir string
+	config       *harlequinConfig.Config
+	runner       *harlequinBuild.BuildRunner
+	commands     CommandRunner
+	callbacks    *BuildCallbacks
+}
+
+// CBuilderParams contains parameters for creating a CBuilder
+type CBuilderParams struct {
+	Config     *harlequinConfig.Config
+	ProjectDir string // Directory containing CMakeLists.txt
+	OutputDir  string
+	Callbacks  *BuildCallbacks
+}
+
+func NewCBuilder(params CBuilderParams) *CBuilder {
+	// Generate a temporary workspace directory
+	workspaceDir := filepath.Join(os.TempDir(), "harlequin-c-build-"+generateRandomID())
+
+	runner, err := harlequinBuild.NewAOBuildRunner(params.Config, workspaceDir)
+	if err != nil {
+		panic(err)
+	}
+
+	callbacks := params.Callbacks
+	if callbacks == nil {
+		callbacks = DefaultLoggingCallbacks()
+	}
+
+	return &CBuilder{
+		projectDir:   params.ProjectDir,
+		outputDir:    params.OutputDir,
+		config:       params.Config,
+		workspaceDir: workspaceDir,
+		runner:       runner,
+		commands:     ExecCommandRunner{},
+		callbacks:    callbacks,
+	}
+}
+
+// SetCommandRunner substitutes the runner used for docker and wasm-opt
+// invocations; tests use it to install a FakeCommandRunner
+func (b *CBuilder) SetCommandRunner(commands CommandRunner) {
+	b.commands = commands
+}
+
+// IsCProject reports whether the project directory contains a CMake project
+// that should be built with the CBuilder instead of the AOS builder
+func IsCProject(projectDir string) bool {
+	_, err := os.Stat(filepath.Join(projectDir, "CMakeLists.txt"))
+	return err == nil
+}
+
+// Build performs the complete C build process: copies the project into a
+// workspace, runs the Conan/CMake/emcc pipeline in the container, and copies
+// the built module to the output directory
+func (b *CBuilder) Build(ctx context.Context) error {
+	processDir := filepath.Join(b.workspaceDir, "c-process")
+
+	// Step 1: Copy the project into the workspace so container builds never
+	// dirty the source tree
+	if err := executeStep(ctx, "CopyProjectFiles", b.callbacks.OnCopyAOSFiles, func() error {
+		return b.copyProject(processDir)
+	}); err != nil {
+		return fmt.Errorf("failed to prepare workspace: %w", err)
+	}
+
+	// Step 2: Run the Conan/CMake/emcc pipeline in the container
+	if err := executeStep(ctx, "WasmCompile", b.callbacks.OnWasmCompile, func() error {
+		return b.buildWithDocker(ctx, processDir)
+	}); err != nil {
+		return fmt.Errorf("failed to build WASM: %w", err)
+	}
+
+	// Step 2b: Optionally run wasm-opt on the built module
+	if b.config.Optimize {
+		if err := executeStepWithMetadata(ctx, "OptimizeWasm", b.callbacks.OnOptimizeWasm, func(metadata map[string]interface{}) error {
+			return optimizeWasm(ctx, b.commands, b.runner.GetImageName(), b.config.DockerUser, processDir, metadata)
+		}); err != nil {
+			return fmt.Errorf("failed to optimize WASM: %w", err)
+		}
+	}
+
+	// Step 3: Copy outputs to the specified directory
+	if err := executeStep(ctx, "CopyOutputs", b.callbacks.OnCopyOutputs, func() error {
+		if err := os.MkdirAll(b.outputDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		return copyFile(filepath.Join(processDir, "process.wasm"), filepath.Join(b.outputDir, "process.wasm"))
+	}); err != nil {
+		return fmt.Errorf("failed to copy build outputs: %w", err)
+	}
+
+	// Step 3b: Optionally analyze the metered module and write a report
+	if b.config.MeteringReport {
+		if err := executeStepWithMetadata(ctx, "MeteringReport", b.callbacks.OnMeteringReport, func(metadata map[string]interface{}) error {
+			return generateMeteringReport(b.outputDir, metadata)
+		}); err != nil {
+			return fmt.Errorf("failed to generate metering report: %w", err)
+		}
+	}
+
+	// Clean up workspace automatically
+	if err := executeStep(ctx, "Cleanup", b.callbacks.OnCleanup, func() error {
+		return os.RemoveAll(b.workspaceDir)
+	}); err != nil {
+		debug.Printf("Warning: failed to clean workspace: %v\n", err)
+		// Don't fail the build for cleanup issues
+	}
+
+	return nil
+}
+
+// copyProject copies the project sources into the workspace, skipping hidden
+// directories and any stale build directory
+func (b *CBuilder) copyProject(processDir string) error {
+	if err := copyDirectory(b.projectDir, processDir); err != nil {
+		return err
+	}
+
+	// Drop artifacts from previous host-side builds so the container
+	// configures from scratch
+	if err := os.RemoveAll(filepath.Join(processDir, "build")); err != nil {
+		return fmt.Errorf("failed to remove stale build directory: %w", err)
+	}
+	return os.RemoveAll(filepath.Join(processDir, ".git"))
+}
+
+// emccMemoryFlags renders the emcc linker flags derived from the harlequin
+// config (stack size, memory limits, and 64-bit memory when targeting wasm64)
+func (b *CBuilder) emccMemoryFlags() string {
+	flags := []string{
+		fmt.Sprintf("-sSTACK_SIZE=%d", b.config.StackSize),
+		fmt.Sprintf("-sINITIAL_MEMORY=%d", b.config.InitialMemory),
+		fmt.Sprintf("-sMAXIMUM_MEMORY=%d", b.config.MaximumMemory),
+		"-sALLOW_MEMORY_GROWTH=1",
+	}
+	if b.config.Target == 64 {
+		flags = append(flags, "-sMEMORY64=1")
+	}
+	return strings.Join(flags, " ")
+}
+
+// buildWithDocker runs the Conan/CMake/emcc pipeline inside the AO build
+// container and validates the resulting module
+func (b *CBuilder) buildWithDocker(ctx context.Context, processDir string) error {
+	debug.Printf("Building C WASM module in directory: %s\n", processDir)
+
+	// Get absolute path for Docker volume mount
+	absProcessDir, err := filepath.Abs(processDir)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	imageName := b.runner.GetImageName()
+
+	// Load the image from the offline cache if the daemon does not have it
+	if err := EnsureDockerImage(ctx, b.commands, imageName); err != nil {
+		return err
+	}
+
+	// Conan resolution only runs when the template ships a conanfile
+	script := strings.Join([]string{
+		"set -e",
+		"cd /src",
+		"if [ -f conanfile.txt ] || [ -f conanfile.py ]; then conan install . --output-folder=build --build=missing; fi",
+		fmt.Sprintf("emcmake cmake -S . -B build -DCMAKE_BUILD_TYPE=Release \"-DCMAKE_EXE_LINKER_FLAGS=%s\"", b.emccMemoryFlags()),
+		"cmake --build build",
+		"cp build/process.wasm process.wasm",
+	}, " && ")
+
+	debug.Printf("Container build script: %s\n", script)
+
+	args := []string{"run", "--platform", "linux/amd64"}
+	args = append(args, dockerUserArgs(b.config.DockerUser)...)
+	args = append(args,
+		"-v", fmt.Sprintf("%s:/src", absProcessDir),
+		"--entrypoint", "/bin/sh",
+		imageName,
+		"-c", script,
+	)
+	output, err := b.commands.Run(ctx, processDir, "docker", args...)
+
+	if err != nil {
+		debug.Printf("Docker build failed with output:\n%s\n", string(output))
+		return fmt.Errorf("docker build failed: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+
+	debug.Printf("Docker build completed successfully:\n%s\n", string(output))
+
+	// Verify that process.wasm was created
+	wasmPath := filepath.Join(processDir, "process.wasm")
+	if _, err := os.Stat(wasmPath); os.IsNotExist(err) {
+		return fmt.Errorf("process.wasm was not created by the build process")
+	}
+
+	// Validate that AO can actually load the module before it is shipped anywhere
+	wasmBinary, err := os.ReadFile(wasmPath)
+	if err != nil {
+		return fmt.Errorf("failed to read built process.wasm: %w", err)
+	}
+	wasmInfo, err := harlequinWasm.ParseWasmBinary(wasmBinary)
+	if err != nil {
+		return fmt.Errorf("failed to parse built process.wasm: %w", err)
+	}
+	if err := harlequinWasm.ValidateForAO(wasmInfo, b.config.ModuleFormat); err != nil {
+		return err
+	}
+
+	debug.Printf("✅ C WASM module successfully built: %s\n", wasmPath)
+	return nil
+}
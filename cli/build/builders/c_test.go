@@ -0,0 +1,67 @@
+package builders
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	harlequinConfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+)
+
+func TestIsCProject(t *testing.T) {
+	tempDir := t.TempDir()
+
+	if IsCProject(tempDir) {
+		t.Error("Expected empty directory to not be a C project")
+	}
+
+	cmakeFile := filepath.Join(tempDir, "CMakeLists.txt")
+	if err := os.WriteFile(cmakeFile, []byte("project(test)\n"), 0644); err != nil {
+		t.Fatalf("Failed to write CMakeLists.txt: %v", err)
+	}
+
+	if !IsCProject(tempDir) {
+		t.Error("Expected directory with CMakeLists.txt to be a C project")
+	}
+}
+
+func TestEmccMemoryFlags(t *testing.T) {
+	config := harlequinConfig.NewConfig(nil)
+	builder := NewCBuilder(CBuilderParams{
+		Config:     config,
+		ProjectDir: ".",
+		OutputDir:  "./dist",
+		Callbacks:  CallbacksSilent,
+	})
+
+	flags := builder.emccMemoryFlags()
+
+	expected := []string{
+		"-sSTACK_SIZE=3145728",
+		"-sINITIAL_MEMORY=4194304",
+		"-sALLOW_MEMORY_GROWTH=1",
+	}
+	for _, flag := range expected {
+		if !strings.Contains(flags, flag) {
+			t.Errorf("Expected flags to contain %q, got %q", flag, flags)
+		}
+	}
+
+	// 32-bit target must not enable MEMORY64
+	if strings.Contains(flags, "MEMORY64") {
+		t.Errorf("Expected no MEMORY64 flag for 32-bit target, got %q", flags)
+	}
+
+	// 64-bit target enables MEMORY64
+	config.Target = 64
+	builder64 := NewCBuilder(CBuilderParams{
+		Config:     config,
+		ProjectDir: ".",
+		OutputDir:  "./dist",
+		Callbacks:  CallbacksSilent,
+	})
+	if !strings.Contains(builder64.emccMemoryFlags(), "-sMEMORY64=1") {
+		t.Error("Expected MEMORY64 flag for 64-bit target")
+	}
+}
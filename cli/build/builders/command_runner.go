@@ -0,0 +1,49 @@
+package builders
+
+import (
+	"context"
+	"os/exec"
+)
+
+// CommandRunner abstracts the external commands the builders execute (docker
+// runs, image loads, wasm-opt). Production builds use ExecCommandRunner;
+// tests substitute FakeCommandRunner so the full Build() orchestration can be
+// exercised without Docker.
+type CommandRunner interface {
+	// Run executes the command in dir (the process working directory when
+	// empty) and returns its combined stdout and stderr.
+	Run(ctx context.Context, dir, name string, args ...string) ([]byte, error)
+}
+
+// ExecCommandRunner runs commands on the host via os/exec
+type ExecCommandRunner struct{}
+
+func (ExecCommandRunner) Run(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
+
+// FakeCall records one command executed through a FakeCommandRunner
+type FakeCall struct {
+	Dir  string
+	Name string
+	Args []string
+}
+
+// FakeCommandRunner records every command and delegates to OnRun, so tests
+// can fabricate command side effects (like writing process.wasm into the
+// mounted workspace) or inject failures at a chosen step. A nil OnRun makes
+// every command succeed with empty output.
+type FakeCommandRunner struct {
+	Calls []FakeCall
+	OnRun func(dir, name string, args []string) ([]byte, error)
+}
+
+func (f *FakeCommandRunner) Run(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	f.Calls = append(f.Calls, FakeCall{Dir: dir, Name: name, Args: args})
+	if f.OnRun == nil {
+		return nil, nil
+	}
+	return f.OnRun(dir, name, args)
+}
@@ -0,0 +1,90 @@
+package builders
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	harlequinConfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+)
+
+// ManifestFileName is the attestation written next to the other build outputs
+const ManifestFileName = "build-manifest.json"
+
+// ToolkitVersion is the harlequin version stamped into build manifests.
+// It is set from main at startup (GoReleaser injects the release version).
+var ToolkitVersion = "dev"
+
+// BuildManifest records everything needed to reproduce and verify a build:
+// the effective config, the AOS sources, the build image, and the hashes of
+// the produced artifacts. Third parties can check that an on-chain module
+// corresponds to published source by rebuilding from the manifest and
+// comparing hashes (see `harlequin build verify`).
+type BuildManifest struct {
+	ToolkitVersion    string    `json:"toolkit_version"`
+	CreatedAt         time.Time `json:"created_at"`
+	Entrypoint        string    `json:"entrypoint"`
+	ConfigYAML        string    `json:"config_yaml"` // Snapshot of the effective build config
+	AOSRepoURL        string    `json:"aos_repo_url"`
+	AOSGitHash        string    `json:"aos_git_hash"`
+	DockerImage       string    `json:"docker_image"`
+	DockerImageDigest string    `json:"docker_image_digest,omitempty"` // Best-effort; empty when the image has no digest
+	BundledLuaSHA256  string    `json:"bundled_lua_sha256"`
+	WasmSHA256        string    `json:"wasm_sha256"`
+}
+
+// Config parses the config snapshot recorded in the manifest
+func (m *BuildManifest) Config() *harlequinConfig.Config {
+	return harlequinConfig.FromYAML(m.ConfigYAML)
+}
+
+// WriteBuildManifest writes the manifest as indented JSON
+func WriteBuildManifest(path string, manifest *BuildManifest) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal build manifest: %w", err)
+	}
+	return os.WriteFile(path, content, 0644)
+}
+
+// LoadBuildManifest reads a manifest written by a previous build
+func LoadBuildManifest(path string) (*BuildManifest, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read build manifest: %w", err)
+	}
+	var manifest BuildManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse build manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// FileSHA256 returns the hex SHA-256 of a file's content
+func FileSHA256(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// dockerImageDigest resolves the repo digest of the build image, returning ""
+// when the image is not present locally or carries no digest (e.g. built
+// locally rather than pulled)
+func dockerImageDigest(ctx context.Context, commands CommandRunner, imageName string) string {
+	output, err := commands.Run(ctx, "", "docker", "image", "inspect",
+		"--format", "{{if .RepoDigests}}{{index .RepoDigests 0}}{{end}}", imageName)
+	if err != nil {
+		debug.Printf("Could not resolve docker image digest for %s: %v\n", imageName, err)
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,69 @@
+package builders
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	harlequinConfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+)
+
+func TestBuildManifestRoundTrip(t *testing.T) {
+	tempDir := t.TempDir()
+	manifestPath := filepath.Join(tempDir, ManifestFileName)
+
+	config := harlequinConfig.NewConfig(nil)
+	manifest := &BuildManifest{
+		ToolkitVersion:   "1.2.3",
+		CreatedAt:        time.Now().UTC(),
+		Entrypoint:       "main.lua",
+		ConfigYAML:       harlequinConfig.ToYAML(config),
+		AOSRepoURL:       AOSRepoURL,
+		AOSGitHash:       config.AOSGitHash,
+		DockerImage:      "p3rmaw3b/ao:test",
+		BundledLuaSHA256: "aaaa",
+		WasmSHA256:       "bbbb",
+	}
+
+	if err := WriteBuildManifest(manifestPath, manifest); err != nil {
+		t.Fatalf("WriteBuildManifest failed: %v", err)
+	}
+
+	loaded, err := LoadBuildManifest(manifestPath)
+	if err != nil {
+		t.Fatalf("LoadBuildManifest failed: %v", err)
+	}
+
+	if loaded.ToolkitVersion != "1.2.3" || loaded.WasmSHA256 != "bbbb" {
+		t.Errorf("Unexpected manifest contents: %+v", loaded)
+	}
+
+	// The config snapshot must round-trip into an equivalent config
+	restored := loaded.Config()
+	if restored.AOSGitHash != config.AOSGitHash || restored.ModuleFormat != config.ModuleFormat {
+		t.Errorf("Config snapshot did not round-trip: %+v", restored)
+	}
+}
+
+func TestFileSHA256(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "artifact.bin")
+	if err := os.WriteFile(path, []byte("hello"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	hash, err := FileSHA256(path)
+	if err != nil {
+		t.Fatalf("FileSHA256 failed: %v", err)
+	}
+	// sha256("hello")
+	expected := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if hash != expected {
+		t.Errorf("Expected %s, got %s", expected, hash)
+	}
+
+	if _, err := FileSHA256(filepath.Join(tempDir, "missing")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
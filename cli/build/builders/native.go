@@ -0,0 +1,180 @@
+package builders
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	harlequinConfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	"gopkg.in/yaml.v2"
+)
+
+// NativeStubFileName is the generated registration stub compiled alongside
+// the declared native module sources
+const NativeStubFileName = "harlequin_natives.c"
+
+// nativeLibsDir is the workspace subdirectory native module sources are
+// staged into, relative to the aos-process directory
+const nativeLibsDir = "libs"
+
+// Native module names become C identifiers (luaopen_<name>), so they are
+// restricted accordingly
+var nativeModuleNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// PrepareNativeModules stages the native (C) modules declared in the config
+// into the AOS workspace so the container build compiles and links them into
+// the wasm. Each module's sources are copied under libs/<name>/, a generated
+// stub registers every module in package.preload under its declared name, and
+// the workspace config.yml gains the extra compile arguments that hand the
+// staged sources to ao-build-module. projectDir is the directory the declared
+// source paths are relative to (where the config file lives).
+func PrepareNativeModules(config *harlequinConfig.Config, projectDir, processDir string) error {
+	if len(config.NativeModules) == 0 {
+		return nil
+	}
+
+	libsDir := filepath.Join(processDir, nativeLibsDir)
+	var extraArgs []string
+
+	for _, module := range config.NativeModules {
+		if !nativeModuleNamePattern.MatchString(module.Name) {
+			return fmt.Errorf("native module name %q is not a valid C identifier (needed for luaopen_%s)", module.Name, module.Name)
+		}
+		if len(module.Sources) == 0 {
+			return fmt.Errorf("native module %q declares no sources", module.Name)
+		}
+
+		moduleDir := filepath.Join(libsDir, module.Name)
+		for _, source := range module.Sources {
+			sourcePath := filepath.Join(projectDir, source)
+			info, err := os.Stat(sourcePath)
+			if err != nil {
+				return fmt.Errorf("native module %q: source %s: %w", module.Name, source, err)
+			}
+			target := filepath.Join(moduleDir, filepath.Base(source))
+			if info.IsDir() {
+				if err := copyDirectory(sourcePath, target); err != nil {
+					return fmt.Errorf("native module %q: failed to copy %s: %w", module.Name, source, err)
+				}
+			} else if err := copyFile(sourcePath, target); err != nil {
+				return fmt.Errorf("native module %q: failed to copy %s: %w", module.Name, source, err)
+			}
+		}
+
+		// Compile every staged C source; paths are relative to /src inside
+		// the container
+		sources, err := collectCSources(processDir, moduleDir)
+		if err != nil {
+			return fmt.Errorf("native module %q: %w", module.Name, err)
+		}
+		if len(sources) == 0 {
+			return fmt.Errorf("native module %q has no C sources after staging", module.Name)
+		}
+		extraArgs = append(extraArgs, sources...)
+
+		extraArgs = append(extraArgs, "-I"+filepath.ToSlash(filepath.Join(nativeLibsDir, module.Name)))
+		for _, includeDir := range module.IncludeDirs {
+			extraArgs = append(extraArgs, "-I"+filepath.ToSlash(filepath.Join(nativeLibsDir, module.Name, includeDir)))
+		}
+		extraArgs = append(extraArgs, module.CFlags...)
+	}
+
+	// The stub is compiled last so every luaopen_<name> it references has
+	// already been linked in
+	stubPath := filepath.Join(libsDir, NativeStubFileName)
+	if err := os.WriteFile(stubPath, []byte(generateNativeStub(config.NativeModules)), 0644); err != nil {
+		return fmt.Errorf("failed to write native registration stub: %w", err)
+	}
+	extraArgs = append(extraArgs, filepath.ToSlash(filepath.Join(nativeLibsDir, NativeStubFileName)))
+
+	debug.Printf("Staged %d native module(s) with compile args: %s\n", len(config.NativeModules), strings.Join(extraArgs, " "))
+	return appendExtraCompileArgs(filepath.Join(processDir, "config.yml"), extraArgs)
+}
+
+// collectCSources returns the .c and .cpp files under dir, as slash-separated
+// paths relative to root, sorted for a deterministic compile line
+func collectCSources(root, dir string) ([]string, error) {
+	var sources []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		ext := filepath.Ext(path)
+		if ext != ".c" && ext != ".cpp" {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan staged sources: %w", err)
+	}
+	sort.Strings(sources)
+	return sources, nil
+}
+
+// generateNativeStub renders the C stub that registers every declared module
+// in package.preload, so require('<name>') resolves at runtime without the
+// (unavailable) dynamic C loader
+func generateNativeStub(modules []harlequinConfig.NativeModule) string {
+	var stub strings.Builder
+	stub.WriteString("/* Generated by harlequin - do not edit.\n")
+	stub.WriteString(" * Registers the native modules declared in .harlequin.yaml in\n")
+	stub.WriteString(" * package.preload so require() can resolve them. */\n")
+	stub.WriteString("#include \"lua.h\"\n")
+	stub.WriteString("#include \"lauxlib.h\"\n\n")
+
+	for _, module := range modules {
+		stub.WriteString(fmt.Sprintf("extern int luaopen_%s(lua_State *L);\n", module.Name))
+	}
+
+	stub.WriteString("\nvoid harlequin_preload_natives(lua_State *L) {\n")
+	stub.WriteString("\tluaL_getsubtable(L, LUA_REGISTRYINDEX, LUA_PRELOAD_TABLE);\n")
+	for _, module := range modules {
+		stub.WriteString(fmt.Sprintf("\tlua_pushcfunction(L, luaopen_%s);\n", module.Name))
+		stub.WriteString(fmt.Sprintf("\tlua_setfield(L, -2, \"%s\");\n", module.Name))
+	}
+	stub.WriteString("\tlua_pop(L, 1);\n")
+	stub.WriteString("}\n")
+	return stub.String()
+}
+
+// appendExtraCompileArgs merges args into the extra_compile_args list of the
+// workspace config.yml that ao-build-module reads, creating the file when the
+// project shipped no config
+func appendExtraCompileArgs(configPath string, args []string) error {
+	values := make(map[string]interface{})
+
+	content, err := os.ReadFile(configPath)
+	if err == nil {
+		if err := yaml.Unmarshal(content, &values); err != nil {
+			return fmt.Errorf("failed to parse workspace config.yml: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read workspace config.yml: %w", err)
+	}
+
+	existing, _ := values["extra_compile_args"].([]interface{})
+	for _, arg := range args {
+		existing = append(existing, arg)
+	}
+	values["extra_compile_args"] = existing
+
+	updated, err := yaml.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to marshal workspace config.yml: %w", err)
+	}
+	return os.WriteFile(configPath, updated, 0644)
+}
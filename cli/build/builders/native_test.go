@@ -0,0 +1,128 @@
+package builders
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	harlequinConfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"gopkg.in/yaml.v2"
+)
+
+func TestPrepareNativeModules(t *testing.T) {
+	projectDir := t.TempDir()
+	processDir := t.TempDir()
+
+	// A mixed project: one C rock with a source file and a header directory
+	rockDir := filepath.Join(projectDir, "native", "lsqlite3")
+	if err := os.MkdirAll(filepath.Join(rockDir, "include"), 0755); err != nil {
+		t.Fatalf("Failed to create rock directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rockDir, "lsqlite3.c"), []byte("int luaopen_lsqlite3;\n"), 0644); err != nil {
+		t.Fatalf("Failed to write rock source: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rockDir, "include", "lsqlite3.h"), []byte("/* header */\n"), 0644); err != nil {
+		t.Fatalf("Failed to write rock header: %v", err)
+	}
+
+	// The workspace already has a config.yml copied from the project
+	configPath := filepath.Join(processDir, "config.yml")
+	if err := os.WriteFile(configPath, []byte("stack_size: 3145728\n"), 0644); err != nil {
+		t.Fatalf("Failed to write workspace config: %v", err)
+	}
+
+	config := harlequinConfig.NewConfig(nil)
+	config.NativeModules = []harlequinConfig.NativeModule{
+		{
+			Name:        "lsqlite3",
+			Sources:     []string{"native/lsqlite3"},
+			IncludeDirs: []string{"lsqlite3/include"},
+			CFlags:      []string{"-DSQLITE_OMIT_LOAD_EXTENSION"},
+		},
+	}
+
+	if err := PrepareNativeModules(config, projectDir, processDir); err != nil {
+		t.Fatalf("PrepareNativeModules failed: %v", err)
+	}
+
+	// Sources are staged under libs/<name>/
+	stagedSource := filepath.Join(processDir, "libs", "lsqlite3", "lsqlite3", "lsqlite3.c")
+	if _, err := os.Stat(stagedSource); err != nil {
+		t.Errorf("Expected staged source at %s: %v", stagedSource, err)
+	}
+
+	// The registration stub preloads the module under its declared name
+	stub, err := os.ReadFile(filepath.Join(processDir, "libs", NativeStubFileName))
+	if err != nil {
+		t.Fatalf("Failed to read registration stub: %v", err)
+	}
+	for _, want := range []string{"extern int luaopen_lsqlite3(lua_State *L);", `lua_setfield(L, -2, "lsqlite3");`} {
+		if !strings.Contains(string(stub), want) {
+			t.Errorf("Expected stub to contain %q, got:\n%s", want, stub)
+		}
+	}
+
+	// config.yml keeps its existing keys and gains the compile arguments
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read workspace config: %v", err)
+	}
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		t.Fatalf("Failed to parse workspace config: %v", err)
+	}
+	if values["stack_size"] != 3145728 {
+		t.Errorf("Expected stack_size to be preserved, got %v", values["stack_size"])
+	}
+
+	rawArgs, ok := values["extra_compile_args"].([]interface{})
+	if !ok {
+		t.Fatalf("Expected extra_compile_args list, got %T", values["extra_compile_args"])
+	}
+	var args []string
+	for _, raw := range rawArgs {
+		args = append(args, raw.(string))
+	}
+	joined := strings.Join(args, " ")
+	for _, want := range []string{
+		"libs/lsqlite3/lsqlite3/lsqlite3.c",
+		"-Ilibs/lsqlite3",
+		"-Ilibs/lsqlite3/lsqlite3/include",
+		"-DSQLITE_OMIT_LOAD_EXTENSION",
+		"libs/" + NativeStubFileName,
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("Expected compile args to contain %q, got %q", want, joined)
+		}
+	}
+
+	// The stub must come after the module sources so its externs resolve
+	if strings.Index(joined, "libs/"+NativeStubFileName) < strings.Index(joined, "lsqlite3.c") {
+		t.Error("Expected the registration stub to be compiled after the module sources")
+	}
+}
+
+func TestPrepareNativeModulesRejectsBadNames(t *testing.T) {
+	config := harlequinConfig.NewConfig(nil)
+	config.NativeModules = []harlequinConfig.NativeModule{
+		{Name: "my-rock", Sources: []string{"rock.c"}},
+	}
+
+	err := PrepareNativeModules(config, t.TempDir(), t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "not a valid C identifier") {
+		t.Errorf("Expected invalid name error, got %v", err)
+	}
+}
+
+func TestPrepareNativeModulesRequiresSources(t *testing.T) {
+	config := harlequinConfig.NewConfig(nil)
+	config.NativeModules = []harlequinConfig.NativeModule{
+		{Name: "rock"},
+	}
+
+	err := PrepareNativeModules(config, t.TempDir(), t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "declares no sources") {
+		t.Errorf("Expected missing sources error, got %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package builders
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	harlequinConfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/logging"
+	harlequinRetry "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/retry"
+)
+
+// Offline disables network access for builds: the AOS repository and docker
+// images are resolved from local caches only. It is set from the global
+// --offline flag at startup, like ToolkitVersion.
+var Offline bool
+
+// commitHashPattern matches full git commit hashes. Only immutable refs are
+// cached; branches and tags can move, so they are always re-cloned online.
+var commitHashPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// CacheRoot returns the harlequin cache directory
+func CacheRoot() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, "harlequin"), nil
+}
+
+// aosCacheDir returns the cache location for an AOS checkout at a commit hash
+func aosCacheDir(commitHash string) (string, error) {
+	root, err := CacheRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, "aos", commitHash), nil
+}
+
+// imageCachePath returns the cache location for a saved docker image tarball
+func imageCachePath(imageName string) (string, error) {
+	root, err := CacheRoot()
+	if err != nil {
+		return "", err
+	}
+	sanitized := strings.NewReplacer("/", "_", ":", "_").Replace(imageName)
+	return filepath.Join(root, "images", sanitized+".tar"), nil
+}
+
+// hasCachedAOSRepo reports whether a checkout for the commit hash is cached
+func hasCachedAOSRepo(commitHash string) bool {
+	dir, err := aosCacheDir(commitHash)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
+
+// hasLocalDockerImage reports whether the image exists in the docker daemon
+// or as a cached tarball
+func hasLocalDockerImage(ctx context.Context, imageName string) bool {
+	if exec.CommandContext(ctx, "docker", "image", "inspect", imageName).Run() == nil {
+		return true
+	}
+	tarPath, err := imageCachePath(imageName)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(tarPath)
+	return err == nil
+}
+
+// PullImages forces a pre-pull of the build image before building. It is set
+// from the build --pull flag, like Offline.
+var PullImages bool
+
+// PullBuildImage pulls the build image up front, streaming docker's own
+// progress output, and turns a missing image or tag into actionable guidance
+func PullBuildImage(ctx context.Context, imageName, moduleFormat string) error {
+	logging.Printf("📦 Pulling build image %s...\n", imageName)
+
+	pullCmd := exec.CommandContext(ctx, "docker", "pull", "--platform", "linux/amd64", imageName)
+	var stderr strings.Builder
+	if !logging.Quiet() {
+		pullCmd.Stdout = os.Stdout
+	}
+	pullCmd.Stderr = &stderr
+
+	if err := pullCmd.Run(); err != nil {
+		if missingErr := missingImageError(imageName, moduleFormat, stderr.String()); missingErr != nil {
+			return missingErr
+		}
+		return fmt.Errorf("failed to pull image %s: %w\n%s", imageName, err, stderr.String())
+	}
+	return nil
+}
+
+// missingImageError translates docker's image-not-found output into guidance;
+// it returns nil when the output does not indicate a missing image
+func missingImageError(imageName, moduleFormat, output string) error {
+	for _, marker := range []string{"manifest unknown", "not found", "pull access denied"} {
+		if strings.Contains(output, marker) {
+			return fmt.Errorf("docker image %s does not exist - check that module_format %q is supported by this toolkit version and that any docker_image_digest pin is correct", imageName, moduleFormat)
+		}
+	}
+	return nil
+}
+
+// EnsureDockerImage makes the build image available to the docker daemon,
+// loading it from the offline cache when needed. Online, missing images are
+// left for docker run to pull.
+func EnsureDockerImage(ctx context.Context, commands CommandRunner, imageName string) error {
+	if _, err := commands.Run(ctx, "", "docker", "image", "inspect", imageName); err == nil {
+		return nil
+	}
+
+	tarPath, err := imageCachePath(imageName)
+	if err == nil {
+		if _, statErr := os.Stat(tarPath); statErr == nil {
+			debug.Printf("Loading docker image %s from cache: %s\n", imageName, tarPath)
+			if output, loadErr := commands.Run(ctx, "", "docker", "load", "-i", tarPath); loadErr != nil {
+				return fmt.Errorf("failed to load cached image %s: %w\n%s", imageName, loadErr, output)
+			}
+			return nil
+		}
+	}
+
+	if Offline {
+		return fmt.Errorf("offline mode: docker image %s is not available locally - run 'harlequin prefetch' while online", imageName)
+	}
+	return nil
+}
+
+// MissingOfflineArtifacts lists the cached artifacts a build would need that
+// are not available locally, so offline builds can fail fast with all of them
+// at once
+func MissingOfflineArtifacts(ctx context.Context, config *harlequinConfig.Config, workspaceDir, imageName string) []string {
+	options := NewAOSCopyOptions(config, workspaceDir)
+
+	var missing []string
+	if !hasCachedAOSRepo(options.CommitHash) {
+		missing = append(missing, fmt.Sprintf("AOS repository %s@%s", options.RepoURL, options.CommitHash))
+	}
+	if !hasLocalDockerImage(ctx, imageName) {
+		missing = append(missing, fmt.Sprintf("docker image %s", imageName))
+	}
+	return missing
+}
+
+// PrefetchAOSRepo populates the AOS checkout cache for a commit hash
+func PrefetchAOSRepo(ctx context.Context, repoURL, commitHash string) error {
+	if !commitHashPattern.MatchString(commitHash) {
+		return fmt.Errorf("only commit hashes can be prefetched; %q is a moving ref", commitHash)
+	}
+	if hasCachedAOSRepo(commitHash) {
+		return nil
+	}
+
+	tempDir := filepath.Join(os.TempDir(), "harlequin-aos-prefetch")
+	if err := cloneAOSRepo(ctx, repoURL, commitHash, tempDir); err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	return cacheAOSCheckout(tempDir, commitHash)
+}
+
+// PrefetchDockerImage pulls an image and saves it to the offline cache
+func PrefetchDockerImage(ctx context.Context, imageName string) error {
+	pullCmd := exec.CommandContext(ctx, "docker", "pull", "--platform", "linux/amd64", imageName)
+	if output, err := pullCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to pull image %s: %w\n%s", imageName, err, output)
+	}
+
+	tarPath, err := imageCachePath(imageName)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(tarPath), 0755); err != nil {
+		return fmt.Errorf("failed to create image cache directory: %w", err)
+	}
+
+	saveCmd := exec.CommandContext(ctx, "docker", "save", "-o", tarPath, imageName)
+	if output, err := saveCmd.CombinedOutput(); err != nil {
+		os.Remove(tarPath)
+		return fmt.Errorf("failed to save image %s: %w\n%s", imageName, err, output)
+	}
+	return nil
+}
+
+// cloneAOSRepo clones a repository and checks out the requested ref
+func cloneAOSRepo(ctx context.Context, repoURL, commitHash, targetDir string) error {
+	debug.Printf("Cloning repository: %s\n", repoURL)
+	// Each attempt starts from a clean target so a half-finished clone never
+	// poisons the retry
+	if err := harlequinRetry.Clones.Do(ctx, "git clone", func() error {
+		if err := os.RemoveAll(targetDir); err != nil {
+			return harlequinRetry.Permanent(fmt.Errorf("failed to remove existing directory: %w", err))
+		}
+		cloneCmd := exec.CommandContext(ctx, "git", "clone", repoURL, targetDir)
+		return cloneCmd.Run()
+	}); err != nil {
+		return fmt.Errorf("failed to clone repository: %w", err)
+	}
+
+	debug.Printf("Checking out commit: %s\n", commitHash)
+	checkoutCmd := exec.CommandContext(ctx, "git", "checkout", commitHash)
+	checkoutCmd.Dir = targetDir
+	if err := checkoutCmd.Run(); err != nil {
+		return fmt.Errorf("failed to checkout commit %s: %w", commitHash, err)
+	}
+	return nil
+}
+
+// cacheAOSCheckout copies a checked-out repository into the cache. Failures
+// are non-fatal for builds, so callers decide whether to surface the error.
+func cacheAOSCheckout(repoDir, commitHash string) error {
+	cacheDir, err := aosCacheDir(commitHash)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(cacheDir), 0755); err != nil {
+		return fmt.Errorf("failed to create AOS cache directory: %w", err)
+	}
+
+	// Copy into a temp sibling first so a partial copy never looks cached
+	stagingDir := cacheDir + ".tmp"
+	os.RemoveAll(stagingDir)
+	if err := copyDirectory(repoDir, stagingDir); err != nil {
+		os.RemoveAll(stagingDir)
+		return fmt.Errorf("failed to populate AOS cache: %w", err)
+	}
+	if err := os.Rename(stagingDir, cacheDir); err != nil {
+		os.RemoveAll(stagingDir)
+		return fmt.Errorf("failed to populate AOS cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package builders
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	harlequinConfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+)
+
+// minimalAOWasm assembles the smallest WASM binary that passes the AO
+// validation: a module exporting a "handle" function and a wasm32 memory.
+// Dropping the handle export produces a parseable but invalid module.
+func minimalAOWasm(withHandle bool) []byte {
+	module := []byte{
+		0x00, 0x61, 0x73, 0x6d, // magic "\0asm"
+		0x01, 0x00, 0x00, 0x00, // version 1
+		0x01, 0x04, 0x01, 0x60, 0x00, 0x00, // type section: one () -> () func type
+		0x03, 0x02, 0x01, 0x00, // function section: one function of type 0
+		0x05, 0x04, 0x01, 0x01, 0x01, 0x02, // memory section: min 1, max 2 pages (wasm32)
+	}
+
+	if withHandle {
+		module = append(module,
+			0x07, 0x13, 0x02, // export section: two exports
+			0x06, 'h', 'a', 'n', 'd', 'l', 'e', 0x00, 0x00, // "handle" -> function 0
+			0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00, // "memory" -> memory 0
+		)
+	} else {
+		module = append(module,
+			0x07, 0x0a, 0x01, // export section: memory only
+			0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00,
+		)
+	}
+
+	return append(module,
+		0x0a, 0x04, 0x01, 0x02, 0x00, 0x0b, // code section: one empty body
+	)
+}
+
+const orchestrationTestProcess = `require('.boot')
+
+Handlers.append("first", function() end)
+Handlers.append("second", function() end)
+`
+
+// newOrchestrationFixture sets up everything Build() needs without Docker or
+// network: a local git repository standing in for AOS, a Lua entrypoint, and
+// a builder wired to a FakeCommandRunner. The fake's default OnRun fabricates
+// a valid process.wasm when the docker build command runs.
+func newOrchestrationFixture(t *testing.T) (*AOSBuilder, *FakeCommandRunner, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	tempDir := t.TempDir()
+	// Keep the AOS checkout cache inside the test sandbox
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(tempDir, "cache"))
+
+	// A local git repository with a process/ directory stands in for AOS
+	repoDir := filepath.Join(tempDir, "aos-repo")
+	if err := os.MkdirAll(filepath.Join(repoDir, "process"), 0755); err != nil {
+		t.Fatalf("Failed to create fixture repo: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "process", "process.lua"), []byte(orchestrationTestProcess), 0644); err != nil {
+		t.Fatalf("Failed to write process.lua: %v", err)
+	}
+	runGit(t, repoDir, "init", "-q", "-b", "main")
+	runGit(t, repoDir, "add", ".")
+	runGit(t, repoDir, "commit", "-q", "-m", "fixture")
+
+	entrypoint := filepath.Join(tempDir, "main.lua")
+	if err := os.WriteFile(entrypoint, []byte("local M = {}\n\nfunction M.info()\n  return \"orchestration test\"\nend\n\nreturn M\n"), 0644); err != nil {
+		t.Fatalf("Failed to write entrypoint: %v", err)
+	}
+
+	config := &harlequinConfig.Config{
+		ModuleFormat: "wasm32-unknown-emscripten-metering",
+		AOSRepoURL:   repoDir,
+		AOSGitHash:   "main",
+	}
+	configFilePath := filepath.Join(tempDir, ".harlequin.yaml")
+	outputDir := filepath.Join(tempDir, "dist")
+
+	builder := newAOSBuilderWithWorkspace(AOSBuilderParams{
+		Config:         config,
+		ConfigFilePath: &configFilePath,
+		Entrypoint:     entrypoint,
+		OutputDir:      outputDir,
+		Callbacks:      CallbacksSilent,
+	}, filepath.Join(tempDir, "workspace"))
+
+	fake := &FakeCommandRunner{
+		OnRun: func(dir, name string, args []string) ([]byte, error) {
+			if isDockerBuildCall(name, args) {
+				return nil, os.WriteFile(filepath.Join(dir, "process.wasm"), minimalAOWasm(true), 0644)
+			}
+			return nil, nil
+		},
+	}
+	builder.SetCommandRunner(fake)
+
+	return builder, fake, outputDir
+}
+
+// runGit runs a git command in the fixture repository with a fixed identity
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	fullArgs := append([]string{"-c", "user.email=test@example.com", "-c", "user.name=test"}, args...)
+	cmd := exec.Command("git", fullArgs...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+	}
+}
+
+// isDockerBuildCall reports whether a recorded command is the container build
+func isDockerBuildCall(name string, args []string) bool {
+	return name == "docker" && len(args) > 0 && args[len(args)-1] == "ao-build-module"
+}
+
+// isWasmOptCall reports whether a recorded command is a wasm-opt invocation,
+// either via a local binary or the build container fallback
+func isWasmOptCall(name string, args []string) bool {
+	if strings.HasSuffix(name, "wasm-opt") {
+		return true
+	}
+	for _, arg := range args {
+		if arg == "wasm-opt" {
+			return true
+		}
+	}
+	return false
+}
+
+func TestBuildOrchestrationWithFakeRunner(t *testing.T) {
+	builder, fake, outputDir := newOrchestrationFixture(t)
+
+	if err := builder.Build(context.Background()); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	for _, artifact := range []string{"process.wasm", "bundled.lua", ManifestFileName} {
+		if _, err := os.Stat(filepath.Join(outputDir, artifact)); err != nil {
+			t.Errorf("Expected build output %s: %v", artifact, err)
+		}
+	}
+
+	// The docker build must have run against the configured image with the
+	// workspace mounted at /src
+	var buildCall *FakeCall
+	for i, call := range fake.Calls {
+		if isDockerBuildCall(call.Name, call.Args) {
+			buildCall = &fake.Calls[i]
+		}
+	}
+	if buildCall == nil {
+		t.Fatal("Expected a docker build command to be executed")
+	}
+	joined := strings.Join(buildCall.Args, " ")
+	if !strings.Contains(joined, builder.runner.GetImageName()) {
+		t.Errorf("Expected the build command to use image %s, got: %s", builder.runner.GetImageName(), joined)
+	}
+	if !strings.Contains(joined, ":/src") {
+		t.Errorf("Expected the workspace to be mounted at /src, got: %s", joined)
+	}
+}
+
+func TestBuildOrchestrationRunsWasmOpt(t *testing.T) {
+	builder, fake, _ := newOrchestrationFixture(t)
+	builder.config.Optimize = true
+
+	if err := builder.Build(context.Background()); err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	for _, call := range fake.Calls {
+		if isWasmOptCall(call.Name, call.Args) {
+			return
+		}
+	}
+	t.Error("Expected a wasm-opt command when optimize is enabled")
+}
+
+func TestBuildFailsWhenWasmCompileFails(t *testing.T) {
+	builder, fake, _ := newOrchestrationFixture(t)
+	fake.OnRun = func(dir, name string, args []string) ([]byte, error) {
+		if isDockerBuildCall(name, args) {
+			return []byte("emcc: error"), fmt.Errorf("exit status 1")
+		}
+		return nil, nil
+	}
+
+	err := builder.Build(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to build WASM") {
+		t.Errorf("Expected a WASM build failure, got: %v", err)
+	}
+}
+
+func TestBuildFailsWhenModuleIsInvalid(t *testing.T) {
+	builder, fake, _ := newOrchestrationFixture(t)
+	fake.OnRun = func(dir, name string, args []string) ([]byte, error) {
+		if isDockerBuildCall(name, args) {
+			// A parseable module that is missing the handle export
+			return nil, os.WriteFile(filepath.Join(dir, "process.wasm"), minimalAOWasm(false), 0644)
+		}
+		return nil, nil
+	}
+
+	err := builder.Build(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "handle") {
+		t.Errorf("Expected the AO validation to reject the module, got: %v", err)
+	}
+}
+
+func TestBuildFailsWhenWasmOptFails(t *testing.T) {
+	builder, fake, _ := newOrchestrationFixture(t)
+	builder.config.Optimize = true
+	previous := fake.OnRun
+	fake.OnRun = func(dir, name string, args []string) ([]byte, error) {
+		if isWasmOptCall(name, args) {
+			return []byte("wasm-opt: error"), fmt.Errorf("exit status 1")
+		}
+		return previous(dir, name, args)
+	}
+
+	err := builder.Build(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to optimize WASM") {
+		t.Errorf("Expected a wasm-opt failure, got: %v", err)
+	}
+}
+
+func TestBuildProceedsPastBrokenEntrypointSyntax(t *testing.T) {
+	builder, fake, _ := newOrchestrationFixture(t)
+	if err := os.WriteFile(builder.entrypoint, []byte("local x = =\n"), 0644); err != nil {
+		t.Fatalf("Failed to write entrypoint: %v", err)
+	}
+
+	// The pre-build syntax check is advisory (its parser is Lua 5.1, the
+	// container runs 5.3), so unparseable user code must warn, not block
+	if err := builder.Build(context.Background()); err != nil {
+		t.Fatalf("Expected the build to proceed despite the parse warning, got: %v", err)
+	}
+
+	ranDocker := false
+	for _, call := range fake.Calls {
+		if call.Name == "docker" {
+			ranDocker = true
+		}
+	}
+	if !ranDocker {
+		t.Error("Expected the build to reach the docker step")
+	}
+}
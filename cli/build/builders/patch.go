@@ -0,0 +1,156 @@
+package builders
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	lua "github.com/yuin/gopher-lua"
+
+	harlequinConfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+)
+
+// Injection points for code inserted into the AOS process.lua
+const (
+	PatchPointLastHandler = "last-handler" // After the last Handlers.append (the default)
+	PatchPointBeforeInit  = "before-init"  // Before the first Handlers.append, i.e. before the default handlers
+	PatchPointAfterBoot   = "after-boot"   // After the .boot require
+	PatchPointMarker      = "marker"       // Replacing a marker comment line
+	PatchPointAppend      = "append"       // At the end of the file
+)
+
+var (
+	handlersAppendPattern = regexp.MustCompile(`(?m)^.*Handlers\.append.*$`)
+	bootRequirePattern    = regexp.MustCompile(`(?m)^.*require\(['"]\.boot['"]\).*$`)
+)
+
+// insertAtPoint inserts code into the process content at a named injection
+// point. The marker argument is only consulted for the "marker" point.
+func insertAtPoint(content, code, point, marker string) (string, error) {
+	switch point {
+	case "", PatchPointLastHandler:
+		matches := handlersAppendPattern.FindAllStringIndex(content, -1)
+		if len(matches) == 0 {
+			return "", fmt.Errorf("no Handlers.append found in process.lua")
+		}
+		position := matches[len(matches)-1][1]
+		return content[:position] + "\n" + code + content[position:], nil
+
+	case PatchPointBeforeInit:
+		match := handlersAppendPattern.FindStringIndex(content)
+		if match == nil {
+			return "", fmt.Errorf("no Handlers.append found in process.lua")
+		}
+		return content[:match[0]] + code + "\n" + content[match[0]:], nil
+
+	case PatchPointAfterBoot:
+		match := bootRequirePattern.FindStringIndex(content)
+		if match == nil {
+			return "", fmt.Errorf("no .boot require found in process.lua")
+		}
+		return content[:match[1]] + "\n" + code + content[match[1]:], nil
+
+	case PatchPointMarker:
+		if marker == "" {
+			return "", fmt.Errorf("injection point %q needs a marker comment", PatchPointMarker)
+		}
+		if !strings.Contains(content, marker) {
+			return "", fmt.Errorf("marker %q not found in process.lua", marker)
+		}
+		return strings.Replace(content, marker, code, 1), nil
+
+	case PatchPointAppend:
+		return strings.TrimRight(content, "\n") + "\n\n" + code + "\n", nil
+
+	default:
+		return "", fmt.Errorf("unknown injection point %q", point)
+	}
+}
+
+// CheckLuaSyntax compiles Lua source without running it, so broken code
+// fails the build before Docker ever starts. The name shows up in the
+// file/line position of the parse error.
+func CheckLuaSyntax(name, code string) error {
+	L := lua.NewState()
+	defer L.Close()
+	if _, err := L.Load(strings.NewReader(code), name); err != nil {
+		return fmt.Errorf("%s has a Lua syntax error: %w", name, err)
+	}
+	return nil
+}
+
+// warnLuaSyntax runs the syntax check but only warns on failure. The
+// embedded parser implements Lua 5.1 while AOS runs Lua 5.3, so code using
+// 5.3-only syntax (bitwise operators, integer division, goto) parses fine
+// in the container but not here - a parse error is a hint, not proof, that
+// the build will fail.
+func warnLuaSyntax(name, code string) {
+	if err := CheckLuaSyntax(name, code); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+		fmt.Println("   The pre-build check uses a Lua 5.1 parser; Lua 5.3-only syntax will still build.")
+	}
+}
+
+// ApplyProcessPatches applies the config's patch declarations to the AOS
+// process file: user patch files at their injection points, then
+// Handlers.remove calls for the default handlers the config drops. The
+// patched result is syntax-checked before it is written back.
+func ApplyProcessPatches(processFilePath string, patches *harlequinConfig.ProcessPatchConfig, projectDir string) error {
+	if patches == nil {
+		return nil
+	}
+
+	content, err := os.ReadFile(processFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read process file: %w", err)
+	}
+	fileContent := string(content)
+
+	for _, patchFile := range patches.Files {
+		patchPath := patchFile.Path
+		if !filepath.IsAbs(patchPath) {
+			patchPath = filepath.Join(projectDir, patchPath)
+		}
+		patchCode, err := os.ReadFile(patchPath)
+		if err != nil {
+			return fmt.Errorf("failed to read patch file %s: %w", patchFile.Path, err)
+		}
+		if err := CheckLuaSyntax(patchFile.Path, string(patchCode)); err != nil {
+			return err
+		}
+
+		point := patchFile.Point
+		if point == "" {
+			point = PatchPointAppend
+		}
+		fileContent, err = insertAtPoint(fileContent, strings.TrimRight(string(patchCode), "\n"), point, patchFile.Marker)
+		if err != nil {
+			return fmt.Errorf("failed to apply patch file %s: %w", patchFile.Path, err)
+		}
+		debug.Printf("Applied patch file %s at %s\n", patchFile.Path, point)
+	}
+
+	if len(patches.RemoveHandlers) > 0 {
+		var removals []string
+		for _, name := range patches.RemoveHandlers {
+			removals = append(removals, fmt.Sprintf("Handlers.remove(%q)", name))
+		}
+		fileContent, err = insertAtPoint(fileContent, strings.Join(removals, "\n"), PatchPointAppend, "")
+		if err != nil {
+			return fmt.Errorf("failed to remove default handlers: %w", err)
+		}
+		debug.Printf("Removing default handlers: %s\n", strings.Join(patches.RemoveHandlers, ", "))
+	}
+
+	if err := CheckLuaSyntax("patched process.lua", fileContent); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(processFilePath, []byte(fileContent), 0644); err != nil {
+		return fmt.Errorf("failed to write patched process file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package builders
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	harlequinConfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+)
+
+const patchTestProcess = `require('.boot')
+
+local something = "test"
+
+Handlers.append("first", function() end)
+-- HARLEQUIN:EXTRA
+Handlers.append("second", function() end)
+
+print("done")
+`
+
+func TestInsertAtPointBeforeInit(t *testing.T) {
+	result, err := insertAtPoint(patchTestProcess, "local injected = true", PatchPointBeforeInit, "")
+	if err != nil {
+		t.Fatalf("insertAtPoint failed: %v", err)
+	}
+
+	injectedIndex := strings.Index(result, "local injected")
+	firstHandlerIndex := strings.Index(result, "Handlers.append")
+	if injectedIndex == -1 || firstHandlerIndex == -1 {
+		t.Fatal("Expected both the injected code and Handlers.append in the result")
+	}
+	if injectedIndex > firstHandlerIndex {
+		t.Error("Expected before-init code to land before the first Handlers.append")
+	}
+}
+
+func TestInsertAtPointAfterBoot(t *testing.T) {
+	result, err := insertAtPoint(patchTestProcess, "local injected = true", PatchPointAfterBoot, "")
+	if err != nil {
+		t.Fatalf("insertAtPoint failed: %v", err)
+	}
+
+	bootIndex := strings.Index(result, "require('.boot')")
+	injectedIndex := strings.Index(result, "local injected")
+	somethingIndex := strings.Index(result, "local something")
+	if injectedIndex < bootIndex || injectedIndex > somethingIndex {
+		t.Error("Expected after-boot code between the .boot require and the rest of the file")
+	}
+}
+
+func TestInsertAtPointMarker(t *testing.T) {
+	result, err := insertAtPoint(patchTestProcess, "local injected = true", PatchPointMarker, "-- HARLEQUIN:EXTRA")
+	if err != nil {
+		t.Fatalf("insertAtPoint failed: %v", err)
+	}
+	if strings.Contains(result, "-- HARLEQUIN:EXTRA") {
+		t.Error("Expected the marker comment to be replaced")
+	}
+	if !strings.Contains(result, "local injected = true") {
+		t.Error("Expected the injected code in place of the marker")
+	}
+
+	if _, err := insertAtPoint(patchTestProcess, "x = 1", PatchPointMarker, "-- MISSING"); err == nil {
+		t.Error("Expected an error for a marker that is not present")
+	}
+}
+
+func TestInsertAtPointRejectsUnknownPoint(t *testing.T) {
+	if _, err := insertAtPoint(patchTestProcess, "x = 1", "somewhere", ""); err == nil {
+		t.Error("Expected an error for an unknown injection point")
+	}
+}
+
+func TestCheckLuaSyntax(t *testing.T) {
+	if err := CheckLuaSyntax("good.lua", "local x = 1\nreturn x"); err != nil {
+		t.Errorf("Expected valid Lua to pass, got: %v", err)
+	}
+	if err := CheckLuaSyntax("bad.lua", "local x = = 1"); err == nil {
+		t.Error("Expected invalid Lua to fail the syntax check")
+	}
+}
+
+func TestApplyProcessPatches(t *testing.T) {
+	tempDir := t.TempDir()
+
+	processPath := filepath.Join(tempDir, "process.lua")
+	if err := os.WriteFile(processPath, []byte(patchTestProcess), 0644); err != nil {
+		t.Fatalf("Failed to write process file: %v", err)
+	}
+	patchPath := filepath.Join(tempDir, "extra.lua")
+	if err := os.WriteFile(patchPath, []byte("local patched = true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write patch file: %v", err)
+	}
+
+	patches := &harlequinConfig.ProcessPatchConfig{
+		RemoveHandlers: []string{"_default"},
+		Files: []harlequinConfig.ProcessPatchFile{
+			{Path: "extra.lua", Point: PatchPointMarker, Marker: "-- HARLEQUIN:EXTRA"},
+		},
+	}
+
+	if err := ApplyProcessPatches(processPath, patches, tempDir); err != nil {
+		t.Fatalf("ApplyProcessPatches failed: %v", err)
+	}
+
+	content, err := os.ReadFile(processPath)
+	if err != nil {
+		t.Fatalf("Failed to read patched process file: %v", err)
+	}
+	result := string(content)
+
+	if !strings.Contains(result, "local patched = true") {
+		t.Error("Expected the patch file content in the patched process")
+	}
+	if strings.Contains(result, "-- HARLEQUIN:EXTRA") {
+		t.Error("Expected the marker comment to be replaced")
+	}
+	if !strings.Contains(result, `Handlers.remove("_default")`) {
+		t.Error("Expected a Handlers.remove call for the dropped default handler")
+	}
+}
+
+func TestApplyProcessPatchesRejectsBrokenPatch(t *testing.T) {
+	tempDir := t.TempDir()
+
+	processPath := filepath.Join(tempDir, "process.lua")
+	if err := os.WriteFile(processPath, []byte(patchTestProcess), 0644); err != nil {
+		t.Fatalf("Failed to write process file: %v", err)
+	}
+	patchPath := filepath.Join(tempDir, "broken.lua")
+	if err := os.WriteFile(patchPath, []byte("local x = =\n"), 0644); err != nil {
+		t.Fatalf("Failed to write patch file: %v", err)
+	}
+
+	patches := &harlequinConfig.ProcessPatchConfig{
+		Files: []harlequinConfig.ProcessPatchFile{{Path: "broken.lua"}},
+	}
+
+	if err := ApplyProcessPatches(processPath, patches, tempDir); err == nil {
+		t.Error("Expected a syntax error to fail the patching")
+	}
+
+	// The process file must be untouched after a failed patch run
+	content, err := os.ReadFile(processPath)
+	if err != nil {
+		t.Fatalf("Failed to read process file: %v", err)
+	}
+	if string(content) != patchTestProcess {
+		t.Error("Expected the process file to be unchanged after a failed patch")
+	}
+}
@@ -0,0 +1,121 @@
+package builders
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+)
+
+// IsTealEntrypoint reports whether the entrypoint is a Teal source file that
+// needs transpiling before the Lua bundling step
+func IsTealEntrypoint(entrypoint string) bool {
+	return strings.HasSuffix(entrypoint, ".tl")
+}
+
+// TranspileTealProject type-checks and transpiles a Teal project into a
+// temporary directory of generated Lua, returning the path of the generated
+// entrypoint. Every .tl file under the entrypoint's directory is transpiled
+// with its relative path preserved, and all other files (plain Lua modules,
+// vendored dependencies, embedded assets) are copied alongside so require()
+// resolution in the bundler is unaffected. Type-check failures surface as
+// build errors carrying the compiler's diagnostics.
+//
+// The caller is responsible for removing the returned directory's parent
+// (the generated entrypoint sits at the root of the temp directory tree).
+func TranspileTealProject(ctx context.Context, entrypoint string) (string, string, error) {
+	tlPath, err := exec.LookPath("tl")
+	if err != nil {
+		return "", "", fmt.Errorf("teal compiler not found on PATH (install it with: luarocks install tl)")
+	}
+
+	srcDir := filepath.Dir(entrypoint)
+	absSrcDir, err := filepath.Abs(srcDir)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve project directory: %w", err)
+	}
+
+	genDir, err := os.MkdirTemp("", "harlequin-teal-gen-*")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create transpile directory: %w", err)
+	}
+
+	debug.Printf("Transpiling Teal project %s into %s\n", absSrcDir, genDir)
+
+	walkErr := filepath.WalkDir(absSrcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// Don't descend into hidden directories such as .git
+			if name := d.Name(); strings.HasPrefix(name, ".") && path != absSrcDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		relPath, err := filepath.Rel(absSrcDir, path)
+		if err != nil {
+			return err
+		}
+
+		if !strings.HasSuffix(path, ".tl") {
+			// Copy everything else verbatim so plain Lua modules, vendored
+			// dependencies and embedded assets stay resolvable
+			return copyFile(path, filepath.Join(genDir, relPath))
+		}
+
+		// Type-check first so failures carry the compiler's diagnostics
+		checkCmd := exec.CommandContext(ctx, tlPath, "check", path)
+		checkCmd.Dir = absSrcDir
+		if output, err := checkCmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("type check failed for %s:\n%s", relPath, strings.TrimSpace(string(output)))
+		}
+
+		// Transpile into the generated tree, preserving the relative path
+		outPath := filepath.Join(genDir, strings.TrimSuffix(relPath, ".tl")+".lua")
+		if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		genCmd := exec.CommandContext(ctx, tlPath, "gen", path, "-o", outPath)
+		genCmd.Dir = absSrcDir
+		if output, err := genCmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("transpile failed for %s:\n%s", relPath, strings.TrimSpace(string(output)))
+		}
+
+		debug.Printf("Transpiled %s -> %s\n", relPath, outPath)
+		return nil
+	})
+	if walkErr != nil {
+		os.RemoveAll(genDir)
+		return "", "", walkErr
+	}
+
+	relEntry, err := filepath.Rel(absSrcDir, mustAbs(entrypoint))
+	if err != nil {
+		os.RemoveAll(genDir)
+		return "", "", fmt.Errorf("failed to resolve entrypoint: %w", err)
+	}
+
+	genEntrypoint := filepath.Join(genDir, strings.TrimSuffix(relEntry, ".tl")+".lua")
+	if _, err := os.Stat(genEntrypoint); err != nil {
+		os.RemoveAll(genDir)
+		return "", "", fmt.Errorf("transpiled entrypoint not found at %s: %w", genEntrypoint, err)
+	}
+
+	return genEntrypoint, genDir, nil
+}
+
+// mustAbs resolves a path to absolute, falling back to the input on error
+func mustAbs(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return abs
+}
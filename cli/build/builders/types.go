@@ -2,6 +2,7 @@ package builders
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	harlequinConfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
@@ -22,6 +23,8 @@ type BuildInjectionOptions struct {
 	ProcessFilePath string
 	BundledCodePath string
 	RequireName     string // The name to use in require() statement
+	Point           string // Injection point (see patch.go); empty means after the last Handlers.append
+	Marker          string // Marker comment replaced when Point is "marker"
 }
 
 // AOSBuilderParams contains parameters for creating an AOSBuilder
@@ -46,12 +49,16 @@ type BuildStepInfo struct {
 
 // BuildCallbacks defines callback functions for each step of the build process
 type BuildCallbacks struct {
-	OnCopyAOSFiles func(ctx context.Context, info BuildStepInfo)
-	OnBundleLua    func(ctx context.Context, info BuildStepInfo)
+	OnCopyAOSFiles  func(ctx context.Context, info BuildStepInfo)
+	OnTranspileTeal func(ctx context.Context, info BuildStepInfo)
+	OnBundleLua     func(ctx context.Context, info BuildStepInfo)
 	OnInjectLua    func(ctx context.Context, info BuildStepInfo)
-	OnWasmCompile  func(ctx context.Context, info BuildStepInfo)
-	OnCopyOutputs  func(ctx context.Context, info BuildStepInfo)
-	OnCleanup      func(ctx context.Context, info BuildStepInfo)
+	OnPrepareNative  func(ctx context.Context, info BuildStepInfo)
+	OnWasmCompile    func(ctx context.Context, info BuildStepInfo)
+	OnOptimizeWasm   func(ctx context.Context, info BuildStepInfo)
+	OnMeteringReport func(ctx context.Context, info BuildStepInfo)
+	OnCopyOutputs    func(ctx context.Context, info BuildStepInfo)
+	OnCleanup        func(ctx context.Context, info BuildStepInfo)
 }
 
 // NoOpCallbacks returns a BuildCallbacks with no-op functions
@@ -63,12 +70,16 @@ func NoOpCallbacks() *BuildCallbacks {
 var (
 	// CallbacksSilent provides no-op callbacks for silent operation
 	CallbacksSilent = &BuildCallbacks{
-		OnCopyAOSFiles: func(ctx context.Context, info BuildStepInfo) {},
-		OnBundleLua:    func(ctx context.Context, info BuildStepInfo) {},
+		OnCopyAOSFiles:  func(ctx context.Context, info BuildStepInfo) {},
+		OnTranspileTeal: func(ctx context.Context, info BuildStepInfo) {},
+		OnBundleLua:     func(ctx context.Context, info BuildStepInfo) {},
 		OnInjectLua:    func(ctx context.Context, info BuildStepInfo) {},
-		OnWasmCompile:  func(ctx context.Context, info BuildStepInfo) {},
-		OnCopyOutputs:  func(ctx context.Context, info BuildStepInfo) {},
-		OnCleanup:      func(ctx context.Context, info BuildStepInfo) {},
+		OnPrepareNative:  func(ctx context.Context, info BuildStepInfo) {},
+		OnWasmCompile:    func(ctx context.Context, info BuildStepInfo) {},
+		OnOptimizeWasm:   func(ctx context.Context, info BuildStepInfo) {},
+		OnMeteringReport: func(ctx context.Context, info BuildStepInfo) {},
+		OnCopyOutputs:    func(ctx context.Context, info BuildStepInfo) {},
+		OnCleanup:        func(ctx context.Context, info BuildStepInfo) {},
 	}
 
 	// CallbacksDefault provides standard emoji-based logging
@@ -80,6 +91,13 @@ var (
 				println("❌ Failed to prepare AOS workspace:", info.Error.Error())
 			}
 		},
+		OnTranspileTeal: func(ctx context.Context, info BuildStepInfo) {
+			if info.Success {
+				println("🩵 Step 1b: Transpiling Teal sources...")
+			} else {
+				println("❌ Teal type check failed:", info.Error.Error())
+			}
+		},
 		OnBundleLua: func(ctx context.Context, info BuildStepInfo) {
 			if info.Success {
 				println("📦 Step 2: Bundling Lua project...")
@@ -94,6 +112,13 @@ var (
 				println("❌ Failed to inject Lua code:", info.Error.Error())
 			}
 		},
+		OnPrepareNative: func(ctx context.Context, info BuildStepInfo) {
+			if info.Success {
+				println("🔩 Step 4b: Staging native C modules...")
+			} else {
+				println("❌ Failed to prepare native modules:", info.Error.Error())
+			}
+		},
 		OnWasmCompile: func(ctx context.Context, info BuildStepInfo) {
 			if info.Success {
 				println("🏗️  Step 5: Building WASM with Docker...")
@@ -101,6 +126,22 @@ var (
 				println("❌ Failed to compile WASM:", info.Error.Error())
 			}
 		},
+		OnOptimizeWasm: func(ctx context.Context, info BuildStepInfo) {
+			if info.Success {
+				println("🗜️  Optimizing WASM with wasm-opt...", FormatOptimizationSizes(info))
+			} else {
+				println("❌ Failed to optimize WASM:", info.Error.Error())
+			}
+		},
+		OnMeteringReport: func(ctx context.Context, info BuildStepInfo) {
+			if info.Success {
+				if report, ok := info.Metadata["report"].(string); ok {
+					print(report)
+				}
+			} else {
+				println("❌ Failed to generate metering report:", info.Error.Error())
+			}
+		},
 		OnCopyOutputs: func(ctx context.Context, info BuildStepInfo) {
 			if info.Success {
 				println("📋 Step 6: Copying build outputs...")
@@ -126,6 +167,13 @@ var (
 				println("❌ Workspace setup failed after", info.Duration.String()+":", info.Error.Error())
 			}
 		},
+		OnTranspileTeal: func(ctx context.Context, info BuildStepInfo) {
+			if info.Success {
+				println("⏱️  Teal transpilation completed in", info.Duration.String())
+			} else {
+				println("❌ Teal transpilation failed after", info.Duration.String()+":", info.Error.Error())
+			}
+		},
 		OnBundleLua: func(ctx context.Context, info BuildStepInfo) {
 			if info.Success {
 				println("⏱️  Lua bundling completed in", info.Duration.String())
@@ -140,6 +188,13 @@ var (
 				println("❌ Code injection failed after", info.Duration.String()+":", info.Error.Error())
 			}
 		},
+		OnPrepareNative: func(ctx context.Context, info BuildStepInfo) {
+			if info.Success {
+				println("⏱️  Native module staging completed in", info.Duration.String())
+			} else {
+				println("❌ Native module staging failed after", info.Duration.String()+":", info.Error.Error())
+			}
+		},
 		OnWasmCompile: func(ctx context.Context, info BuildStepInfo) {
 			if info.Success {
 				println("⏱️  WASM compilation completed in", info.Duration.String())
@@ -147,6 +202,20 @@ var (
 				println("❌ WASM compilation failed after", info.Duration.String()+":", info.Error.Error())
 			}
 		},
+		OnOptimizeWasm: func(ctx context.Context, info BuildStepInfo) {
+			if info.Success {
+				println("⏱️  WASM optimization completed in", info.Duration.String(), FormatOptimizationSizes(info))
+			} else {
+				println("❌ WASM optimization failed after", info.Duration.String()+":", info.Error.Error())
+			}
+		},
+		OnMeteringReport: func(ctx context.Context, info BuildStepInfo) {
+			if info.Success {
+				println("⏱️  Metering report generated in", info.Duration.String())
+			} else {
+				println("❌ Metering report failed after", info.Duration.String()+":", info.Error.Error())
+			}
+		},
 		OnCopyOutputs: func(ctx context.Context, info BuildStepInfo) {
 			if info.Success {
 				println("⏱️  Output copying completed in", info.Duration.String())
@@ -168,3 +237,26 @@ var (
 func DefaultLoggingCallbacks() *BuildCallbacks {
 	return CallbacksDefault
 }
+
+// FormatOptimizationSizes renders the before/after sizes recorded by the
+// OptimizeWasm step, e.g. "(2.1 MB → 1.4 MB)". Returns "" if no sizes were recorded.
+func FormatOptimizationSizes(info BuildStepInfo) string {
+	before, beforeOk := info.Metadata["size_before"].(int64)
+	after, afterOk := info.Metadata["size_after"].(int64)
+	if !beforeOk || !afterOk {
+		return ""
+	}
+	return fmt.Sprintf("(%s → %s)", formatByteSize(before), formatByteSize(after))
+}
+
+// formatByteSize renders a byte count in a human-readable unit
+func formatByteSize(size int64) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
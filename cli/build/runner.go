@@ -3,6 +3,7 @@ package build
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	harlequinConfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
 )
@@ -30,7 +31,15 @@ func NewBuildRunner(cfg *harlequinConfig.Config, workspaceDir, imageName, contai
 
 // NewAOBuildRunner creates a new build runner instance with default AO image and container name
 func NewAOBuildRunner(cfg *harlequinConfig.Config, workspaceDir string) (*BuildRunner, error) {
-	return NewBuildRunner(cfg, workspaceDir, AOBuildContainerDockerImage, ContainerName)
+	imageName := AOBuildContainerDockerImage
+
+	// A digest pinned in the config replaces the versioned tag, so builds run
+	// against an immutable image
+	if cfg != nil && cfg.DockerImageDigest != "" {
+		imageName = strings.SplitN(AOBuildContainerDockerImage, ":", 2)[0] + "@" + cfg.DockerImageDigest
+	}
+
+	return NewBuildRunner(cfg, workspaceDir, imageName, ContainerName)
 }
 
 // Close closes the build runner and cleans up resources
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/everFinance/goar/types"
+	luautils "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/lua_utils"
+)
+
+// ProcessABIFileName is where the generated ABI lives in a project
+const ProcessABIFileName = "process-abi.json"
+
+// ProcessABI is the machine-readable description of the actions a process
+// handles, generated from its source and published alongside the module
+type ProcessABI struct {
+	Version    string      `json:"version"`
+	Entrypoint string      `json:"entrypoint"`
+	Actions    []ABIAction `json:"actions"`
+}
+
+// ABIAction describes one handler: the Action tag it matches and the message
+// tags its implementation reads
+type ABIAction struct {
+	Handler string   `json:"handler"`
+	Action  string   `json:"action,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// ExtractProcessABI parses the project's handlers into an ABI. Lua and Teal
+// entrypoints are followed through their whole bundle dependency graph; Go
+// and AssemblyScript entrypoints are scanned for their action dispatch.
+func ExtractProcessABI(entrypoint string) (*ProcessABI, error) {
+	abi := &ProcessABI{
+		Version:    "1",
+		Entrypoint: entrypoint,
+	}
+
+	switch {
+	case strings.HasSuffix(entrypoint, ".lua"), strings.HasSuffix(entrypoint, ".tl"):
+		files, err := luautils.ProjectLuaFiles(entrypoint)
+		if err != nil {
+			// Teal sources resolve requires the same way but are not
+			// bundleable before transpilation - fall back to the entrypoint
+			files = []string{entrypoint}
+		}
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			abi.Actions = append(abi.Actions, extractLuaActions(string(content))...)
+		}
+	case strings.HasSuffix(entrypoint, ".go"), strings.HasSuffix(entrypoint, ".ts"):
+		content, err := os.ReadFile(entrypoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entrypoint, err)
+		}
+		abi.Actions = extractDispatchActions(string(content), strings.HasSuffix(entrypoint, ".go"))
+	default:
+		return nil, fmt.Errorf("unsupported entrypoint type: %s", entrypoint)
+	}
+
+	if len(abi.Actions) == 0 {
+		return nil, fmt.Errorf("no handlers found in %s", entrypoint)
+	}
+	return abi, nil
+}
+
+var (
+	handlerNamePattern = regexp.MustCompile(`^\s*"([^"]+)"`)
+	matchingTagPattern = regexp.MustCompile(`hasMatchingTag\(\s*"Action",\s*"([^"]+)"`)
+	tagFieldPattern    = regexp.MustCompile(`msg\.Tags\.([A-Za-z_][A-Za-z0-9_]*)`)
+	tagIndexPattern    = regexp.MustCompile(`msg\.Tags\[\s*"([^"]+)"\s*\]`)
+	goCasePattern      = regexp.MustCompile(`case "([^"]+)":`)
+	asBranchPattern    = regexp.MustCompile(`action === "([^"]+)"`)
+)
+
+// extractLuaActions finds every Handlers.add block in a Lua or Teal source
+// and reads its handler name, matched Action tag, and referenced message tags
+func extractLuaActions(source string) []ABIAction {
+	var actions []ABIAction
+	for _, block := range luaHandlerAddBlocks(source) {
+		action := ABIAction{}
+		if match := handlerNamePattern.FindStringSubmatch(block); match != nil {
+			action.Handler = match[1]
+		} else {
+			continue
+		}
+		if match := matchingTagPattern.FindStringSubmatch(block); match != nil {
+			action.Action = match[1]
+		}
+
+		tagSet := make(map[string]bool)
+		for _, match := range tagFieldPattern.FindAllStringSubmatch(block, -1) {
+			tagSet[match[1]] = true
+		}
+		for _, match := range tagIndexPattern.FindAllStringSubmatch(block, -1) {
+			tagSet[match[1]] = true
+		}
+		for tag := range tagSet {
+			action.Tags = append(action.Tags, tag)
+		}
+		sort.Strings(action.Tags)
+
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+// luaHandlerAddBlocks returns the argument span of each Handlers.add call,
+// tracking string literals and line comments so parentheses inside them never
+// unbalance the scan
+func luaHandlerAddBlocks(source string) []string {
+	var blocks []string
+	for offset := 0; ; {
+		index := strings.Index(source[offset:], "Handlers.add(")
+		if index < 0 {
+			break
+		}
+		start := offset + index + len("Handlers.add(")
+
+		depth := 1
+		inString := byte(0)
+		end := -1
+		for i := start; i < len(source); i++ {
+			c := source[i]
+			if inString != 0 {
+				if c == '\\' {
+					i++
+				} else if c == inString {
+					inString = 0
+				}
+				continue
+			}
+			switch c {
+			case '"', '\'':
+				inString = c
+			case '-':
+				if i+1 < len(source) && source[i+1] == '-' {
+					for i < len(source) && source[i] != '\n' {
+						i++
+					}
+				}
+			case '(':
+				depth++
+			case ')':
+				depth--
+				if depth == 0 {
+					end = i
+				}
+			}
+			if end >= 0 {
+				break
+			}
+		}
+		if end < 0 {
+			break
+		}
+
+		blocks = append(blocks, source[start:end])
+		offset = end + 1
+	}
+	return blocks
+}
+
+// extractDispatchActions reads the action dispatch the Go and AssemblyScript
+// templates use: a switch over the action for Go, else-if branches for AS
+func extractDispatchActions(source string, isGo bool) []ABIAction {
+	pattern := asBranchPattern
+	if isGo {
+		pattern = goCasePattern
+	}
+
+	var actions []ABIAction
+	seen := make(map[string]bool)
+	for _, match := range pattern.FindAllStringSubmatch(source, -1) {
+		if seen[match[1]] {
+			continue
+		}
+		seen[match[1]] = true
+		actions = append(actions, ABIAction{
+			Handler: strings.ToLower(match[1]),
+			Action:  match[1],
+		})
+	}
+	return actions
+}
+
+// WriteProcessABI writes the ABI as pretty-printed JSON
+func WriteProcessABI(abi *ProcessABI, path string) error {
+	data, err := json.MarshalIndent(abi, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal ABI: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// LoadProcessABI reads a previously generated ABI, returning nil without an
+// error when the project has none
+func LoadProcessABI(path string) (*ProcessABI, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var abi ProcessABI
+	if err := json.Unmarshal(data, &abi); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &abi, nil
+}
+
+// validateMessageAgainstABI warns when the project's ABI does not declare the
+// action being sent, or when the matched handler reads tags the message does
+// not carry. Warnings only - the deployed module may differ from local source.
+func validateMessageAgainstABI(action string, tags []types.Tag) {
+	abi, err := LoadProcessABI(ProcessABIFileName)
+	if err != nil || abi == nil {
+		return
+	}
+
+	var matched *ABIAction
+	var known []string
+	for i, abiAction := range abi.Actions {
+		if abiAction.Action == "" {
+			continue
+		}
+		known = append(known, abiAction.Action)
+		if abiAction.Action == action {
+			matched = &abi.Actions[i]
+		}
+	}
+
+	if matched == nil {
+		fmt.Printf("⚠️  Action %q is not declared in %s (known actions: %s)\n", action, ProcessABIFileName, strings.Join(known, ", "))
+		return
+	}
+
+	provided := make(map[string]bool)
+	for _, tag := range tags {
+		provided[tag.Name] = true
+	}
+	for _, tag := range matched.Tags {
+		if !provided[tag] {
+			fmt.Printf("⚠️  Handler %q reads tag %q, which this message does not set\n", matched.Handler, tag)
+		}
+	}
+}
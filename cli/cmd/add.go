@@ -0,0 +1,386 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/luadeps"
+)
+
+// arweaveTxIDPattern matches a base64url-encoded Arweave transaction ID
+var arweaveTxIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{43}$`)
+
+// HandleAddCommand handles the add command for vendoring Lua dependencies
+func HandleAddCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling add command with args: %v", args)
+
+	var source string
+	var name string
+	var registry string
+	var gatewayURL string
+	var cuURL string
+	var networkPreset string
+
+	// Parse command line arguments
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--name", "-n":
+			if i+1 < len(args) {
+				name = args[i+1]
+				i++
+			}
+		case "--registry":
+			if i+1 < len(args) {
+				registry = args[i+1]
+				i++
+			}
+		case "--gateway":
+			if i+1 < len(args) {
+				gatewayURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--cu":
+			if i+1 < len(args) {
+				cuURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--network":
+			if i+1 < len(args) {
+				networkPreset = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintAddUsage()
+			return
+		default:
+			if !strings.HasPrefix(args[i], "-") && source == "" {
+				source = args[i]
+			}
+		}
+	}
+
+	network := resolveNetwork(networkFlags{Preset: networkPreset, Gateway: gatewayURL, CU: cuURL})
+	gatewayURL = strings.TrimRight(network.Gateway, "/")
+	cuURL = strings.TrimRight(network.CU, "/")
+
+	projectDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if source == "" {
+		// No source: verify the lockfile against the vendored tree
+		verifyLockfile(projectDir)
+		return
+	}
+
+	lockfile, err := luadeps.LoadLockfile(projectDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var content []byte
+	var locked luadeps.LockedPackage
+
+	switch {
+	case arweaveTxIDPattern.MatchString(source):
+		if name == "" {
+			fmt.Println("Error: --name is required when adding by transaction ID")
+			os.Exit(1)
+		}
+		content, locked, err = fetchArweavePackage(ctx, gatewayURL, source)
+	case isGitSource(source):
+		content, locked, err = fetchGitPackage(ctx, source, &name)
+	default:
+		if registry == "" {
+			fmt.Println("Error: --registry <process-id> is required when adding by package name")
+			os.Exit(1)
+		}
+		if name == "" {
+			name = source
+		}
+		content, locked, err = fetchRegistryPackage(ctx, gatewayURL, cuURL, registry, source)
+	}
+	if err != nil {
+		fmt.Printf("❌ Failed to fetch package: %v\n", err)
+		os.Exit(1)
+	}
+
+	checksum, err := luadeps.Vendor(projectDir, name, content)
+	if err != nil {
+		fmt.Printf("❌ Failed to vendor package: %v\n", err)
+		os.Exit(1)
+	}
+	locked.SHA256 = checksum
+
+	lockfile.Packages[name] = locked
+	if err := lockfile.Save(projectDir); err != nil {
+		fmt.Printf("❌ Failed to update lockfile: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("✅ Package added successfully!")
+	fmt.Printf("   📦 Name: %s\n", name)
+	fmt.Printf("   📄 Vendored: %s (%d bytes)\n", luadeps.VendorPath(".", name), len(content))
+	fmt.Printf("   🔒 Pinned: %s (%s)\n", locked.Reference, locked.Source)
+	fmt.Printf("   💡 Use it with: require(%q)\n", name)
+}
+
+// verifyLockfile checks vendored packages against the lockfile
+func verifyLockfile(projectDir string) {
+	lockfile, err := luadeps.LoadLockfile(projectDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(lockfile.Packages) == 0 {
+		fmt.Println("No dependencies in " + luadeps.LockfileName)
+		fmt.Println()
+		fmt.Println("Add one with:")
+		fmt.Println("    harlequin add <txID> --name <name>")
+		fmt.Println("    harlequin add <git-url>")
+		return
+	}
+
+	broken := lockfile.Verify(projectDir)
+
+	fmt.Printf("🎭 %d locked package(s):\n", len(lockfile.Packages))
+	for _, pkgName := range lockfile.Names() {
+		pkg := lockfile.Packages[pkgName]
+		status := "✓"
+		for _, brokenName := range broken {
+			if brokenName == pkgName {
+				status = "✗ (missing or modified)"
+				break
+			}
+		}
+		fmt.Printf("   %s %s  %s %s\n", status, pkgName, pkg.Source, pkg.Reference)
+	}
+
+	if len(broken) > 0 {
+		fmt.Println()
+		fmt.Println("Re-add broken packages to restore them from their pinned sources.")
+		os.Exit(1)
+	}
+}
+
+// fetchArweavePackage downloads a Lua module published on Arweave by txID
+func fetchArweavePackage(ctx context.Context, gatewayURL, txID string) ([]byte, luadeps.LockedPackage, error) {
+	fmt.Printf("🎭 Fetching %s from %s\n", txID, gatewayURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gatewayURL+"/"+txID, nil)
+	if err != nil {
+		return nil, luadeps.LockedPackage{}, err
+	}
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, luadeps.LockedPackage{}, fmt.Errorf("gateway unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, luadeps.LockedPackage{}, fmt.Errorf("gateway error (HTTP %d)", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, luadeps.LockedPackage{}, err
+	}
+
+	return content, luadeps.LockedPackage{Source: "arweave", Reference: txID}, nil
+}
+
+// isGitSource reports whether the source looks like a git repository URL
+func isGitSource(source string) bool {
+	return strings.HasSuffix(strings.SplitN(source, "#", 2)[0], ".git") ||
+		strings.HasPrefix(source, "git@") ||
+		strings.HasPrefix(source, "https://github.com/") ||
+		strings.HasPrefix(source, "https://gitlab.com/")
+}
+
+// fetchGitPackage clones a git repository and vendors a Lua file from it.
+// A fragment selects the file within the repo (url#path/to/module.lua);
+// without one, <name>.lua at the repository root is used.
+func fetchGitPackage(ctx context.Context, source string, name *string) ([]byte, luadeps.LockedPackage, error) {
+	parts := strings.SplitN(source, "#", 2)
+	repoURL := parts[0]
+
+	filePath := ""
+	if len(parts) == 2 {
+		filePath = parts[1]
+	}
+
+	if *name == "" {
+		base := strings.TrimSuffix(filepath.Base(repoURL), ".git")
+		if filePath != "" {
+			base = strings.TrimSuffix(filepath.Base(filePath), ".lua")
+		}
+		*name = base
+	}
+	if filePath == "" {
+		filePath = *name + ".lua"
+	}
+
+	fmt.Printf("🎭 Cloning %s\n", repoURL)
+
+	tempDir, err := os.MkdirTemp("", "harlequin-add-*")
+	if err != nil {
+		return nil, luadeps.LockedPackage{}, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	cloneCmd := exec.CommandContext(ctx, "git", "clone", "--depth", "1", repoURL, tempDir)
+	if output, err := cloneCmd.CombinedOutput(); err != nil {
+		return nil, luadeps.LockedPackage{}, fmt.Errorf("git clone failed: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	revCmd := exec.CommandContext(ctx, "git", "-C", tempDir, "rev-parse", "HEAD")
+	commitBytes, err := revCmd.Output()
+	if err != nil {
+		return nil, luadeps.LockedPackage{}, fmt.Errorf("failed to resolve commit: %w", err)
+	}
+	commit := strings.TrimSpace(string(commitBytes))
+
+	content, err := os.ReadFile(filepath.Join(tempDir, filePath))
+	if err != nil {
+		return nil, luadeps.LockedPackage{}, fmt.Errorf("repository has no %s: %w", filePath, err)
+	}
+
+	return content, luadeps.LockedPackage{Source: "git", Reference: commit, URL: repoURL}, nil
+}
+
+// fetchRegistryPackage resolves a package name to a txID via a registry
+// process dry-run, then downloads the module from the gateway
+func fetchRegistryPackage(ctx context.Context, gatewayURL, cuURL, registry, packageName string) ([]byte, luadeps.LockedPackage, error) {
+	fmt.Printf("🎭 Resolving %q via registry %s\n", packageName, registry)
+
+	dryRunMessage := map[string]interface{}{
+		"Id":     "HARLEQUIN_ADD_MESSAGE",
+		"Target": registry,
+		"Owner":  "HARLEQUIN_ADD_OWNER",
+		"Tags": []map[string]string{
+			{"name": "Action", "value": "Resolve"},
+			{"name": "Name", "value": packageName},
+		},
+	}
+	body, err := json.Marshal(dryRunMessage)
+	if err != nil {
+		return nil, luadeps.LockedPackage{}, err
+	}
+
+	dryRunURL := fmt.Sprintf("%s/dry-run?process-id=%s", cuURL, registry)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dryRunURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, luadeps.LockedPackage{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, luadeps.LockedPackage{}, fmt.Errorf("compute unit unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, luadeps.LockedPackage{}, fmt.Errorf("registry dry-run error (HTTP %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	var result messageResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, luadeps.LockedPackage{}, fmt.Errorf("failed to parse registry response: %w", err)
+	}
+	if len(result.Error) > 0 && string(result.Error) != "null" {
+		return nil, luadeps.LockedPackage{}, fmt.Errorf("registry error: %s", formatResultValue(result.Error))
+	}
+
+	// The registry replies with the txID, either raw or as {"txid": "..."}
+	txID := ""
+	if len(result.Messages) > 0 {
+		var reply struct {
+			Data string `json:"Data"`
+		}
+		if err := json.Unmarshal(result.Messages[0], &reply); err == nil {
+			txID = reply.Data
+		}
+	}
+	if txID == "" {
+		txID = formatResultValue(result.Output)
+	}
+	var structured struct {
+		TxID string `json:"txid"`
+	}
+	if err := json.Unmarshal([]byte(txID), &structured); err == nil && structured.TxID != "" {
+		txID = structured.TxID
+	}
+	txID = strings.TrimSpace(txID)
+	if !arweaveTxIDPattern.MatchString(txID) {
+		return nil, luadeps.LockedPackage{}, fmt.Errorf("registry returned no txID for %q", packageName)
+	}
+
+	content, locked, err := fetchArweavePackage(ctx, gatewayURL, txID)
+	if err != nil {
+		return nil, luadeps.LockedPackage{}, err
+	}
+	locked.Source = "registry"
+	locked.URL = registry
+	return content, locked, nil
+}
+
+// PrintAddUsage prints usage information for the add command
+func PrintAddUsage() {
+	fmt.Println("🎭 Harlequin Add - Vendor Lua Dependencies for AO Processes")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin add [SOURCE] [OPTIONS]")
+	fmt.Println()
+	fmt.Println("ARGUMENTS:")
+	fmt.Println("    SOURCE                 Arweave txID, git URL, or registry package name")
+	fmt.Println("                           (omit to verify the lockfile)")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -n, --name <NAME>        Module name used in require() (required for txIDs)")
+	fmt.Println("    --registry <PROCESS_ID>  Registry process for name resolution")
+	fmt.Println("    --gateway <URL>          Gateway URL (default: " + defaultGatewayURL + ")")
+	fmt.Println("    --cu <URL>               Compute unit URL for registry lookups (default: " + defaultComputeURL + ")")
+	fmt.Println("    --network <PRESET>       Endpoint preset: mainnet, testnet, or localnet")
+	fmt.Println("    -h, --help               Show this help message")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    Fetches a Lua module, vendors it under lua_modules/, and pins it in")
+	fmt.Println("    " + luadeps.LockfileName + ". The bundler resolves require() calls against")
+	fmt.Println("    the vendored tree, so vendored modules work in builds and harlequin eval.")
+	fmt.Println()
+	fmt.Println("    For git sources, a #fragment selects the file within the repository;")
+	fmt.Println("    without one, <name>.lua at the repository root is used.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    # Vendor a module published on Arweave")
+	fmt.Println("    harlequin add nF6Mjc_qeQSNrPyOXmDjgA43fWmRc_zvNZ2KcTALq-Y --name utils")
+	fmt.Println()
+	fmt.Println("    # Vendor a file from a git repository")
+	fmt.Println("    harlequin add https://github.com/example/ao-utils.git#src/utils.lua --name ao.utils")
+	fmt.Println()
+	fmt.Println("    # Resolve a name via a registry process")
+	fmt.Println("    harlequin add utils --registry abc123...")
+	fmt.Println()
+	fmt.Println("    # Verify vendored packages against the lockfile")
+	fmt.Println("    harlequin add")
+}
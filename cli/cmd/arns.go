@@ -0,0 +1,372 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/everFinance/goar/types"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+)
+
+// arnsRecord is the gateway resolver's view of an ArNS name
+type arnsRecord struct {
+	TxID       string `json:"txId"`
+	ProcessID  string `json:"processId"`
+	TTLSeconds int    `json:"ttlSeconds"`
+}
+
+// HandleArnsCommand handles the arns command for pointing ArNS names at
+// deployed artifacts
+func HandleArnsCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling arns command with args: %v", args)
+
+	if len(args) == 0 {
+		PrintArnsUsage()
+		return
+	}
+
+	switch args[0] {
+	case "set":
+		handleArnsSet(ctx, args[1:])
+	case "get":
+		handleArnsGet(ctx, args[1:])
+	case "--help", "-h", "help":
+		PrintArnsUsage()
+	default:
+		fmt.Printf("Error: Unknown arns subcommand: %s\n", args[0])
+		PrintArnsUsage()
+		os.Exit(1)
+	}
+}
+
+// handleArnsGet resolves an ArNS name through the gateway resolver
+func handleArnsGet(ctx context.Context, args []string) {
+	var name string
+	var gatewayURL string
+	var networkPreset string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--gateway":
+			if i+1 < len(args) {
+				gatewayURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--network":
+			if i+1 < len(args) {
+				networkPreset = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintArnsUsage()
+			return
+		default:
+			if !strings.HasPrefix(args[i], "-") && name == "" {
+				name = args[i]
+			}
+		}
+	}
+
+	if name == "" {
+		fmt.Println("Error: No ArNS name specified")
+		PrintArnsUsage()
+		os.Exit(1)
+	}
+
+	network := resolveNetwork(networkFlags{Preset: networkPreset, Gateway: gatewayURL})
+	record, err := resolveArNSRecord(ctx, network.Gateway, name)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("🎭 ArNS record for %s\n", name)
+	fmt.Printf("   • Points at: %s\n", record.TxID)
+	fmt.Printf("   • ANT process: %s\n", record.ProcessID)
+	fmt.Printf("   • TTL: %ds\n", record.TTLSeconds)
+}
+
+// handleArnsSet updates the ANT record behind an ArNS name to point at a new
+// transaction ID
+func handleArnsSet(ctx context.Context, args []string) {
+	var name string
+	var txID string
+	var undername = "@"
+	var ttlSeconds = 900
+	var walletPath string
+	var dryRun bool
+	var gatewayURL string
+	var cuURL string
+	var muURL string
+	var networkPreset string
+	waitTimeout := "2m"
+
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--undername", "-u":
+			if i+1 < len(args) {
+				undername = args[i+1]
+				i++
+			}
+		case "--ttl":
+			if i+1 < len(args) {
+				ttl, err := strconv.Atoi(args[i+1])
+				if err != nil || ttl <= 0 {
+					fmt.Printf("Error: invalid --ttl value %q\n", args[i+1])
+					os.Exit(1)
+				}
+				ttlSeconds = ttl
+				i++
+			}
+		case "--wallet-file", "-w":
+			if i+1 < len(args) {
+				walletPath = args[i+1]
+				i++
+			}
+		case "--dry-run":
+			dryRun = true
+		case "--gateway":
+			if i+1 < len(args) {
+				gatewayURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--cu":
+			if i+1 < len(args) {
+				cuURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--mu":
+			if i+1 < len(args) {
+				muURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--network":
+			if i+1 < len(args) {
+				networkPreset = args[i+1]
+				i++
+			}
+		case "--wait-timeout":
+			if i+1 < len(args) {
+				waitTimeout = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintArnsUsage()
+			return
+		default:
+			if !strings.HasPrefix(args[i], "-") {
+				positional = append(positional, args[i])
+			}
+		}
+	}
+
+	if len(positional) >= 1 {
+		name = positional[0]
+	}
+	if len(positional) >= 2 {
+		txID = positional[1]
+	}
+	if name == "" || txID == "" {
+		fmt.Println("Error: Both an ArNS name and a transaction ID are required")
+		PrintArnsUsage()
+		os.Exit(1)
+	}
+	if !arweaveTxIDPattern.MatchString(txID) {
+		fmt.Printf("Error: %q is not a valid Arweave transaction ID\n", txID)
+		os.Exit(1)
+	}
+	if walletPath == "" {
+		if os.Getenv("WALLET_PATH") != "" {
+			walletPath = os.Getenv("WALLET_PATH")
+		} else {
+			walletPath = "key.json"
+		}
+	}
+
+	waitDuration, err := time.ParseDuration(waitTimeout)
+	if err != nil {
+		fmt.Printf("Error: invalid --wait-timeout value %q (use e.g. 90s, 5m)\n", waitTimeout)
+		os.Exit(1)
+	}
+
+	network := resolveNetwork(networkFlags{Preset: networkPreset, Gateway: gatewayURL, CU: cuURL, MU: muURL})
+
+	// Resolve the name so the update targets the right ANT process and the
+	// preview can show what the record currently points at
+	record, err := resolveArNSRecord(ctx, network.Gateway, name)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if record.ProcessID == "" {
+		fmt.Printf("Error: the resolver reports no ANT process for %s - is the name registered?\n", name)
+		os.Exit(1)
+	}
+
+	fmt.Printf("🎭 ArNS update for %s\n", name)
+	fmt.Printf("   • ANT process: %s\n", record.ProcessID)
+	fmt.Printf("   • Undername: %s\n", undername)
+	fmt.Printf("   • Current target: %s\n", record.TxID)
+	fmt.Printf("   • New target: %s\n", txID)
+	fmt.Printf("   • TTL: %ds\n", ttlSeconds)
+	fmt.Println()
+
+	if dryRun {
+		fmt.Println("🌵 DRY RUN MODE")
+		fmt.Println("   • No Set-Record message will be sent")
+		fmt.Printf("   • Would send Action: Set-Record to %s\n", record.ProcessID)
+		fmt.Println()
+		fmt.Println("✅ Dry run completed successfully!")
+		return
+	}
+
+	// The ANT only accepts the update from its controlling wallet; a rejected
+	// message surfaces as an error in the compute unit result below
+	messageTags := []types.Tag{
+		{Name: "Data-Protocol", Value: "ao"},
+		{Name: "Variant", Value: "ao.TN.1"},
+		{Name: "Type", Value: "Message"},
+		{Name: "Action", Value: "Set-Record"},
+		{Name: "Sub-Domain", Value: undername},
+		{Name: "Transaction-Id", Value: txID},
+		{Name: "TTL-Seconds", Value: strconv.Itoa(ttlSeconds)},
+	}
+
+	signedItem, messageID, err := signMessageLocal(walletPath, record.ProcessID, nil, messageTags)
+	if err != nil {
+		fmt.Printf("❌ Failed to sign Set-Record message: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("📨 Message ID: %s\n", messageID)
+	fmt.Println("📡 Delivering Set-Record to messenger unit...")
+
+	muURL = strings.TrimRight(network.MU, "/")
+	cuURL = strings.TrimRight(network.CU, "/")
+	if err := sendToMessengerUnit(ctx, muURL, signedItem); err != nil {
+		fmt.Printf("❌ Failed to deliver message: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("⏳ Waiting for the ANT to evaluate the update...")
+	result, err := pollMessageResult(ctx, cuURL, messageID, record.ProcessID)
+	if err != nil {
+		fmt.Printf("❌ Failed to fetch result: %v\n", err)
+		fmt.Printf("💡 Check manually: %s/result/%s?process-id=%s\n", cuURL, messageID, record.ProcessID)
+		os.Exit(1)
+	}
+	if len(result.Error) > 0 && string(result.Error) != "null" {
+		fmt.Printf("❌ The ANT rejected the update: %s\n", formatResultValue(result.Error))
+		fmt.Println("💡 Does the wallet control this name?")
+		os.Exit(1)
+	}
+
+	fmt.Println("✅ Record updated")
+
+	// Propagation check: gateways cache resolved records for the old TTL, so
+	// not seeing the new target in time is a warning rather than a failure
+	if waitDuration > 0 {
+		if confirmArNSPropagation(ctx, network.Gateway, name, undername, txID, waitDuration) {
+			fmt.Printf("✅ %s now resolves to %s\n", name, txID)
+		} else {
+			fmt.Printf("⚠️  The gateway still serves the old record - resolvers refresh after the TTL (%ds)\n", record.TTLSeconds)
+		}
+	}
+	fmt.Printf("🎭 https://%s.%s\n", name, strings.TrimPrefix(network.Gateway, "https://"))
+}
+
+// resolveArNSRecord asks the gateway's ArNS resolver for a name's record
+func resolveArNSRecord(ctx context.Context, gatewayURL, name string) (*arnsRecord, error) {
+	resolverURL := fmt.Sprintf("%s/ar-io/resolver/records/%s", strings.TrimRight(gatewayURL, "/"), name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resolverURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach ArNS resolver: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("ArNS name %q is not registered", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("ArNS resolver error (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+
+	var record arnsRecord
+	if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
+		return nil, fmt.Errorf("failed to parse resolver response: %w", err)
+	}
+	return &record, nil
+}
+
+// confirmArNSPropagation polls the resolver until the name serves the new
+// target or the timeout elapses
+func confirmArNSPropagation(ctx context.Context, gatewayURL, name, undername, txID string, timeout time.Duration) bool {
+	resolveName := name
+	if undername != "@" {
+		resolveName = undername + "_" + name
+	}
+
+	deadline := time.Now().Add(timeout)
+	interval := 5 * time.Second
+	for time.Now().Before(deadline) {
+		record, err := resolveArNSRecord(ctx, gatewayURL, resolveName)
+		if err == nil && record.TxID == txID {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(interval):
+		}
+	}
+	return false
+}
+
+// PrintArnsUsage prints usage information for the arns command
+func PrintArnsUsage() {
+	fmt.Println("🎭 Harlequin ArNS - Point Friendly Names at Deployed Artifacts")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin arns set <NAME> <TXID> [OPTIONS]   # Update the record behind a name")
+	fmt.Println("    harlequin arns get <NAME> [OPTIONS]          # Show what a name resolves to")
+	fmt.Println()
+	fmt.Println("OPTIONS (set):")
+	fmt.Println("    -u, --undername <NAME>   Undername to update (default: @, the apex record)")
+	fmt.Println("    --ttl <SECONDS>          Record TTL in seconds (default: 900)")
+	fmt.Println("    -w, --wallet-file <FILE> Wallet controlling the name's ANT (default: key.json)")
+	fmt.Println("    --dry-run                Preview the state change without sending anything")
+	fmt.Println("    --wait-timeout <DUR>     How long to wait for the gateway to serve the new")
+	fmt.Println("                             record (default: 2m, 0 to skip)")
+	fmt.Println("    --gateway <URL>          Arweave gateway / resolver URL")
+	fmt.Println("    --cu <URL>               Compute unit URL")
+	fmt.Println("    --mu <URL>               Messenger unit URL")
+	fmt.Println("    --network <PRESET>       Endpoint preset: mainnet, testnet, or localnet")
+	fmt.Println("    -h, --help               Show this help message")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    # Point a name at a freshly published site manifest")
+	fmt.Println("    harlequin publish-site frontend/dist")
+	fmt.Println("    harlequin arns set my-app <manifest-txid>")
+	fmt.Println()
+	fmt.Println("    # Preview the change first")
+	fmt.Println("    harlequin arns set my-app <txid> --dry-run")
+	fmt.Println()
+	fmt.Println("    # Update an undername with a custom TTL")
+	fmt.Println("    harlequin arns set my-app <txid> --undername staging --ttl 300")
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//go:embed embedded_blueprints/*
+var embeddedBlueprints embed.FS
+
+// ListBlueprints returns the names of the embedded process blueprints
+func ListBlueprints() []string {
+	entries, err := embeddedBlueprints.ReadDir("embedded_blueprints")
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isValidBlueprint reports whether a blueprint with the given name is embedded
+func isValidBlueprint(name string) bool {
+	for _, blueprint := range ListBlueprints() {
+		if blueprint == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyBlueprint layers an embedded blueprint onto an initialized project:
+// its files are written into the target directory with the same variable
+// substitution the template extraction uses, and a require line is appended
+// to a Lua entrypoint so the blueprint's handlers load with the process.
+// Existing files are never overwritten.
+func ApplyBlueprint(name, targetDir, projectName, authorName, githubUser string, variables map[string]string) error {
+	if !isValidBlueprint(name) {
+		return fmt.Errorf("unknown blueprint: %s. Available: %s", name, strings.Join(ListBlueprints(), ", "))
+	}
+
+	root := "embedded_blueprints/" + name
+	err := fs.WalkDir(embeddedBlueprints, root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		destPath := filepath.Join(targetDir, relPath)
+
+		if _, err := os.Stat(destPath); err == nil {
+			fmt.Printf("⚠️  Skipping %s (already exists)\n", relPath)
+			return nil
+		}
+
+		content, err := embeddedBlueprints.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read blueprint file %s: %w", path, err)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", relPath, err)
+		}
+		substituted := substituteVariables(string(content), projectName, authorName, githubUser, variables)
+		if err := os.WriteFile(destPath, []byte(substituted), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", relPath, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Load the blueprint's handlers from a Lua entrypoint, when there is one
+	if err := appendBlueprintRequire(name, targetDir); err != nil {
+		return err
+	}
+
+	fmt.Printf("✨ Applied %s blueprint\n", name)
+	return nil
+}
+
+// appendBlueprintRequire appends a require for the blueprint's handler module
+// to the project's Lua entrypoint. Non-Lua projects keep the blueprint files
+// as reference material without entrypoint changes.
+func appendBlueprintRequire(name, targetDir string) error {
+	var entrypoint string
+	var content []byte
+	for _, candidate := range []string{"main.lua", "main.tl"} {
+		data, err := os.ReadFile(filepath.Join(targetDir, candidate))
+		if err == nil {
+			entrypoint = filepath.Join(targetDir, candidate)
+			content = data
+			break
+		}
+	}
+	if entrypoint == "" {
+		return nil
+	}
+
+	requireLine := fmt.Sprintf("require(\"blueprints.%s\")", name)
+	if strings.Contains(string(content), requireLine) {
+		return nil
+	}
+
+	updated := strings.TrimRight(string(content), "\n") + fmt.Sprintf("\n\n-- %s blueprint handlers\n%s\n", name, requireLine)
+	if err := os.WriteFile(entrypoint, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("failed to update %s: %w", entrypoint, err)
+	}
+	return nil
+}
@@ -2,19 +2,29 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/build"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/build/builders"
 	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
 	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/logging"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/memprofile"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/telemetry"
 	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/tui"
 )
 
-// RunInteractiveTUI launches the interactive TUI interface
+// RunInteractiveTUI launches the interactive TUI interface, falling back to
+// sequential prompts when the terminal cannot host the alt-screen UI
 func RunInteractiveTUI(ctx context.Context) error {
+	if PlainFallback() {
+		return RunPlainCLI(ctx)
+	}
 	return tui.RunBuildTUI(ctx)
 }
 
@@ -25,6 +35,14 @@ func HandleBuildCommand(ctx context.Context, args []string) {
 	var entrypoint string
 	var outputDir string
 	var configPath string
+	var packages []string
+	var allPackages bool
+
+	// Subcommand: verify a build manifest by rebuilding and comparing hashes
+	if len(args) > 0 && args[0] == "verify" {
+		handleBuildVerify(ctx, args[1:])
+		return
+	}
 
 	// Process arguments
 	for i := 0; i < len(args); i++ {
@@ -59,6 +77,18 @@ func HandleBuildCommand(ctx context.Context, args []string) {
 			}
 			configPath = args[i+1]
 			i++ // Skip the next argument as it's the value
+		case "--package", "-p":
+			if i+1 >= len(args) {
+				fmt.Printf("Error: --package requires a value\n\n")
+				PrintBuildUsage()
+				os.Exit(1)
+			}
+			packages = append(packages, args[i+1])
+			i++ // Skip the next argument as it's the value
+		case "--all":
+			allPackages = true
+		case "--pull":
+			builders.PullImages = true
 		default:
 			// If it starts with -, it's an unknown flag
 			if strings.HasPrefix(arg, "-") {
@@ -78,6 +108,12 @@ func HandleBuildCommand(ctx context.Context, args []string) {
 		debug.SetEnabled(true)
 	}
 
+	// Workspace mode: build the selected packages in dependency order
+	if allPackages || len(packages) > 0 {
+		handleWorkspaceBuild(ctx, configPath, packages)
+		return
+	}
+
 	// Require entrypoint for non-interactive build
 	if entrypoint == "" {
 		fmt.Println("Error: --entrypoint is required for non-interactive build")
@@ -107,6 +143,99 @@ func handleNonInteractiveBuild(ctx context.Context, entrypoint, outputDir, confi
 		cfg = loadConfig()
 	}
 
+	if err := runBuild(ctx, cfg, entrypoint, outputDir); err != nil {
+		reportBuildError(err)
+		os.Exit(1)
+	}
+}
+
+// reportBuildError prints a build error, distinguishing user cancellation and
+// timeouts from genuine build failures
+func reportBuildError(err error) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		fmt.Println("⚠️  Build cancelled by user")
+	case errors.Is(err, context.DeadlineExceeded):
+		fmt.Println("❌ Build timed out - raise the limit with --timeout")
+	default:
+		fmt.Printf("Build failed: %v\n", err)
+		printExplainHints(err.Error())
+	}
+}
+
+// handleWorkspaceBuild builds workspace packages in dependency order
+func handleWorkspaceBuild(ctx context.Context, configPath string, packages []string) {
+	var cfg *config.Config
+	if configPath != "" {
+		cfg = config.ReadConfigFile(configPath)
+	} else if _, err := os.Stat(".harlequin.yaml"); err == nil {
+		cfg = config.ReadConfigFile(".harlequin.yaml")
+	} else {
+		cfg = loadConfig()
+	}
+
+	ordered, err := cfg.ResolveWorkspacePackages(packages)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("🎭 Building %d workspace package(s)\n", len(ordered))
+	fmt.Println()
+
+	for i, pkg := range ordered {
+		fmt.Printf("▶️  [%d/%d] %s (%s)\n", i+1, len(ordered), pkg.Name, pkg.Entrypoint)
+
+		if err := runBuild(ctx, cfg.PackageConfig(&pkg), pkg.Entrypoint, pkg.OutputDir); err != nil {
+			fmt.Printf("❌ Package %s did not complete:\n", pkg.Name)
+			reportBuildError(err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("✓  [%d/%d] %s\n", i+1, len(ordered), pkg.Name)
+		fmt.Println()
+	}
+
+	fmt.Println("✅ Workspace build completed successfully!")
+}
+
+// buildCallbacks selects the step callbacks for CLI builds: silent in quiet
+// mode, the emoji progress output otherwise
+func buildCallbacks() *builders.BuildCallbacks {
+	if logging.Quiet() {
+		return builders.CallbacksSilent
+	}
+	return builders.CallbacksDefault
+}
+
+// runBuild runs a single build with the given config and entrypoint
+func runBuild(ctx context.Context, cfg *config.Config, entrypoint, outputDir string) (err error) {
+	// Build duration and error category feed the opt-in telemetry; the defer
+	// also covers the failure paths that exit before main's own recording
+	buildStart := time.Now()
+	defer func() {
+		telemetry.Record("build", time.Since(buildStart), telemetry.Categorize(err))
+	}()
+
+	// Surface memory limits that look far off from profiled usage before
+	// spending a build on them
+	warnMemoryConfig(cfg)
+
+	// CMake projects (the C template) build through the CBuilder pipeline
+	projectDir := filepath.Dir(entrypoint)
+	if builders.IsCProject(projectDir) {
+		if outputDir == "" {
+			outputDir = "dist"
+		}
+		builder := builders.NewCBuilder(builders.CBuilderParams{
+			Config:     cfg,
+			ProjectDir: projectDir,
+			OutputDir:  outputDir,
+			Callbacks:  buildCallbacks(),
+		})
+		return builder.Build(ctx)
+	}
+
 	// Set output directory if provided
 	if outputDir != "" {
 		// TODO: Update config with output directory when config supports it
@@ -116,23 +245,143 @@ func handleNonInteractiveBuild(ctx context.Context, entrypoint, outputDir, confi
 	// Get current working directory as workspace
 	workspaceDir, err := os.Getwd()
 	if err != nil {
-		fmt.Printf("Error getting current directory: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("error getting current directory: %w", err)
 	}
 
 	// Create build runner
 	runner, err := build.NewAOBuildRunner(cfg, workspaceDir)
 	if err != nil {
-		fmt.Printf("Failed to create build runner: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to create build runner: %w", err)
 	}
 	defer runner.Close()
 
 	// Build the project with specified entrypoint
-	if err := runner.BuildProject(ctx, entrypoint); err != nil {
-		fmt.Printf("Build failed: %v\n", err)
+	return runner.BuildProject(ctx, entrypoint)
+}
+
+// handleBuildVerify rebuilds from a build manifest and checks that the
+// produced artifacts hash to the recorded values
+func handleBuildVerify(ctx context.Context, args []string) {
+	manifestPath := ""
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--debug", "-d":
+			debug.SetEnabled(true)
+		case "--help", "-h":
+			printBuildVerifyUsage()
+			return
+		default:
+			if !strings.HasPrefix(args[i], "-") && manifestPath == "" {
+				manifestPath = args[i]
+			}
+		}
+	}
+
+	if manifestPath == "" {
+		manifestPath = filepath.Join("dist", builders.ManifestFileName)
+	}
+
+	manifest, err := builders.LoadBuildManifest(manifestPath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("🎭 Verifying build manifest")
+	fmt.Printf("   📄 Manifest: %s\n", manifestPath)
+	fmt.Printf("   🔖 Built with harlequin %s\n", manifest.ToolkitVersion)
+	fmt.Printf("   📦 AOS: %s @ %s\n", manifest.AOSRepoURL, manifest.AOSGitHash)
+	fmt.Println()
+
+	verifyDir, err := os.MkdirTemp("", "harlequin-verify-*")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer os.RemoveAll(verifyDir)
+
+	// Rebuild with the exact config recorded in the manifest
+	builder := builders.NewAOSBuilder(builders.AOSBuilderParams{
+		Config:     manifest.Config(),
+		Entrypoint: manifest.Entrypoint,
+		OutputDir:  verifyDir,
+		Callbacks:  buildCallbacks(),
+	})
+	if err := builder.Build(ctx); err != nil {
+		fmt.Printf("❌ Rebuild failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	mismatches := 0
+	checks := []struct {
+		name     string
+		file     string
+		expected string
+	}{
+		{"bundled.lua", filepath.Join(verifyDir, "bundled.lua"), manifest.BundledLuaSHA256},
+		{"process.wasm", filepath.Join(verifyDir, "process.wasm"), manifest.WasmSHA256},
+	}
+	for _, check := range checks {
+		actual, err := builders.FileSHA256(check.file)
+		if err != nil {
+			fmt.Printf("❌ %s: missing from rebuild (%v)\n", check.name, err)
+			mismatches++
+			continue
+		}
+		if actual != check.expected {
+			fmt.Printf("❌ %s: hash mismatch\n", check.name)
+			fmt.Printf("   expected: %s\n", check.expected)
+			fmt.Printf("   actual:   %s\n", actual)
+			mismatches++
+		} else {
+			fmt.Printf("✓  %s: %s\n", check.name, actual)
+		}
+	}
+
+	fmt.Println()
+	if mismatches > 0 {
+		fmt.Printf("❌ Verification failed: %d artifact(s) do not match the manifest\n", mismatches)
+		fmt.Println("   The sources, config, or build image differ from the attested build.")
 		os.Exit(1)
 	}
+	fmt.Println("✅ Verification succeeded: rebuild matches the manifest")
+}
+
+// printBuildVerifyUsage prints usage for the build verify subcommand
+func printBuildVerifyUsage() {
+	fmt.Println("🎭 Harlequin Build Verify - Check Artifacts Against a Build Manifest")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin build verify [MANIFEST] [OPTIONS]")
+	fmt.Println()
+	fmt.Println("ARGUMENTS:")
+	fmt.Println("    MANIFEST        Path to build-manifest.json (default: dist/" + builders.ManifestFileName + ")")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -d, --debug     Enable debug logging")
+	fmt.Println("    -h, --help      Show this help message")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    Rebuilds the project using the config snapshot recorded in the manifest")
+	fmt.Println("    and compares the hashes of bundled.lua and process.wasm. A match proves")
+	fmt.Println("    the attested artifacts correspond to the current sources.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    harlequin build verify")
+	fmt.Println("    harlequin build verify dist/build-manifest.json")
+}
+
+// warnMemoryConfig compares the configured memory limits against the usage
+// recorded by profiled eval runs (harlequin eval --memory-profile)
+func warnMemoryConfig(cfg *config.Config) {
+	obs, ok, err := memprofile.Load(".")
+	if err != nil || !ok {
+		return
+	}
+	for _, warning := range memprofile.Assess(cfg.InitialMemory, cfg.MaximumMemory, obs) {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
 }
 
 // loadConfig loads configuration from various sources
@@ -159,6 +408,7 @@ func PrintBuildUsage() {
 	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Println("  harlequin build --entrypoint <file> [flags]")
+	fmt.Println("  harlequin build verify [manifest]  # Verify artifacts against a build manifest")
 	fmt.Println()
 	fmt.Println("Required Flags:")
 	fmt.Println("  --entrypoint <file>    Path to the main Lua file to build")
@@ -166,11 +416,22 @@ func PrintBuildUsage() {
 	fmt.Println("Optional Flags:")
 	fmt.Println("  --outputDir <dir>      Directory to output build artifacts")
 	fmt.Println("  --configPath <file>    Path to custom configuration file")
+	fmt.Println("  -p, --package <name>   Build a workspace package (repeatable)")
+	fmt.Println("  --all                  Build all workspace packages")
+	fmt.Println("  --pull                 Pre-pull the build image, showing pull progress")
 	fmt.Println("  -d, --debug            Enable debug logging for detailed output")
 	fmt.Println("  -h, --help             Show this help message")
 	fmt.Println()
+	fmt.Println("Workspace Mode:")
+	fmt.Println("  Projects with a 'workspace' section in .harlequin.yaml can build one,")
+	fmt.Println("  several, or all packages. Packages build in dependency order, with")
+	fmt.Println("  depends_on entries built first.")
+	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  harlequin build --entrypoint main.lua")
+	fmt.Println("  harlequin build --entrypoint main.tl    # Teal project (type-checked first)")
+	fmt.Println("  harlequin build --package token --package registry")
+	fmt.Println("  harlequin build --all")
 	fmt.Println("  harlequin build --entrypoint src/app.lua --outputDir dist")
 	fmt.Println("  harlequin build --entrypoint main.lua --configPath custom.yaml")
 	fmt.Println("  harlequin build --entrypoint main.lua --debug")
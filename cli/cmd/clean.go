@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/build"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/build/builders"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+)
+
+// staleWorkspacePatterns are the temp directory names builders leave behind
+// when a previous run was interrupted before its deferred cleanup could run
+var staleWorkspacePatterns = []string{
+	"harlequin-aos-build-*",
+	"harlequin-c-build-*",
+	"harlequin-teal-gen-*",
+	"harlequin-aos-repo",
+	"harlequin-aos-prefetch",
+}
+
+// HandleCleanCommand handles the clean command for removing leftovers of
+// interrupted or crashed runs
+func HandleCleanCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling clean command with args: %v", args)
+
+	var dryRun bool
+	var includeCache bool
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--dry-run":
+			dryRun = true
+		case "--cache":
+			includeCache = true
+		case "--help", "-h":
+			PrintCleanUsage()
+			return
+		default:
+			fmt.Printf("Unknown option: %s\n\n", args[i])
+			PrintCleanUsage()
+			os.Exit(1)
+		}
+	}
+
+	fmt.Println("🎭 Cleaning up leftovers from previous runs")
+	fmt.Println()
+
+	removed := cleanStaleWorkspaces(dryRun)
+	removed += cleanDanglingContainers(ctx, dryRun)
+	if includeCache {
+		removed += cleanCaches(dryRun)
+	}
+
+	fmt.Println()
+	if removed == 0 {
+		fmt.Println("✨ Nothing to clean")
+	} else if dryRun {
+		fmt.Printf("🌵 DRY RUN - %d item(s) would be removed\n", removed)
+	} else {
+		fmt.Printf("✅ Removed %d item(s)\n", removed)
+	}
+}
+
+// cleanStaleWorkspaces removes builder temp directories from the system temp
+// dir and returns how many it removed
+func cleanStaleWorkspaces(dryRun bool) int {
+	removed := 0
+	for _, pattern := range staleWorkspacePatterns {
+		matches, err := filepath.Glob(filepath.Join(os.TempDir(), pattern))
+		if err != nil {
+			continue
+		}
+		for _, match := range matches {
+			if dryRun {
+				fmt.Printf("   • Would remove workspace %s\n", match)
+				removed++
+				continue
+			}
+			if err := os.RemoveAll(match); err != nil {
+				fmt.Printf("   ⚠️  Failed to remove %s: %v\n", match, err)
+				continue
+			}
+			fmt.Printf("   • Removed workspace %s\n", match)
+			removed++
+		}
+	}
+	return removed
+}
+
+// cleanDanglingContainers removes exited containers of the AO build image.
+// Builds run the image without --rm, so interrupted runs leave them behind.
+func cleanDanglingContainers(ctx context.Context, dryRun bool) int {
+	// The build image repository without its tag; containers from any pinned
+	// version are matched
+	imageRepo := strings.SplitN(build.AOBuildContainerDockerImage, ":", 2)[0]
+
+	output, err := exec.CommandContext(ctx, "docker", "ps", "-a",
+		"--filter", "status=exited",
+		"--format", "{{.ID}} {{.Image}}").Output()
+	if err != nil {
+		// No docker daemon is not an error for clean; there is just nothing
+		// to remove
+		debug.Printf("Skipping container cleanup: %v", err)
+		return 0
+	}
+
+	removed := 0
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !strings.HasPrefix(fields[1], imageRepo) {
+			continue
+		}
+		containerID := fields[0]
+		if dryRun {
+			fmt.Printf("   • Would remove container %s (%s)\n", containerID, fields[1])
+			removed++
+			continue
+		}
+		if err := exec.CommandContext(ctx, "docker", "rm", containerID).Run(); err != nil {
+			fmt.Printf("   ⚠️  Failed to remove container %s: %v\n", containerID, err)
+			continue
+		}
+		fmt.Printf("   • Removed container %s (%s)\n", containerID, fields[1])
+		removed++
+	}
+	return removed
+}
+
+// cleanCaches removes the harlequin cache directory: cached AOS checkouts and
+// saved docker image tarballs. The next online run repopulates it.
+func cleanCaches(dryRun bool) int {
+	root, err := builders.CacheRoot()
+	if err != nil {
+		fmt.Printf("   ⚠️  Failed to locate cache directory: %v\n", err)
+		return 0
+	}
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return 0
+	}
+
+	if dryRun {
+		fmt.Printf("   • Would remove cache %s\n", root)
+		return 1
+	}
+	if err := os.RemoveAll(root); err != nil {
+		fmt.Printf("   ⚠️  Failed to remove %s: %v\n", root, err)
+		return 0
+	}
+	fmt.Printf("   • Removed cache %s\n", root)
+	return 1
+}
+
+// PrintCleanUsage prints usage information for the clean command
+func PrintCleanUsage() {
+	fmt.Println("🎭 Harlequin Clean - Remove Leftovers From Previous Runs")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin clean [OPTIONS]")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    --cache      Also clear cached AOS checkouts and docker image tarballs")
+	fmt.Println("    --dry-run    List what would be removed without removing anything")
+	fmt.Println("    -h, --help   Show this help message")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    Removes temp build workspaces (harlequin-aos-build-*, harlequin-c-build-*,")
+	fmt.Println("    ...) and exited containers of the AO build image that interrupted runs")
+	fmt.Println("    left behind. With --cache it also clears the offline build caches, which")
+	fmt.Println("    'harlequin prefetch' can repopulate.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    harlequin clean")
+	fmt.Println("    harlequin clean --dry-run")
+	fmt.Println("    harlequin clean --cache")
+}
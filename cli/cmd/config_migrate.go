@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	harlequinconfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	"gopkg.in/yaml.v2"
+)
+
+// oldConfigCandidates are where legacy build configs live, in search order
+var oldConfigCandidates = []string{
+	"ao-build-config.yml",
+	"ao-build-config.yaml",
+	"build_configs/ao-build-config.yml",
+	"build_configs/ao-build-config.yaml",
+}
+
+// buildConfigKeys are legacy keys that map directly onto the .harlequin.yaml
+// build schema
+var buildConfigKeys = map[string]bool{
+	"stack_size":      true,
+	"initial_memory":  true,
+	"maximum_memory":  true,
+	"target":          true,
+	"compute_limit":   true,
+	"module_format":   true,
+	"aos_git_hash":    true,
+	"flavour":         true,
+	"optimize":        true,
+	"metering_report": true,
+}
+
+// uploadTagKeys maps legacy publishing keys onto the upload tag preset in
+// .harlequin-tags.yaml, where upload-module already merges them from
+var uploadTagKeys = map[string]string{
+	"data_protocol":   "Data-Protocol",
+	"variant":         "Variant",
+	"type":            "Type",
+	"input_encoding":  "Input-Encoding",
+	"output_encoding": "Output-Encoding",
+	"content_type":    "Content-Type",
+	"app_name":        "App-Name",
+	"app_version":     "App-Version",
+	"author":          "Author",
+}
+
+// HandleConfigCommand handles the config command and its subcommands
+func HandleConfigCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling config command with args: %v", args)
+
+	if len(args) == 0 {
+		PrintConfigUsage()
+		return
+	}
+
+	switch args[0] {
+	case "migrate":
+		handleConfigMigrate(args[1:])
+	case "--help", "-h", "help":
+		PrintConfigUsage()
+	default:
+		fmt.Printf("Unknown config subcommand: %s\n\n", args[0])
+		PrintConfigUsage()
+		os.Exit(1)
+	}
+}
+
+// handleConfigMigrate rewrites a legacy ao-build-config.yml into the current
+// .harlequin.yaml schema, moving publishing keys into the upload tag preset
+// and reporting keys it does not recognize
+func handleConfigMigrate(args []string) {
+	var fromPath string
+	var toPath string
+	var dryRun bool
+	var force bool
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--from", "-f":
+			if i+1 < len(args) {
+				fromPath = args[i+1]
+				i++
+			}
+		case "--to", "-t":
+			if i+1 < len(args) {
+				toPath = args[i+1]
+				i++
+			}
+		case "--dry-run":
+			dryRun = true
+		case "--force":
+			force = true
+		case "--help", "-h":
+			PrintConfigUsage()
+			return
+		default:
+			if !strings.HasPrefix(args[i], "-") && fromPath == "" {
+				fromPath = args[i]
+			}
+		}
+	}
+
+	if fromPath == "" {
+		for _, candidate := range oldConfigCandidates {
+			if _, err := os.Stat(candidate); err == nil {
+				fromPath = candidate
+				break
+			}
+		}
+		if fromPath == "" {
+			fmt.Println("❌ No legacy config found (looked for ao-build-config.yml, also under build_configs/)")
+			fmt.Println("   Use --from to point at the file to migrate")
+			os.Exit(1)
+		}
+	}
+	if toPath == "" {
+		toPath = ".harlequin.yaml"
+	}
+
+	content, err := os.ReadFile(fromPath)
+	if err != nil {
+		fmt.Printf("❌ Failed to read %s: %v\n", fromPath, err)
+		os.Exit(1)
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		fmt.Printf("❌ Failed to parse %s: %v\n", fromPath, err)
+		os.Exit(1)
+	}
+
+	// Split the legacy keys into build config, upload tags, and unknowns
+	buildKeys := map[string]interface{}{}
+	uploadTags := map[string]string{}
+	var unknown []string
+	for key, value := range raw {
+		switch {
+		case buildConfigKeys[key]:
+			buildKeys[key] = value
+		case uploadTagKeys[key] != "":
+			uploadTags[uploadTagKeys[key]] = fmt.Sprint(value)
+		default:
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+
+	// Round-trip the build keys over a default config so anything the legacy
+	// file does not set lands on the current defaults, then apply flavour
+	// handling exactly as a fresh config would
+	buildYAML, err := yaml.Marshal(buildKeys)
+	if err != nil {
+		fmt.Printf("❌ Failed to re-marshal build keys: %v\n", err)
+		os.Exit(1)
+	}
+	migrated := harlequinconfig.NewConfig(nil)
+	if err := yaml.Unmarshal(buildYAML, migrated); err != nil {
+		fmt.Printf("❌ Failed to apply build keys: %v\n", err)
+		os.Exit(1)
+	}
+	if migrated.Flavour != "" {
+		migrated.ApplyFlavour(migrated.Flavour)
+	}
+
+	// Comments cannot survive a YAML round trip, but the original header
+	// comments can be carried over verbatim
+	header := legacyHeaderComments(string(content))
+	output := header + harlequinconfig.ToYAML(migrated)
+
+	fmt.Printf("🎭 Migrating %s → %s\n", fromPath, toPath)
+	fmt.Println()
+	fmt.Printf("   • Build keys migrated: %d\n", len(buildKeys))
+	if len(uploadTags) > 0 {
+		fmt.Printf("   • Publishing keys moved to %s: %d\n", harlequinconfig.UploadTagsFileName, len(uploadTags))
+	}
+	if len(unknown) > 0 {
+		fmt.Printf("   ⚠️  Unknown keys (not migrated): %s\n", strings.Join(unknown, ", "))
+	}
+	fmt.Println()
+
+	if dryRun {
+		fmt.Println("🌵 DRY RUN - would write:")
+		fmt.Println()
+		for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+			fmt.Printf("   %s\n", line)
+		}
+		return
+	}
+
+	if _, err := os.Stat(toPath); err == nil && !force {
+		fmt.Printf("❌ %s already exists (use --force to overwrite, or --dry-run to preview)\n", toPath)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(toPath, []byte(output), 0644); err != nil {
+		fmt.Printf("❌ Failed to write %s: %v\n", toPath, err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Wrote %s\n", toPath)
+
+	if len(uploadTags) > 0 {
+		// Merge over any existing preset rather than clobbering it
+		existing, err := harlequinconfig.LoadUploadTags(".")
+		if err != nil {
+			fmt.Printf("❌ Failed to read %s: %v\n", harlequinconfig.UploadTagsFileName, err)
+			os.Exit(1)
+		}
+		for key, value := range uploadTags {
+			if _, taken := existing[key]; !taken {
+				existing[key] = value
+			}
+		}
+		if err := harlequinconfig.SaveUploadTags(".", existing); err != nil {
+			fmt.Printf("❌ Failed to write %s: %v\n", harlequinconfig.UploadTagsFileName, err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Wrote %s\n", harlequinconfig.UploadTagsFileName)
+	}
+
+	fmt.Println()
+	fmt.Printf("💡 Once builds pass, delete %s\n", fromPath)
+}
+
+// legacyHeaderComments returns the leading comment block of the legacy file,
+// so at least the header commentary survives the migration
+func legacyHeaderComments(content string) string {
+	var header []string
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") || trimmed == "" {
+			header = append(header, line)
+			continue
+		}
+		break
+	}
+	// Trim trailing blank lines so the header sits flush above the config
+	for len(header) > 0 && strings.TrimSpace(header[len(header)-1]) == "" {
+		header = header[:len(header)-1]
+	}
+	if len(header) == 0 {
+		return ""
+	}
+	return strings.Join(header, "\n") + "\n"
+}
+
+// PrintConfigUsage prints usage information for the config command
+func PrintConfigUsage() {
+	fmt.Println("🎭 Harlequin Config - Configuration Utilities")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin config <SUBCOMMAND> [OPTIONS]")
+	fmt.Println()
+	fmt.Println("SUBCOMMANDS:")
+	fmt.Println("    migrate         Rewrite a legacy ao-build-config.yml as .harlequin.yaml")
+	fmt.Println()
+	fmt.Println("MIGRATE OPTIONS:")
+	fmt.Println("    -f, --from <FILE>   Legacy config to migrate (default: auto-detected)")
+	fmt.Println("    -t, --to <FILE>     Destination (default: .harlequin.yaml)")
+	fmt.Println("    --dry-run           Print the migrated config without writing")
+	fmt.Println("    --force             Overwrite an existing destination file")
+	fmt.Println("    -h, --help          Show this help message")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    Build keys move into the current .harlequin.yaml schema with defaults")
+	fmt.Println("    and flavour handling applied. Publishing keys (app_name, author,")
+	fmt.Println("    variant, ...) move into the .harlequin-tags.yaml upload preset, which")
+	fmt.Println("    upload-module already merges. Unknown keys are reported, and header")
+	fmt.Println("    comments are carried over.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    harlequin config migrate")
+	fmt.Println("    harlequin config migrate --from build_configs/ao-build-config.yml --dry-run")
+}
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/build/builders"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+)
+
+// HandleDebugCommand handles the debug command and its subcommands
+func HandleDebugCommand(ctx context.Context, args []string) {
+	if len(args) == 0 {
+		fmt.Printf("Error: debug requires a subcommand\n\n")
+		PrintDebugUsage()
+		os.Exit(1)
+	}
+
+	subcommand := args[0]
+	switch subcommand {
+	case "export":
+		HandleDebugExportCommand(ctx, args[1:])
+	case "help", "--help", "-h":
+		PrintDebugUsage()
+	default:
+		fmt.Printf("Unknown subcommand: %s\n\n", subcommand)
+		PrintDebugUsage()
+		os.Exit(1)
+	}
+}
+
+// HandleDebugExportCommand gathers diagnostics into a tarball for bug reports
+func HandleDebugExportCommand(ctx context.Context, args []string) {
+	var outputPath string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--output", "-o":
+			if i+1 < len(args) {
+				outputPath = args[i+1]
+				i++
+			} else {
+				fmt.Printf("Error: %s requires a value\n", arg)
+				os.Exit(1)
+			}
+		case "--help", "-h":
+			PrintDebugExportUsage()
+			return
+		default:
+			fmt.Printf("Unknown argument: %s\n\n", arg)
+			PrintDebugExportUsage()
+			os.Exit(1)
+		}
+	}
+
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("harlequin-debug-%s.tar.gz", time.Now().Format("20060102-150405"))
+	}
+
+	fmt.Println("🎭 Exporting debug bundle...")
+
+	if err := writeDebugBundle(outputPath); err != nil {
+		fmt.Printf("❌ Failed to export debug bundle: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Debug bundle written to %s\n", outputPath)
+	fmt.Println("   Wallet material is never included; review the bundle before sharing.")
+}
+
+// writeDebugBundle collects diagnostics into a gzipped tarball at outputPath
+func writeDebugBundle(outputPath string) error {
+	out, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", outputPath, err)
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	defer gz.Close()
+
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	// Environment diagnostics and version information
+	if err := addBundleFile(tw, "environment.txt", []byte(environmentReport())); err != nil {
+		return err
+	}
+
+	// Debug log, if one has been written
+	if logData, err := os.ReadFile(debug.LogFilePath); err == nil {
+		if err := addBundleFile(tw, "harlequin-debug.log", logData); err != nil {
+			return err
+		}
+	}
+
+	// Project config with secret-looking values scrubbed
+	if configData, err := os.ReadFile(".harlequin.yaml"); err == nil {
+		sanitized, err := sanitizeConfigYAML(configData)
+		if err != nil {
+			return fmt.Errorf("failed to sanitize .harlequin.yaml: %w", err)
+		}
+		if err := addBundleFile(tw, "harlequin.yaml", sanitized); err != nil {
+			return err
+		}
+	}
+
+	// Last build manifest, if the project has been built
+	manifestPath := filepath.Join("dist", builders.ManifestFileName)
+	if manifestData, err := os.ReadFile(manifestPath); err == nil {
+		if err := addBundleFile(tw, builders.ManifestFileName, manifestData); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addBundleFile writes a single file entry into the tarball
+func addBundleFile(tw *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s header: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// environmentReport builds a plain-text summary of the host environment
+func environmentReport() string {
+	var report strings.Builder
+
+	fmt.Fprintf(&report, "toolkit version: %s\n", builders.ToolkitVersion)
+	fmt.Fprintf(&report, "go runtime: %s\n", runtime.Version())
+	fmt.Fprintf(&report, "os/arch: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&report, "generated: %s\n", time.Now().Format(time.RFC3339))
+
+	if dockerVersion, err := exec.Command("docker", "--version").Output(); err == nil {
+		fmt.Fprintf(&report, "docker: %s", dockerVersion)
+	} else {
+		report.WriteString("docker: not available\n")
+	}
+
+	report.WriteString("\nenvironment:\n")
+	var harlequinVars []string
+	for _, entry := range os.Environ() {
+		if strings.HasPrefix(entry, "HARLEQUIN_") || strings.HasPrefix(entry, "WALLET=") {
+			harlequinVars = append(harlequinVars, entry)
+		}
+	}
+	sort.Strings(harlequinVars)
+	for _, entry := range harlequinVars {
+		name, value, _ := strings.Cut(entry, "=")
+		if sensitiveKey(name) {
+			value = "[redacted]"
+		}
+		fmt.Fprintf(&report, "  %s=%s\n", name, value)
+	}
+
+	return report.String()
+}
+
+// sanitizeConfigYAML redacts values under secret-looking keys in the config
+func sanitizeConfigYAML(data []byte) ([]byte, error) {
+	var parsed map[string]interface{}
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+	scrubSecrets(parsed)
+	return yaml.Marshal(parsed)
+}
+
+// scrubSecrets recursively replaces values whose keys look like credentials
+func scrubSecrets(node map[string]interface{}) {
+	for key, value := range node {
+		if sensitiveKey(key) {
+			node[key] = "[redacted]"
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			scrubSecrets(nested)
+		}
+	}
+}
+
+// sensitiveKey reports whether a config key or env var may hold wallet
+// material or other credentials
+func sensitiveKey(name string) bool {
+	lower := strings.ToLower(name)
+	for _, marker := range []string{"wallet", "key", "secret", "token", "password"} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintDebugUsage prints usage information for the debug command
+func PrintDebugUsage() {
+	fmt.Println("🎭 Harlequin Debug - Diagnostics for bug reports")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin debug <SUBCOMMAND> [OPTIONS]")
+	fmt.Println()
+	fmt.Println("SUBCOMMANDS:")
+	fmt.Println("    export    Bundle the debug log, sanitized config, and environment")
+	fmt.Println("              diagnostics into a tarball")
+	fmt.Println("    help      Show this help message")
+	fmt.Println()
+	fmt.Println("For subcommand help, use: harlequin debug <SUBCOMMAND> --help")
+}
+
+// PrintDebugExportUsage prints usage information for the debug export subcommand
+func PrintDebugExportUsage() {
+	fmt.Println("🎭 Harlequin Debug Export - Create a support bundle")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin debug export [OPTIONS]")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -o, --output <FILE>    Path for the tarball (default: harlequin-debug-<timestamp>.tar.gz)")
+	fmt.Println("    -h, --help             Show this help message")
+	fmt.Println()
+	fmt.Println("The bundle contains the debug log, the project config with secret-looking")
+	fmt.Println("values redacted, environment diagnostics, and the last build manifest.")
+	fmt.Println("Wallet files are never included.")
+}
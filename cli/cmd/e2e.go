@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/everFinance/goar/types"
+	harlequinconfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/e2e"
+)
+
+// HandleE2ECommand handles the e2e command for running scenario files
+// against a deployed process
+func HandleE2ECommand(ctx context.Context, args []string) {
+	debug.Printf("Handling e2e command with args: %v", args)
+
+	var scenarioPath string
+	var processOverride string
+	var walletPath string
+	var junitPath string
+	var muURL string
+	var cuURL string
+	var networkPreset string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--process", "-p":
+			if i+1 < len(args) {
+				processOverride = args[i+1]
+				i++
+			}
+		case "--wallet-file", "-w":
+			if i+1 < len(args) {
+				walletPath = args[i+1]
+				i++
+			}
+		case "--junit":
+			if i+1 < len(args) {
+				junitPath = args[i+1]
+				i++
+			}
+		case "--mu":
+			if i+1 < len(args) {
+				muURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--cu":
+			if i+1 < len(args) {
+				cuURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--network":
+			if i+1 < len(args) {
+				networkPreset = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintE2EUsage()
+			return
+		default:
+			if !strings.HasPrefix(args[i], "-") && scenarioPath == "" {
+				scenarioPath = args[i]
+			}
+		}
+	}
+
+	if scenarioPath == "" {
+		fmt.Println("❌ Scenario file is required")
+		PrintE2EUsage()
+		os.Exit(1)
+	}
+	if walletPath == "" {
+		if os.Getenv("WALLET_PATH") != "" {
+			walletPath = os.Getenv("WALLET_PATH")
+		} else {
+			walletPath = "key.json"
+		}
+	}
+
+	scenario, err := e2e.LoadScenario(scenarioPath)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if processOverride != "" {
+		scenario.Process = processOverride
+	}
+
+	network := resolveNetwork(networkFlags{Preset: networkPreset, CU: cuURL, MU: muURL})
+	muURL = strings.TrimRight(network.MU, "/")
+	cuURL = strings.TrimRight(network.CU, "/")
+
+	fmt.Printf("🎭 Running scenario: %s (%d steps)\n", scenario.Name, len(scenario.Steps))
+	fmt.Println()
+
+	results := runScenario(ctx, scenario, walletPath, muURL, cuURL, network)
+
+	passed, failed := 0, 0
+	for _, result := range results {
+		if result.Err == nil && len(result.Failures) == 0 {
+			passed++
+		} else {
+			failed++
+		}
+	}
+
+	fmt.Println()
+	if failed == 0 {
+		fmt.Printf("✅ %d/%d steps passed\n", passed, len(results))
+	} else {
+		fmt.Printf("❌ %d/%d steps failed\n", failed, len(results))
+	}
+
+	if junitPath != "" {
+		if err := e2e.WriteJUnit(junitPath, scenario.Name, results); err != nil {
+			fmt.Printf("⚠️  %v\n", err)
+		} else {
+			fmt.Printf("📋 JUnit report written to %s\n", junitPath)
+		}
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// runScenario executes each step in order, printing progress as it goes
+func runScenario(ctx context.Context, scenario *e2e.Scenario, walletPath, muURL, cuURL string, network harlequinconfig.NetworkConfig) []e2e.StepResult {
+	results := make([]e2e.StepResult, 0, len(scenario.Steps))
+
+	for _, step := range scenario.Steps {
+		started := time.Now()
+		result := e2e.StepResult{Name: step.Name}
+
+		processID := step.Process
+		if processID == "" {
+			processID = scenario.Process
+		}
+		if processID == "" {
+			result.Err = fmt.Errorf("no process ID: set process in the scenario or pass --process")
+		} else {
+			stepResult, err := executeStep(ctx, step, processID, walletPath, muURL, cuURL)
+			if err != nil {
+				result.Err = err
+			} else {
+				result.Failures = e2e.Evaluate(step, *stepResult)
+			}
+		}
+		result.Duration = time.Since(started)
+
+		switch {
+		case result.Err != nil:
+			fmt.Printf("❌ %s (%.1fs) - %v\n", step.Name, result.Duration.Seconds(), result.Err)
+		case len(result.Failures) > 0:
+			fmt.Printf("❌ %s (%.1fs)\n", step.Name, result.Duration.Seconds())
+			for _, failure := range result.Failures {
+				fmt.Printf("   • %s\n", failure)
+			}
+		default:
+			fmt.Printf("✅ %s (%.1fs)\n", step.Name, result.Duration.Seconds())
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// executeStep signs, delivers, and polls one step's message, converting the
+// compute unit result into the shape the assertions evaluate
+func executeStep(ctx context.Context, step e2e.Step, processID, walletPath, muURL, cuURL string) (*e2e.Result, error) {
+	// AO protocol tags first, then scenario tags (matches the message command)
+	messageTags := []types.Tag{
+		{Name: "Data-Protocol", Value: "ao"},
+		{Name: "Variant", Value: "ao.TN.1"},
+		{Name: "Type", Value: "Message"},
+		{Name: "Action", Value: step.Send.Action},
+	}
+	for name, value := range step.Send.Tags {
+		messageTags = append(messageTags, types.Tag{Name: name, Value: value})
+	}
+
+	signedItem, messageID, err := signMessageLocal(walletPath, processID, []byte(step.Send.Data), messageTags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	if err := sendToMessengerUnit(ctx, muURL, signedItem); err != nil {
+		return nil, fmt.Errorf("failed to deliver message: %w", err)
+	}
+
+	result, err := pollMessageResult(ctx, cuURL, messageID, processID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch result: %w", err)
+	}
+
+	return convertMessageResult(result), nil
+}
+
+// convertMessageResult flattens a compute unit result for assertion checks
+func convertMessageResult(result *messageResult) *e2e.Result {
+	converted := &e2e.Result{
+		Messages: convertPackets(result.Messages),
+		Spawns:   convertPackets(result.Spawns),
+	}
+	if len(result.Output) > 0 && string(result.Output) != "null" {
+		converted.Output = formatResultValue(result.Output)
+	}
+	if len(result.Error) > 0 && string(result.Error) != "null" {
+		converted.Error = formatResultValue(result.Error)
+	}
+	return converted
+}
+
+// convertPackets extracts target and tags from raw result messages
+func convertPackets(raw []json.RawMessage) []e2e.Packet {
+	packets := make([]e2e.Packet, 0, len(raw))
+	for _, message := range raw {
+		var parsed struct {
+			Target string      `json:"Target"`
+			Tags   []types.Tag `json:"Tags"`
+		}
+		if err := json.Unmarshal(message, &parsed); err != nil {
+			continue
+		}
+		packet := e2e.Packet{Target: parsed.Target, Tags: make(map[string]string, len(parsed.Tags))}
+		for _, tag := range parsed.Tags {
+			packet.Tags[tag.Name] = tag.Value
+		}
+		packets = append(packets, packet)
+	}
+	return packets
+}
+
+// PrintE2EUsage prints usage information for the e2e command
+func PrintE2EUsage() {
+	fmt.Println("🎭 Harlequin E2E - Expect-Style Tests Against Deployed Processes")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin e2e <SCENARIO.yaml> [OPTIONS]")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -p, --process <ID>       Target process (overrides the scenario)")
+	fmt.Println("    -w, --wallet-file <FILE> Path to Arweave wallet JSON file (default: key.json)")
+	fmt.Println("    --junit <FILE>           Write a JUnit XML report for CI")
+	fmt.Println("    --mu <URL>               Messenger unit URL (default: " + defaultMessengerURL + ")")
+	fmt.Println("    --cu <URL>               Compute unit URL (default: " + defaultComputeURL + ")")
+	fmt.Println("    --network <PRESET>       Endpoint preset: mainnet, testnet, or localnet")
+	fmt.Println("    -h, --help               Show this help message")
+	fmt.Println()
+	fmt.Println("SCENARIO FORMAT:")
+	fmt.Println("    name: counter smoke test")
+	fmt.Println("    process: <PROCESS_ID>")
+	fmt.Println("    steps:")
+	fmt.Println("      - name: increment works")
+	fmt.Println("        send:")
+	fmt.Println("          action: Increment")
+	fmt.Println("          data: \"1\"")
+	fmt.Println("          tags:")
+	fmt.Println("            Slot: main")
+	fmt.Println("        expect:")
+	fmt.Println("          output: \"count: 1\"          # substring")
+	fmt.Println("          output_matches: \"count: \\\\d+\" # regexp")
+	fmt.Println("          messages:")
+	fmt.Println("            - tags:")
+	fmt.Println("                Action: Credit-Notice")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    harlequin e2e scenarios/counter.yaml --network localnet")
+	fmt.Println("    harlequin e2e scenarios/counter.yaml --process abc123... --junit report.xml")
+}
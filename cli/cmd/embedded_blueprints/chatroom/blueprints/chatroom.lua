@@ -0,0 +1,61 @@
+-- Chatroom blueprint for {{PROJECT_NAME}}
+--
+-- A broadcast chatroom: processes register as members, and every Broadcast
+-- message is relayed to all registered members.
+local json = require("json")
+
+Members = Members or {}
+
+Handlers.add(
+  "register",
+  Handlers.utils.hasMatchingTag("Action", "Register"),
+  function(msg)
+    for _, member in ipairs(Members) do
+      if member == msg.From then
+        msg.reply({ Data = "Already registered" })
+        return
+      end
+    end
+    table.insert(Members, msg.From)
+    msg.reply({ Data = "Registered" })
+  end
+)
+
+Handlers.add(
+  "unregister",
+  Handlers.utils.hasMatchingTag("Action", "Unregister"),
+  function(msg)
+    for i, member in ipairs(Members) do
+      if member == msg.From then
+        table.remove(Members, i)
+        msg.reply({ Data = "Unregistered" })
+        return
+      end
+    end
+    msg.reply({ Data = "Not registered" })
+  end
+)
+
+Handlers.add(
+  "broadcast",
+  Handlers.utils.hasMatchingTag("Action", "Broadcast"),
+  function(msg)
+    for _, member in ipairs(Members) do
+      ao.send({
+        Target = member,
+        Action = "Broadcasted",
+        Data = msg.Data,
+        Tags = { Broadcaster = msg.From },
+      })
+    end
+    msg.reply({ Data = "Broadcast to " .. #Members .. " members" })
+  end
+)
+
+Handlers.add(
+  "members",
+  Handlers.utils.hasMatchingTag("Action", "Members"),
+  function(msg)
+    msg.reply({ Data = json.encode(Members) })
+  end
+)
@@ -0,0 +1,8 @@
+-- Tests for the chatroom blueprint.
+--
+-- Dry-run the handlers against the embedded emulator:
+--     harlequin eval --action Register
+--     harlequin eval --action Broadcast --data "hello"
+--
+-- TODO: replace with real assertions for your chatroom's rules.
+error("chatroom blueprint tests not implemented")
@@ -0,0 +1,63 @@
+-- Staking blueprint for {{PROJECT_NAME}}
+--
+-- Stake and unstake against the token blueprint's Balances table, with a
+-- block-height unbonding delay before withdrawn stake is released.
+local json = require("json")
+
+Balances = Balances or {}
+Stakers = Stakers or {}
+Unstaking = Unstaking or {}
+UnstakeDelay = UnstakeDelay or 670
+
+Handlers.add(
+  "stake",
+  Handlers.utils.hasMatchingTag("Action", "Stake"),
+  function(msg)
+    local qty = tonumber(msg.Tags.Quantity)
+    assert(qty and qty > 0, "Quantity must be a positive number")
+    assert((Balances[msg.From] or 0) >= qty, "Insufficient balance to stake")
+
+    Balances[msg.From] = Balances[msg.From] - qty
+    Stakers[msg.From] = (Stakers[msg.From] or 0) + qty
+    msg.reply({ Data = json.encode({ Staked = Stakers[msg.From] }) })
+  end
+)
+
+Handlers.add(
+  "unstake",
+  Handlers.utils.hasMatchingTag("Action", "Unstake"),
+  function(msg)
+    local qty = tonumber(msg.Tags.Quantity)
+    assert(qty and qty > 0, "Quantity must be a positive number")
+    assert((Stakers[msg.From] or 0) >= qty, "Insufficient stake")
+
+    Stakers[msg.From] = Stakers[msg.From] - qty
+    Unstaking[msg.From] = {
+      amount = (Unstaking[msg.From] and Unstaking[msg.From].amount or 0) + qty,
+      release = tonumber(msg["Block-Height"] or 0) + UnstakeDelay,
+    }
+    msg.reply({ Data = json.encode(Unstaking[msg.From]) })
+  end
+)
+
+Handlers.add(
+  "withdraw",
+  Handlers.utils.hasMatchingTag("Action", "Withdraw"),
+  function(msg)
+    local pending = Unstaking[msg.From]
+    assert(pending and pending.amount > 0, "Nothing to withdraw")
+    assert(tonumber(msg["Block-Height"] or 0) >= pending.release, "Unstake delay has not elapsed")
+
+    Balances[msg.From] = (Balances[msg.From] or 0) + pending.amount
+    Unstaking[msg.From] = nil
+    msg.reply({ Data = tostring(Balances[msg.From]) })
+  end
+)
+
+Handlers.add(
+  "stakers",
+  Handlers.utils.hasMatchingTag("Action", "Stakers"),
+  function(msg)
+    msg.reply({ Data = json.encode(Stakers) })
+  end
+)
@@ -0,0 +1,8 @@
+-- Tests for the staking blueprint.
+--
+-- Dry-run the handlers against the embedded emulator:
+--     harlequin eval --action Stake --tag Quantity=100
+--     harlequin eval --action Stakers
+--
+-- TODO: replace with real assertions for your staking rules.
+error("staking blueprint tests not implemented")
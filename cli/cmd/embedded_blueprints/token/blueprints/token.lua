@@ -0,0 +1,57 @@
+-- Token blueprint for {{PROJECT_NAME}}
+--
+-- A minimal fungible token: balances, transfers, and token metadata.
+-- State lives in globals so it survives process evaluation steps.
+local json = require("json")
+
+Balances = Balances or { [ao.id] = 10000 }
+Name = Name or "{{PROJECT_NAME}}"
+Ticker = Ticker or "TKN"
+Denomination = Denomination or 12
+
+Handlers.add(
+  "info",
+  Handlers.utils.hasMatchingTag("Action", "Info"),
+  function(msg)
+    msg.reply({
+      Data = json.encode({
+        Name = Name,
+        Ticker = Ticker,
+        Denomination = Denomination,
+      })
+    })
+  end
+)
+
+Handlers.add(
+  "balance",
+  Handlers.utils.hasMatchingTag("Action", "Balance"),
+  function(msg)
+    local target = msg.Tags.Recipient or msg.From
+    msg.reply({ Data = tostring(Balances[target] or 0) })
+  end
+)
+
+Handlers.add(
+  "balances",
+  Handlers.utils.hasMatchingTag("Action", "Balances"),
+  function(msg)
+    msg.reply({ Data = json.encode(Balances) })
+  end
+)
+
+Handlers.add(
+  "transfer",
+  Handlers.utils.hasMatchingTag("Action", "Transfer"),
+  function(msg)
+    local qty = tonumber(msg.Tags.Quantity)
+    local recipient = msg.Tags.Recipient
+    assert(recipient, "Recipient is required")
+    assert(qty and qty > 0, "Quantity must be a positive number")
+    assert((Balances[msg.From] or 0) >= qty, "Insufficient balance")
+
+    Balances[msg.From] = Balances[msg.From] - qty
+    Balances[recipient] = (Balances[recipient] or 0) + qty
+    msg.reply({ Data = json.encode({ Action = "Debit-Notice", Quantity = qty, Recipient = recipient }) })
+  end
+)
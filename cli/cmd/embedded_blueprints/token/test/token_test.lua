@@ -0,0 +1,9 @@
+-- Tests for the token blueprint.
+--
+-- Dry-run the handlers against the embedded emulator:
+--     harlequin eval --action Info
+--     harlequin eval --action Balance
+--     harlequin eval --action Transfer --tag Recipient=abc123 --tag Quantity=10
+--
+-- TODO: replace with real assertions for your token's rules.
+error("token blueprint tests not implemented")
@@ -0,0 +1,56 @@
+-- Voting blueprint for {{PROJECT_NAME}}
+--
+-- Stake-weighted proposals: anyone with stake can propose, votes are weighted
+-- by the voter's stake, and proposals close at a block-height deadline.
+local json = require("json")
+
+Stakers = Stakers or {}
+Proposals = Proposals or {}
+
+Handlers.add(
+  "propose",
+  Handlers.utils.hasMatchingTag("Action", "Propose"),
+  function(msg)
+    local name = msg.Tags.Name
+    local deadline = tonumber(msg.Tags.Deadline)
+    assert(name and name ~= "", "Name is required")
+    assert(deadline and deadline > 0, "Deadline must be a block height")
+    assert(not Proposals[name], "Proposal already exists")
+
+    Proposals[name] = {
+      proposer = msg.From,
+      deadline = deadline,
+      yay = 0,
+      nay = 0,
+      voted = {},
+    }
+    msg.reply({ Data = "Proposal " .. name .. " open until block " .. deadline })
+  end
+)
+
+Handlers.add(
+  "vote",
+  Handlers.utils.hasMatchingTag("Action", "Vote"),
+  function(msg)
+    local proposal = Proposals[msg.Tags.Name or ""]
+    local side = msg.Tags.Side
+    local weight = Stakers[msg.From] or 0
+    assert(proposal, "Unknown proposal")
+    assert(side == "yay" or side == "nay", "Side must be yay or nay")
+    assert(weight > 0, "Only stakers can vote")
+    assert(not proposal.voted[msg.From], "Already voted")
+    assert(tonumber(msg["Block-Height"] or 0) <= proposal.deadline, "Voting has closed")
+
+    proposal[side] = proposal[side] + weight
+    proposal.voted[msg.From] = side
+    msg.reply({ Data = json.encode({ yay = proposal.yay, nay = proposal.nay }) })
+  end
+)
+
+Handlers.add(
+  "proposals",
+  Handlers.utils.hasMatchingTag("Action", "Proposals"),
+  function(msg)
+    msg.reply({ Data = json.encode(Proposals) })
+  end
+)
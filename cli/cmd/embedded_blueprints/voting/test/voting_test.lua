@@ -0,0 +1,8 @@
+-- Tests for the voting blueprint.
+--
+-- Dry-run the handlers against the embedded emulator:
+--     harlequin eval --action Propose --tag Name=upgrade --tag Deadline=1000000
+--     harlequin eval --action Proposals
+--
+-- TODO: replace with real assertions for your voting rules.
+error("voting blueprint tests not implemented")
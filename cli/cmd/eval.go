@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	luautils "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/lua_utils"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/emulator"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/memprofile"
+)
+
+// HandleEvalCommand handles the eval command for dry-run evaluation of a
+// message against a local Lua module in the embedded emulator
+func HandleEvalCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling eval command with args: %v", args)
+
+	var entrypoint string
+	var action string
+	var data string
+	var dataFile string
+	var allowCycles bool
+	var memoryProfile bool
+	tags := make(map[string]string)
+
+	// Parse command line arguments
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--entrypoint", "-e":
+			if i+1 < len(args) {
+				entrypoint = args[i+1]
+				i++
+			}
+		case "--action", "-a":
+			if i+1 < len(args) {
+				action = args[i+1]
+				i++
+			}
+		case "--data", "-d":
+			if i+1 < len(args) {
+				data = args[i+1]
+				i++
+			}
+		case "--data-file":
+			if i+1 < len(args) {
+				dataFile = args[i+1]
+				i++
+			}
+		case "--tag", "-t":
+			if i+1 < len(args) {
+				tag, err := parseSignTag(args[i+1])
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				tags[tag.Name] = tag.Value
+				i++
+			}
+		case "--allow-cycles":
+			allowCycles = true
+		case "--memory-profile", "-m":
+			memoryProfile = true
+		case "--help", "-h":
+			PrintEvalUsage()
+			return
+		default:
+			if !strings.HasPrefix(args[i], "-") && entrypoint == "" {
+				entrypoint = args[i]
+			}
+		}
+	}
+
+	if entrypoint == "" {
+		entrypoint = "main.lua"
+	}
+	if action == "" {
+		fmt.Println("Error: No action specified (use --action)")
+		PrintEvalUsage()
+		os.Exit(1)
+	}
+	if dataFile != "" {
+		content, err := os.ReadFile(dataFile)
+		if err != nil {
+			fmt.Printf("Error: Failed to read data file: %v\n", err)
+			os.Exit(1)
+		}
+		data = string(content)
+	}
+
+	fmt.Printf("🎭 Evaluating %s message against %s\n", action, entrypoint)
+
+	// Bundle the module exactly as a build would
+	bundledLua, err := luautils.BundleWithOptions(entrypoint, luautils.BundleOptions{
+		AllowCycles: allowCycles,
+	})
+	if err != nil {
+		fmt.Printf("❌ Bundling failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("📦 Bundled module: %d bytes\n", len(bundledLua))
+
+	result, err := emulator.Eval(ctx, bundledLua, emulator.Options{
+		Action:  action,
+		Data:    data,
+		Tags:    tags,
+		Profile: memoryProfile,
+	})
+	if err != nil {
+		fmt.Printf("❌ Evaluation failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	if !result.Handled {
+		fmt.Println("⚠️  No handler matched the message")
+		printMemoryReport(result, action)
+		return
+	}
+
+	fmt.Printf("✅ Handled by %q in %s\n", result.HandlerName, result.Duration)
+
+	if result.Output != "" {
+		fmt.Println("   📤 Output:")
+		for _, line := range strings.Split(result.Output, "\n") {
+			fmt.Printf("      %s\n", line)
+		}
+	}
+
+	if len(result.Messages) > 0 {
+		fmt.Printf("   📬 Messages: %d\n", len(result.Messages))
+		for _, msg := range result.Messages {
+			fmt.Printf("      • %s → %s", msg.Action, msg.Target)
+			if msg.Data != "" {
+				fmt.Printf("  %s", truncateEvalData(msg.Data))
+			}
+			fmt.Println()
+		}
+	}
+
+	if len(result.Spawns) > 0 {
+		fmt.Printf("   🐣 Spawns: %d\n", len(result.Spawns))
+	}
+
+	fmt.Printf("   ⛽ Gas Estimate: ~%d units (wall-clock proxy, not metered)\n", result.GasEstimate)
+
+	printMemoryReport(result, action)
+}
+
+// printMemoryReport renders the memory profile of an evaluation, compares it
+// against the configured build limits, and records the observation so the
+// build command can warn when limits drift from observed usage
+func printMemoryReport(result *emulator.Result, action string) {
+	memory := result.Memory
+	if memory == nil {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("🧠 MEMORY PROFILE (host-heap proxy for WASM linear memory)")
+	loadGrowth := memory.LoadedBytes - memory.BaselineBytes
+	if loadGrowth < 0 {
+		loadGrowth = 0
+	}
+	fmt.Printf("   Baseline:     %s\n", memprofile.FormatBytes(memory.BaselineBytes))
+	fmt.Printf("   After load:   %s (+%s for the module)\n",
+		memprofile.FormatBytes(memory.LoadedBytes),
+		memprofile.FormatBytes(loadGrowth))
+	fmt.Printf("   Peak:         %s\n", memprofile.FormatBytes(memory.PeakBytes))
+	if len(memory.GrowthEvents) > 0 {
+		fmt.Printf("   Growth:       %d events", len(memory.GrowthEvents))
+		last := memory.GrowthEvents[len(memory.GrowthEvents)-1]
+		fmt.Printf(" (last at %s, heap %s)\n", last.At.Round(time.Millisecond), memprofile.FormatBytes(last.Bytes))
+	} else {
+		fmt.Println("   Growth:       no growth events during evaluation")
+	}
+
+	cfg := loadConfig()
+	fmt.Printf("   Configured:   initial_memory %s, maximum_memory %s\n",
+		memprofile.FormatBytes(int64(cfg.InitialMemory)),
+		memprofile.FormatBytes(int64(cfg.MaximumMemory)))
+
+	observation := memprofile.Observation{
+		PeakBytes: memory.PeakBytes,
+		LoadBytes: loadGrowth,
+		Action:    action,
+	}
+	for _, warning := range memprofile.Assess(cfg.InitialMemory, cfg.MaximumMemory, observation) {
+		fmt.Printf("   ⚠️  %s\n", warning)
+	}
+
+	if err := memprofile.Record(".", observation); err != nil {
+		debug.Printf("Failed to record memory observation: %v", err)
+	}
+}
+
+// truncateEvalData shortens message data for single-line display
+func truncateEvalData(data string) string {
+	data = strings.ReplaceAll(data, "\n", " ")
+	if len(data) > 60 {
+		return data[:57] + "..."
+	}
+	return data
+}
+
+// PrintEvalUsage prints usage information for the eval command
+func PrintEvalUsage() {
+	fmt.Println("🎭 Harlequin Eval - Dry-Run Messages Against a Local Module")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin eval [ENTRYPOINT] --action <ACTION> [OPTIONS]")
+	fmt.Println()
+	fmt.Println("ARGUMENTS:")
+	fmt.Println("    ENTRYPOINT             Entry Lua file to bundle and load (default: main.lua)")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -e, --entrypoint <FILE>  Entry Lua file to bundle and load")
+	fmt.Println("    -a, --action <ACTION>    Action tag for the evaluated message (required)")
+	fmt.Println("    -d, --data <DATA>        Message data (inline)")
+	fmt.Println("    --data-file <FILE>       Read message data from a file")
+	fmt.Println("    -t, --tag <NAME=VALUE>   Additional tag (repeatable)")
+	fmt.Println("    --allow-cycles           Bundle circular dependencies with lazy-loading stubs")
+	fmt.Println("    -m, --memory-profile     Sample memory usage and compare against build limits")
+	fmt.Println("    -h, --help               Show this help message")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    Bundles the module, loads it into an embedded Lua emulator with an")
+	fmt.Println("    aos-compatible Handlers shim, evaluates one message, and shows the")
+	fmt.Println("    handler response, sent messages, and spawns. No Docker or network")
+	fmt.Println("    access required. The emulator covers a subset of aos, so use a real")
+	fmt.Println("    process for final verification.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    # Evaluate an Info message against main.lua")
+	fmt.Println("    harlequin eval --action Info")
+	fmt.Println()
+	fmt.Println("    # Evaluate a transfer with JSON data")
+	fmt.Println("    harlequin eval src/process.lua --action Transfer --data '{\"qty\":100}'")
+	fmt.Println()
+	fmt.Println("    # Pass extra tags to the handler")
+	fmt.Println("    harlequin eval --action Balance --tag Recipient=abc123...")
+}
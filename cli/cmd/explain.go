@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/explain"
+)
+
+// HandleExplainCommand handles the explain command for diagnosing build and
+// upload errors
+func HandleExplainCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling explain command with args: %v", args)
+
+	var filePath string
+	var textParts []string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--file", "-f":
+			if i+1 < len(args) {
+				filePath = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintExplainUsage()
+			return
+		default:
+			textParts = append(textParts, args[i])
+		}
+	}
+
+	errText := strings.Join(textParts, " ")
+	if filePath != "" {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			fmt.Printf("❌ Failed to read %s: %v\n", filePath, err)
+			os.Exit(1)
+		}
+		errText = string(content)
+	} else if errText == "" {
+		// Piped input: harlequin build 2>&1 | harlequin explain
+		if stat, err := os.Stdin.Stat(); err == nil && (stat.Mode()&os.ModeCharDevice) == 0 {
+			content, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				fmt.Printf("❌ Failed to read stdin: %v\n", err)
+				os.Exit(1)
+			}
+			errText = string(content)
+		}
+	}
+
+	if strings.TrimSpace(errText) == "" {
+		fmt.Println("❌ No error text to explain")
+		PrintExplainUsage()
+		os.Exit(1)
+	}
+
+	issues := explain.Match(errText)
+	if len(issues) == 0 {
+		fmt.Println("🔍 No known failure signature recognized")
+		fmt.Println()
+		fmt.Println("💡 Re-run the failing command with --debug for the full error chain,")
+		fmt.Println("   and report unrecognized errors so they can be added:")
+		fmt.Println("   https://github.com/the-permaweb-harlequin/harlequin-toolkit/issues")
+		return
+	}
+
+	for i, issue := range issues {
+		if i > 0 {
+			fmt.Println()
+		}
+		printExplainIssue(issue)
+	}
+}
+
+// printExplainIssue renders one recognized issue with its remediation
+func printExplainIssue(issue explain.Issue) {
+	fmt.Printf("🔍 %s\n", issue.Title)
+	fmt.Println()
+	fmt.Printf("   %s\n", issue.Detail)
+	fmt.Println()
+	for i, step := range issue.Steps {
+		fmt.Printf("   %d. %s\n", i+1, step)
+	}
+	fmt.Println()
+	fmt.Printf("   📖 %s\n", issue.DocURL)
+}
+
+// printExplainHints prints compact remediation for errors recognized in a
+// failure path, so commands can surface next steps alongside the raw error
+func printExplainHints(errText string) {
+	for _, issue := range explain.Match(errText) {
+		fmt.Printf("💡 %s - %s\n", issue.Title, issue.Steps[0])
+		fmt.Printf("   More: harlequin explain %q\n", firstLine(errText))
+	}
+}
+
+// PrintExplainUsage prints usage information for the explain command
+func PrintExplainUsage() {
+	fmt.Println("🎭 Harlequin Explain - Diagnose Build and Upload Errors")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin explain <ERROR TEXT>")
+	fmt.Println("    harlequin explain --file <LOG FILE>")
+	fmt.Println("    <failing command> 2>&1 | harlequin explain")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -f, --file <FILE>        Read the error text from a file")
+	fmt.Println("    -h, --help               Show this help message")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    Matches the error text against known failure signatures - Docker not")
+	fmt.Println("    running, missing platform emulation on Apple Silicon, Turbo payment")
+	fmt.Println("    errors, aos Handlers issues, signing server size limits - and prints")
+	fmt.Println("    targeted remediation steps with documentation links.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    harlequin explain \"Cannot connect to the Docker daemon\"")
+	fmt.Println("    harlequin build 2>&1 | harlequin explain")
+	fmt.Println("    harlequin explain --file build.log")
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	luautils "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/lua_utils"
+)
+
+// HandleFmtCommand handles the fmt command for formatting Lua sources
+func HandleFmtCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling fmt command with args: %v", args)
+
+	var entrypoint string
+	var bundlePath string
+	var checkOnly bool
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--entrypoint", "-e":
+			if i+1 < len(args) {
+				entrypoint = args[i+1]
+				i++
+			}
+		case "--bundle", "-b":
+			if i+1 < len(args) {
+				bundlePath = args[i+1]
+				i++
+			}
+		case "--check", "-c":
+			checkOnly = true
+		case "--help", "-h":
+			PrintFmtUsage()
+			return
+		default:
+			if entrypoint == "" {
+				entrypoint = args[i]
+			}
+		}
+	}
+
+	// Default to main.lua when nothing else is specified
+	if entrypoint == "" && bundlePath == "" {
+		if _, err := os.Stat("main.lua"); err == nil {
+			entrypoint = "main.lua"
+		} else {
+			fmt.Println("❌ No entrypoint specified and no main.lua found")
+			PrintFmtUsage()
+			os.Exit(1)
+		}
+	}
+
+	// Collect the files to format: the bundle dependency graph of the
+	// entrypoint, plus an emitted bundle when requested
+	var files []string
+	if entrypoint != "" {
+		absEntrypoint, err := filepath.Abs(entrypoint)
+		if err != nil {
+			fmt.Printf("❌ Failed to resolve entrypoint path: %v\n", err)
+			os.Exit(1)
+		}
+		files, err = luautils.ProjectLuaFiles(absEntrypoint)
+		if err != nil {
+			fmt.Printf("❌ Failed to walk dependency graph: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if bundlePath != "" {
+		files = append(files, bundlePath)
+	}
+
+	changed := 0
+	unformatted := []string{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Printf("❌ Failed to read %s: %v\n", file, err)
+			os.Exit(1)
+		}
+
+		formatted := luautils.FormatLua(string(content))
+		if formatted == string(content) {
+			continue
+		}
+
+		if checkOnly {
+			unformatted = append(unformatted, file)
+			continue
+		}
+
+		if err := os.WriteFile(file, []byte(formatted), 0644); err != nil {
+			fmt.Printf("❌ Failed to write %s: %v\n", file, err)
+			os.Exit(1)
+		}
+		fmt.Printf("✨ Formatted %s\n", file)
+		changed++
+	}
+
+	if checkOnly {
+		if len(unformatted) > 0 {
+			fmt.Printf("⚠️  %d of %d files need formatting:\n", len(unformatted), len(files))
+			for _, file := range unformatted {
+				fmt.Printf("   %s\n", file)
+			}
+			os.Exit(1)
+		}
+		fmt.Printf("✅ All %d files are formatted\n", len(files))
+		return
+	}
+
+	fmt.Printf("✅ Checked %d files, formatted %d\n", len(files), changed)
+}
+
+// PrintFmtUsage prints usage information for the fmt command
+func PrintFmtUsage() {
+	fmt.Println("🎭 Harlequin Fmt - Format Lua Sources")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin fmt [ENTRYPOINT] [OPTIONS]")
+	fmt.Println()
+	fmt.Println("ARGUMENTS:")
+	fmt.Println("    ENTRYPOINT      Entry Lua file; its whole dependency graph is formatted")
+	fmt.Println("                    (default: main.lua)")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -e, --entrypoint <FILE>  Entry Lua file (alternative to positional argument)")
+	fmt.Println("    -b, --bundle <FILE>      Also format an emitted bundle for readability")
+	fmt.Println("    -c, --check              Report unformatted files without writing; exit 1 if any")
+	fmt.Println("    -h, --help               Show this help message")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    harlequin fmt                          # Format main.lua and everything it requires")
+	fmt.Println("    harlequin fmt src/process.lua          # Format a specific dependency graph")
+	fmt.Println("    harlequin fmt --check                  # CI mode: fail when formatting is needed")
+	fmt.Println("    harlequin fmt --bundle dist/process.bundled.lua")
+}
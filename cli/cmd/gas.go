@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	luautils "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/lua_utils"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/emulator"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/snapshot"
+)
+
+// gasWarnFraction flags actions whose estimate approaches the Compute-Limit
+const gasWarnFraction = 0.5
+
+// HandleGasCommand handles the gas command for estimating compute consumed
+// per handler from scaled emulator timings
+func HandleGasCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling gas command with args: %v", args)
+
+	var casesPath string
+	var entrypoint string
+	var runs = 5
+	var allowCycles bool
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--entrypoint", "-e":
+			if i+1 < len(args) {
+				entrypoint = args[i+1]
+				i++
+			}
+		case "--runs", "-r":
+			if i+1 < len(args) {
+				parsed, err := strconv.Atoi(args[i+1])
+				if err != nil || parsed < 1 {
+					fmt.Printf("Error: invalid runs: %s\n", args[i+1])
+					os.Exit(1)
+				}
+				runs = parsed
+				i++
+			}
+		case "--allow-cycles":
+			allowCycles = true
+		case "--help", "-h":
+			PrintGasUsage()
+			return
+		default:
+			if !strings.HasPrefix(args[i], "-") && casesPath == "" {
+				casesPath = args[i]
+			}
+		}
+	}
+
+	if entrypoint == "" {
+		entrypoint = "main.lua"
+	}
+
+	cases, err := loadGasCorpus(casesPath, entrypoint)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	bundledLua, err := luautils.BundleWithOptions(entrypoint, luautils.BundleOptions{
+		AllowCycles: allowCycles,
+	})
+	if err != nil {
+		fmt.Printf("❌ Bundling failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg := loadConfig()
+	computeLimit, err := strconv.ParseInt(cfg.ComputeLimit, 10, 64)
+	if err != nil {
+		fmt.Printf("⚠️  Could not parse compute_limit %q - reporting raw estimates only\n", cfg.ComputeLimit)
+		computeLimit = 0
+	}
+
+	fmt.Printf("🎭 Estimating compute for %d cases (%d runs each)\n", len(cases), runs)
+	fmt.Println()
+
+	type estimate struct {
+		name   string
+		action string
+		gas    int64
+	}
+	estimates := make([]estimate, 0, len(cases))
+
+	for _, gasCase := range cases {
+		samples := make([]int64, 0, runs)
+		for run := 0; run < runs; run++ {
+			result, err := emulator.Eval(ctx, bundledLua, emulator.Options{
+				Action:  gasCase.Action,
+				Data:    gasCase.Data,
+				Tags:    gasCase.Tags,
+				Timeout: 60 * time.Second,
+			})
+			if err != nil {
+				fmt.Printf("❌ %s - evaluation failed: %v\n", gasCase.Name, err)
+				samples = nil
+				break
+			}
+			if !result.Handled {
+				fmt.Printf("⚠️  %s - no handler matched, skipping\n", gasCase.Name)
+				samples = nil
+				break
+			}
+			samples = append(samples, result.GasEstimate)
+		}
+		if len(samples) == 0 {
+			continue
+		}
+		estimates = append(estimates, estimate{
+			name:   gasCase.Name,
+			action: gasCase.Action,
+			gas:    medianGas(samples),
+		})
+	}
+
+	if len(estimates) == 0 {
+		fmt.Println("❌ No cases produced an estimate")
+		os.Exit(1)
+	}
+
+	sort.Slice(estimates, func(i, j int) bool { return estimates[i].gas > estimates[j].gas })
+
+	fmt.Println("⛽ COMPUTE REPORT (time-based estimate, not CU-metered)")
+	if computeLimit > 0 {
+		fmt.Printf("   Compute-Limit: %s\n", cfg.ComputeLimit)
+		fmt.Println("   Estimates are emulator wall-clock time scaled to gas-like units,")
+		fmt.Println("   so read % OF LIMIT as a rough guide, not a metered margin")
+	}
+	fmt.Println()
+	fmt.Printf("   %-24s %-16s %14s %12s\n", "CASE", "ACTION", "GAS (median)", "% OF LIMIT")
+
+	flagged := 0
+	for _, est := range estimates {
+		share := "-"
+		marker := "  "
+		if computeLimit > 0 {
+			fraction := float64(est.gas) / float64(computeLimit)
+			share = fmt.Sprintf("%.2f%%", fraction*100)
+			if fraction >= 1 {
+				marker = "❌"
+				flagged++
+			} else if fraction >= gasWarnFraction {
+				marker = "⚠️ "
+				flagged++
+			}
+		}
+		fmt.Printf("   %-24s %-16s %14d %12s %s\n", est.name, est.action, est.gas, share, marker)
+	}
+
+	fmt.Println()
+	if flagged > 0 {
+		fmt.Printf("⚠️  %d actions approach or exceed the configured Compute-Limit\n", flagged)
+		fmt.Println("💡 Raise compute_limit in .harlequin.yaml or split the work across messages")
+		os.Exit(1)
+	}
+	fmt.Println("✅ All actions are comfortably under the configured Compute-Limit")
+}
+
+// loadGasCorpus reads the sample message corpus, falling back to one bare
+// message per ABI action when no cases file exists
+func loadGasCorpus(casesPath, entrypoint string) ([]snapshot.Case, error) {
+	if casesPath == "" {
+		if _, err := os.Stat("harlequin.test.yaml"); err == nil {
+			casesPath = "harlequin.test.yaml"
+		}
+	}
+	if casesPath != "" {
+		return snapshot.LoadCases(casesPath)
+	}
+
+	abi, err := ExtractProcessABI(entrypoint)
+	if err != nil || len(abi.Actions) == 0 {
+		return nil, fmt.Errorf("no cases file and no actions found in %s - write a harlequin.test.yaml corpus", entrypoint)
+	}
+
+	cases := make([]snapshot.Case, 0, len(abi.Actions))
+	seen := make(map[string]bool)
+	for _, action := range abi.Actions {
+		if action.Action == "" || seen[action.Action] {
+			continue
+		}
+		seen[action.Action] = true
+		cases = append(cases, snapshot.Case{Name: action.Action, Action: action.Action})
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("no cases file and no actions found in %s - write a harlequin.test.yaml corpus", entrypoint)
+	}
+	return cases, nil
+}
+
+// medianGas picks the median sample, damping scheduler noise in the
+// wall-clock-derived estimates
+func medianGas(samples []int64) int64 {
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	return samples[len(samples)/2]
+}
+
+// PrintGasUsage prints usage information for the gas command
+func PrintGasUsage() {
+	fmt.Println("🎭 Harlequin Gas - Per-Handler Compute Estimation")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin gas [CASES.yaml] [OPTIONS]")
+	fmt.Println()
+	fmt.Println("ARGUMENTS:")
+	fmt.Println("    CASES.yaml             Sample messages to measure (default: harlequin.test.yaml,")
+	fmt.Println("                           or one bare message per ABI action)")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -r, --runs <N>           Evaluations per case, median reported (default: 5)")
+	fmt.Println("    -e, --entrypoint <FILE>  Entry Lua file to bundle and load (default: main.lua)")
+	fmt.Println("    --allow-cycles           Bundle circular dependencies with lazy-loading stubs")
+	fmt.Println("    -h, --help               Show this help message")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    Evaluates each sample message in the embedded emulator and reports the")
+	fmt.Println("    median gas estimate per action next to the configured compute_limit,")
+	fmt.Println("    flagging actions that approach it. Estimates are a wall-clock proxy,")
+	fmt.Println("    not CU metering - treat them as relative weights, and verify hot")
+	fmt.Println("    handlers against a real compute unit before relying on the margin.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    harlequin gas")
+	fmt.Println("    harlequin gas cases/token.yaml --runs 10")
+	fmt.Println("    harlequin gas -e src/process.lua")
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// handleGenerateClient writes a typed aoconnect wrapper for the process ABI,
+// one method per action, so frontends never hand-write message plumbing
+func handleGenerateClient(args []string) {
+	lang := "ts"
+	abiPath := ProcessABIFileName
+	outDir := "client"
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--lang", "-l":
+			if i+1 < len(args) {
+				lang = args[i+1]
+				i++
+			}
+		case "--abi":
+			if i+1 < len(args) {
+				abiPath = args[i+1]
+				i++
+			}
+		case "--out", "-o":
+			if i+1 < len(args) {
+				outDir = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintGenerateUsage()
+			return
+		}
+	}
+
+	if lang != "ts" {
+		fmt.Printf("❌ Unsupported client language: %s (supported: ts)\n", lang)
+		os.Exit(1)
+	}
+
+	abi, err := LoadProcessABI(abiPath)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if abi == nil {
+		// No generated ABI yet - extract one from the project source first
+		abi, err = ExtractProcessABI(detectProjectEntrypoint())
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			fmt.Println("   Run harlequin generate abi first, or pass --abi")
+			os.Exit(1)
+		}
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		fmt.Printf("❌ Failed to create %s: %v\n", outDir, err)
+		os.Exit(1)
+	}
+
+	clientPath := filepath.Join(outDir, "index.ts")
+	if err := os.WriteFile(clientPath, []byte(renderTSClient(abi)), 0644); err != nil {
+		fmt.Printf("❌ Failed to write %s: %v\n", clientPath, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✨ Wrote %s with %d action methods\n", clientPath, len(abi.Actions))
+	fmt.Println("   Install the runtime dependency with: npm install @permaweb/aoconnect")
+}
+
+// renderTSClient renders the aoconnect wrapper: a tags interface per action
+// that reads tags, and a ProcessClient class with one method per action
+func renderTSClient(abi *ProcessABI) string {
+	var b strings.Builder
+
+	b.WriteString("// Generated by harlequin generate client — do not edit by hand.\n")
+	b.WriteString("// Regenerate after handler changes with: harlequin generate abi && harlequin generate client\n")
+	b.WriteString("import {\n  message,\n  result,\n  createDataItemSigner,\n} from \"@permaweb/aoconnect\";\n\n")
+
+	b.WriteString("export interface ClientOptions {\n")
+	b.WriteString("  processId: string;\n")
+	b.WriteString("  signer: ReturnType<typeof createDataItemSigner>;\n")
+	b.WriteString("}\n\n")
+
+	// One tags interface per action that reads message tags
+	for _, action := range abi.Actions {
+		if action.Action == "" || len(action.Tags) == 0 {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("export interface %sTags {\n", tsTypeName(action.Action)))
+		for _, tag := range action.Tags {
+			b.WriteString(fmt.Sprintf("  %q: string;\n", tag))
+		}
+		b.WriteString("}\n\n")
+	}
+
+	b.WriteString("export class ProcessClient {\n")
+	b.WriteString("  constructor(private readonly options: ClientOptions) {}\n\n")
+	b.WriteString("  private async send(\n")
+	b.WriteString("    action: string,\n")
+	b.WriteString("    tags: Record<string, string>,\n")
+	b.WriteString("    data?: string,\n")
+	b.WriteString("  ) {\n")
+	b.WriteString("    const messageId = await message({\n")
+	b.WriteString("      process: this.options.processId,\n")
+	b.WriteString("      signer: this.options.signer,\n")
+	b.WriteString("      data,\n")
+	b.WriteString("      tags: [\n")
+	b.WriteString("        { name: \"Action\", value: action },\n")
+	b.WriteString("        ...Object.entries(tags).map(([name, value]) => ({ name, value })),\n")
+	b.WriteString("      ],\n")
+	b.WriteString("    });\n")
+	b.WriteString("    return result({ process: this.options.processId, message: messageId });\n")
+	b.WriteString("  }\n")
+
+	for _, action := range abi.Actions {
+		if action.Action == "" {
+			continue
+		}
+		methodName := tsMethodName(action.Action)
+		if len(action.Tags) > 0 {
+			b.WriteString(fmt.Sprintf("\n  async %s(tags: %sTags, data?: string) {\n", methodName, tsTypeName(action.Action)))
+			b.WriteString(fmt.Sprintf("    return this.send(%q, { ...tags }, data);\n", action.Action))
+		} else {
+			b.WriteString(fmt.Sprintf("\n  async %s(data?: string) {\n", methodName))
+			b.WriteString(fmt.Sprintf("    return this.send(%q, {}, data);\n", action.Action))
+		}
+		b.WriteString("  }\n")
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// tsTypeName converts an Action tag value to a TypeScript type name:
+// "Get-Balance" becomes "GetBalance"
+func tsTypeName(action string) string {
+	var b strings.Builder
+	upperNext := true
+	for _, r := range action {
+		if r == '-' || r == '_' || r == ' ' {
+			upperNext = true
+			continue
+		}
+		if upperNext {
+			b.WriteRune(unicode.ToUpper(r))
+			upperNext = false
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// tsMethodName converts an Action tag value to a TypeScript method name:
+// "Get-Balance" becomes "getBalance"
+func tsMethodName(action string) string {
+	name := tsTypeName(action)
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
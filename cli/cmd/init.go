@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -20,6 +21,7 @@ import (
 var availableTemplates = []string{
 	"assemblyscript",
 	"go",
+	"teal",
 }
 
 // Template metadata
@@ -63,6 +65,18 @@ var templateInfoMap = map[string]TemplateInfo{
 			"Efficient memory usage",
 		},
 	},
+	"teal": {
+		Name:        "Teal AO Process",
+		Description: "Typed Lua AO Process transpiled with the Teal compiler",
+		Language:    "Teal",
+		BuildSystem: "Teal + AOS build container",
+		Features: []string{
+			"Static types over plain Lua",
+			"Type-checked handlers at build time",
+			"Transpiles to Lua before bundling",
+			"Runs on the standard AOS module",
+		},
+	},
 }
 
 // HandleInitCommand handles the init command for project initialization
@@ -74,7 +88,10 @@ func HandleInitCommand(ctx context.Context, args []string) {
 	var targetDir string
 	var authorName string
 	var githubUser string
+	var conflictMode string
 	var interactive bool = true
+	var blueprints []string
+	variables := make(map[string]string)
 
 	// If no arguments, run interactive mode
 	if len(args) == 0 {
@@ -130,6 +147,37 @@ func HandleInitCommand(ctx context.Context, args []string) {
 				githubUser = args[i+1]
 				i++
 			}
+		case "--conflict":
+			if i+1 < len(args) {
+				conflictMode = args[i+1]
+				if conflictMode != "merge" && conflictMode != "overwrite" && conflictMode != "abort" {
+					fmt.Printf("Error: invalid conflict mode '%s'. Valid modes: merge, overwrite, abort\n", conflictMode)
+					os.Exit(1)
+				}
+				if conflictMode == "abort" {
+					conflictMode = ""
+				}
+				i++
+			}
+		case "--blueprint", "-b":
+			if i+1 < len(args) {
+				if !isValidBlueprint(args[i+1]) {
+					fmt.Printf("Error: unknown blueprint '%s'. Available blueprints: %s\n", args[i+1], strings.Join(ListBlueprints(), ", "))
+					os.Exit(1)
+				}
+				blueprints = append(blueprints, args[i+1])
+				i++
+			}
+		case "--var":
+			if i+1 < len(args) {
+				name, value, found := strings.Cut(args[i+1], "=")
+				if !found || name == "" {
+					fmt.Printf("Error: invalid --var %q, expected NAME=VALUE\n", args[i+1])
+					os.Exit(1)
+				}
+				variables[name] = value
+				i++
+			}
 		case "--interactive":
 			interactive = true
 		case "--non-interactive", "--no-interactive":
@@ -165,16 +213,32 @@ func HandleInitCommand(ctx context.Context, args []string) {
 			os.Exit(1)
 		}
 
-		err := InitializeProject(projectName, templateLang, targetDir, authorName, githubUser)
+		err := InitializeProjectWithVariables(projectName, templateLang, targetDir, authorName, githubUser, conflictMode, variables)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 			os.Exit(1)
 		}
+
+		// Layer requested blueprints onto the fresh project
+		resolvedDir := targetDir
+		if resolvedDir == "" {
+			resolvedDir = projectName
+		}
+		for _, blueprint := range blueprints {
+			if err := ApplyBlueprint(blueprint, resolvedDir, projectName, authorName, githubUser, variables); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
 	}
 }
 
 // runInteractiveInit runs the interactive TUI for project initialization
 func runInteractiveInit(ctx context.Context, projectName, targetDir, authorName, githubUser string) error {
+	if PlainFallback() {
+		return runPlainInit(ctx, projectName, targetDir, authorName, githubUser)
+	}
+
 	wizard := components.NewInitWizardComponent()
 
 	// Pre-populate fields if provided
@@ -192,10 +256,48 @@ func runInteractiveInit(ctx context.Context, projectName, targetDir, authorName,
 		wizard.GitHubUser = githubUser
 	}
 
+	// Supply the template file list and conflict detection for the preview step
+	wizard.ListTemplateFiles = func(templateLang string) []string {
+		files, err := ListTemplateFiles(templateLang)
+		if err != nil {
+			debug.Printf("Failed to list template files: %v", err)
+			return nil
+		}
+		return files
+	}
+	wizard.TargetDirConflict = TargetDirHasFiles
+
+	// Surface template-declared variables as wizard prompts
+	wizard.ListTemplateVariables = func(templateLang string) []components.WizardVariable {
+		declared, err := LoadTemplateVariables(templateLang)
+		if err != nil {
+			debug.Printf("Failed to load template variables: %v", err)
+			return nil
+		}
+		variables := make([]components.WizardVariable, len(declared))
+		for i, v := range declared {
+			variables[i] = components.WizardVariable{
+				Name:    v.Name,
+				Prompt:  v.Prompt,
+				Default: v.Default,
+				Pattern: v.Pattern,
+			}
+		}
+		return variables
+	}
+	wizard.ValidateVariable = func(variable components.WizardVariable, value string) error {
+		return ValidateTemplateVariable(TemplateVariable{
+			Name:    variable.Name,
+			Prompt:  variable.Prompt,
+			Default: variable.Default,
+			Pattern: variable.Pattern,
+		}, value)
+	}
+
 	// Set up completion callback
 	var resultErr error
-	wizard.OnComplete = func(pName, tLang, aName, gUser, tDir string) {
-		resultErr = InitializeProject(pName, tLang, tDir, aName, gUser)
+	wizard.OnComplete = func(pName, tLang, aName, gUser, tDir, conflictMode string, variables map[string]string) {
+		resultErr = InitializeProjectWithVariables(pName, tLang, tDir, aName, gUser, conflictMode, variables)
 	}
 
 	// Run the TUI
@@ -209,7 +311,21 @@ func runInteractiveInit(ctx context.Context, projectName, targetDir, authorName,
 
 // InitializeProject creates a new project from the specified template
 func InitializeProject(projectName, templateLang, targetDir, authorName, githubUser string) error {
-	debug.Printf("Initializing project: %s with template: %s", projectName, templateLang)
+	return InitializeProjectWithMode(projectName, templateLang, targetDir, authorName, githubUser, "")
+}
+
+// InitializeProjectWithMode creates a project with explicit conflict handling
+// for an existing non-empty target directory: "merge" adds template files on
+// top of existing ones, "overwrite" deletes the directory first, and the
+// default aborts.
+func InitializeProjectWithMode(projectName, templateLang, targetDir, authorName, githubUser, conflictMode string) error {
+	return InitializeProjectWithVariables(projectName, templateLang, targetDir, authorName, githubUser, conflictMode, nil)
+}
+
+// InitializeProjectWithVariables additionally substitutes template-declared
+// variables (from the template.yaml manifest) throughout the scaffolded files
+func InitializeProjectWithVariables(projectName, templateLang, targetDir, authorName, githubUser, conflictMode string, variables map[string]string) error {
+	debug.Printf("Initializing project: %s with template: %s (conflict mode: %s)", projectName, templateLang, conflictMode)
 
 	// Validate template language
 	if !isValidTemplate(templateLang) {
@@ -221,9 +337,18 @@ func InitializeProject(projectName, templateLang, targetDir, authorName, githubU
 		targetDir = projectName
 	}
 
-	// Check if target directory already exists
-	if _, err := os.Stat(targetDir); err == nil {
-		return fmt.Errorf("directory %s already exists", targetDir)
+	// Resolve conflicts with an existing non-empty target directory
+	switch conflictMode {
+	case "overwrite":
+		if err := os.RemoveAll(targetDir); err != nil {
+			return fmt.Errorf("failed to remove existing directory %s: %w", targetDir, err)
+		}
+	case "merge":
+		// Extract over whatever is there, keeping existing files
+	default:
+		if TargetDirHasFiles(targetDir) {
+			return fmt.Errorf("directory %s already exists and is not empty (use --conflict merge or --conflict overwrite)", targetDir)
+		}
 	}
 
 	// Load embedded templates
@@ -237,6 +362,16 @@ func InitializeProject(projectName, templateLang, targetDir, authorName, githubU
 		return fmt.Errorf("template not found: %s. Available templates: %s", templateLang, strings.Join(availableTemplates, ", "))
 	}
 
+	// Apply defaults and validation for template-declared variables
+	declared, err := LoadTemplateVariables(templateLang)
+	if err != nil {
+		return fmt.Errorf("failed to load template manifest: %w", err)
+	}
+	resolved, err := resolveTemplateVariables(declared, variables)
+	if err != nil {
+		return err
+	}
+
 	fmt.Printf("Creating project '%s' from %s template...\n", projectName, template.Name)
 
 	// Create target directory
@@ -246,10 +381,12 @@ func InitializeProject(projectName, templateLang, targetDir, authorName, githubU
 	}
 
 	// Extract embedded template
-	err = extractEmbeddedTemplate(templateLang, targetDir, projectName, authorName, githubUser)
+	err = extractEmbeddedTemplate(templateLang, targetDir, projectName, authorName, githubUser, resolved)
 	if err != nil {
-		// Clean up on error
-		os.RemoveAll(targetDir)
+		// Clean up on error, unless merging into pre-existing files
+		if conflictMode != "merge" {
+			os.RemoveAll(targetDir)
+		}
 		return fmt.Errorf("failed to extract embedded template: %w", err)
 	}
 
@@ -261,8 +398,59 @@ func InitializeProject(projectName, templateLang, targetDir, authorName, githubU
 
 
 
-// substituteVariables replaces template variables with actual values
-func substituteVariables(content, projectName, authorName, githubUser string) string {
+// TargetDirHasFiles reports whether the target directory exists and is
+// non-empty, meaning project creation would conflict with existing files
+func TargetDirHasFiles(targetDir string) bool {
+	entries, err := os.ReadDir(targetDir)
+	return err == nil && len(entries) > 0
+}
+
+// ListTemplateFiles returns the files a template would generate, for
+// previewing before anything touches the disk
+func ListTemplateFiles(templateLang string) ([]string, error) {
+	tarballPath := fmt.Sprintf("embedded_templates/%s.tar.gz", templateLang)
+	tarballData, err := embeddedTemplates.ReadFile(tarballPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded template: %w", err)
+	}
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(tarballData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+
+	var files []string
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tar header: %w", err)
+		}
+
+		// Skip the same special files that extraction skips
+		if strings.HasPrefix(header.Name, ".harlequin-template.json") ||
+			strings.HasPrefix(header.Name, "install.sh") ||
+			isTemplateManifest(header.Name) {
+			continue
+		}
+
+		if header.Typeflag == tar.TypeReg {
+			files = append(files, header.Name)
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// substituteVariables replaces template variables with actual values,
+// including any variables the template declares in its manifest
+func substituteVariables(content, projectName, authorName, githubUser string, variables map[string]string) string {
 	replacements := map[string]string{
 		"{{PROJECT_NAME}}": projectName,
 		"{{AUTHOR_NAME}}":  authorName,
@@ -277,6 +465,11 @@ func substituteVariables(content, projectName, authorName, githubUser string) st
 		replacements["{{GITHUB_USER}}"] = "your-username"
 	}
 
+	// Template-declared variables substitute as {{NAME}}
+	for name, value := range variables {
+		replacements[fmt.Sprintf("{{%s}}", name)] = value
+	}
+
 	result := content
 	for placeholder, value := range replacements {
 		result = strings.ReplaceAll(result, placeholder, value)
@@ -306,7 +499,7 @@ func PrintInitUsage() {
 	fmt.Println("    harlequin init <LANGUAGE> [OPTIONS]  # Non-interactive mode")
 	fmt.Println()
 	fmt.Println("ARGUMENTS:")
-	fmt.Println("    LANGUAGE        Template language (assemblyscript, go)")
+	fmt.Println("    LANGUAGE        Template language (assemblyscript, go, teal)")
 	fmt.Println()
 	fmt.Println("OPTIONS:")
 	fmt.Println("    -n, --name <NAME>           Project name (required in non-interactive mode)")
@@ -314,6 +507,9 @@ func PrintInitUsage() {
 	fmt.Println("    -d, --dir <DIRECTORY>       Target directory (default: project name)")
 	fmt.Println("    -a, --author <AUTHOR>       Author name")
 	fmt.Println("    -g, --github <USERNAME>     GitHub username")
+	fmt.Println("    --conflict <MODE>           Existing directory handling: merge, overwrite, abort (default: abort)")
+	fmt.Println("    --var <NAME=VALUE>          Set a template-declared variable (repeatable)")
+	fmt.Println("    -b, --blueprint <NAME>      Layer a process blueprint onto the project (repeatable)")
 	fmt.Println("    --interactive               Force interactive mode")
 	fmt.Println("    --non-interactive           Skip interactive prompts")
 	fmt.Println("    -h, --help                  Show this help message")
@@ -336,10 +532,13 @@ func PrintInitUsage() {
 		fmt.Printf("    %-15s %s\n", template, info.Description)
 	}
 	fmt.Println()
+	fmt.Println("AVAILABLE BLUEPRINTS:")
+	fmt.Printf("    %s\n", strings.Join(ListBlueprints(), ", "))
+	fmt.Println()
 }
 
 // extractEmbeddedTemplate extracts the embedded template tarball to the target directory
-func extractEmbeddedTemplate(templateLang, targetDir, projectName, authorName, githubUser string) error {
+func extractEmbeddedTemplate(templateLang, targetDir, projectName, authorName, githubUser string, variables map[string]string) error {
 	// Read the embedded tarball
 	tarballPath := fmt.Sprintf("embedded_templates/%s.tar.gz", templateLang)
 	tarballData, err := embeddedTemplates.ReadFile(tarballPath)
@@ -369,7 +568,8 @@ func extractEmbeddedTemplate(templateLang, targetDir, projectName, authorName, g
 
 		// Skip special files
 		if strings.HasPrefix(header.Name, ".harlequin-template.json") ||
-		   strings.HasPrefix(header.Name, "install.sh") {
+		   strings.HasPrefix(header.Name, "install.sh") ||
+		   isTemplateManifest(header.Name) {
 			continue
 		}
 
@@ -402,7 +602,7 @@ func extractEmbeddedTemplate(templateLang, targetDir, projectName, authorName, g
 			}
 
 			// Replace template variables
-			processedContent := substituteVariables(string(content), projectName, authorName, githubUser)
+			processedContent := substituteVariables(string(content), projectName, authorName, githubUser, variables)
 
 			if _, err := file.WriteString(processedContent); err != nil {
 				file.Close()
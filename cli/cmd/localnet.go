@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	harlequinconfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+)
+
+// localnetComposeFile is the docker-compose stack backing `harlequin localnet`:
+// an arlocal Arweave gateway plus the ao compute and messenger units, on the
+// ports the localnet network preset expects.
+const localnetComposeFile = `# Managed by harlequin localnet - edits are overwritten on the next "up"
+services:
+  gateway:
+    image: textury/arlocal:latest
+    ports:
+      - "4000:1984"
+    restart: unless-stopped
+
+  cu:
+    image: ghcr.io/permaweb/ao-cu:latest
+    ports:
+      - "6363:6363"
+    environment:
+      - GATEWAY_URL=http://gateway:1984
+      - UPLOADER_URL=http://gateway:1984
+    depends_on:
+      - gateway
+    restart: unless-stopped
+
+  mu:
+    image: ghcr.io/permaweb/ao-mu:latest
+    ports:
+      - "3004:3004"
+    environment:
+      - GATEWAY_URL=http://gateway:1984
+      - CU_URL=http://cu:6363
+      - UPLOAD_NODE_URL=http://gateway:1984
+    depends_on:
+      - cu
+    restart: unless-stopped
+`
+
+// localnetProjectName isolates the stack from other compose projects
+const localnetProjectName = "harlequin-localnet"
+
+// HandleLocalnetCommand handles the localnet command and its subcommands
+func HandleLocalnetCommand(ctx context.Context, args []string) {
+	if len(args) == 0 {
+		PrintLocalnetUsage()
+		return
+	}
+
+	switch args[0] {
+	case "up":
+		handleLocalnetUp(ctx)
+	case "down":
+		handleLocalnetDown(ctx)
+	case "status":
+		handleLocalnetStatus(ctx)
+	case "--help", "-h", "help":
+		PrintLocalnetUsage()
+	default:
+		fmt.Printf("Unknown localnet subcommand: %s\n\n", args[0])
+		PrintLocalnetUsage()
+		os.Exit(1)
+	}
+}
+
+// composeFilePath writes the managed compose file under the user config
+// directory and returns its path
+func composeFilePath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	dir := filepath.Join(configDir, "harlequin", "localnet")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create localnet directory: %w", err)
+	}
+	path := filepath.Join(dir, "docker-compose.yaml")
+	if err := os.WriteFile(path, []byte(localnetComposeFile), 0644); err != nil {
+		return "", fmt.Errorf("failed to write compose file: %w", err)
+	}
+	return path, nil
+}
+
+// runCompose executes a docker compose command against the managed stack
+func runCompose(ctx context.Context, composePath string, args ...string) error {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return fmt.Errorf("docker is not installed or not in PATH")
+	}
+
+	composeArgs := append([]string{"compose", "-f", composePath, "-p", localnetProjectName}, args...)
+	debug.Printf("Running docker %v", composeArgs)
+
+	cmd := exec.CommandContext(ctx, "docker", composeArgs...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// handleLocalnetUp starts the stack and makes localnet the default network
+func handleLocalnetUp(ctx context.Context) {
+	composePath, err := composeFilePath()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("🎭 Starting local AO stack...")
+	if err := runCompose(ctx, composePath, "up", "-d"); err != nil {
+		fmt.Printf("❌ Failed to start localnet: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := persistNetworkPreset(harlequinconfig.NetworkLocalnet); err != nil {
+		fmt.Printf("⚠️  Localnet is running but could not be made the default network: %v\n", err)
+	}
+
+	localnet, _ := harlequinconfig.NetworkPreset(harlequinconfig.NetworkLocalnet)
+	fmt.Println()
+	fmt.Println("✅ Localnet is up!")
+	fmt.Printf("   • Gateway: %s\n", localnet.Gateway)
+	fmt.Printf("   • CU:      %s\n", localnet.CU)
+	fmt.Printf("   • MU:      %s\n", localnet.MU)
+	fmt.Println()
+	fmt.Println("Commands now target localnet by default. Use --network mainnet to")
+	fmt.Println("override, or run: harlequin localnet down")
+}
+
+// handleLocalnetDown stops the stack and restores the default network
+func handleLocalnetDown(ctx context.Context) {
+	composePath, err := composeFilePath()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("🎭 Stopping local AO stack...")
+	if err := runCompose(ctx, composePath, "down"); err != nil {
+		fmt.Printf("❌ Failed to stop localnet: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := clearNetworkPreset(); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+	fmt.Println("✅ Localnet stopped. Commands target mainnet again.")
+}
+
+// handleLocalnetStatus shows the container states and probes the endpoints
+func handleLocalnetStatus(ctx context.Context) {
+	composePath, err := composeFilePath()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := runCompose(ctx, composePath, "ps"); err != nil {
+		fmt.Printf("❌ Failed to query localnet: %v\n", err)
+		os.Exit(1)
+	}
+
+	localnet, _ := harlequinconfig.NetworkPreset(harlequinconfig.NetworkLocalnet)
+	fmt.Println()
+	fmt.Println("🎭 Endpoint health:")
+	probeEndpoint(ctx, "Gateway", localnet.Gateway)
+	probeEndpoint(ctx, "CU", localnet.CU)
+	probeEndpoint(ctx, "MU", localnet.MU)
+
+	if persistedNetworkPreset() == harlequinconfig.NetworkLocalnet {
+		fmt.Println()
+		fmt.Println("Localnet is the default network for commands.")
+	}
+}
+
+// probeEndpoint reports whether a service answers HTTP requests
+func probeEndpoint(ctx context.Context, name, url string) {
+	probeCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(probeCtx, http.MethodGet, url, nil)
+	if err != nil {
+		fmt.Printf("   • %-7s %s ❌ (%v)\n", name, url, err)
+		return
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		fmt.Printf("   • %-7s %s ❌ (unreachable)\n", name, url)
+		return
+	}
+	resp.Body.Close()
+	fmt.Printf("   • %-7s %s ✅\n", name, url)
+}
+
+// PrintLocalnetUsage prints usage information for the localnet command
+func PrintLocalnetUsage() {
+	fmt.Println("🎭 Harlequin Localnet - Run a Local Arweave + AO Stack")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin localnet <SUBCOMMAND>")
+	fmt.Println()
+	fmt.Println("SUBCOMMANDS:")
+	fmt.Println("    up        Start the stack and make localnet the default network")
+	fmt.Println("    down      Stop the stack and restore the mainnet default")
+	fmt.Println("    status    Show container states and probe the endpoints")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    Runs an arlocal Arweave gateway plus ao compute and messenger units")
+	fmt.Println("    via docker compose, on the ports of the localnet network preset")
+	fmt.Println("    (gateway :4000, CU :6363, MU :3004). While the stack is up, message,")
+	fmt.Println("    state, add, and upload-module target it automatically - end-to-end")
+	fmt.Println("    testing without spending credits.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    harlequin localnet up")
+	fmt.Println("    harlequin message <process-id> --action Ping")
+	fmt.Println("    harlequin localnet status")
+	fmt.Println("    harlequin localnet down")
+	fmt.Println()
+}
@@ -35,6 +35,7 @@ func HandleLuaUtilsCommand(ctx context.Context, args []string) {
 // HandleBundleCommand handles the bundle subcommand
 func HandleBundleCommand(ctx context.Context, args []string) {
 	var debugMode bool
+	var allowCycles bool
 	var entrypoint string
 	var outputPath string
 
@@ -44,6 +45,8 @@ func HandleBundleCommand(ctx context.Context, args []string) {
 		switch arg {
 		case "--debug", "-d":
 			debugMode = true
+		case "--allow-cycles":
+			allowCycles = true
 		case "--help", "-h":
 			PrintBundleUsage()
 			return
@@ -102,7 +105,7 @@ func HandleBundleCommand(ctx context.Context, args []string) {
 	}
 
 	// Perform the bundling
-	if err := performBundle(entrypoint, outputPath); err != nil {
+	if err := performBundle(entrypoint, outputPath, allowCycles); err != nil {
 		fmt.Printf("Bundle failed: %v\n", err)
 		os.Exit(1)
 	}
@@ -111,7 +114,7 @@ func HandleBundleCommand(ctx context.Context, args []string) {
 }
 
 // performBundle performs the actual bundling operation
-func performBundle(entrypoint, outputPath string) error {
+func performBundle(entrypoint, outputPath string, allowCycles bool) error {
 	// Check if entrypoint file exists
 	if _, err := os.Stat(entrypoint); os.IsNotExist(err) {
 		return fmt.Errorf("entrypoint file does not exist: %s", entrypoint)
@@ -125,23 +128,16 @@ func performBundle(entrypoint, outputPath string) error {
 		return fmt.Errorf("failed to get absolute path for entrypoint: %w", err)
 	}
 
-	// Perform the bundling using lua_utils
-	bundledContent, err := luautils.Bundle(absEntrypoint)
-	if err != nil {
-		return fmt.Errorf("failed to bundle Lua files: %w", err)
-	}
-
-	debug.Printf("Bundle process completed, writing to output: %s", outputPath)
-
 	// Ensure output directory exists
 	outputDir := filepath.Dir(outputPath)
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Write the bundled content to the output file
-	if err := os.WriteFile(outputPath, []byte(bundledContent), 0644); err != nil {
-		return fmt.Errorf("failed to write bundled file: %w", err)
+	// Stream the bundle straight to the output file so large projects never
+	// need the whole bundle in memory
+	if err := luautils.BundleToFile(absEntrypoint, outputPath, luautils.BundleOptions{AllowCycles: allowCycles}); err != nil {
+		return fmt.Errorf("failed to bundle Lua files: %w", err)
 	}
 
 	debug.Printf("Bundle written successfully to: %s", outputPath)
@@ -184,6 +180,8 @@ func PrintBundleUsage() {
 	fmt.Println("Optional Flags:")
 	fmt.Println("  --outputPath <file>    Path to output the bundled file")
 	fmt.Println("                         (default: <entrypoint>.bundled.lua)")
+	fmt.Println("  --allow-cycles         Bundle circular dependencies using lazy-loading stubs")
+	fmt.Println("                         (by default cycles fail the bundle with the cycle path)")
 	fmt.Println("  -d, --debug            Enable debug logging for detailed output")
 	fmt.Println("  -h, --help             Show this help message")
 	fmt.Println()
@@ -195,7 +193,8 @@ func PrintBundleUsage() {
 	fmt.Println("How it works:")
 	fmt.Println("  • Analyzes your main Lua file for require() statements")
 	fmt.Println("  • Recursively resolves all dependencies")
-	fmt.Println("  • Handles circular dependencies gracefully")
+	fmt.Println("  • Detects circular dependencies and reports the cycle path")
+	fmt.Println("  • Embeds JSON/text assets required via markers like require(\"data.routes_json\")")
 	fmt.Println("  • Creates a single bundled file with all modules included")
 	fmt.Println("  • Preserves the original module structure and functionality")
 	fmt.Println()
@@ -0,0 +1,425 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/everFinance/goar"
+	"github.com/everFinance/goar/types"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/registry"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/retry"
+	signingserver "github.com/the-permaweb-harlequin/harlequin-toolkit/remote-signing/server"
+)
+
+const (
+	defaultMessengerURL = "https://mu.ao-testnet.xyz"
+	defaultComputeURL   = "https://cu.ao-testnet.xyz"
+)
+
+// messageResult represents the result returned by the compute unit
+type messageResult struct {
+	Output   json.RawMessage   `json:"Output"`
+	Messages []json.RawMessage `json:"Messages"`
+	Spawns   []json.RawMessage `json:"Spawns"`
+	Error    json.RawMessage   `json:"Error"`
+	GasUsed  int64             `json:"GasUsed"`
+}
+
+// HandleMessageCommand handles the message command for sending messages to AO processes
+func HandleMessageCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling message command with args: %v", args)
+
+	var processID string
+	var action string
+	var data string
+	var dataFile string
+	var walletPath string
+	var remote bool
+	var tags []types.Tag
+	var muURL string
+	var cuURL string
+	var networkPreset string
+
+	// Parse command line arguments
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--action", "-a":
+			if i+1 < len(args) {
+				action = args[i+1]
+				i++
+			}
+		case "--data", "-d":
+			if i+1 < len(args) {
+				data = args[i+1]
+				i++
+			}
+		case "--data-file":
+			if i+1 < len(args) {
+				dataFile = args[i+1]
+				i++
+			}
+		case "--tag", "-t":
+			if i+1 < len(args) {
+				tag, err := parseSignTag(args[i+1])
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				tags = append(tags, tag)
+				i++
+			}
+		case "--wallet-file", "-w":
+			if i+1 < len(args) {
+				walletPath = args[i+1]
+				i++
+			}
+		case "--remote":
+			remote = true
+		case "--mu":
+			if i+1 < len(args) {
+				muURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--cu":
+			if i+1 < len(args) {
+				cuURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--network":
+			if i+1 < len(args) {
+				networkPreset = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintMessageUsage()
+			return
+		default:
+			if !strings.HasPrefix(args[i], "-") && processID == "" {
+				processID = args[i]
+			}
+		}
+	}
+
+	network := resolveNetwork(networkFlags{Preset: networkPreset, CU: cuURL, MU: muURL})
+	muURL = strings.TrimRight(network.MU, "/")
+	cuURL = strings.TrimRight(network.CU, "/")
+
+	if processID == "" {
+		fmt.Println("Error: No process ID specified")
+		PrintMessageUsage()
+		os.Exit(1)
+	}
+	if action == "" {
+		fmt.Println("Error: No action specified (use --action)")
+		PrintMessageUsage()
+		os.Exit(1)
+	}
+	if dataFile != "" {
+		content, err := os.ReadFile(dataFile)
+		if err != nil {
+			fmt.Printf("Error: Failed to read data file: %v\n", err)
+			os.Exit(1)
+		}
+		data = string(content)
+	}
+	if walletPath == "" {
+		if os.Getenv("WALLET_PATH") != "" {
+			walletPath = os.Getenv("WALLET_PATH")
+		} else {
+			walletPath = "key.json"
+		}
+	}
+
+	// AO protocol tags first, then user tags
+	messageTags := []types.Tag{
+		{Name: "Data-Protocol", Value: "ao"},
+		{Name: "Variant", Value: "ao.TN.1"},
+		{Name: "Type", Value: "Message"},
+		{Name: "Action", Value: action},
+	}
+	messageTags = append(messageTags, tags...)
+
+	// Sanity-check the message against the project's generated ABI
+	validateMessageAgainstABI(action, tags)
+
+	fmt.Printf("🎭 Sending %s message to %s\n", action, processID)
+
+	var signedItem []byte
+	var messageID string
+	var err error
+
+	if remote {
+		signedItem, messageID, err = signMessageRemote(processID, []byte(data), messageTags)
+	} else {
+		signedItem, messageID, err = signMessageLocal(walletPath, processID, []byte(data), messageTags)
+	}
+	if err != nil {
+		fmt.Printf("❌ Failed to sign message: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("📨 Message ID: %s\n", messageID)
+	fmt.Println("📡 Delivering to messenger unit...")
+
+	if err := sendToMessengerUnit(ctx, muURL, signedItem); err != nil {
+		fmt.Printf("❌ Failed to deliver message: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Best effort: stamp the last-message time if the process is registered
+	if err := registry.Touch(".", processID, time.Now()); err != nil {
+		debug.Printf("Failed to stamp process registry: %v", err)
+	}
+
+	fmt.Println("⏳ Waiting for result from compute unit...")
+
+	result, err := pollMessageResult(ctx, cuURL, messageID, processID)
+	if err != nil {
+		fmt.Printf("❌ Failed to fetch result: %v\n", err)
+		fmt.Printf("💡 Check manually: %s/result/%s?process-id=%s\n", cuURL, messageID, processID)
+		os.Exit(1)
+	}
+
+	printMessageResult(result)
+}
+
+// signMessageLocal creates and signs the message DataItem with a local wallet
+func signMessageLocal(walletPath, processID string, data []byte, tags []types.Tag) ([]byte, string, error) {
+	var walletBytes []byte
+	if os.Getenv("WALLET") != "" {
+		walletBytes = []byte(os.Getenv("WALLET"))
+	} else {
+		content, err := os.ReadFile(walletPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read wallet file %s: %w", walletPath, err)
+		}
+		walletBytes = content
+	}
+
+	signer, err := goar.NewSigner(walletBytes)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create signer: %w", err)
+	}
+
+	itemSigner, err := goar.NewItemSigner(signer)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create item signer: %w", err)
+	}
+
+	item, err := itemSigner.CreateAndSignItem(data, processID, "", tags)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	return item.ItemBinary, item.Id, nil
+}
+
+// signMessageRemote signs the message DataItem through the remote signing flow
+// (browser wallet, no local keyfile required)
+func signMessageRemote(processID string, data []byte, tags []types.Tag) ([]byte, string, error) {
+	signingServer := signingserver.NewSigningServer(signingserver.DefaultConfig())
+	defer signingServer.Close()
+
+	result, err := signingServer.Sign(&signingserver.UploadRequest{
+		Data:   data,
+		Tags:   tags,
+		Target: processID,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return result.SignedData, result.DataItemID, nil
+}
+
+// sendToMessengerUnit delivers the signed DataItem to the messenger unit.
+// Re-posting the same signed item is safe (its ID is deterministic), so
+// transient failures ride the shared retry policy.
+func sendToMessengerUnit(ctx context.Context, muURL string, signedItem []byte) error {
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	return retry.Queries.Do(ctx, "messenger unit delivery", func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, muURL, bytes.NewReader(signedItem))
+		if err != nil {
+			return retry.Permanent(err)
+		}
+		req.Header.Set("Content-Type", "application/octet-stream")
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			body, _ := io.ReadAll(resp.Body)
+			statusErr := fmt.Errorf("messenger unit error (HTTP %d): %s", resp.StatusCode, string(body))
+			if !retry.IsRetryableStatus(resp.StatusCode) {
+				return retry.Permanent(statusErr)
+			}
+			return statusErr
+		}
+
+		return nil
+	})
+}
+
+// pollMessageResult polls the compute unit until the message result is available
+func pollMessageResult(ctx context.Context, cuURL, messageID, processID string) (*messageResult, error) {
+	resultURL := fmt.Sprintf("%s/result/%s?process-id=%s", cuURL, messageID, processID)
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	var lastErr error
+	for attempt := 0; attempt < 30; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(2 * time.Second):
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, resultURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			lastErr = fmt.Errorf("compute unit error (HTTP %d): %s", resp.StatusCode, string(body))
+			continue
+		}
+
+		var result messageResult
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("failed to parse result: %w", err)
+			continue
+		}
+
+		return &result, nil
+	}
+
+	return nil, fmt.Errorf("result not available after 30 attempts: %w", lastErr)
+}
+
+// printMessageResult pretty-prints the compute unit result
+func printMessageResult(result *messageResult) {
+	fmt.Println()
+
+	if len(result.Error) > 0 && string(result.Error) != "null" {
+		fmt.Println("❌ PROCESS ERROR")
+		fmt.Printf("   %s\n", formatResultValue(result.Error))
+		return
+	}
+
+	fmt.Println("✅ MESSAGE PROCESSED")
+
+	if len(result.Output) > 0 && string(result.Output) != "null" {
+		fmt.Println("   📤 Output:")
+		for _, line := range strings.Split(formatResultValue(result.Output), "\n") {
+			fmt.Printf("      %s\n", line)
+		}
+	}
+
+	if len(result.Messages) > 0 {
+		fmt.Printf("   📬 Messages: %d\n", len(result.Messages))
+		for _, msg := range result.Messages {
+			var parsed struct {
+				Target string      `json:"Target"`
+				Tags   []types.Tag `json:"Tags"`
+			}
+			if err := json.Unmarshal(msg, &parsed); err != nil {
+				continue
+			}
+			msgAction := ""
+			for _, tag := range parsed.Tags {
+				if tag.Name == "Action" {
+					msgAction = tag.Value
+					break
+				}
+			}
+			fmt.Printf("      • %s → %s\n", msgAction, parsed.Target)
+		}
+	}
+
+	if len(result.Spawns) > 0 {
+		fmt.Printf("   🐣 Spawns: %d\n", len(result.Spawns))
+	}
+
+	if result.GasUsed > 0 {
+		fmt.Printf("   ⛽ Gas Used: %d\n", result.GasUsed)
+	}
+}
+
+// formatResultValue renders a CU result field for display, preferring the
+// "data" field of aos-style output objects
+func formatResultValue(raw json.RawMessage) string {
+	var output struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &output); err == nil && len(output.Data) > 0 {
+		raw = output.Data
+	}
+
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return str
+	}
+
+	return string(raw)
+}
+
+// PrintMessageUsage prints usage information for the message command
+func PrintMessageUsage() {
+	fmt.Println("🎭 Harlequin Message - Send Messages to AO Processes")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin message <PROCESS_ID> --action <ACTION> [OPTIONS]")
+	fmt.Println()
+	fmt.Println("ARGUMENTS:")
+	fmt.Println("    PROCESS_ID             ID of the AO process to message")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -a, --action <ACTION>    Action tag for the message (required)")
+	fmt.Println("    -d, --data <DATA>        Message data (inline)")
+	fmt.Println("    --data-file <FILE>       Read message data from a file")
+	fmt.Println("    -t, --tag <NAME=VALUE>   Additional tag (repeatable)")
+	fmt.Println("    -w, --wallet-file <FILE> Path to Arweave wallet JSON file (default: key.json)")
+	fmt.Println("    --remote                 Sign with a browser wallet via the remote signing server")
+	fmt.Println("    --mu <URL>               Messenger unit URL (default: " + defaultMessengerURL + ")")
+	fmt.Println("    --cu <URL>               Compute unit URL (default: " + defaultComputeURL + ")")
+	fmt.Println("    --network <PRESET>       Endpoint preset: mainnet, testnet, or localnet")
+	fmt.Println("    -h, --help               Show this help message")
+	fmt.Println()
+	fmt.Println("ENVIRONMENT VARIABLES:")
+	fmt.Println("    WALLET                   Wallet JSON (alternative to --wallet-file)")
+	fmt.Println("    WALLET_PATH              Path to wallet file (alternative to --wallet-file)")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    # Send an Info message and print the response")
+	fmt.Println("    harlequin message abc123... --action Info")
+	fmt.Println()
+	fmt.Println("    # Send a transfer with data and extra tags")
+	fmt.Println("    harlequin message abc123... --action Transfer --tag Recipient=def456... --tag Quantity=100")
+	fmt.Println()
+	fmt.Println("    # Sign with a browser wallet instead of a local keyfile")
+	fmt.Println("    harlequin message abc123... --action Info --remote")
+}
@@ -0,0 +1,429 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/everFinance/goar/utils"
+	"github.com/project-kardeshev/go-ardrive-turbo/pkg/signers"
+	"github.com/project-kardeshev/go-ardrive-turbo/pkg/turbo"
+	"github.com/project-kardeshev/go-ardrive-turbo/pkg/types"
+	harlequinconfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/wallet"
+)
+
+// channelPointerType tags the small data items that map a channel name to a
+// module transaction ID, giving teams mutable channels on top of immutable
+// uploads
+const channelPointerType = "Module-Channel-Pointer"
+
+// channelPointer is one published channel mapping, newest first in queries
+type channelPointer struct {
+	ID       string
+	Module   string
+	Previous string
+}
+
+// HandleModulesCommand handles the modules command and its subcommands
+func HandleModulesCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling modules command with args: %v", args)
+
+	if len(args) == 0 {
+		PrintModulesUsage()
+		return
+	}
+
+	switch args[0] {
+	case "promote":
+		handleModulesPromote(ctx, args[1:])
+	case "rollback":
+		handleModulesRollback(ctx, args[1:])
+	case "history":
+		handleModulesHistory(ctx, args[1:])
+	case "--help", "-h", "help":
+		PrintModulesUsage()
+	default:
+		fmt.Printf("Unknown modules subcommand: %s\n\n", args[0])
+		PrintModulesUsage()
+		os.Exit(1)
+	}
+}
+
+// modulesFlags are the options shared by the modules subcommands
+type modulesFlags struct {
+	channel    string
+	walletPath string
+	network    harlequinconfig.NetworkConfig
+	positional string
+}
+
+// parseModulesFlags parses the shared channel/wallet/network options,
+// returning the first positional argument separately
+func parseModulesFlags(args []string) modulesFlags {
+	flags := modulesFlags{channel: "production"}
+	var networkPreset, gatewayURL string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--channel", "-c":
+			if i+1 < len(args) {
+				flags.channel = args[i+1]
+				i++
+			}
+		case "--wallet-file", "-w":
+			if i+1 < len(args) {
+				flags.walletPath = args[i+1]
+				i++
+			}
+		case "--network":
+			if i+1 < len(args) {
+				networkPreset = args[i+1]
+				i++
+			}
+		case "--gateway":
+			if i+1 < len(args) {
+				gatewayURL = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintModulesUsage()
+			os.Exit(0)
+		default:
+			if !strings.HasPrefix(args[i], "-") && flags.positional == "" {
+				flags.positional = args[i]
+			}
+		}
+	}
+
+	flags.network = resolveNetwork(networkFlags{Preset: networkPreset, Gateway: gatewayURL})
+	return flags
+}
+
+// handleModulesPromote publishes a pointer mapping a channel to a module txID
+func handleModulesPromote(ctx context.Context, args []string) {
+	flags := parseModulesFlags(args)
+	if flags.positional == "" {
+		fmt.Println("❌ Module transaction ID is required")
+		PrintModulesUsage()
+		os.Exit(1)
+	}
+	moduleID := flags.positional
+
+	jwk, err := loadUploadWallet(flags.walletPath)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	owner, _ := jwk["n"].(string)
+	address, err := utils.OwnerToAddress(owner)
+	if err != nil {
+		fmt.Printf("❌ Failed to derive wallet address: %v\n", err)
+		os.Exit(1)
+	}
+
+	// The previous pointer becomes the rollback target
+	previous := ""
+	if pointers, err := queryChannelPointers(ctx, flags.network.Gateway, address, flags.channel, 1); err == nil && len(pointers) > 0 {
+		if pointers[0].Module == moduleID {
+			fmt.Printf("⚠️  Channel %s already points at %s\n", flags.channel, moduleID)
+			return
+		}
+		previous = pointers[0].Module
+	}
+
+	pointerID, err := publishChannelPointer(ctx, jwk, flags.network, flags.channel, moduleID, previous)
+	if err != nil {
+		fmt.Printf("❌ Failed to publish channel pointer: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Promoted %s to %s\n", moduleID, flags.channel)
+	fmt.Printf("   📍 Pointer: %s\n", pointerID)
+	if previous != "" {
+		fmt.Printf("   ⏮️  Rollback target: %s\n", previous)
+	}
+}
+
+// handleModulesRollback repoints a channel at the module it pointed to
+// before its latest promotion
+func handleModulesRollback(ctx context.Context, args []string) {
+	flags := parseModulesFlags(args)
+
+	jwk, err := loadUploadWallet(flags.walletPath)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	owner, _ := jwk["n"].(string)
+	address, err := utils.OwnerToAddress(owner)
+	if err != nil {
+		fmt.Printf("❌ Failed to derive wallet address: %v\n", err)
+		os.Exit(1)
+	}
+
+	pointers, err := queryChannelPointers(ctx, flags.network.Gateway, address, flags.channel, 1)
+	if err != nil {
+		fmt.Printf("❌ Failed to query channel pointers: %v\n", err)
+		os.Exit(1)
+	}
+	if len(pointers) == 0 {
+		fmt.Printf("❌ Channel %s has no promotions to roll back\n", flags.channel)
+		os.Exit(1)
+	}
+	current := pointers[0]
+	if current.Previous == "" {
+		fmt.Printf("❌ Channel %s has no previous version recorded\n", flags.channel)
+		os.Exit(1)
+	}
+
+	pointerID, err := publishChannelPointer(ctx, jwk, flags.network, flags.channel, current.Previous, current.Module)
+	if err != nil {
+		fmt.Printf("❌ Failed to publish channel pointer: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Rolled %s back to %s\n", flags.channel, current.Previous)
+	fmt.Printf("   📍 Pointer: %s\n", pointerID)
+	fmt.Printf("   ⏮️  Rollback target: %s\n", current.Module)
+}
+
+// handleModulesHistory lists recent promotions for a channel
+func handleModulesHistory(ctx context.Context, args []string) {
+	flags := parseModulesFlags(args)
+
+	jwk, err := loadUploadWallet(flags.walletPath)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	owner, _ := jwk["n"].(string)
+	address, err := utils.OwnerToAddress(owner)
+	if err != nil {
+		fmt.Printf("❌ Failed to derive wallet address: %v\n", err)
+		os.Exit(1)
+	}
+
+	pointers, err := queryChannelPointers(ctx, flags.network.Gateway, address, flags.channel, 10)
+	if err != nil {
+		fmt.Printf("❌ Failed to query channel pointers: %v\n", err)
+		os.Exit(1)
+	}
+	if len(pointers) == 0 {
+		fmt.Printf("📭 Channel %s has no promotions yet\n", flags.channel)
+		return
+	}
+
+	fmt.Printf("🎭 Channel %s (%d most recent promotions)\n", flags.channel, len(pointers))
+	fmt.Println()
+	for i, pointer := range pointers {
+		marker := "  "
+		if i == 0 {
+			marker = "▶ "
+		}
+		fmt.Printf("%s%s (pointer %s)\n", marker, pointer.Module, pointer.ID)
+	}
+}
+
+// publishChannelPointer signs and uploads the pointer data item mapping a
+// channel name to a module transaction ID
+func publishChannelPointer(ctx context.Context, jwk map[string]interface{}, network harlequinconfig.NetworkConfig, channel, moduleID, previous string) (string, error) {
+	signer, err := signers.NewArweaveSigner(jwk)
+	if err != nil {
+		return "", fmt.Errorf("failed to create signer: %w", err)
+	}
+	turboClient := turbo.Authenticated(turboConfigForNetwork(network), signer)
+
+	pointer := map[string]string{
+		"channel":    channel,
+		"module":     moduleID,
+		"promotedAt": time.Now().UTC().Format(time.RFC3339),
+	}
+	if previous != "" {
+		pointer["previous"] = previous
+	}
+	data, err := json.Marshal(pointer)
+	if err != nil {
+		return "", err
+	}
+
+	tags := []types.Tag{
+		{Name: "Content-Type", Value: "application/json"},
+		{Name: "Type", Value: channelPointerType},
+		{Name: "App-Name", Value: "Harlequin-CLI"},
+		{Name: "Channel", Value: channel},
+		{Name: "Module", Value: moduleID},
+	}
+	if previous != "" {
+		tags = append(tags, types.Tag{Name: "Previous", Value: previous})
+	}
+
+	result, err := turboClient.Upload(ctx, &types.UploadRequest{
+		Data: data,
+		Tags: tags,
+	})
+	if err != nil {
+		return "", err
+	}
+	return result.ID, nil
+}
+
+// queryChannelPointers fetches the channel's most recent pointers from the
+// gateway GraphQL API, newest first
+func queryChannelPointers(ctx context.Context, gatewayURL, owner, channel string, limit int) ([]channelPointer, error) {
+	query := fmt.Sprintf(`{
+		transactions(
+			first: %d
+			sort: HEIGHT_DESC
+			owners: [%q]
+			tags: [
+				{ name: "Type", values: [%q] }
+				{ name: "Channel", values: [%q] }
+			]
+		) {
+			edges { node { id tags { name value } } }
+		}
+	}`, limit, owner, channelPointerType, channel)
+
+	body, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(gatewayURL, "/")+"/graphql", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gateway unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("gateway error (HTTP %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	var queryResult struct {
+		Data struct {
+			Transactions struct {
+				Edges []struct {
+					Node struct {
+						ID   string `json:"id"`
+						Tags []struct {
+							Name  string `json:"name"`
+							Value string `json:"value"`
+						} `json:"tags"`
+					} `json:"node"`
+				} `json:"edges"`
+			} `json:"transactions"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&queryResult); err != nil {
+		return nil, fmt.Errorf("failed to parse gateway response: %w", err)
+	}
+
+	var pointers []channelPointer
+	for _, edge := range queryResult.Data.Transactions.Edges {
+		pointer := channelPointer{ID: edge.Node.ID}
+		for _, tag := range edge.Node.Tags {
+			switch tag.Name {
+			case "Module":
+				pointer.Module = tag.Value
+			case "Previous":
+				pointer.Previous = tag.Value
+			}
+		}
+		pointers = append(pointers, pointer)
+	}
+	return pointers, nil
+}
+
+// loadUploadWallet resolves a wallet the same way uploads do: the WALLET
+// environment variable, a stored profile name, a wallet file, then the
+// active profile
+func loadUploadWallet(walletPath string) (map[string]interface{}, error) {
+	var jwk map[string]interface{}
+
+	if os.Getenv("WALLET") != "" {
+		if err := json.Unmarshal([]byte(os.Getenv("WALLET")), &jwk); err != nil {
+			return nil, fmt.Errorf("failed to parse WALLET environment variable: %w", err)
+		}
+		return jwk, nil
+	}
+
+	if walletPath == "" {
+		if os.Getenv("WALLET_PATH") != "" {
+			walletPath = os.Getenv("WALLET_PATH")
+		} else {
+			walletPath = "key.json"
+		}
+	}
+
+	if wallet.Exists(walletPath) {
+		return wallet.Load(walletPath)
+	}
+
+	walletContent, readErr := ioutil.ReadFile(walletPath)
+	if readErr != nil {
+		// Fall back to the active wallet profile when no wallet file exists
+		active, _ := wallet.Current()
+		if active == "" {
+			return nil, fmt.Errorf("failed to read wallet file %s: %w", walletPath, readErr)
+		}
+		return wallet.Load(active)
+	}
+
+	walletContent, readErr = decryptWalletIfNeeded(walletContent, walletPath)
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	if err := json.Unmarshal(walletContent, &jwk); err != nil {
+		return nil, fmt.Errorf("failed to parse wallet file: %w", err)
+	}
+	return jwk, nil
+}
+
+// PrintModulesUsage prints usage information for the modules command
+func PrintModulesUsage() {
+	fmt.Println("🎭 Harlequin Modules - Mutable Channels Over Immutable Uploads")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin modules <SUBCOMMAND> [OPTIONS]")
+	fmt.Println()
+	fmt.Println("SUBCOMMANDS:")
+	fmt.Println("    promote <TXID>  Point a channel at a module transaction ID")
+	fmt.Println("    rollback        Repoint a channel at its previous module")
+	fmt.Println("    history         List recent promotions for a channel")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -c, --channel <NAME>       Channel name (default: production)")
+	fmt.Println("    -w, --wallet-file <FILE>   Wallet file or profile name (default: key.json)")
+	fmt.Println("    --network <PRESET>         Network preset from .harlequin.yaml")
+	fmt.Println("    --gateway <URL>            Gateway for pointer queries")
+	fmt.Println("    -h, --help                 Show this help message")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    Each promotion publishes a small pointer data item mapping a channel")
+	fmt.Println("    name to a module txID, signed by your wallet. Consumers resolve a")
+	fmt.Println("    channel by querying the newest pointer from your address, so uploads")
+	fmt.Println("    stay immutable while channels move.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    harlequin modules promote abc123... --channel production")
+	fmt.Println("    harlequin modules rollback --channel production")
+	fmt.Println("    harlequin modules history --channel staging")
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	harlequinconfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+)
+
+// networkFlags collects the endpoint overrides a command parsed from its
+// arguments. Empty fields fall back to the project config and then to the
+// selected preset.
+type networkFlags struct {
+	Preset  string // --network
+	Gateway string // --gateway
+	CU      string // --cu
+	MU      string // --mu
+	Bundler string // --bundler
+}
+
+// resolveNetwork returns the effective network endpoints for a command: the
+// project config's network section overlaid with any flag overrides. Exits
+// with an error message when an unknown preset is requested.
+func resolveNetwork(flags networkFlags) harlequinconfig.NetworkConfig {
+	network := &harlequinconfig.NetworkConfig{}
+	if content, err := os.ReadFile(".harlequin.yaml"); err == nil {
+		if cfg := harlequinconfig.FromYAML(string(content)); cfg.Network != nil {
+			network = cfg.Network
+		}
+	}
+
+	if flags.Preset != "" {
+		network.Preset = flags.Preset
+	}
+	// A persisted preset (set by `harlequin localnet up`) is the lowest
+	// priority default, below the project config and flags
+	if network.Preset == "" {
+		network.Preset = persistedNetworkPreset()
+	}
+	if flags.Gateway != "" {
+		network.Gateway = flags.Gateway
+	}
+	if flags.CU != "" {
+		network.CU = flags.CU
+	}
+	if flags.MU != "" {
+		network.MU = flags.MU
+	}
+	if flags.Bundler != "" {
+		network.Bundler = flags.Bundler
+	}
+
+	resolved, err := network.Resolve()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	return resolved
+}
+
+// presetFilePath returns the file persisting the default network preset
+// across invocations
+func presetFilePath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	dir := filepath.Join(configDir, "harlequin")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+	return filepath.Join(dir, "network"), nil
+}
+
+// persistNetworkPreset makes a preset the default for commands that do not
+// select a network explicitly
+func persistNetworkPreset(name string) error {
+	path, err := presetFilePath()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(name+"\n"), 0644)
+}
+
+// clearNetworkPreset removes the persisted default preset
+func clearNetworkPreset() error {
+	path, err := presetFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// persistedNetworkPreset returns the persisted default preset, or an empty
+// string when none is set
+func persistedNetworkPreset() string {
+	path, err := presetFilePath()
+	if err != nil {
+		return ""
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/tui/components"
+)
+
+// PlainMode forces the sequential prompt fallback instead of the alt-screen
+// TUI. It is set from the global --plain flag at startup.
+var PlainMode bool
+
+// PlainFallback reports whether interactive flows should render as plain
+// sequential prompts: either requested with --plain, or because the terminal
+// cannot host the alt-screen UI (dumb terminals, CI, redirected output).
+func PlainFallback() bool {
+	if PlainMode {
+		return true
+	}
+	if term := os.Getenv("TERM"); term == "" || term == "dumb" {
+		return true
+	}
+	if os.Getenv("CI") != "" {
+		return true
+	}
+	// Redirected output (pipes, log files) cannot render the alt screen
+	if info, err := os.Stdout.Stat(); err == nil && info.Mode()&os.ModeCharDevice == 0 {
+		return true
+	}
+	return false
+}
+
+// plainReader shares a single stdin reader across sequential prompts
+var plainReader = bufio.NewReader(os.Stdin)
+
+// promptLine asks a free-form question, returning the default when the user
+// just presses enter
+func promptLine(label, defaultValue string) string {
+	if defaultValue != "" {
+		fmt.Printf("%s [%s]: ", label, defaultValue)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+
+	line, err := plainReader.ReadString('\n')
+	if err != nil {
+		return defaultValue
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return defaultValue
+	}
+	return line
+}
+
+// promptSelect asks the user to pick one option by number, returning the
+// option's value
+func promptSelect(label string, options [][2]string) string {
+	fmt.Println(label)
+	for i, option := range options {
+		fmt.Printf("  %d) %s\n", i+1, option[1])
+	}
+
+	for {
+		answer := promptLine("Choice", "1")
+		index, err := strconv.Atoi(answer)
+		if err == nil && index >= 1 && index <= len(options) {
+			return options[index-1][0]
+		}
+		fmt.Printf("Please enter a number between 1 and %d\n", len(options))
+	}
+}
+
+// promptYesNo asks a yes/no question
+func promptYesNo(label string, defaultYes bool) bool {
+	def := "y/N"
+	if defaultYes {
+		def = "Y/n"
+	}
+	answer := strings.ToLower(promptLine(fmt.Sprintf("%s (%s)", label, def), ""))
+	if answer == "" {
+		return defaultYes
+	}
+	return answer == "y" || answer == "yes"
+}
+
+// RunPlainCLI is the sequential-prompt replacement for the main interactive
+// TUI, usable over SSH, screen readers, and CI logs
+func RunPlainCLI(ctx context.Context) error {
+	fmt.Println("🎭 Harlequin - Arweave Development Toolkit")
+	fmt.Println()
+
+	command := promptSelect("What would you like to do?", [][2]string{
+		{"init", "Initialize a new AO process project"},
+		{"build", "Build an AOS project"},
+		{"upload-module", "Upload a built WASM module to Arweave"},
+		{"quit", "Quit"},
+	})
+
+	switch command {
+	case "init":
+		return runPlainInit(ctx, "", "", "", "")
+	case "build":
+		return runPlainBuild(ctx)
+	case "upload-module":
+		return runPlainUpload(ctx)
+	}
+	return nil
+}
+
+// runPlainInit walks through project creation with sequential prompts
+func runPlainInit(ctx context.Context, projectName, targetDir, authorName, githubUser string) error {
+	fmt.Println("🎭 Create New AO Process Project")
+	fmt.Println()
+
+	options := make([][2]string, len(availableTemplates))
+	for i, template := range availableTemplates {
+		description := template
+		if info, ok := templateInfoMap[template]; ok {
+			description = fmt.Sprintf("%s - %s", template, info.Description)
+		}
+		options[i] = [2]string{template, description}
+	}
+	templateLang := promptSelect("Select a template:", options)
+
+	for projectName == "" {
+		projectName = promptLine("Project name", "")
+	}
+	if authorName == "" {
+		authorName = promptLine("Author name (optional)", "")
+	}
+	if githubUser == "" {
+		githubUser = promptLine("GitHub username (optional)", "")
+	}
+
+	// Prompt for any variables the template declares in its manifest
+	variables := make(map[string]string)
+	if declared, err := LoadTemplateVariables(templateLang); err == nil {
+		for _, variable := range declared {
+			prompt := variable.Prompt
+			if prompt == "" {
+				prompt = variable.Name
+			}
+			for {
+				value := promptLine(prompt, variable.Default)
+				if err := ValidateTemplateVariable(variable, value); err != nil {
+					fmt.Printf("❌ %v\n", err)
+					continue
+				}
+				variables[variable.Name] = value
+				break
+			}
+		}
+	}
+
+	// Offer the blueprint library for Lua-based templates
+	var blueprints []string
+	if available := ListBlueprints(); len(available) > 0 {
+		answer := promptLine(fmt.Sprintf("Blueprints to layer on, comma-separated (%s; empty for none)", strings.Join(available, ", ")), "")
+		for _, name := range strings.Split(answer, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if !isValidBlueprint(name) {
+				fmt.Printf("⚠️  Skipping unknown blueprint %q\n", name)
+				continue
+			}
+			blueprints = append(blueprints, name)
+		}
+	}
+
+	// Resolve conflicts with an existing non-empty directory up front
+	conflictMode := ""
+	resolvedDir := targetDir
+	if resolvedDir == "" {
+		resolvedDir = projectName
+	}
+	if TargetDirHasFiles(resolvedDir) {
+		conflictMode = promptSelect(fmt.Sprintf("Directory %s/ already exists and is not empty:", resolvedDir), [][2]string{
+			{"merge", "Merge: add template files, keeping existing ones"},
+			{"overwrite", "Overwrite: delete the directory and start fresh"},
+			{"", "Abort"},
+		})
+		if conflictMode == "" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	if err := InitializeProjectWithVariables(projectName, templateLang, targetDir, authorName, githubUser, conflictMode, variables); err != nil {
+		return err
+	}
+
+	for _, blueprint := range blueprints {
+		if err := ApplyBlueprint(blueprint, resolvedDir, projectName, authorName, githubUser, variables); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runPlainBuild walks through a build with sequential prompts
+func runPlainBuild(ctx context.Context) error {
+	fmt.Println("🎭 Build AO Process")
+	fmt.Println()
+
+	var entrypoint string
+	if luaFiles, err := components.FindLuaFilesQuick("."); err == nil && len(luaFiles) > 0 {
+		ranked := components.RankLuaFiles(".", luaFiles)
+		options := make([][2]string, len(ranked))
+		for i, file := range ranked {
+			options[i] = [2]string{file.Path, fmt.Sprintf("%s (%s)", file.Path, file.Reason)}
+		}
+		entrypoint = promptSelect("Select the entrypoint file:", options)
+	} else {
+		entrypoint = promptLine("Entrypoint file", "main.lua")
+	}
+
+	outputDir := promptLine("Output directory", "dist")
+
+	handleNonInteractiveBuild(ctx, entrypoint, outputDir, "")
+	return nil
+}
+
+// runPlainUpload walks through a module upload with sequential prompts
+func runPlainUpload(ctx context.Context) error {
+	fmt.Println("🎭 Upload Module to Arweave")
+	fmt.Println()
+
+	wasmPath := promptLine("WASM file", "dist/process.wasm")
+
+	configPath := ""
+	if _, err := os.Stat(".harlequin.yaml"); err == nil {
+		configPath = ".harlequin.yaml"
+	}
+	configPath = promptLine("Config file", configPath)
+
+	walletPath := promptLine("Wallet file or profile (blank for WALLET env / active profile)", "")
+	version := promptLine("Version tag (optional)", "")
+	gitHash := promptLine("Git hash tag (optional)", "")
+	dryRun := promptYesNo("Dry run (show what would be uploaded)?", true)
+
+	network := resolveNetwork(networkFlags{})
+	return uploadModule(ctx, wasmPath, configPath, walletPath, version, gitHash, dryRun, false, false, false, false, nil, network, 0)
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/build"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/build/builders"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+)
+
+// HandlePrefetchCommand populates the offline caches (AOS checkout and build
+// image) so later builds can run with --offline
+func HandlePrefetchCommand(ctx context.Context, args []string) {
+	var configPath string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--configPath":
+			if i+1 >= len(args) {
+				fmt.Printf("Error: --configPath requires a value\n\n")
+				PrintPrefetchUsage()
+				os.Exit(1)
+			}
+			configPath = args[i+1]
+			i++
+		case "--help", "-h":
+			PrintPrefetchUsage()
+			return
+		default:
+			fmt.Printf("Unknown argument: %s\n\n", args[i])
+			PrintPrefetchUsage()
+			os.Exit(1)
+		}
+	}
+
+	cfg := loadPrefetchConfig(configPath)
+	options := builders.NewAOSCopyOptions(cfg, ".")
+
+	fmt.Println("🎭 Prefetching artifacts for offline builds...")
+
+	failed := false
+
+	fmt.Printf("📦 AOS repository %s@%s\n", options.RepoURL, options.CommitHash)
+	if err := builders.PrefetchAOSRepo(ctx, options.RepoURL, options.CommitHash); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		failed = true
+	} else {
+		fmt.Println("✅ AOS repository cached")
+	}
+
+	fmt.Printf("📦 Docker image %s\n", build.AOBuildContainerDockerImage)
+	if err := builders.PrefetchDockerImage(ctx, build.AOBuildContainerDockerImage); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		failed = true
+	} else {
+		fmt.Println("✅ Docker image cached")
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+
+	fmt.Println("✅ Offline caches are ready - build with --offline")
+}
+
+// loadPrefetchConfig resolves the project config the same way build does,
+// falling back to defaults when there is no .harlequin.yaml
+func loadPrefetchConfig(configPath string) *config.Config {
+	if configPath != "" {
+		cfg := config.ReadConfigFile(configPath)
+		if cfg == nil {
+			fmt.Printf("Error: Failed to load config from %s\n", configPath)
+			os.Exit(1)
+		}
+		return cfg
+	}
+
+	if _, err := os.Stat(".harlequin.yaml"); err == nil {
+		if cfg := config.ReadConfigFile(".harlequin.yaml"); cfg != nil {
+			return cfg
+		}
+	}
+
+	return config.NewConfig(nil)
+}
+
+// PrintPrefetchUsage prints usage information for the prefetch command
+func PrintPrefetchUsage() {
+	fmt.Println("🎭 Harlequin Prefetch - Populate offline build caches")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin prefetch [OPTIONS]")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    --configPath <PATH>    Config file to read the AOS ref from (default: .harlequin.yaml)")
+	fmt.Println("    -h, --help             Show this help message")
+	fmt.Println()
+	fmt.Println("Caches the AOS repository checkout and the build docker image so that")
+	fmt.Println("subsequent builds work with the global --offline flag.")
+}
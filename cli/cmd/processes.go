@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/registry"
+)
+
+// HandleProcessesCommand handles the processes command and its subcommands
+func HandleProcessesCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling processes command with args: %v", args)
+
+	if len(args) == 0 {
+		handleProcessesList()
+		return
+	}
+
+	switch args[0] {
+	case "list":
+		handleProcessesList()
+	case "add":
+		handleProcessesAdd(args[1:])
+	case "remove":
+		handleProcessesRemove(args[1:])
+	case "--help", "-h", "help":
+		PrintProcessesUsage()
+	default:
+		fmt.Printf("Unknown processes subcommand: %s\n\n", args[0])
+		PrintProcessesUsage()
+		os.Exit(1)
+	}
+}
+
+// handleProcessesList prints the project registry as a table
+func handleProcessesList() {
+	processes, err := registry.Load(".")
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(processes) == 0 {
+		fmt.Println("✨ No processes registered - add one with harlequin processes add <PROCESS_ID>")
+		return
+	}
+
+	fmt.Println("🎭 Registered Processes")
+	fmt.Println()
+	fmt.Printf("%-16s %-45s %-10s %s\n", "NAME", "PROCESS ID", "MODULE", "LAST MESSAGE")
+	for _, p := range processes {
+		name := p.Name
+		if name == "" {
+			name = "-"
+		}
+		version := p.ModuleVersion
+		if version == "" {
+			version = "-"
+		}
+		fmt.Printf("%-16s %-45s %-10s %s\n", name, p.ProcessID, version, formatLastMessage(p.LastMessageAt))
+	}
+}
+
+// formatLastMessage renders a last-message timestamp as a relative age
+func formatLastMessage(at time.Time) string {
+	if at.IsZero() {
+		return "never"
+	}
+
+	age := time.Since(at)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	}
+}
+
+// handleProcessesAdd registers or updates a process in the project registry
+func handleProcessesAdd(args []string) {
+	process := registry.Process{}
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--name", "-n":
+			if i+1 < len(args) {
+				process.Name = args[i+1]
+				i++
+			}
+		case "--module":
+			if i+1 < len(args) {
+				process.ModuleID = args[i+1]
+				i++
+			}
+		case "--module-version":
+			if i+1 < len(args) {
+				process.ModuleVersion = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintProcessesUsage()
+			os.Exit(0)
+		default:
+			if !strings.HasPrefix(args[i], "-") && process.ProcessID == "" {
+				process.ProcessID = args[i]
+			}
+		}
+	}
+
+	if process.ProcessID == "" {
+		fmt.Println("❌ Process ID is required")
+		PrintProcessesUsage()
+		os.Exit(1)
+	}
+
+	if err := registry.Add(".", process); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Registered process %s\n", process.ProcessID)
+}
+
+// handleProcessesRemove deletes a process from the project registry
+func handleProcessesRemove(args []string) {
+	processID := ""
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") && processID == "" {
+			processID = arg
+		}
+	}
+
+	if processID == "" {
+		fmt.Println("❌ Process ID is required")
+		PrintProcessesUsage()
+		os.Exit(1)
+	}
+
+	if err := registry.Remove(".", processID); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Removed process %s\n", processID)
+}
+
+// PrintProcessesUsage prints usage information for the processes command
+func PrintProcessesUsage() {
+	fmt.Println("🎭 Harlequin Processes - Track Deployed AO Processes")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin processes [SUBCOMMAND] [OPTIONS]")
+	fmt.Println()
+	fmt.Println("SUBCOMMANDS:")
+	fmt.Println("    list                 List registered processes (default)")
+	fmt.Println("    add <PROCESS_ID>     Register a process in this project")
+	fmt.Println("    remove <PROCESS_ID>  Remove a process from the registry")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -n, --name <NAME>          Friendly name for the process")
+	fmt.Println("    --module <TXID>            Module the process was spawned from")
+	fmt.Println("    --module-version <VER>     Module version at deploy time")
+	fmt.Println("    -h, --help                 Show this help message")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    The registry lives in .harlequin-processes.json next to your project.")
+	fmt.Println("    harlequin message stamps the last-message time of registered")
+	fmt.Println("    processes, and the TUI process dashboard renders the registry with")
+	fmt.Println("    quick messaging and log tailing.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    harlequin processes add abc123... --name counter --module-version 1.2.0")
+	fmt.Println("    harlequin processes list")
+	fmt.Println("    harlequin processes remove abc123...")
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// HandlePublishSiteCommand publishes a web frontend build directory to
+// Arweave: every file becomes its own data item and an Arweave path manifest
+// ties them together, so the printed manifest txID serves the whole site
+func HandlePublishSiteCommand(ctx context.Context, args []string) {
+	var dir string
+	var dryRun bool
+	var walletPath string
+	var gatewayURL string
+	var bundlerURL string
+	var networkPreset string
+	var waitForSeed bool
+	seedTimeout := "5m"
+	customTags := map[string]string{}
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--dry-run":
+			dryRun = true
+		case "--wallet-file", "-w":
+			if i+1 < len(args) {
+				walletPath = args[i+1]
+				i++
+			}
+		case "--tag", "-t":
+			if i+1 < len(args) {
+				key, value, err := parseTagFlag(args[i+1])
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				customTags[key] = value
+				i++
+			}
+		case "--gateway":
+			if i+1 < len(args) {
+				gatewayURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--bundler":
+			if i+1 < len(args) {
+				bundlerURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--network":
+			if i+1 < len(args) {
+				networkPreset = args[i+1]
+				i++
+			}
+		case "--wait-for-seed":
+			waitForSeed = true
+		case "--seed-timeout":
+			if i+1 < len(args) {
+				seedTimeout = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintPublishSiteUsage()
+			return
+		default:
+			if !strings.HasPrefix(args[i], "-") && dir == "" {
+				dir = args[i]
+			}
+		}
+	}
+
+	if dir == "" {
+		fmt.Println("Error: No directory specified")
+		PrintPublishSiteUsage()
+		os.Exit(1)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		fmt.Printf("Error: %s is not a directory\n", dir)
+		os.Exit(1)
+	}
+
+	if walletPath == "" {
+		if os.Getenv("WALLET_PATH") != "" {
+			walletPath = os.Getenv("WALLET_PATH")
+		} else {
+			walletPath = "key.json"
+		}
+	}
+
+	network := resolveNetwork(networkFlags{Preset: networkPreset, Gateway: gatewayURL, Bundler: bundlerURL})
+
+	var seedWait time.Duration
+	if waitForSeed {
+		duration, err := time.ParseDuration(seedTimeout)
+		if err != nil {
+			fmt.Printf("Error: invalid --seed-timeout value %q (use e.g. 90s, 10m)\n", seedTimeout)
+			os.Exit(1)
+		}
+		seedWait = duration
+	}
+
+	if err := uploadDirectoryArtifact(ctx, dir, walletPath, dryRun, customTags, network, seedWait); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// PrintPublishSiteUsage prints usage information for the publish-site command
+func PrintPublishSiteUsage() {
+	fmt.Println("🎭 Harlequin Publish Site - Publish a Web Frontend to Arweave")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin publish-site <DIR> [OPTIONS]")
+	fmt.Println()
+	fmt.Println("ARGUMENTS:")
+	fmt.Println("    DIR                      Build output directory to publish (e.g. frontend/dist)")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -w, --wallet-file <FILE> Arweave wallet JSON file or wallet profile name (default: key.json,")
+	fmt.Println("                             falling back to the active wallet profile)")
+	fmt.Println("    -t, --tag <KEY=VALUE>    Add or override a manifest tag (repeatable)")
+	fmt.Println("    --gateway <URL>          Arweave gateway URL for result links")
+	fmt.Println("    --bundler <URL>          Bundler / upload service URL")
+	fmt.Println("    --network <PRESET>       Endpoint preset: mainnet, testnet, or localnet")
+	fmt.Println("    --dry-run                List what would be uploaded without uploading")
+	fmt.Println("    --wait-for-seed          Poll the gateway until the manifest is retrievable before")
+	fmt.Println("                             reporting success")
+	fmt.Println("    --seed-timeout <DUR>     How long to wait for seeding (default: 5m)")
+	fmt.Println("    -h, --help               Show this help message")
+	fmt.Println()
+	fmt.Println("BEHAVIOR:")
+	fmt.Println("    • Every file in the directory is uploaded as its own data item with the")
+	fmt.Println("      Content-Type matching its extension (hidden files are skipped)")
+	fmt.Println("    • An Arweave path manifest (arweave/paths) mapping the relative paths to")
+	fmt.Println("      their data items is uploaded last; index.html becomes the manifest index")
+	fmt.Println("    • The manifest transaction ID is printed - serve the site from <gateway>/<txID>")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    # Publish a vite build")
+	fmt.Println("    harlequin publish-site frontend/dist")
+	fmt.Println()
+	fmt.Println("    # See what would be uploaded first")
+	fmt.Println("    harlequin publish-site frontend/dist --dry-run")
+	fmt.Println()
+	fmt.Println("    # Publish and wait until the site is retrievable")
+	fmt.Println("    harlequin publish-site frontend/dist --wait-for-seed")
+}
@@ -2,14 +2,18 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"strconv"
 	"syscall"
+	"time"
 )
 
 // RemoteSigningConfig represents the configuration for the remote signing server
@@ -20,6 +24,17 @@ type RemoteSigningConfig struct {
 	MaxDataSize    int64    `json:"max_data_size"`
 	SigningTimeout int      `json:"signing_timeout_minutes"`
 	FrontendURL    string   `json:"frontend_url"` // URL for the frontend (for development)
+	StoragePath    string   `json:"storage_path"` // Path to the embedded storage file (empty = in-memory)
+	BundlerURL     string   `json:"bundler_url"`  // Bundler target for direct submission (empty = disabled)
+	Daemon         bool     `json:"daemon"`       // Run the server in the background
+}
+
+// remoteSigningState records a daemonized server so stop/status can find it
+type remoteSigningState struct {
+	PID       int       `json:"pid"`
+	Host      string    `json:"host"`
+	Port      int       `json:"port"`
+	StartedAt time.Time `json:"started_at"`
 }
 
 // DefaultRemoteSigningConfig returns the default configuration
@@ -31,6 +46,7 @@ func DefaultRemoteSigningConfig() *RemoteSigningConfig {
 		MaxDataSize:   10 * 1024 * 1024, // 10MB
 		SigningTimeout: 30,               // 30 minutes
 		FrontendURL:    "",               // Empty by default (uses same host)
+		StoragePath:    "",               // Empty by default (in-memory storage)
 	}
 }
 
@@ -98,7 +114,7 @@ func startRemoteSigningServer(ctx context.Context, args []string) error {
 	}
 
 	// Build command arguments
-	cmdArgs := []string{"start"}
+	cmdArgs := []string{}
 	cmdArgs = append(cmdArgs, "--port", strconv.Itoa(config.Port))
 	cmdArgs = append(cmdArgs, "--host", config.Host)
 	cmdArgs = append(cmdArgs, "--timeout", strconv.Itoa(config.SigningTimeout))
@@ -106,6 +122,16 @@ func startRemoteSigningServer(ctx context.Context, args []string) error {
 	if config.FrontendURL != "" {
 		cmdArgs = append(cmdArgs, "--frontend-url", config.FrontendURL)
 	}
+	if config.StoragePath != "" {
+		cmdArgs = append(cmdArgs, "--storage", config.StoragePath)
+	}
+	if config.BundlerURL != "" {
+		cmdArgs = append(cmdArgs, "--bundler", config.BundlerURL)
+	}
+
+	if config.Daemon {
+		return startRemoteSigningDaemon(remoteSigningPath, cmdArgs, config)
+	}
 
 	// Create and start the command
 	cmd := exec.CommandContext(ctx, remoteSigningPath, cmdArgs...)
@@ -141,30 +167,302 @@ func startRemoteSigningServer(ctx context.Context, args []string) error {
 	return cmd.Wait()
 }
 
-// stopRemoteSigningServer stops the remote signing server
+// startRemoteSigningDaemon starts the server detached from the terminal and
+// records its PID in a state file so stop/status can find it later
+func startRemoteSigningDaemon(remoteSigningPath string, cmdArgs []string, config *RemoteSigningConfig) error {
+	// Refuse to start a second daemon if one is already running
+	if state, err := readRemoteSigningState(); err == nil && isProcessRunning(state.PID) {
+		return fmt.Errorf("remote signing server already running (PID %d). Stop it first with: harlequin remote-signing stop", state.PID)
+	}
+
+	stateDir, err := remoteSigningStateDir()
+	if err != nil {
+		return err
+	}
+
+	// Redirect output to a log file since the daemon has no terminal
+	logPath := filepath.Join(stateDir, "remote-signing.log")
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(remoteSigningPath, cmdArgs...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start remote signing server: %w", err)
+	}
+
+	state := &remoteSigningState{
+		PID:       cmd.Process.Pid,
+		Host:      config.Host,
+		Port:      config.Port,
+		StartedAt: time.Now(),
+	}
+	if err := writeRemoteSigningState(state); err != nil {
+		cmd.Process.Kill()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	// Detach: the daemon keeps running after the CLI exits
+	cmd.Process.Release()
+
+	fmt.Printf("🎭 Remote Signing Server started in daemon mode (PID %d)\n", state.PID)
+	fmt.Printf("📡 Server available at: http://%s:%d\n", config.Host, config.Port)
+	fmt.Printf("📜 Logs: %s\n", logPath)
+	fmt.Println("🛑 Stop with: harlequin remote-signing stop")
+	return nil
+}
+
+// stopRemoteSigningServer stops a daemonized remote signing server
 func stopRemoteSigningServer(args []string) error {
-	fmt.Println("🛑 Stop command is not yet implemented for daemon mode")
-	fmt.Println("To stop the server, use Ctrl+C in the terminal where it's running")
+	state, err := readRemoteSigningState()
+	if err != nil {
+		return fmt.Errorf("no running server found. Was it started with --daemon?")
+	}
+
+	if !isProcessRunning(state.PID) {
+		// Stale state file from a crashed or externally killed server
+		removeRemoteSigningState()
+		return fmt.Errorf("server (PID %d) is not running, removed stale state file", state.PID)
+	}
+
+	process, err := os.FindProcess(state.PID)
+	if err != nil {
+		return fmt.Errorf("failed to find server process: %w", err)
+	}
+
+	fmt.Printf("🛑 Stopping Remote Signing Server (PID %d)...\n", state.PID)
+	if err := process.Signal(syscall.SIGTERM); err != nil {
+		return fmt.Errorf("failed to stop server: %w", err)
+	}
+
+	// Wait for the process to exit gracefully
+	for i := 0; i < 20; i++ {
+		if !isProcessRunning(state.PID) {
+			break
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+
+	if isProcessRunning(state.PID) {
+		fmt.Println("⚠️  Server did not stop gracefully, sending SIGKILL...")
+		process.Signal(syscall.SIGKILL)
+	}
+
+	removeRemoteSigningState()
+	fmt.Println("✅ Remote Signing Server stopped")
 	return nil
 }
 
-// checkRemoteSigningStatus checks the status of the remote signing server
+// checkRemoteSigningStatus queries the server's /health and /status endpoints
+// and renders version, uptime, request counts, storage backend, and the
+// configured limits; --json emits both payloads as one JSON document
 func checkRemoteSigningStatus(args []string) error {
 	config := DefaultRemoteSigningConfig()
 
-	// Parse arguments for host and port
-	if err := parseStatusArgs(args, config); err != nil {
+	// A daemonized server's state file provides the host and port defaults
+	if state, err := readRemoteSigningState(); err == nil {
+		config.Host = state.Host
+		config.Port = state.Port
+	}
+
+	// Parse arguments for host, port, and output format
+	jsonOutput, err := parseStatusArgs(args, config)
+	if err != nil {
 		return fmt.Errorf("failed to parse arguments: %w", err)
 	}
 
-	// TODO: Implement HTTP client request to get status
-	url := fmt.Sprintf("http://%s:%d/status", config.Host, config.Port)
-	fmt.Printf("🔍 Checking server status at %s\n", url)
-	fmt.Println("Status command not yet fully implemented")
+	baseURL := fmt.Sprintf("http://%s:%d", config.Host, config.Port)
+	if !jsonOutput {
+		fmt.Printf("🔍 Checking server status at %s\n", baseURL)
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	healthBody, err := fetchRemoteSigningEndpoint(client, baseURL+"/health")
+	if err != nil {
+		return fmt.Errorf("server is not reachable: %w", err)
+	}
+	statusBody, err := fetchRemoteSigningEndpoint(client, baseURL+"/status")
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		combined, err := json.MarshalIndent(map[string]json.RawMessage{
+			"health": healthBody,
+			"status": statusBody,
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to render status JSON: %w", err)
+		}
+		fmt.Println(string(combined))
+		return nil
+	}
+
+	var health struct {
+		Status  string `json:"status"`
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(healthBody, &health); err != nil {
+		return fmt.Errorf("failed to parse health response: %w", err)
+	}
+
+	var status struct {
+		Server struct {
+			Status  string `json:"status"`
+			Uptime  string `json:"uptime"`
+			Version string `json:"version"`
+		} `json:"server"`
+		Requests struct {
+			Total   int `json:"total"`
+			Signed  int `json:"signed"`
+			Pending int `json:"pending"`
+		} `json:"requests"`
+		WebSockets struct {
+			ConnectedClients int `json:"connected_clients"`
+		} `json:"websockets"`
+		Storage struct {
+			Backend string `json:"backend"`
+			Path    string `json:"path"`
+		} `json:"storage"`
+		Config struct {
+			MaxDataSize    int64  `json:"max_data_size"`
+			SigningTimeout string `json:"signing_timeout"`
+			MaxWSClients   int    `json:"max_ws_clients"`
+			MaxSSEClients  int    `json:"max_sse_clients"`
+			BundlerURL     string `json:"bundler_url"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(statusBody, &status); err != nil {
+		return fmt.Errorf("failed to parse status response: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("✅ Server is %s (version %s)\n", health.Status, health.Version)
+	fmt.Printf("⏱️  Uptime: %s\n", status.Server.Uptime)
+	fmt.Printf("📋 Requests: %d total, %d signed, %d pending\n",
+		status.Requests.Total, status.Requests.Signed, status.Requests.Pending)
+	fmt.Printf("🔌 Connected WebSocket clients: %d\n", status.WebSockets.ConnectedClients)
+
+	if status.Storage.Path != "" {
+		fmt.Printf("💾 Storage: %s (%s)\n", status.Storage.Backend, status.Storage.Path)
+	} else if status.Storage.Backend != "" {
+		fmt.Printf("💾 Storage: %s\n", status.Storage.Backend)
+	}
+	if status.Config.SigningTimeout != "" {
+		fmt.Printf("⚙️  Limits: max data size %d bytes, signing timeout %s, %d WS / %d SSE clients\n",
+			status.Config.MaxDataSize, status.Config.SigningTimeout,
+			status.Config.MaxWSClients, status.Config.MaxSSEClients)
+	}
+	if status.Config.BundlerURL != "" {
+		fmt.Printf("📦 Bundler: %s\n", status.Config.BundlerURL)
+	}
+
+	if state, err := readRemoteSigningState(); err == nil && isProcessRunning(state.PID) {
+		fmt.Printf("👻 Daemon PID: %d (started %s)\n", state.PID, state.StartedAt.Format(time.RFC3339))
+	}
 
 	return nil
 }
 
+// fetchRemoteSigningEndpoint retrieves a JSON endpoint from the signing server
+func fetchRemoteSigningEndpoint(client *http.Client, url string) (json.RawMessage, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned HTTP %d for %s", resp.StatusCode, url)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", url, err)
+	}
+	return json.RawMessage(body), nil
+}
+
+// remoteSigningStateDir returns the directory holding the daemon state file,
+// creating it if needed
+func remoteSigningStateDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	stateDir := filepath.Join(homeDir, ".harlequin")
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create state directory: %w", err)
+	}
+	return stateDir, nil
+}
+
+// remoteSigningStatePath returns the path to the daemon state file
+func remoteSigningStatePath() (string, error) {
+	stateDir, err := remoteSigningStateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, "remote-signing.json"), nil
+}
+
+// readRemoteSigningState reads the daemon state file
+func readRemoteSigningState() (*remoteSigningState, error) {
+	statePath, err := remoteSigningStatePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var state remoteSigningState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file: %w", err)
+	}
+	return &state, nil
+}
+
+// writeRemoteSigningState writes the daemon state file
+func writeRemoteSigningState(state *remoteSigningState) error {
+	statePath, err := remoteSigningStatePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(statePath, data, 0644)
+}
+
+// removeRemoteSigningState deletes the daemon state file
+func removeRemoteSigningState() {
+	if statePath, err := remoteSigningStatePath(); err == nil {
+		os.Remove(statePath)
+	}
+}
+
+// isProcessRunning reports whether a process with the given PID exists
+func isProcessRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// Signal 0 performs error checking without sending a signal
+	return process.Signal(syscall.Signal(0)) == nil
+}
+
 // parseRemoteSigningArgs parses command line arguments for remote signing commands
 func parseRemoteSigningArgs(args []string, config *RemoteSigningConfig) error {
 	for i := 0; i < len(args); i++ {
@@ -211,6 +509,20 @@ func parseRemoteSigningArgs(args []string, config *RemoteSigningConfig) error {
 			}
 			config.FrontendURL = args[i+1]
 			i++
+		case "--storage":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--storage requires a value")
+			}
+			config.StoragePath = args[i+1]
+			i++
+		case "--bundler":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--bundler requires a value")
+			}
+			config.BundlerURL = args[i+1]
+			i++
+		case "--daemon", "-d":
+			config.Daemon = true
 		case "--help":
 			printRemoteSigningHelp()
 			os.Exit(0)
@@ -221,34 +533,38 @@ func parseRemoteSigningArgs(args []string, config *RemoteSigningConfig) error {
 	return nil
 }
 
-// parseStatusArgs parses command line arguments for the status command
-func parseStatusArgs(args []string, config *RemoteSigningConfig) error {
+// parseStatusArgs parses command line arguments for the status command and
+// reports whether JSON output was requested
+func parseStatusArgs(args []string, config *RemoteSigningConfig) (bool, error) {
+	jsonOutput := false
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
 		case "--port", "-p":
 			if i+1 >= len(args) {
-				return fmt.Errorf("--port requires a value")
+				return false, fmt.Errorf("--port requires a value")
 			}
 			port, err := strconv.Atoi(args[i+1])
 			if err != nil {
-				return fmt.Errorf("invalid port number: %s", args[i+1])
+				return false, fmt.Errorf("invalid port number: %s", args[i+1])
 			}
 			config.Port = port
 			i++
 		case "--host", "-h":
 			if i+1 >= len(args) {
-				return fmt.Errorf("--host requires a value")
+				return false, fmt.Errorf("--host requires a value")
 			}
 			config.Host = args[i+1]
 			i++
+		case "--json":
+			jsonOutput = true
 		case "--help":
 			printRemoteSigningHelp()
 			os.Exit(0)
 		default:
-			return fmt.Errorf("unknown argument: %s", args[i])
+			return false, fmt.Errorf("unknown argument: %s", args[i])
 		}
 	}
-	return nil
+	return jsonOutput, nil
 }
 
 // findProjectRoot finds the project root directory
@@ -297,13 +613,24 @@ func printRemoteSigningHelp() {
 	fmt.Println("  -t, --timeout <minutes>  Signing timeout in minutes (default: 30)")
 	fmt.Println("      --max-size <bytes>   Maximum data item size in bytes (default: 10MB)")
 	fmt.Println("      --frontend-url <url> Frontend URL for development (e.g., http://localhost:5173)")
+	fmt.Println("      --storage <path>     Path to the embedded storage file (default: in-memory)")
+	fmt.Println("      --bundler <target>   Bundler target for direct submission: turbo, turbo-dev, or a custom gateway URL")
+	fmt.Println("  -d, --daemon             Run the server in the background")
+	fmt.Println()
+	fmt.Println("Status Command Flags:")
+	fmt.Println("  -p, --port <port>        Server port (default: 8080, or the running daemon's)")
+	fmt.Println("  -h, --host <host>        Server host (default: localhost, or the running daemon's)")
+	fmt.Println("      --json               Print the raw /health and /status payloads as JSON")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  harlequin remote-signing start")
 	fmt.Println("  harlequin remote-signing start --port 9000")
 	fmt.Println("  harlequin remote-signing start --host 0.0.0.0 --port 8080")
 	fmt.Println("  harlequin remote-signing start --frontend-url http://localhost:5173")
+	fmt.Println("  harlequin remote-signing start --daemon")
 	fmt.Println("  harlequin remote-signing status")
+	fmt.Println("  harlequin remote-signing status --json")
+	fmt.Println("  harlequin remote-signing stop")
 	fmt.Println()
 	fmt.Println("How it works:")
 	fmt.Println("  1. Submit data items via POST / to get a signing UUID")
@@ -316,6 +643,7 @@ func printRemoteSigningHelp() {
 	fmt.Println("  POST /<uuid>          Submit signed data item")
 	fmt.Println("  GET /sign/<uuid>      Web interface for signing")
 	fmt.Println("  GET /ws               WebSocket endpoint for callbacks")
+	fmt.Println("  GET /health           Server health, version, and uptime")
 	fmt.Println("  GET /status           Server status and statistics")
 	fmt.Println()
 }
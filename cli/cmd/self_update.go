@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/build/builders"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/retry"
+)
+
+// releaseSigningPublicKey is the hex-encoded Ed25519 key used to verify
+// detached release signatures. It is empty until releases ship signatures;
+// HARLEQUIN_RELEASE_PUBKEY overrides it for testing.
+var releaseSigningPublicKey = ""
+
+// HandleSelfUpdateCommand handles the self-update command
+func HandleSelfUpdateCommand(ctx context.Context, args []string) {
+	config := DefaultVersionManagementConfig()
+	channel := "stable"
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--channel", "-c":
+			if i+1 >= len(args) {
+				fmt.Printf("Error: --channel requires a value (stable, beta)\n\n")
+				printSelfUpdateUsage()
+				os.Exit(1)
+			}
+			channel = args[i+1]
+			i++
+		case "--help", "-h":
+			printSelfUpdateUsage()
+			return
+		default:
+			fmt.Printf("Unknown argument: %s\n\n", args[i])
+			printSelfUpdateUsage()
+			os.Exit(1)
+		}
+	}
+
+	if channel != "stable" && channel != "beta" {
+		fmt.Printf("Error: invalid channel '%s'. Valid channels: stable, beta\n", channel)
+		os.Exit(1)
+	}
+
+	if err := selfUpdate(ctx, config, channel); err != nil {
+		fmt.Printf("❌ Self-update failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// selfUpdate downloads the latest release for the channel, verifies it, and
+// atomically replaces the running executable
+func selfUpdate(ctx context.Context, config *VersionManagementConfig, channel string) error {
+	fmt.Printf("🎭 Checking for updates on the %s channel...\n", channel)
+
+	releases, err := fetchAvailableVersions(ctx, config)
+	if err != nil {
+		return fmt.Errorf("failed to fetch releases: %w", err)
+	}
+
+	latest, err := latestForChannel(releases, channel)
+	if err != nil {
+		return err
+	}
+
+	latestVersion := strings.TrimPrefix(latest.TagName, "v")
+	currentVersion := strings.TrimPrefix(builders.ToolkitVersion, "v")
+	if currentVersion == latestVersion {
+		fmt.Printf("✅ Already up to date (version %s)\n", currentVersion)
+		return nil
+	}
+
+	downloadURL := fmt.Sprintf("%s/releases/%s/%s/%s", config.BaseURL, latestVersion, runtime.GOOS, runtime.GOARCH)
+	fmt.Printf("📥 Downloading version %s from: %s\n", latestVersion, downloadURL)
+
+	binary, err := fetchURL(ctx, config, downloadURL)
+	if err != nil {
+		return fmt.Errorf("failed to download binary: %w", err)
+	}
+
+	if err := verifyChecksum(ctx, config, downloadURL, binary); err != nil {
+		return err
+	}
+
+	if err := verifySignature(ctx, config, downloadURL, binary); err != nil {
+		return err
+	}
+
+	if err := replaceExecutable(binary); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Updated harlequin from %s to %s\n", currentVersion, latestVersion)
+	return nil
+}
+
+// latestForChannel picks the newest release for the channel. The stable
+// channel skips prerelease tags (anything with a -suffix); beta includes them.
+func latestForChannel(releases []Release, channel string) (*Release, error) {
+	for i := range releases {
+		version := strings.TrimPrefix(releases[i].TagName, "v")
+		if channel == "stable" && strings.Contains(version, "-") {
+			continue
+		}
+		return &releases[i], nil
+	}
+	return nil, fmt.Errorf("no releases found on the %s channel", channel)
+}
+
+// verifyChecksum downloads the published SHA256 for the binary and compares it
+func verifyChecksum(ctx context.Context, config *VersionManagementConfig, downloadURL string, binary []byte) error {
+	checksumData, err := fetchURL(ctx, config, downloadURL+".sha256")
+	if err != nil {
+		return fmt.Errorf("failed to download checksum: %w", err)
+	}
+
+	// Checksum files may be bare digests or "digest  filename" lines
+	expected := strings.Fields(strings.TrimSpace(string(checksumData)))
+	if len(expected) == 0 {
+		return fmt.Errorf("checksum file is empty")
+	}
+
+	actual := sha256.Sum256(binary)
+	if !strings.EqualFold(expected[0], hex.EncodeToString(actual[:])) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expected[0], hex.EncodeToString(actual[:]))
+	}
+
+	fmt.Println("🔒 SHA256 checksum verified")
+	return nil
+}
+
+// verifySignature checks the detached Ed25519 signature when the release
+// publishes one. Missing signatures are allowed; invalid ones are fatal.
+func verifySignature(ctx context.Context, config *VersionManagementConfig, downloadURL string, binary []byte) error {
+	signature, err := fetchURL(ctx, config, downloadURL+".sig")
+	if err != nil {
+		// Signatures are optional until all releases ship them
+		return nil
+	}
+
+	keyHex := releaseSigningPublicKey
+	if override := os.Getenv("HARLEQUIN_RELEASE_PUBKEY"); override != "" {
+		keyHex = override
+	}
+	if keyHex == "" {
+		fmt.Println("⚠️  Release is signed but no public key is configured; skipping signature check")
+		return nil
+	}
+
+	publicKey, err := hex.DecodeString(keyHex)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid release signing public key")
+	}
+
+	sig := strings.TrimSpace(string(signature))
+	sigBytes, err := hex.DecodeString(sig)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(publicKey), binary, sigBytes) {
+		return fmt.Errorf("signature verification failed")
+	}
+
+	fmt.Println("🔏 Release signature verified")
+	return nil
+}
+
+// fetchURL downloads a URL body, failing on non-200 responses
+func fetchURL(ctx context.Context, config *VersionManagementConfig, url string) ([]byte, error) {
+	if builders.Offline {
+		return nil, fmt.Errorf("offline mode: cannot download %s", url)
+	}
+
+	client := &http.Client{
+		Timeout: config.Timeout,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Release fetches ride the shared retry policy so a transient 5xx from
+	// the install service doesn't abort an update
+	var body []byte
+	err = retry.Queries.Do(ctx, "release fetch", func() error {
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			statusErr := fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+			if !retry.IsRetryableStatus(resp.StatusCode) {
+				return retry.Permanent(statusErr)
+			}
+			return statusErr
+		}
+
+		body, err = io.ReadAll(resp.Body)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// replaceExecutable swaps the running binary for the downloaded one. The new
+// binary is written next to the current one so the final rename is atomic.
+func replaceExecutable(binary []byte) error {
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate current executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exePath); err == nil {
+		exePath = resolved
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(exePath), ".harlequin-update-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+
+	if _, err := tmpFile.Write(binary); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0755); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to set permissions: %w", err)
+	}
+
+	// Move the running binary aside first so the rename works on platforms
+	// that refuse to overwrite a running executable
+	oldPath := exePath + ".old"
+	os.Remove(oldPath)
+	if err := os.Rename(exePath, oldPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace %s: %w", exePath, err)
+	}
+	if err := os.Rename(tmpPath, exePath); err != nil {
+		os.Rename(oldPath, exePath)
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace %s: %w", exePath, err)
+	}
+	os.Remove(oldPath)
+
+	return nil
+}
+
+// printSelfUpdateUsage prints usage information for the self-update command
+func printSelfUpdateUsage() {
+	fmt.Println("🎭 Harlequin Self-Update Command")
+	fmt.Println()
+	fmt.Println("Update harlequin in place, with checksum verification")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  harlequin self-update [flags]")
+	fmt.Println()
+	fmt.Println("Flags:")
+	fmt.Println("  -c, --channel <channel>  Release channel: stable, beta (default: stable)")
+	fmt.Println("  -h, --help               Show this help message")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  harlequin self-update                   # Update to the latest stable release")
+	fmt.Println("  harlequin self-update --channel beta    # Include prerelease versions")
+	fmt.Println()
+	fmt.Println("This command will:")
+	fmt.Println("  • Query the release API for the newest version on the channel")
+	fmt.Println("  • Download the binary for this platform")
+	fmt.Println("  • Verify its SHA256 checksum (and detached signature when published)")
+	fmt.Println("  • Atomically replace the running executable")
+	fmt.Println()
+}
@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	harlequinconfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/wallet"
+)
+
+// completionCommands is the command list baked into the generated shell
+// completion script
+var completionCommands = []string{
+	"init", "templates", "build", "upload", "publish-site", "arns", "modules",
+	"lua-utils", "fmt", "generate", "wasm", "remote-signing", "sign", "message",
+	"eval", "state", "config", "run-task", "add", "wallet", "localnet", "ci",
+	"debug", "prefetch", "clean", "install", "self-update", "telemetry", "setup",
+	"uninstall", "versions", "version", "help",
+}
+
+// HandleSetupCommand walks a new user through the first-run setup: config
+// directory, default network, wallet, Docker check, and shell completion
+func HandleSetupCommand(ctx context.Context, args []string) {
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--help", "-h":
+			PrintSetupUsage()
+			return
+		default:
+			fmt.Printf("Unknown argument: %s\n\n", args[i])
+			PrintSetupUsage()
+			os.Exit(1)
+		}
+	}
+
+	fmt.Println("🎭 Harlequin First-Run Setup")
+	fmt.Println()
+
+	setupConfigDirectory()
+	setupDefaultNetwork()
+	setupWallet()
+	setupDockerCheck(ctx)
+	setupShellCompletion()
+
+	fmt.Println("🚀 Setup complete! Create your first project with: harlequin init")
+}
+
+// setupConfigDirectory creates the user config directory
+func setupConfigDirectory() {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		fmt.Printf("❌ Failed to locate user config directory: %v\n", err)
+		os.Exit(1)
+	}
+	dir := filepath.Join(configDir, "harlequin")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		fmt.Printf("❌ Failed to create config directory: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Config directory: %s\n", dir)
+	fmt.Println()
+}
+
+// setupDefaultNetwork persists the default gateway/bundler preset
+func setupDefaultNetwork() {
+	current := persistedNetworkPreset()
+	if current != "" {
+		fmt.Printf("✅ Default network already set: %s\n", current)
+		if !promptYesNo("Change it?", false) {
+			fmt.Println()
+			return
+		}
+	}
+
+	preset := promptSelect("Which network should commands use by default?", [][2]string{
+		{harlequinconfig.NetworkMainnet, "Mainnet - arweave.net gateway, ArDrive bundler"},
+		{harlequinconfig.NetworkTestnet, "Testnet - AO testnet endpoints"},
+		{harlequinconfig.NetworkLocalnet, "Localnet - the harlequin localnet stack"},
+	})
+	if err := persistNetworkPreset(preset); err != nil {
+		fmt.Printf("⚠️  Could not persist the network preset: %v\n", err)
+	} else {
+		fmt.Printf("✅ Default network: %s (override per command with --network)\n", preset)
+	}
+	fmt.Println()
+}
+
+// setupWallet offers to import an existing wallet or generate a fresh one
+func setupWallet() {
+	profiles, err := wallet.List()
+	if err == nil && len(profiles) > 0 {
+		fmt.Printf("✅ Wallet profiles already configured (%d found)\n", len(profiles))
+		fmt.Println()
+		return
+	}
+
+	choice := promptSelect("Set up a wallet?", [][2]string{
+		{"import", "Import an existing JWK wallet file"},
+		{"generate", "Generate a fresh wallet"},
+		{"skip", "Skip for now"},
+	})
+
+	switch choice {
+	case "import":
+		path := promptLine("Path to the wallet JWK file", "wallet.json")
+		if err := wallet.Add("default", path); err != nil {
+			fmt.Printf("⚠️  Could not import the wallet: %v\n", err)
+			break
+		}
+		if err := wallet.Use("default"); err != nil {
+			fmt.Printf("⚠️  Could not activate the profile: %v\n", err)
+			break
+		}
+		fmt.Println("✅ Wallet imported as profile 'default'")
+	case "generate":
+		fmt.Println("⏳ Generating a 4096-bit wallet (this can take a moment)...")
+		address, err := wallet.Generate("default")
+		if err != nil {
+			fmt.Printf("⚠️  Could not generate a wallet: %v\n", err)
+			break
+		}
+		if err := wallet.Use("default"); err != nil {
+			fmt.Printf("⚠️  Could not activate the profile: %v\n", err)
+			break
+		}
+		fmt.Printf("✅ Wallet generated as profile 'default'\n")
+		fmt.Printf("   • Address: %s\n", address)
+		if dir, err := wallet.ProfilesDir(); err == nil {
+			fmt.Printf("   ⚠️  Back up %s somewhere safe - it is the only copy\n", filepath.Join(dir, "default.json"))
+		}
+	case "skip":
+		fmt.Println("💡 Add one later with: harlequin wallet add <name> <path>")
+	}
+	fmt.Println()
+}
+
+// setupDockerCheck verifies Docker is installed and the daemon is reachable
+func setupDockerCheck(ctx context.Context) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		fmt.Println("⚠️  Docker not found - builds need it")
+		fmt.Println("   • Install it from https://docs.docker.com/get-docker/")
+		fmt.Println()
+		return
+	}
+
+	if err := exec.CommandContext(ctx, "docker", "info").Run(); err != nil {
+		fmt.Println("⚠️  Docker is installed but the daemon is not reachable")
+		fmt.Println("   • Start Docker Desktop (or the docker service) before building")
+	} else {
+		fmt.Println("✅ Docker is installed and running")
+	}
+	fmt.Println()
+}
+
+// setupShellCompletion writes the completion script and offers to source it
+// from the user's shell rc file
+func setupShellCompletion() {
+	if !promptYesNo("Install shell completion?", true) {
+		fmt.Println()
+		return
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		fmt.Printf("⚠️  Could not locate the config directory: %v\n", err)
+		fmt.Println()
+		return
+	}
+	scriptPath := filepath.Join(configDir, "harlequin", "completion.bash")
+
+	script := fmt.Sprintf(`# harlequin shell completion (bash and zsh via bashcompinit)
+_harlequin() {
+  local cur="${COMP_WORDS[COMP_CWORD]}"
+  if [ "$COMP_CWORD" -eq 1 ]; then
+    COMPREPLY=( $(compgen -W "%s" -- "$cur") )
+  fi
+}
+complete -F _harlequin harlequin
+`, strings.Join(completionCommands, " "))
+
+	if err := os.WriteFile(scriptPath, []byte(script), 0644); err != nil {
+		fmt.Printf("⚠️  Could not write the completion script: %v\n", err)
+		fmt.Println()
+		return
+	}
+	fmt.Printf("✅ Completion script written to %s\n", scriptPath)
+
+	rcPath, sourceLine := completionRCLine(scriptPath)
+	if rcPath == "" {
+		fmt.Printf("💡 Add this line to your shell rc file:\n   source %s\n", scriptPath)
+		fmt.Println()
+		return
+	}
+
+	if rcAlreadySources(rcPath, scriptPath) {
+		fmt.Printf("✅ %s already sources it\n", rcPath)
+	} else if promptYesNo(fmt.Sprintf("Add it to %s?", rcPath), true) {
+		rcFile, err := os.OpenFile(rcPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			fmt.Printf("⚠️  Could not open %s: %v\n", rcPath, err)
+		} else {
+			defer rcFile.Close()
+			if _, err := fmt.Fprintf(rcFile, "\n%s\n", sourceLine); err != nil {
+				fmt.Printf("⚠️  Could not update %s: %v\n", rcPath, err)
+			} else {
+				fmt.Printf("✅ Added to %s - restart your shell to pick it up\n", rcPath)
+			}
+		}
+	} else {
+		fmt.Printf("💡 Add it yourself with:\n   %s\n", sourceLine)
+	}
+	fmt.Println()
+}
+
+// completionRCLine picks the rc file and source line for the user's shell
+func completionRCLine(scriptPath string) (rcPath, sourceLine string) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", ""
+	}
+
+	shell := filepath.Base(os.Getenv("SHELL"))
+	switch shell {
+	case "zsh":
+		// zsh runs bash completion functions through bashcompinit
+		return filepath.Join(home, ".zshrc"),
+			fmt.Sprintf("autoload -U +X bashcompinit && bashcompinit\nsource %s", scriptPath)
+	case "bash":
+		return filepath.Join(home, ".bashrc"), fmt.Sprintf("source %s", scriptPath)
+	default:
+		return "", ""
+	}
+}
+
+// rcAlreadySources reports whether the rc file already references the script
+func rcAlreadySources(rcPath, scriptPath string) bool {
+	content, err := os.ReadFile(rcPath)
+	return err == nil && strings.Contains(string(content), scriptPath)
+}
+
+// PrintSetupUsage prints usage information for the setup command
+func PrintSetupUsage() {
+	fmt.Println("🎭 Harlequin Setup Command")
+	fmt.Println()
+	fmt.Println("Guided first-run setup for a new machine")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  harlequin setup")
+	fmt.Println()
+	fmt.Println("This command will:")
+	fmt.Println("  • Create the user config directory")
+	fmt.Println("  • Pick the default network (gateway, bundler, CU/MU)")
+	fmt.Println("  • Import or generate a wallet profile (optional)")
+	fmt.Println("  • Check that Docker is installed and running")
+	fmt.Println("  • Install shell completion (optional)")
+	fmt.Println()
+	fmt.Println("Every step is skippable; run it again any time.")
+	fmt.Println()
+}
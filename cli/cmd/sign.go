@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/everFinance/goar/types"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	signingserver "github.com/the-permaweb-harlequin/harlequin-toolkit/remote-signing/server"
+)
+
+// HandleSignCommand handles the sign command for signing and uploading arbitrary files
+// through the remote signing flow (browser wallet, no local keyfile required)
+func HandleSignCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling sign command with args: %v", args)
+
+	var filePath string
+	var tags []types.Tag
+	var target string
+	var anchor string
+	var bundler string
+	config := signingserver.DefaultConfig()
+
+	// Parse command line arguments
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--tag", "-t":
+			if i+1 < len(args) {
+				tag, err := parseSignTag(args[i+1])
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				tags = append(tags, tag)
+				i++
+			}
+		case "--target":
+			if i+1 < len(args) {
+				target = args[i+1]
+				i++
+			}
+		case "--anchor":
+			if i+1 < len(args) {
+				anchor = args[i+1]
+				i++
+			}
+		case "--bundler":
+			if i+1 < len(args) {
+				bundler = args[i+1]
+				i++
+			}
+		case "--port", "-p":
+			if i+1 < len(args) {
+				port, err := strconv.Atoi(args[i+1])
+				if err != nil {
+					fmt.Printf("Error: Invalid port number: %s\n", args[i+1])
+					os.Exit(1)
+				}
+				config.Port = port
+				i++
+			}
+		case "--host":
+			if i+1 < len(args) {
+				config.Host = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintSignUsage()
+			return
+		default:
+			if !strings.HasPrefix(args[i], "-") && filePath == "" {
+				filePath = args[i]
+			}
+		}
+	}
+
+	if filePath == "" {
+		fmt.Println("Error: No file specified")
+		PrintSignUsage()
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		fmt.Printf("Error: Failed to read file: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Add a Content-Type tag based on the file extension unless the user set one
+	if !hasTag(tags, "Content-Type") {
+		contentType := mime.TypeByExtension(filepath.Ext(filePath))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		tags = append(tags, types.Tag{Name: "Content-Type", Value: contentType})
+	}
+	if !hasTag(tags, "File-Name") {
+		tags = append(tags, types.Tag{Name: "File-Name", Value: filepath.Base(filePath)})
+	}
+
+	// The server submits to the bundler directly when one is configured
+	config.BundlerURL = bundler
+
+	fmt.Printf("🎭 Signing %s (%d bytes)\n", filePath, len(data))
+
+	signingServer := signingserver.NewSigningServer(config)
+	defer signingServer.Close()
+
+	result, err := signingServer.Upload(&signingserver.UploadRequest{
+		Data:     data,
+		Filename: filepath.Base(filePath),
+		Tags:     tags,
+		Target:   target,
+		Anchor:   anchor,
+	})
+	if err != nil {
+		fmt.Printf("❌ Signing failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("✅ File signed successfully!")
+	fmt.Printf("   📦 DataItem ID: %s\n", result.DataItemID)
+	if bundler != "" {
+		fmt.Printf("   🌐 View at: https://arweave.net/%s\n", result.DataItemID)
+	}
+	if result.BundlerResponse != "" {
+		debug.Printf("Bundler response: %s", result.BundlerResponse)
+	}
+}
+
+// parseSignTag parses a name=value tag argument
+func parseSignTag(arg string) (types.Tag, error) {
+	parts := strings.SplitN(arg, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return types.Tag{}, fmt.Errorf("invalid tag %q (expected name=value)", arg)
+	}
+	return types.Tag{Name: parts[0], Value: parts[1]}, nil
+}
+
+// hasTag reports whether a tag with the given name is already present
+func hasTag(tags []types.Tag, name string) bool {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintSignUsage prints usage information for the sign command
+func PrintSignUsage() {
+	fmt.Println("🎭 Harlequin Sign - Sign and Upload Files via Browser Wallet")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin sign <FILE> [OPTIONS]")
+	fmt.Println()
+	fmt.Println("ARGUMENTS:")
+	fmt.Println("    FILE                   Path to the file to sign and upload")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -t, --tag <NAME=VALUE>   Tag to attach to the data item (repeatable)")
+	fmt.Println("    --target <ADDRESS>       Target address for the data item")
+	fmt.Println("    --anchor <ANCHOR>        Anchor for the data item (generated if omitted)")
+	fmt.Println("    --bundler <TARGET>       Submit to a bundler after signing: turbo, turbo-dev, or a URL")
+	fmt.Println("    -p, --port <PORT>        Signing server port (default: 8080)")
+	fmt.Println("    --host <HOST>            Signing server host (default: localhost)")
+	fmt.Println("    -h, --help               Show this help message")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    Creates an ANS-104 data item from the file, starts the local signing")
+	fmt.Println("    server if one is not already running, and opens your browser so you")
+	fmt.Println("    can sign with a wallet extension. No local keyfile is required.")
+	fmt.Println()
+	fmt.Println("    A Content-Type tag is derived from the file extension unless provided.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    # Sign a file and print its DataItem ID")
+	fmt.Println("    harlequin sign photo.png")
+	fmt.Println()
+	fmt.Println("    # Sign with custom tags and upload via Turbo")
+	fmt.Println("    harlequin sign process.wasm --tag App-Name=MyApp --bundler turbo")
+	fmt.Println()
+	fmt.Println("    # Use an already-running signing server on another port")
+	fmt.Println("    harlequin sign data.json --port 9090")
+}
@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+)
+
+const defaultGatewayURL = "https://arweave.net"
+
+// HandleStateCommand handles the state command for downloading process state
+// snapshots from a compute unit or checkpoint
+func HandleStateCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling state command with args: %v", args)
+
+	var processID string
+	var outputPath string
+	var checkpoint bool
+	action := "State"
+	var cuURL string
+	var gatewayURL string
+	var networkPreset string
+
+	// Parse command line arguments
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--action", "-a":
+			if i+1 < len(args) {
+				action = args[i+1]
+				i++
+			}
+		case "--output", "-o":
+			if i+1 < len(args) {
+				outputPath = args[i+1]
+				i++
+			}
+		case "--checkpoint":
+			checkpoint = true
+		case "--cu":
+			if i+1 < len(args) {
+				cuURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--gateway":
+			if i+1 < len(args) {
+				gatewayURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--network":
+			if i+1 < len(args) {
+				networkPreset = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintStateUsage()
+			return
+		default:
+			if !strings.HasPrefix(args[i], "-") && processID == "" {
+				processID = args[i]
+			}
+		}
+	}
+
+	network := resolveNetwork(networkFlags{Preset: networkPreset, CU: cuURL, Gateway: gatewayURL})
+	cuURL = strings.TrimRight(network.CU, "/")
+	gatewayURL = strings.TrimRight(network.Gateway, "/")
+
+	if processID == "" {
+		fmt.Println("Error: No process ID specified")
+		PrintStateUsage()
+		os.Exit(1)
+	}
+
+	var err error
+	if checkpoint {
+		err = downloadCheckpoint(ctx, gatewayURL, processID, outputPath)
+	} else {
+		err = downloadDryRunState(ctx, cuURL, processID, action, outputPath)
+	}
+	if err != nil {
+		fmt.Printf("❌ Failed to fetch state: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// downloadDryRunState evaluates the process's State handler via a dry-run on
+// the compute unit and saves the decoded output
+func downloadDryRunState(ctx context.Context, cuURL, processID, action, outputPath string) error {
+	fmt.Printf("🎭 Fetching %s dry-run state for %s\n", action, processID)
+
+	dryRunMessage := map[string]interface{}{
+		"Id":     "HARLEQUIN_STATE_MESSAGE",
+		"Target": processID,
+		"Owner":  "HARLEQUIN_STATE_OWNER",
+		"Tags": []map[string]string{
+			{"name": "Action", "value": action},
+		},
+	}
+	body, err := json.Marshal(dryRunMessage)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dry-run message: %w", err)
+	}
+
+	dryRunURL := fmt.Sprintf("%s/dry-run?process-id=%s", cuURL, processID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dryRunURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("compute unit unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("compute unit error (HTTP %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	var result messageResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to parse dry-run result: %w", err)
+	}
+
+	if len(result.Error) > 0 && string(result.Error) != "null" {
+		return fmt.Errorf("process error: %s", formatResultValue(result.Error))
+	}
+
+	// Prefer the data of the first sent message (Handlers.utils.reply style),
+	// falling back to the evaluation output
+	state := ""
+	if len(result.Messages) > 0 {
+		var reply struct {
+			Data string `json:"Data"`
+		}
+		if err := json.Unmarshal(result.Messages[0], &reply); err == nil {
+			state = reply.Data
+		}
+	}
+	if state == "" {
+		state = formatResultValue(result.Output)
+	}
+	if state == "" || state == "null" {
+		return fmt.Errorf("process returned no state (does it have a %s handler?)", action)
+	}
+
+	// Pretty-print when the state is JSON
+	extension := "txt"
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(state), &decoded); err == nil {
+		pretty, err := json.MarshalIndent(decoded, "", "  ")
+		if err == nil {
+			state = string(pretty)
+			extension = "json"
+		}
+	}
+
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("%s-state.%s", shortProcessID(processID), extension)
+	}
+	if err := os.WriteFile(outputPath, []byte(state), 0644); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	fmt.Println("✅ State downloaded successfully!")
+	fmt.Printf("   📄 Saved to: %s (%d bytes)\n", outputPath, len(state))
+	return nil
+}
+
+// downloadCheckpoint locates the latest memory checkpoint for the process via
+// the gateway GraphQL API and downloads it
+func downloadCheckpoint(ctx context.Context, gatewayURL, processID, outputPath string) error {
+	fmt.Printf("🎭 Locating latest checkpoint for %s\n", processID)
+
+	query := fmt.Sprintf(`{
+		transactions(
+			first: 1
+			sort: HEIGHT_DESC
+			tags: [
+				{ name: "Type", values: ["Checkpoint"] }
+				{ name: "Process", values: [%q] }
+			]
+		) {
+			edges { node { id } }
+		}
+	}`, processID)
+
+	body, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, gatewayURL+"/graphql", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("gateway unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("gateway error (HTTP %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	var queryResult struct {
+		Data struct {
+			Transactions struct {
+				Edges []struct {
+					Node struct {
+						ID string `json:"id"`
+					} `json:"node"`
+				} `json:"edges"`
+			} `json:"transactions"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&queryResult); err != nil {
+		return fmt.Errorf("failed to parse gateway response: %w", err)
+	}
+
+	edges := queryResult.Data.Transactions.Edges
+	if len(edges) == 0 {
+		return fmt.Errorf("no checkpoint found for process %s", processID)
+	}
+	checkpointID := edges[0].Node.ID
+
+	fmt.Printf("📦 Downloading checkpoint %s\n", checkpointID)
+
+	downloadReq, err := http.NewRequestWithContext(ctx, http.MethodGet, gatewayURL+"/"+checkpointID, nil)
+	if err != nil {
+		return err
+	}
+	downloadResp, err := client.Do(downloadReq)
+	if err != nil {
+		return fmt.Errorf("checkpoint download failed: %w", err)
+	}
+	defer downloadResp.Body.Close()
+
+	if downloadResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("checkpoint download failed (HTTP %d)", downloadResp.StatusCode)
+	}
+
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("%s-checkpoint.bin", shortProcessID(processID))
+	}
+	outputFile, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer outputFile.Close()
+
+	written, err := io.Copy(outputFile, downloadResp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint file: %w", err)
+	}
+
+	fmt.Println("✅ Checkpoint downloaded successfully!")
+	fmt.Printf("   📄 Saved to: %s (%d bytes)\n", outputPath, written)
+	fmt.Printf("   🌐 Source: %s/%s\n", gatewayURL, checkpointID)
+	return nil
+}
+
+// shortProcessID shortens a process ID for use in default filenames
+func shortProcessID(processID string) string {
+	if len(processID) > 8 {
+		return processID[:8]
+	}
+	return processID
+}
+
+// PrintStateUsage prints usage information for the state command
+func PrintStateUsage() {
+	fmt.Println("🎭 Harlequin State - Download Process State Snapshots")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin state <PROCESS_ID> [OPTIONS]")
+	fmt.Println()
+	fmt.Println("ARGUMENTS:")
+	fmt.Println("    PROCESS_ID             ID of the deployed AO process")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -a, --action <ACTION>    Handler to dry-run for state (default: State)")
+	fmt.Println("    -o, --output <FILE>      Output file path (default: <pid>-state.json)")
+	fmt.Println("    --checkpoint             Download the latest memory checkpoint instead")
+	fmt.Println("    --cu <URL>               Compute unit URL (default: " + defaultComputeURL + ")")
+	fmt.Println("    --gateway <URL>          Gateway URL for checkpoints (default: " + defaultGatewayURL + ")")
+	fmt.Println("    --network <PRESET>       Endpoint preset: mainnet, testnet, or localnet")
+	fmt.Println("    -h, --help               Show this help message")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    By default, evaluates the process's State handler via a dry-run on the")
+	fmt.Println("    compute unit and saves the decoded output (pretty-printed when JSON).")
+	fmt.Println("    With --checkpoint, locates the latest process memory checkpoint via the")
+	fmt.Println("    gateway and downloads the raw snapshot instead.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    # Save the current state reported by the State handler")
+	fmt.Println("    harlequin state abc123...")
+	fmt.Println()
+	fmt.Println("    # Use a custom handler and output path")
+	fmt.Println("    harlequin state abc123... --action Info --output info.json")
+	fmt.Println()
+	fmt.Println("    # Download the latest raw memory checkpoint")
+	fmt.Println("    harlequin state abc123... --checkpoint")
+}
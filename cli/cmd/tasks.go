@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+)
+
+// HandleRunTaskCommand handles the run-task command for executing named task
+// pipelines defined in .harlequin.yaml
+func HandleRunTaskCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling run-task command with args: %v", args)
+
+	var taskName string
+	var configPath string
+
+	// Parse command line arguments
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--config", "-c":
+			if i+1 < len(args) {
+				configPath = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintRunTaskUsage()
+			return
+		default:
+			if !strings.HasPrefix(args[i], "-") && taskName == "" {
+				taskName = args[i]
+			}
+		}
+	}
+
+	if configPath == "" {
+		if _, err := os.Stat(".harlequin.yaml"); err == nil {
+			configPath = ".harlequin.yaml"
+		} else {
+			fmt.Println("Error: No .harlequin.yaml found. Tasks are defined in the project config.")
+			PrintRunTaskUsage()
+			os.Exit(1)
+		}
+	}
+
+	cfg := config.ReadConfigFile(configPath)
+	if len(cfg.Tasks) == 0 {
+		fmt.Printf("No tasks defined in %s\n", configPath)
+		fmt.Println()
+		fmt.Println("Define tasks like:")
+		fmt.Println()
+		fmt.Println("    tasks:")
+		fmt.Println("      release:")
+		fmt.Println("        - build")
+		fmt.Println("        - upload-module --dry-run")
+		os.Exit(1)
+	}
+
+	if taskName == "" {
+		printTaskList(cfg)
+		return
+	}
+
+	steps, err := cfg.ResolveTaskSteps(taskName)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("🎭 Running task %q (%d steps)\n", taskName, len(steps))
+	fmt.Println()
+
+	for i, step := range steps {
+		fmt.Printf("▶️  [%d/%d] harlequin %s\n", i+1, len(steps), step)
+
+		if err := runTaskStep(ctx, step); err != nil {
+			fmt.Println()
+			fmt.Printf("❌ Task %q failed at step %d: %v\n", taskName, i+1, err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("✓  [%d/%d] harlequin %s\n", i+1, len(steps), step)
+		fmt.Println()
+	}
+
+	fmt.Printf("✅ Task %q completed successfully!\n", taskName)
+}
+
+// runTaskStep runs one task step as a harlequin subcommand
+func runTaskStep(ctx context.Context, step string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate harlequin executable: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, executable, strings.Fields(step)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+
+	return cmd.Run()
+}
+
+// printTaskList lists the tasks defined in the project config
+func printTaskList(cfg *config.Config) {
+	names := make([]string, 0, len(cfg.Tasks))
+	for name := range cfg.Tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println("🎭 Available tasks:")
+	fmt.Println()
+	for _, name := range names {
+		fmt.Printf("    %s\n", name)
+		for _, step := range cfg.Tasks[name] {
+			fmt.Printf("        • %s\n", step)
+		}
+	}
+	fmt.Println()
+	fmt.Println("Run one with: harlequin run-task <TASK>")
+}
+
+// PrintRunTaskUsage prints usage information for the run-task command
+func PrintRunTaskUsage() {
+	fmt.Println("🎭 Harlequin Run Task - Execute Task Pipelines from .harlequin.yaml")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin run-task [TASK] [OPTIONS]")
+	fmt.Println()
+	fmt.Println("ARGUMENTS:")
+	fmt.Println("    TASK                   Name of the task to run (omit to list tasks)")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -c, --config <FILE>      Path to the config file (default: .harlequin.yaml)")
+	fmt.Println("    -h, --help               Show this help message")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    Tasks are named pipelines of harlequin commands declared in the")
+	fmt.Println("    project config. A step naming another task runs that task first,")
+	fmt.Println("    so shared dependencies execute once in the right order.")
+	fmt.Println()
+	fmt.Println("CONFIGURATION:")
+	fmt.Println("    tasks:")
+	fmt.Println("      build-all:")
+	fmt.Println("        - build --entrypoint main.lua")
+	fmt.Println("      release:")
+	fmt.Println("        - build-all")
+	fmt.Println("        - upload-module --version v1.2.3")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    # List defined tasks")
+	fmt.Println("    harlequin run-task")
+	fmt.Println()
+	fmt.Println("    # Run the release pipeline")
+	fmt.Println("    harlequin run-task release")
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/telemetry"
+)
+
+// HandleTelemetryCommand handles the telemetry command for controlling the
+// opt-in anonymous usage metrics
+func HandleTelemetryCommand(ctx context.Context, args []string) {
+	if len(args) == 0 {
+		printTelemetryStatus()
+		return
+	}
+
+	switch args[0] {
+	case "on":
+		if err := telemetry.SetEnabled(true); err != nil {
+			fmt.Printf("❌ Failed to enable telemetry: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("✅ Telemetry enabled")
+		fmt.Println("💡 Inspect what would be sent any time with: harlequin telemetry show")
+	case "off":
+		if err := telemetry.SetEnabled(false); err != nil {
+			fmt.Printf("❌ Failed to disable telemetry: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("✅ Telemetry disabled and queued events discarded")
+	case "status":
+		printTelemetryStatus()
+	case "show":
+		showTelemetryPayload()
+	case "--help", "-h", "help":
+		PrintTelemetryUsage()
+	default:
+		fmt.Printf("Unknown telemetry subcommand: %s\n\n", args[0])
+		PrintTelemetryUsage()
+		os.Exit(1)
+	}
+}
+
+// printTelemetryStatus reports the consent decision and queue size
+func printTelemetryStatus() {
+	fmt.Println("🎭 Telemetry Status")
+	fmt.Println()
+
+	switch {
+	case !telemetry.ConsentRecorded():
+		fmt.Println("   Consent: not decided yet")
+	case telemetry.Enabled():
+		fmt.Println("   Consent: enabled")
+	default:
+		fmt.Println("   Consent: disabled")
+	}
+
+	events, err := telemetry.Pending()
+	if err != nil {
+		fmt.Printf("   Queue:   unreadable (%v)\n", err)
+	} else {
+		fmt.Printf("   Queue:   %d event(s) waiting to be sent\n", len(events))
+	}
+	fmt.Println()
+	fmt.Println("💡 Change it with: harlequin telemetry on|off")
+}
+
+// showTelemetryPayload prints exactly what the next flush would send
+func showTelemetryPayload() {
+	payload, err := telemetry.PendingPayload()
+	if err != nil {
+		fmt.Printf("❌ Failed to read telemetry queue: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(payload.Events) == 0 {
+		fmt.Println("📭 The telemetry queue is empty - nothing would be sent")
+		return
+	}
+
+	encoded, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		fmt.Printf("❌ Failed to encode telemetry payload: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(encoded))
+}
+
+// MaybePromptTelemetryConsent asks the consent question once, in interactive
+// terminals only. Scripts, CI, and redirected output never see the prompt and
+// telemetry stays off until someone answers.
+func MaybePromptTelemetryConsent() {
+	if telemetry.ConsentRecorded() {
+		return
+	}
+	if os.Getenv("CI") != "" {
+		return
+	}
+	// Only prompt when both ends of the conversation are a terminal
+	if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return
+	}
+	if info, err := os.Stdout.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return
+	}
+
+	fmt.Println("🎭 Help improve Harlequin?")
+	fmt.Println("   Anonymous telemetry records which commands run, build durations, and")
+	fmt.Println("   coarse error categories - never file names, wallet data, or code.")
+	fmt.Println("   Inspect the queue any time with: harlequin telemetry show")
+	enabled := promptYesNo("Enable anonymous telemetry?", false)
+
+	if err := telemetry.SetEnabled(enabled); err != nil {
+		debug.Printf("Failed to record telemetry consent: %v", err)
+		return
+	}
+	if enabled {
+		fmt.Println("✅ Telemetry enabled - thank you! Disable any time with: harlequin telemetry off")
+	} else {
+		fmt.Println("✅ Telemetry disabled - you won't be asked again")
+	}
+	fmt.Println()
+}
+
+// PrintTelemetryUsage prints usage information for the telemetry command
+func PrintTelemetryUsage() {
+	fmt.Println("🎭 Harlequin Telemetry Command")
+	fmt.Println()
+	fmt.Println("Control the opt-in anonymous usage metrics")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  harlequin telemetry [subcommand]")
+	fmt.Println()
+	fmt.Println("Subcommands:")
+	fmt.Println("  on       Opt in to anonymous telemetry")
+	fmt.Println("  off      Opt out and discard queued events")
+	fmt.Println("  status   Show the consent decision and queue size (default)")
+	fmt.Println("  show     Print exactly what the next report would contain")
+	fmt.Println()
+	fmt.Println("What is collected (with consent only):")
+	fmt.Println("  • Which commands run, and how long builds take")
+	fmt.Println("  • Coarse error categories (network, docker, config, ...)")
+	fmt.Println("  • Toolkit version, OS, and architecture")
+	fmt.Println()
+	fmt.Println("What is never collected:")
+	fmt.Println("  • File names, file contents, or source code")
+	fmt.Println("  • Wallet addresses or any wallet data")
+	fmt.Println("  • Error messages, URLs, or process IDs")
+	fmt.Println()
+	fmt.Println("Events queue locally and are sent in batches; nothing leaves the")
+	fmt.Println("machine without opt-in, and --offline suppresses sending entirely.")
+	fmt.Println()
+}
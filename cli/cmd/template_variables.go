@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TemplateVariable is one variable a template declares in its template.yaml
+// manifest, prompted for during init and substituted as {{NAME}} throughout
+// the scaffolded files
+type TemplateVariable struct {
+	Name    string `yaml:"name"`
+	Prompt  string `yaml:"prompt"`
+	Default string `yaml:"default"`
+	Pattern string `yaml:"pattern"`
+}
+
+// templateManifest is the template.yaml file shipped inside a template tarball
+type templateManifest struct {
+	Variables []TemplateVariable `yaml:"variables"`
+}
+
+// LoadTemplateVariables reads the variables a template declares in its
+// template.yaml manifest; templates without a manifest declare none
+func LoadTemplateVariables(templateLang string) ([]TemplateVariable, error) {
+	tarballPath := fmt.Sprintf("embedded_templates/%s.tar.gz", templateLang)
+	tarballData, err := embeddedTemplates.ReadFile(tarballPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded template: %w", err)
+	}
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(tarballData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tar header: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg || !isTemplateManifest(header.Name) {
+			continue
+		}
+
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template manifest: %w", err)
+		}
+
+		var manifest templateManifest
+		if err := yaml.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse template.yaml: %w", err)
+		}
+		return manifest.Variables, nil
+	}
+
+	return nil, nil
+}
+
+// isTemplateManifest reports whether a tar entry is the template.yaml manifest
+func isTemplateManifest(name string) bool {
+	return strings.TrimPrefix(name, "./") == "template.yaml"
+}
+
+// ValidateTemplateVariable checks a value against the variable's validation
+// pattern, matching the whole value
+func ValidateTemplateVariable(variable TemplateVariable, value string) error {
+	if variable.Pattern == "" {
+		return nil
+	}
+
+	pattern, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", variable.Pattern))
+	if err != nil {
+		return fmt.Errorf("template declares an invalid pattern for %s: %w", variable.Name, err)
+	}
+
+	if !pattern.MatchString(value) {
+		return fmt.Errorf("value %q for %s does not match pattern %s", value, variable.Name, variable.Pattern)
+	}
+	return nil
+}
+
+// resolveTemplateVariables merges user-provided values with the template's
+// declared variables, applying defaults and validation. Declared variables
+// with neither a value nor a default are an error in non-interactive use.
+func resolveTemplateVariables(declared []TemplateVariable, provided map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(declared))
+
+	for _, variable := range declared {
+		value, ok := provided[variable.Name]
+		if !ok {
+			if variable.Default == "" {
+				return nil, fmt.Errorf("template variable %s is required (pass --var %s=VALUE)", variable.Name, variable.Name)
+			}
+			value = variable.Default
+		}
+		if err := ValidateTemplateVariable(variable, value); err != nil {
+			return nil, err
+		}
+		resolved[variable.Name] = value
+	}
+
+	// Pass through extra values the user supplied beyond the declared ones
+	for name, value := range provided {
+		if _, ok := resolved[name]; !ok {
+			resolved[name] = value
+		}
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	luautils "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/lua_utils"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/emulator"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/snapshot"
+)
+
+// HandleTestCommand handles the test command for snapshot-testing handler
+// responses in the local emulator
+func HandleTestCommand(ctx context.Context, args []string) {
+	debug.Printf("Handling test command with args: %v", args)
+
+	var casesPath string
+	var entrypoint string
+	var record bool
+	var allowCycles bool
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--entrypoint", "-e":
+			if i+1 < len(args) {
+				entrypoint = args[i+1]
+				i++
+			}
+		case "--snapshot", "-s":
+			record = true
+		case "--allow-cycles":
+			allowCycles = true
+		case "--help", "-h":
+			PrintTestUsage()
+			return
+		default:
+			if !strings.HasPrefix(args[i], "-") && casesPath == "" {
+				casesPath = args[i]
+			}
+		}
+	}
+
+	if casesPath == "" {
+		casesPath = "harlequin.test.yaml"
+	}
+	if entrypoint == "" {
+		entrypoint = "main.lua"
+	}
+
+	cases, err := snapshot.LoadCases(casesPath)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	// Bundle once; each case runs in a fresh emulator so state cannot leak
+	// between recordings
+	bundledLua, err := luautils.BundleWithOptions(entrypoint, luautils.BundleOptions{
+		AllowCycles: allowCycles,
+	})
+	if err != nil {
+		fmt.Printf("❌ Bundling failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	if record {
+		fmt.Printf("🎭 Recording %d snapshots from %s\n", len(cases), entrypoint)
+	} else {
+		fmt.Printf("🎭 Checking %d cases against stored snapshots\n", len(cases))
+	}
+	fmt.Println()
+
+	failed := 0
+	for _, testCase := range cases {
+		result, err := emulator.Eval(ctx, bundledLua, emulator.Options{
+			Action: testCase.Action,
+			Data:   testCase.Data,
+			Tags:   testCase.Tags,
+		})
+		if err != nil {
+			fmt.Printf("❌ %s - evaluation failed: %v\n", testCase.Name, err)
+			failed++
+			continue
+		}
+		current := snapshot.FromResult(result)
+
+		if record {
+			if err := snapshot.Save(snapshot.Dir, testCase.Name, current); err != nil {
+				fmt.Printf("❌ %s - %v\n", testCase.Name, err)
+				failed++
+				continue
+			}
+			fmt.Printf("💾 %s\n", testCase.Name)
+			continue
+		}
+
+		stored, exists, err := snapshot.Load(snapshot.Dir, testCase.Name)
+		if err != nil {
+			fmt.Printf("❌ %s - %v\n", testCase.Name, err)
+			failed++
+			continue
+		}
+		if !exists {
+			fmt.Printf("❌ %s - no snapshot recorded (run harlequin test --snapshot)\n", testCase.Name)
+			failed++
+			continue
+		}
+
+		if changes := snapshot.Diff(stored, current); len(changes) > 0 {
+			fmt.Printf("❌ %s - behavior changed:\n", testCase.Name)
+			for _, change := range changes {
+				for _, line := range strings.Split(change, "\n") {
+					fmt.Printf("   %s\n", line)
+				}
+			}
+			failed++
+			continue
+		}
+
+		fmt.Printf("✅ %s\n", testCase.Name)
+	}
+
+	fmt.Println()
+	if record {
+		if failed == 0 {
+			fmt.Printf("✅ Recorded %d snapshots in %s - commit them with your module\n", len(cases), snapshot.Dir)
+		} else {
+			fmt.Printf("⚠️  Recorded %d of %d snapshots\n", len(cases)-failed, len(cases))
+		}
+	} else if failed == 0 {
+		fmt.Printf("✅ %d/%d snapshots match\n", len(cases), len(cases))
+	} else {
+		fmt.Printf("❌ %d/%d cases failed\n", failed, len(cases))
+		fmt.Println("💡 If the new behavior is intended, re-record with harlequin test --snapshot")
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// PrintTestUsage prints usage information for the test command
+func PrintTestUsage() {
+	fmt.Println("🎭 Harlequin Test - Snapshot Tests in the Local Emulator")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin test [CASES.yaml] [OPTIONS]")
+	fmt.Println()
+	fmt.Println("ARGUMENTS:")
+	fmt.Println("    CASES.yaml             Input messages to evaluate (default: harlequin.test.yaml)")
+	fmt.Println()
+	fmt.Println("OPTIONS:")
+	fmt.Println("    -s, --snapshot           Record snapshots instead of checking them")
+	fmt.Println("    -e, --entrypoint <FILE>  Entry Lua file to bundle and load (default: main.lua)")
+	fmt.Println("    --allow-cycles           Bundle circular dependencies with lazy-loading stubs")
+	fmt.Println("    -h, --help               Show this help message")
+	fmt.Println()
+	fmt.Println("CASES FORMAT:")
+	fmt.Println("    cases:")
+	fmt.Println("      - name: info responds")
+	fmt.Println("        action: Info")
+	fmt.Println("      - action: Transfer")
+	fmt.Println("        data: '{\"qty\":100}'")
+	fmt.Println("        tags:")
+	fmt.Println("          Recipient: abc123...")
+	fmt.Println()
+	fmt.Println("DESCRIPTION:")
+	fmt.Println("    Each case is evaluated in the embedded emulator. With --snapshot the")
+	fmt.Println("    handler response (output, messages, spawns) is recorded under")
+	fmt.Println("    " + snapshot.Dir + "; without it, responses are diffed against the")
+	fmt.Println("    stored recordings so behavioral changes surface before deployment.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    harlequin test --snapshot")
+	fmt.Println("    harlequin test")
+	fmt.Println("    harlequin test cases/token.yaml -e src/process.lua")
+}
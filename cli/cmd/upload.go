@@ -1,46 +1,49 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"log/slog"
 	"math/big"
+	"net/http"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/everFinance/goar/utils"
 	"github.com/project-kardeshev/go-ardrive-turbo/pkg/signers"
 	"github.com/project-kardeshev/go-ardrive-turbo/pkg/turbo"
 	"github.com/project-kardeshev/go-ardrive-turbo/pkg/types"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/build/builders"
+	harlequinconfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
 	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/logging"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/outbox"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/retry"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/wallet"
 	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/wasm"
 	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/tui"
-	"gopkg.in/yaml.v2"
 )
 
-// BuildConfig represents the structure of the build configuration file
-type BuildConfig struct {
-	ComputeLimit    string `yaml:"compute_limit"`
-	MaximumMemory   int    `yaml:"maximum_memory"`
-	ModuleFormat    string `yaml:"module_format"`
-	AOSGitHash      string `yaml:"aos_git_hash"`
-	DataProtocol    string `yaml:"data_protocol,omitempty"`
-	Variant         string `yaml:"variant,omitempty"`
-	Type            string `yaml:"type,omitempty"`
-	InputEncoding   string `yaml:"input_encoding,omitempty"`
-	OutputEncoding  string `yaml:"output_encoding,omitempty"`
-	ContentType     string `yaml:"content_type,omitempty"`
-	AppName         string `yaml:"app_name,omitempty"`
-	AppVersion      string `yaml:"app_version,omitempty"`
-	Author          string `yaml:"author,omitempty"`
-}
-
 // HandleUploadCommand handles the upload command for uploading built modules to Arweave
 func HandleUploadCommand(ctx context.Context, args []string) {
 	debug.Printf("Handling upload command with args: %v", args)
 
+	// upload resume retries submission of signed items queued in the outbox
+	if len(args) > 0 && args[0] == "resume" {
+		handleUploadResume(ctx, args[1:])
+		return
+	}
+
 	// If no arguments provided, run interactive mode
 	if len(args) == 0 {
 		err := runInteractiveUpload(ctx)
@@ -52,17 +55,40 @@ func HandleUploadCommand(ctx context.Context, args []string) {
 	}
 
 	var dryRun bool
+	var force bool
+	var confirmOverBudget bool
+	var useL1 bool
+	var jsonOut bool
+	var withManifest bool
 	var walletPath string
 	var wasmPath string
 	var configPath string
 	var version string
 	var gitHash string
+	var packages []string
+	var allPackages bool
+	var gatewayURL string
+	var bundlerURL string
+	var networkPreset string
+	var waitForSeed bool
+	seedTimeout := "5m"
+	customTags := map[string]string{}
 
 	// Parse command line arguments
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
 		case "--dry-run":
 			dryRun = true
+		case "--force":
+			force = true
+		case "--confirm-over-budget":
+			confirmOverBudget = true
+		case "--l1":
+			useL1 = true
+		case "--json":
+			jsonOut = true
+		case "--with-manifest":
+			withManifest = true
 		case "--wallet-file", "-w":
 			if i+1 < len(args) {
 				walletPath = args[i+1]
@@ -88,6 +114,45 @@ func HandleUploadCommand(ctx context.Context, args []string) {
 				gitHash = args[i+1]
 				i++
 			}
+		case "--package", "-p":
+			if i+1 < len(args) {
+				packages = append(packages, args[i+1])
+				i++
+			}
+		case "--tag", "-t":
+			if i+1 < len(args) {
+				key, value, err := parseTagFlag(args[i+1])
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				customTags[key] = value
+				i++
+			}
+		case "--all":
+			allPackages = true
+		case "--gateway":
+			if i+1 < len(args) {
+				gatewayURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--bundler":
+			if i+1 < len(args) {
+				bundlerURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--network":
+			if i+1 < len(args) {
+				networkPreset = args[i+1]
+				i++
+			}
+		case "--wait-for-seed":
+			waitForSeed = true
+		case "--seed-timeout":
+			if i+1 < len(args) {
+				seedTimeout = args[i+1]
+				i++
+			}
 		case "--help", "-h":
 			PrintUploadUsage()
 			return
@@ -102,7 +167,11 @@ func HandleUploadCommand(ctx context.Context, args []string) {
 	if wasmPath == "" {
 		wasmPath = "dist/process.wasm"
 	}
-	if configPath == "" {
+
+	// Lua sources and directories skip the module flow (and its build config)
+	uploadKind := detectUploadKind(wasmPath)
+
+	if configPath == "" && uploadKind == uploadKindModule {
 		// Try common config locations
 		if _, err := os.Stat(".harlequin.yaml"); err == nil {
 			configPath = ".harlequin.yaml"
@@ -133,15 +202,96 @@ func HandleUploadCommand(ctx context.Context, args []string) {
 		}
 	}
 
-	err := uploadModule(ctx, wasmPath, configPath, walletPath, version, gitHash, dryRun)
+	network := resolveNetwork(networkFlags{Preset: networkPreset, Gateway: gatewayURL, Bundler: bundlerURL})
+
+	// Resolve the seeding wait: zero means report success as soon as the
+	// bundler accepts the upload
+	var seedWait time.Duration
+	if waitForSeed {
+		duration, err := time.ParseDuration(seedTimeout)
+		if err != nil {
+			fmt.Printf("Error: invalid --seed-timeout value %q (use e.g. 90s, 10m)\n", seedTimeout)
+			os.Exit(1)
+		}
+		seedWait = duration
+	}
+
+	// --json replaces the formatted report with one parseable document, so
+	// it only makes sense for a single module upload
+	if jsonOut {
+		if allPackages || len(packages) > 0 {
+			fmt.Println("Error: --json is not supported with --package/--all; upload one module at a time")
+			os.Exit(1)
+		}
+		if uploadKind != uploadKindModule {
+			fmt.Println("Error: --json is only supported for module uploads")
+			os.Exit(1)
+		}
+		uploadJSON = &uploadReport{DryRun: dryRun}
+		logging.SetQuiet(true)
+	}
+	uploadStart := time.Now()
+
+	// Type-aware dispatch: bundled Lua sources and directories (frontends)
+	// get their own tag sets instead of the module flow
+	switch uploadKind {
+	case uploadKindLua:
+		if err := uploadLuaArtifact(ctx, wasmPath, walletPath, version, gitHash, dryRun, customTags, network, seedWait); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	case uploadKindDirectory:
+		if err := uploadDirectoryArtifact(ctx, wasmPath, walletPath, dryRun, customTags, network, seedWait); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Workspace mode: upload the built module of each selected package
+	if allPackages || len(packages) > 0 {
+		cfg := harlequinconfig.ReadConfigFile(configPath)
+		ordered, err := cfg.ResolveWorkspacePackages(packages)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		for _, pkg := range ordered {
+			outputDir := pkg.OutputDir
+			if outputDir == "" {
+				outputDir = "dist"
+			}
+			pkgWasmPath := filepath.Join(outputDir, "process.wasm")
+
+			fmt.Printf("🎭 Uploading workspace package %s (%s)\n", pkg.Name, pkgWasmPath)
+			if err := uploadModule(ctx, pkgWasmPath, configPath, walletPath, version, gitHash, dryRun, force, confirmOverBudget, useL1, withManifest, customTags, network, seedWait); err != nil {
+				fmt.Printf("Error: Package %s failed: %v\n", pkg.Name, err)
+				os.Exit(1)
+			}
+		}
+		return
+	}
+
+	err := uploadModule(ctx, wasmPath, configPath, walletPath, version, gitHash, dryRun, force, confirmOverBudget, useL1, withManifest, customTags, network, seedWait)
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
+
+	if err := emitUploadReport(uploadStart); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
 }
 
 // runInteractiveUpload runs the interactive TUI for module upload
 func runInteractiveUpload(ctx context.Context) error {
+	if PlainFallback() {
+		return runPlainUpload(ctx)
+	}
+
 	// Use the main TUI which has the enhanced balance checking
 	return runMainTUIForUpload(ctx)
 }
@@ -182,44 +332,44 @@ func formatCreditsDisplay(winston string) string {
 }
 
 // uploadModule uploads the WASM module to Arweave using the Turbo client
-func uploadModule(ctx context.Context, wasmPath, configPath, walletPath, version, gitHash string, dryRun bool) error {
+func uploadModule(ctx context.Context, wasmPath, configPath, walletPath, version, gitHash string, dryRun, force, confirmOverBudget, useL1, withManifest bool, customTags map[string]string, network harlequinconfig.NetworkConfig, seedWait time.Duration) error {
 	// Read WASM binary
 	wasmBinary, err := ioutil.ReadFile(wasmPath)
 	if err != nil {
 		return fmt.Errorf("failed to read WASM file %s: %w", wasmPath, err)
 	}
 
-	fmt.Println("🎭 ===============================================")
-	fmt.Println("   HARLEQUIN MODULE UPLOAD")
-	fmt.Println("   ===============================================")
-	fmt.Println()
+	logging.Println("🎭 ===============================================")
+	logging.Println("   HARLEQUIN MODULE UPLOAD")
+	logging.Println("   ===============================================")
+	logging.Println()
 
 	// File Analysis Section
-	fmt.Println("📦 FILE ANALYSIS")
-	fmt.Println("   ───────────────")
-	fmt.Printf("   • WASM File: %s\n", wasmPath)
-	fmt.Printf("   • File Size: %s (%d bytes)\n", wasm.FormatMemorySize(uint32(len(wasmBinary))), len(wasmBinary))
+	logging.Println("📦 FILE ANALYSIS")
+	logging.Println("   ───────────────")
+	logging.Printf("   • WASM File: %s\n", wasmPath)
+	logging.Printf("   • File Size: %s (%d bytes)\n", wasm.FormatMemorySize(uint32(len(wasmBinary))), len(wasmBinary))
 
 	// Parse WASM metadata
 	wasmInfo, err := wasm.ParseWasmBinary(wasmBinary)
 	if err != nil {
-		fmt.Printf("   ⚠️  Could not parse WASM metadata: %v\n", err)
+		logging.Printf("   ⚠️  Could not parse WASM metadata: %v\n", err)
 		wasmInfo = nil
 	} else {
-		fmt.Println("   • WASM Metadata:")
+		logging.Println("   • WASM Metadata:")
 		if wasmInfo.InitialMemory > 0 {
-			fmt.Printf("     - Initial Memory: %s\n", wasm.FormatMemorySize(wasmInfo.InitialMemory))
+			logging.Printf("     - Initial Memory: %s\n", wasm.FormatMemorySize(wasmInfo.InitialMemory))
 		}
 		if wasmInfo.MaxMemory > 0 {
-			fmt.Printf("     - Maximum Memory: %s\n", wasm.FormatMemorySize(wasmInfo.MaxMemory))
+			logging.Printf("     - Maximum Memory: %s\n", wasm.FormatMemorySize(wasmInfo.MaxMemory))
 		}
 		if wasmInfo.StackSize > 0 {
-			fmt.Printf("     - Stack Size: %s\n", wasm.FormatMemorySize(wasmInfo.StackSize))
+			logging.Printf("     - Stack Size: %s\n", wasm.FormatMemorySize(wasmInfo.StackSize))
 		}
-		fmt.Printf("     - Target: %s\n", wasmInfo.Target)
+		logging.Printf("     - Target: %s\n", wasmInfo.Target)
 
 		// Display runtime structure
-		fmt.Printf("     - Functions: %d", wasmInfo.FunctionCount)
+		logging.Printf("     - Functions: %d", wasmInfo.FunctionCount)
 		if len(wasmInfo.Imports) > 0 {
 			importFuncs := 0
 			for _, imp := range wasmInfo.Imports {
@@ -228,16 +378,16 @@ func uploadModule(ctx context.Context, wasmPath, configPath, walletPath, version
 				}
 			}
 			if importFuncs > 0 {
-				fmt.Printf(" (%d imported)", importFuncs)
+				logging.Printf(" (%d imported)", importFuncs)
 			}
 		}
-		fmt.Printf("\n")
+		logging.Printf("\n")
 
 		if wasmInfo.GlobalCount > 0 {
-			fmt.Printf("     - Globals: %d\n", wasmInfo.GlobalCount)
+			logging.Printf("     - Globals: %d\n", wasmInfo.GlobalCount)
 		}
 		if wasmInfo.TableCount > 0 {
-			fmt.Printf("     - Tables: %d\n", wasmInfo.TableCount)
+			logging.Printf("     - Tables: %d\n", wasmInfo.TableCount)
 		}
 
 		// Display key exports
@@ -257,72 +407,100 @@ func uploadModule(ctx context.Context, wasmPath, configPath, walletPath, version
 				}
 			}
 
-			fmt.Printf("     - Exports: %d total\n", len(wasmInfo.Exports))
+			logging.Printf("     - Exports: %d total\n", len(wasmInfo.Exports))
 			if len(funcExports) > 0 {
-				fmt.Printf("       • Functions: %s", funcExports[0])
+				logging.Printf("       • Functions: %s", funcExports[0])
 				if len(funcExports) > 1 {
-					fmt.Printf(" (+%d more)", len(funcExports)-1)
+					logging.Printf(" (+%d more)", len(funcExports)-1)
 				}
-				fmt.Printf("\n")
+				logging.Printf("\n")
 			}
 			if len(memoryExports) > 0 {
-				fmt.Printf("       • Memory: %s\n", memoryExports[0])
+				logging.Printf("       • Memory: %s\n", memoryExports[0])
 			}
 			if len(otherExports) > 0 {
-				fmt.Printf("       • Other: %s", otherExports[0])
+				logging.Printf("       • Other: %s", otherExports[0])
 				if len(otherExports) > 1 {
-					fmt.Printf(" (+%d more)", len(otherExports)-1)
+					logging.Printf(" (+%d more)", len(otherExports)-1)
 				}
-				fmt.Printf("\n")
+				logging.Printf("\n")
 			}
 		}
 
 		if len(wasmInfo.CustomSections) > 0 {
-			fmt.Printf("     - Custom Sections: %d found\n", len(wasmInfo.CustomSections))
+			logging.Printf("     - Custom Sections: %d found\n", len(wasmInfo.CustomSections))
 		}
 	}
-	fmt.Println()
+	logging.Println()
 
 	// Configuration Section
-	fmt.Println("📋 CONFIGURATION")
-	fmt.Println("   ─────────────")
-	configContent, err := ioutil.ReadFile(configPath)
+	logging.Println("📋 CONFIGURATION")
+	logging.Println("   ─────────────")
+	// The canonical config schema keeps publishing keys under upload:, while
+	// legacy ao-build-config.yml files keep them at the top level - the
+	// config package accepts both
+	config, err := harlequinconfig.ReadUploadConfig(configPath)
 	if err != nil {
 		return fmt.Errorf("failed to read config file %s: %w", configPath, err)
 	}
 
-	var config BuildConfig
-	err = yaml.Unmarshal(configContent, &config)
-	if err != nil {
-		return fmt.Errorf("failed to parse config file: %w", err)
+	logging.Printf("   • Config File: %s\n", configPath)
+	logging.Printf("   • Compute Limit: %s\n", config.ComputeLimit)
+	logging.Printf("   • Module Format: %s\n", config.ModuleFormat)
+	if config.AOSGitHash != "" {
+		logging.Printf("   • AOS Git Hash: %s\n", config.AOSGitHash)
 	}
+	logging.Println()
 
-	fmt.Printf("   • Config File: %s\n", configPath)
-	fmt.Printf("   • Compute Limit: %s\n", config.ComputeLimit)
-	fmt.Printf("   • Module Format: %s\n", config.ModuleFormat)
-	if config.AOSGitHash != "" {
-		fmt.Printf("   • AOS Git Hash: %s\n", config.AOSGitHash)
+	// Refuse to upload a module AO cannot load - a bad module would otherwise
+	// only fail once the first message is evaluated on-chain
+	if wasmInfo != nil && config.ModuleFormat != "" {
+		if err := wasm.ValidateForAO(wasmInfo, config.ModuleFormat); err != nil {
+			return fmt.Errorf("refusing to upload: %w", err)
+		}
+	}
+
+	// Size guardrail: oversized modules are almost always unstripped or debug
+	// builds, and uploads cost credits
+	maxUpload := config.MaxUploadSize
+	if maxUpload == 0 {
+		maxUpload = harlequinconfig.DefaultMaxUploadSize
+	}
+	if len(wasmBinary) > maxUpload {
+		if !force {
+			return fmt.Errorf("refusing to upload: %s is %s, over the %s cap (raise max_upload_size in the config or pass --force)",
+				wasmPath, wasm.FormatMemorySize(uint32(len(wasmBinary))), wasm.FormatMemorySize(uint32(maxUpload)))
+		}
+		logging.Printf("   ⚠️  %s exceeds the %s upload cap - continuing because of --force\n",
+			wasmPath, wasm.FormatMemorySize(uint32(maxUpload)))
+	}
+
+	// Metered formats need the gas hook the metering pass injects; without it
+	// the module loads but never charges gas
+	if wasmInfo != nil && strings.Contains(config.ModuleFormat, "metering") && !wasm.HasMeteringImport(wasmInfo) {
+		logging.Printf("   ⚠️  Module format %s is metered but the binary imports no gas hook (usegas) - was it built without metering injection?\n",
+			config.ModuleFormat)
 	}
-	fmt.Println()
 
 	// Create publishing tags - prefer WASM metadata over config where available
 	publishingTags := map[string]string{
 		// AO tags
-		"Data-Protocol":    getOrDefault(config.DataProtocol, "ao"),
-		"Variant":          getOrDefault(config.Variant, "ao.TN.1"),
-		"Type":             getOrDefault(config.Type, "Module"),
-		"Input-Encoding":   getOrDefault(config.InputEncoding, "JSON-1"),
-		"Output-Encoding":  getOrDefault(config.OutputEncoding, "JSON-1"),
-		"Content-Type":     getOrDefault(config.ContentType, "application/wasm"),
+		"Data-Protocol":    getOrDefault(config.Upload.DataProtocol, "ao"),
+		"Variant":          getOrDefault(config.Upload.Variant, "ao.TN.1"),
+		"Type":             getOrDefault(config.Upload.Type, "Module"),
+		"Input-Encoding":   getOrDefault(config.Upload.InputEncoding, "JSON-1"),
+		"Output-Encoding":  getOrDefault(config.Upload.OutputEncoding, "JSON-1"),
+		"Content-Type":     getOrDefault(config.Upload.ContentType, "application/wasm"),
 		"Compute-Limit":    config.ComputeLimit,
 		"Module-Format":    config.ModuleFormat,
 		// Extra tags
-		"App-Name":         getOrDefault(config.AppName, "Harlequin-CLI"),
-		"App-Version":      getOrDefault(config.AppVersion, "1.0.0"),
+		"App-Name":         getOrDefault(config.Upload.AppName, "Harlequin-CLI"),
+		"App-Version":      getOrDefault(config.Upload.AppVersion, "1.0.0"),
 		"AO-Module-Version": version,
-		"Author":           getOrDefault(config.Author, "Harlequin Toolkit"),
+		"Author":           getOrDefault(config.Upload.Author, "Harlequin Toolkit"),
 		"Git-Hash":         gitHash,
 		"AOS-Git-Hash":     config.AOSGitHash,
+		"AOS-Flavour":      getOrDefault(config.Flavour, "aos"),
 	}
 
 	// Use WASM metadata for memory configuration if available
@@ -435,6 +613,49 @@ func uploadModule(ctx context.Context, wasmPath, configPath, walletPath, version
 		}
 	}
 
+	// Attach build provenance tags when a manifest describing this exact
+	// binary sits next to it - makes deployed modules auditable on-chain
+	manifest, manifestPath := locateBuildManifest(wasmPath, wasmBinary)
+	if manifest != nil {
+		manifestHash, err := builders.FileSHA256(manifestPath)
+		if err != nil {
+			return fmt.Errorf("failed to hash build manifest: %w", err)
+		}
+		publishingTags["Build-Manifest"] = manifestHash
+		publishingTags["Source-Hash"] = manifest.BundledLuaSHA256
+		publishingTags["Toolkit-Version"] = manifest.ToolkitVersion
+	} else if withManifest {
+		return fmt.Errorf("--with-manifest requires a %s matching %s - rebuild with harlequin build first", builders.ManifestFileName, wasmPath)
+	}
+
+	// Attach the generated process ABI when the project has one, so clients
+	// can discover the module's actions on-chain. The tag starts as the ABI
+	// hash and is rewritten to its data item ID once uploaded.
+	if _, err := os.Stat(ProcessABIFileName); err == nil {
+		abiHash, err := builders.FileSHA256(ProcessABIFileName)
+		if err != nil {
+			return fmt.Errorf("failed to hash %s: %w", ProcessABIFileName, err)
+		}
+		publishingTags["Process-ABI"] = abiHash
+	}
+
+	// Merge the per-project tag preset, then explicit --tag values - user
+	// provided tags always win over the generated ones
+	presetTags, err := harlequinconfig.LoadUploadTags(".")
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", harlequinconfig.UploadTagsFileName, err)
+	}
+	overrideTags := map[string]string{}
+	for key, value := range presetTags {
+		overrideTags[key] = value
+	}
+	for key, value := range customTags {
+		overrideTags[key] = value
+	}
+	for key, value := range overrideTags {
+		publishingTags[key] = value
+	}
+
 	// Remove empty values
 	for key, value := range publishingTags {
 		if value == "" {
@@ -443,47 +664,47 @@ func uploadModule(ctx context.Context, wasmPath, configPath, walletPath, version
 	}
 
 	// Tags Section
-	fmt.Println("🏷️  UPLOAD TAGS")
-	fmt.Println("   ──────────")
+	logging.Println("🏷️  UPLOAD TAGS")
+	logging.Println("   ──────────")
 
 	// Organize tags by category
 	aaTags := []string{"Data-Protocol", "Variant", "Type", "Input-Encoding", "Output-Encoding", "Content-Type"}
 	memoryTags := []string{"Memory-Limit", "Initial-Memory", "Stack-Size", "Compute-Limit"}
-	buildTags := []string{"Module-Format", "Target", "AO-Module-Version", "Git-Hash", "AOS-Git-Hash"}
-	runtimeTags := []string{"Function-Count", "Export-Count", "Key-Exports", "Exported-Functions", "Exported-Globals", "Exported-Memory", "Exported-Tables"}
+	buildTags := []string{"Module-Format", "Target", "AO-Module-Version", "Git-Hash", "AOS-Git-Hash", "AOS-Flavour", "Build-Manifest", "Source-Hash", "Toolkit-Version"}
+	runtimeTags := []string{"Function-Count", "Export-Count", "Key-Exports", "Exported-Functions", "Exported-Globals", "Exported-Memory", "Exported-Tables", "Process-ABI"}
 	appTags := []string{"App-Name", "App-Version", "Author"}
 
-	fmt.Println("   • AO Protocol:")
+	logging.Println("   • AO Protocol:")
 	for _, tag := range aaTags {
 		if value, exists := publishingTags[tag]; exists {
-			fmt.Printf("     - %s: %s\n", tag, value)
+			logging.Printf("     - %s: %s\n", tag, value)
 		}
 	}
 
-	fmt.Println("   • Memory & Performance:")
+	logging.Println("   • Memory & Performance:")
 	for _, tag := range memoryTags {
 		if value, exists := publishingTags[tag]; exists {
 			if strings.HasSuffix(value, "-b") {
 				// Format byte values nicely
 				if bytes, err := strconv.Atoi(strings.TrimSuffix(value, "-b")); err == nil {
-					fmt.Printf("     - %s: %s\n", tag, wasm.FormatMemorySize(uint32(bytes)))
+					logging.Printf("     - %s: %s\n", tag, wasm.FormatMemorySize(uint32(bytes)))
 				} else {
-					fmt.Printf("     - %s: %s\n", tag, value)
+					logging.Printf("     - %s: %s\n", tag, value)
 				}
 			} else {
-				fmt.Printf("     - %s: %s\n", tag, value)
+				logging.Printf("     - %s: %s\n", tag, value)
 			}
 		}
 	}
 
-	fmt.Println("   • Build Information:")
+	logging.Println("   • Build Information:")
 	for _, tag := range buildTags {
 		if value, exists := publishingTags[tag]; exists {
-			fmt.Printf("     - %s: %s\n", tag, value)
+			logging.Printf("     - %s: %s\n", tag, value)
 		}
 	}
 
-	fmt.Println("   • Runtime Metadata:")
+	logging.Println("   • Runtime Metadata:")
 	for _, tag := range runtimeTags {
 		if value, exists := publishingTags[tag]; exists {
 			// Format JSON arrays nicely for display
@@ -492,45 +713,84 @@ func uploadModule(ctx context.Context, wasmPath, configPath, walletPath, version
 				var items []string
 				if err := json.Unmarshal([]byte(value), &items); err == nil {
 					if len(items) <= 3 {
-						fmt.Printf("     - %s: %s\n", tag, value)
+						logging.Printf("     - %s: %s\n", tag, value)
 					} else {
 						// Show first few items + count for long arrays
 						preview := fmt.Sprintf("[\"%s\", \"%s\", \"%s\" ... +%d more]",
 							items[0], items[1], items[2], len(items)-3)
-						fmt.Printf("     - %s: %s\n", tag, preview)
-						fmt.Printf("       (Full list: %s)\n", value)
+						logging.Printf("     - %s: %s\n", tag, preview)
+						logging.Printf("       (Full list: %s)\n", value)
 					}
 				} else {
-					fmt.Printf("     - %s: %s\n", tag, value)
+					logging.Printf("     - %s: %s\n", tag, value)
 				}
 			} else {
-				fmt.Printf("     - %s: %s\n", tag, value)
+				logging.Printf("     - %s: %s\n", tag, value)
 			}
 		}
 	}
 
-	fmt.Println("   • Application:")
+	logging.Println("   • Application:")
 	for _, tag := range appTags {
 		if value, exists := publishingTags[tag]; exists {
-			fmt.Printf("     - %s: %s\n", tag, value)
+			logging.Printf("     - %s: %s\n", tag, value)
 		}
 	}
-	fmt.Println()
+
+	if len(overrideTags) > 0 {
+		logging.Println("   • Custom:")
+		keys := make([]string, 0, len(overrideTags))
+		for key := range overrideTags {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			logging.Printf("     - %s: %s\n", key, publishingTags[key])
+		}
+	}
+	logging.Println()
+
+	// Snapshot for --json; publishingTags is shared, so tags added later in
+	// the flow (manifest, ABI) appear in the emitted document too
+	recordUpload(func(r *uploadReport) {
+		r.WasmPath = wasmPath
+		r.SizeBytes = len(wasmBinary)
+		r.Tags = publishingTags
+		if wasmInfo != nil {
+			r.Wasm = &uploadReportWasm{
+				Target:        wasmInfo.Target,
+				InitialMemory: wasmInfo.InitialMemory,
+				MaxMemory:     wasmInfo.MaxMemory,
+				StackSize:     wasmInfo.StackSize,
+				FunctionCount: wasmInfo.FunctionCount,
+				ExportCount:   len(wasmInfo.Exports),
+			}
+		}
+	})
 
 	if dryRun {
-		fmt.Println("🌵 DRY RUN MODE")
-		fmt.Println("   ────────────")
-		fmt.Println("   • No actual upload will be performed")
-		fmt.Println("   • All metadata and tags have been generated")
-		fmt.Println("   • Ready for production upload")
-		fmt.Println()
-		fmt.Println("✅ Dry run completed successfully!")
+		// Show what changed since the last recorded upload, so reviewers can
+		// approve releases from the deltas
+		if previous := latestUploadEntry(); previous != nil {
+			printUploadDiff(previous, len(wasmBinary), publishingTags, exportedFunctionNames(wasmInfo))
+		} else {
+			logging.Printf("💡 No previous upload recorded in %s - nothing to diff against\n", uploadLockFileName)
+			logging.Println()
+		}
+
+		logging.Println("🌵 DRY RUN MODE")
+		logging.Println("   ────────────")
+		logging.Println("   • No actual upload will be performed")
+		logging.Println("   • All metadata and tags have been generated")
+		logging.Println("   • Ready for production upload")
+		logging.Println()
+		logging.Println("✅ Dry run completed successfully!")
 		return nil
 	}
 
 	// Wallet Section
-	fmt.Println("🔑 WALLET & AUTHENTICATION")
-	fmt.Println("   ──────────────────────")
+	logging.Println("🔑 WALLET & AUTHENTICATION")
+	logging.Println("   ──────────────────────")
 
 	// Read wallet (only needed for actual uploads)
 	var jwk map[string]interface{}
@@ -539,20 +799,50 @@ func uploadModule(ctx context.Context, wasmPath, configPath, walletPath, version
 		if err != nil {
 			return fmt.Errorf("failed to parse WALLET environment variable: %w", err)
 		}
-		fmt.Println("   • Source: Environment variable")
-	} else {
-		walletContent, err := ioutil.ReadFile(walletPath)
+		logging.Println("   • Source: Environment variable")
+	} else if wallet.Exists(walletPath) {
+		// A stored profile name can be used anywhere a wallet file is expected
+		jwk, err = wallet.Load(walletPath)
 		if err != nil {
-			return fmt.Errorf("failed to read wallet file %s: %w", walletPath, err)
+			return fmt.Errorf("failed to load wallet profile %s: %w", walletPath, err)
 		}
-		err = json.Unmarshal(walletContent, &jwk)
-		if err != nil {
-			return fmt.Errorf("failed to parse wallet file: %w", err)
+		logging.Printf("   • Source: Wallet profile %s\n", walletPath)
+	} else {
+		walletContent, readErr := ioutil.ReadFile(walletPath)
+		if readErr != nil {
+			// Fall back to the active wallet profile when no wallet file exists
+			active, _ := wallet.Current()
+			if active == "" {
+				return fmt.Errorf("failed to read wallet file %s: %w", walletPath, readErr)
+			}
+			jwk, err = wallet.Load(active)
+			if err != nil {
+				return fmt.Errorf("failed to load wallet profile %s: %w", active, err)
+			}
+			logging.Printf("   • Source: Wallet profile %s (active)\n", active)
+		} else {
+			walletContent, err = decryptWalletIfNeeded(walletContent, walletPath)
+			if err != nil {
+				return err
+			}
+			err = json.Unmarshal(walletContent, &jwk)
+			if err != nil {
+				return fmt.Errorf("failed to parse wallet file: %w", err)
+			}
+			logging.Printf("   • Source: %s\n", walletPath)
 		}
-		fmt.Printf("   • Source: %s\n", walletPath)
 	}
-	fmt.Println("   • Status: ✅ Wallet loaded successfully")
-	fmt.Println()
+	logging.Println("   • Status: ✅ Wallet loaded successfully")
+	logging.Println()
+
+	// L1 fallback: post the module as a regular Arweave transaction instead
+	// of a Turbo data item, with the same publishing tags
+	if useL1 {
+		if withManifest {
+			logging.Println("   ⚠️  --with-manifest uploads Turbo data items; skipping it for the L1 upload")
+		}
+		return uploadModuleL1(ctx, jwk, wasmBinary, publishingTags, wasmInfo, version, gitHash, network.Gateway, seedWait)
+	}
 
 	// Create Arweave signer from JWK
 	signer, err := signers.NewArweaveSigner(jwk)
@@ -560,6 +850,29 @@ func uploadModule(ctx context.Context, wasmPath, configPath, walletPath, version
 		return fmt.Errorf("failed to create Arweave signer: %w", err)
 	}
 
+	// Initialize authenticated Turbo client against the selected bundler
+	turboConfig := turboConfigForNetwork(network)
+	turboClient := turbo.Authenticated(turboConfig, signer)
+
+	// Upload the manifest as its own data item first so the module's
+	// Build-Manifest tag can point at its transaction ID instead of a bare hash
+	if withManifest && manifest != nil {
+		manifestID, err := uploadManifestItem(ctx, turboClient, manifestPath, publishingTags["Build-Manifest"])
+		if err != nil {
+			return fmt.Errorf("failed to upload build manifest: %w", err)
+		}
+		publishingTags["Build-Manifest"] = manifestID
+	}
+
+	// Likewise publish the process ABI as its own data item
+	if abiHash := publishingTags["Process-ABI"]; abiHash != "" {
+		abiID, err := uploadProcessABIItem(ctx, turboClient, ProcessABIFileName, abiHash)
+		if err != nil {
+			return fmt.Errorf("failed to upload process ABI: %w", err)
+		}
+		publishingTags["Process-ABI"] = abiID
+	}
+
 	// Convert tags to turbo format
 	var tags []types.Tag
 	for key, value := range publishingTags {
@@ -569,19 +882,25 @@ func uploadModule(ctx context.Context, wasmPath, configPath, walletPath, version
 		})
 	}
 
-	// Initialize authenticated Turbo client (using default config for production)
-	turboClient := turbo.Authenticated(nil, signer)
-
-	fmt.Println("💰 BALANCE & COST CHECK")
-	fmt.Println("   ─────────────────────")
-	fmt.Println("   • Checking wallet balance...")
-
-	// Check wallet balance
-	balance, err := turboClient.GetBalanceForSigner(ctx)
+	logging.Println("💰 BALANCE & COST CHECK")
+	logging.Println("   ─────────────────────")
+	logging.Println("   • Checking wallet balance...")
+
+	// Check wallet balance. 404s mean a new wallet, not a transient failure,
+	// so they skip the retry budget.
+	var balance *types.Balance
+	err = retry.Queries.Do(ctx, "balance check", func() error {
+		var err error
+		balance, err = turboClient.GetBalanceForSigner(ctx)
+		if err != nil && (strings.Contains(err.Error(), "HTTP 404") || strings.Contains(err.Error(), "User Not Found")) {
+			return retry.Permanent(err)
+		}
+		return err
+	})
 	if err != nil {
 		// Check if it's a 404 User Not Found error - treat as 0 balance
 		if strings.Contains(err.Error(), "HTTP 404") || strings.Contains(err.Error(), "User Not Found") {
-			fmt.Println("   • User not found (new wallet) - treating as 0 balance")
+			logging.Println("   • User not found (new wallet) - treating as 0 balance")
 			balance = &types.Balance{
 				WinC:     "0",
 				Credits:  "0",
@@ -592,34 +911,44 @@ func uploadModule(ctx context.Context, wasmPath, configPath, walletPath, version
 		}
 	}
 
-		fmt.Printf("   • Current Balance: %s\n", formatCreditsDisplay(balance.WinC))
+		logging.Printf("   • Current Balance: %s\n", formatCreditsDisplay(balance.WinC))
+	recordUpload(func(r *uploadReport) { r.BalanceWinc = balance.WinC })
 
 	// Estimate upload cost
-	fmt.Println("   • Estimating upload cost...")
-	unauthenticatedClient := turbo.Unauthenticated(nil)
+	logging.Println("   • Estimating upload cost...")
+	unauthenticatedClient := turbo.Unauthenticated(turboConfig)
 	fileSize := int64(len(wasmBinary))
 	debug.Printf("Requesting upload costs for file size: %d bytes", fileSize)
 
-	uploadCosts, err := unauthenticatedClient.GetUploadCosts(ctx, []int64{fileSize})
+	var uploadCosts []types.UploadCost
+	err = retry.Queries.Do(ctx, "cost estimate", func() error {
+		var err error
+		uploadCosts, err = unauthenticatedClient.GetUploadCosts(ctx, []int64{fileSize})
+		// The known API format bug is deterministic; retrying won't fix it
+		if err != nil && strings.Contains(err.Error(), "json: cannot unmarshal object into Go value of type []types.UploadCost") {
+			return retry.Permanent(err)
+		}
+		return err
+	})
 	if err != nil {
 		debug.Printf("GetUploadCosts API error: %v", err)
 
 		// Check if it's a JSON parsing error - this is a known issue with the API
 		if strings.Contains(err.Error(), "json: cannot unmarshal object into Go value of type []types.UploadCost") {
 			debug.Printf("Known issue: API returned object but expected array")
-			fmt.Printf("   • ⚠️  Unable to estimate upload cost due to API format issue\n")
-			fmt.Printf("   • Continuing with upload - costs will be deducted as normal\n")
-			fmt.Printf("   • Note: This is a known temporary issue with the cost estimation API\n")
+			logging.Printf("   • ⚠️  Unable to estimate upload cost due to API format issue\n")
+			logging.Printf("   • Continuing with upload - costs will be deducted as normal\n")
+			logging.Printf("   • Note: This is a known temporary issue with the cost estimation API\n")
 
 			// Skip cost validation and proceed with upload
-			fmt.Println()
-			fmt.Println("🚀 UPLOAD PROCESS")
-			fmt.Println("   ──────────────")
-			fmt.Println("   • Initializing Turbo client...")
-			fmt.Println("   • Preparing data item for upload...")
+			logging.Println()
+			logging.Println("🚀 UPLOAD PROCESS")
+			logging.Println("   ──────────────")
+			logging.Println("   • Initializing Turbo client...")
+			logging.Println("   • Preparing data item for upload...")
 
 			// Jump to upload logic bypassing cost validation
-			return continueUploadWithoutCostCheck(ctx, turboClient, wasmBinary, publishingTags)
+			return continueUploadWithoutCostCheck(ctx, turboClient, wasmBinary, publishingTags, wasmPath, network, seedWait)
 		}
 
 		return fmt.Errorf("failed to estimate upload cost: %w", err)
@@ -630,7 +959,8 @@ func uploadModule(ctx context.Context, wasmPath, configPath, walletPath, version
 	}
 
 	estimatedCost := uploadCosts[0].Winc
-	fmt.Printf("   • Estimated Cost: %s\n", formatCreditsDisplay(estimatedCost))
+	logging.Printf("   • Estimated Cost: %s\n", formatCreditsDisplay(estimatedCost))
+	recordUpload(func(r *uploadReport) { r.EstimatedCostWinc = estimatedCost })
 
 	// Parse balance and cost as integers for comparison
 	balanceInt, err := strconv.ParseInt(balance.WinC, 10, 64)
@@ -643,87 +973,107 @@ func uploadModule(ctx context.Context, wasmPath, configPath, walletPath, version
 		return fmt.Errorf("failed to parse cost estimate: %w", err)
 	}
 
+	// Enforce the project's cost budget, if the config declares one
+	if config.MaxUploadCost != "" {
+		paymentURL := turbo.DefaultConfig().PaymentURL
+		if turboConfig != nil {
+			paymentURL = turboConfig.PaymentURL
+		}
+		budgetWinc, err := harlequinconfig.ResolveMaxUploadCost(ctx, paymentURL, config.MaxUploadCost)
+		if err != nil {
+			return fmt.Errorf("invalid max_upload_cost in config: %w", err)
+		}
+		if costInt > budgetWinc {
+			overageStr := strconv.FormatInt(costInt-budgetWinc, 10)
+			logging.Printf("   • ⚠️  Estimated cost exceeds the project budget\n")
+			logging.Printf("   • Budget (%s): %s\n", config.MaxUploadCost, formatCreditsDisplay(strconv.FormatInt(budgetWinc, 10)))
+			logging.Printf("   • Overage: %s\n", formatCreditsDisplay(overageStr))
+			if !confirmOverBudget {
+				logging.Println()
+				return fmt.Errorf("upload cost %s exceeds the project budget of %s (pass --confirm-over-budget to proceed)",
+					formatCreditsDisplay(estimatedCost), config.MaxUploadCost)
+			}
+			logging.Printf("   • Continuing because of --confirm-over-budget\n")
+		}
+	}
+
 		// Check if balance is sufficient
 	if balanceInt < costInt {
 		shortfallStr := strconv.FormatInt(costInt-balanceInt, 10)
-		fmt.Printf("   • ❌ Insufficient balance!\n")
-		fmt.Printf("   • Required: %s\n", formatCreditsDisplay(estimatedCost))
-		fmt.Printf("   • Available: %s\n", formatCreditsDisplay(balance.WinC))
-		fmt.Printf("   • Shortfall: %s\n", formatCreditsDisplay(shortfallStr))
-		fmt.Println()
+		logging.Printf("   • ❌ Insufficient balance!\n")
+		logging.Printf("   • Required: %s\n", formatCreditsDisplay(estimatedCost))
+		logging.Printf("   • Available: %s\n", formatCreditsDisplay(balance.WinC))
+		logging.Printf("   • Shortfall: %s\n", formatCreditsDisplay(shortfallStr))
+		logging.Println()
 		return fmt.Errorf("insufficient wallet balance: need %s, have %s", formatCreditsDisplay(estimatedCost), formatCreditsDisplay(balance.WinC))
 	}
 
 	remainingStr := strconv.FormatInt(balanceInt-costInt, 10)
-	fmt.Printf("   • ✅ Balance sufficient for upload\n")
-	fmt.Printf("   • Remaining after upload: %s\n", formatCreditsDisplay(remainingStr))
-	fmt.Println()
-
-	fmt.Println("🚀 UPLOAD PROCESS")
-	fmt.Println("   ──────────────")
-	fmt.Println("   • Initializing Turbo client...")
-	fmt.Println("   • Preparing data item for upload...")
-
-	// Create upload request
-	uploadRequest := &types.UploadRequest{
-		Data: wasmBinary,
-		Tags: tags,
-		Events: &types.UploadEvents{
-			OnProgress: func(event types.ProgressEvent) {
-				switch event.Step {
-				case "signing":
-					if event.ProcessedBytes == 0 {
-						fmt.Println("   • Signing data item...")
-					}
-				case "uploading":
-					if event.ProcessedBytes == 0 {
-						fmt.Println("   • Uploading to Arweave...")
-					}
-					if event.ProcessedBytes > 0 && event.ProcessedBytes < event.TotalBytes {
-						percentage := float64(event.ProcessedBytes) / float64(event.TotalBytes) * 100
-						fmt.Printf("   • Upload progress: %.1f%% (%s/%s)\n",
-							percentage,
-							wasm.FormatMemorySize(uint32(event.ProcessedBytes)),
-							wasm.FormatMemorySize(uint32(event.TotalBytes)))
-					}
-				}
-			},
-			OnSigningSuccess: func() {
-				fmt.Println("   • ✅ Data signing completed successfully")
-			},
-			OnUploadSuccess: func(result *types.UploadResult) {
-				fmt.Printf("   • 🎉 Upload completed! Transaction ID: %s\n", result.ID)
-			},
-			OnUploadError: func(err error) {
-				fmt.Printf("   • ❌ Upload failed: %v\n", err)
-			},
-		},
+	logging.Printf("   • ✅ Balance sufficient for upload\n")
+	logging.Printf("   • Remaining after upload: %s\n", formatCreditsDisplay(remainingStr))
+	logging.Println()
+
+	logging.Println("🚀 UPLOAD PROCESS")
+	logging.Println("   ──────────────")
+	logging.Println("   • Initializing Turbo client...")
+	logging.Println("   • Preparing data item for upload...")
+
+	// Sign locally, then submit the signed bytes - if the bundler rejects the
+	// item or the process dies mid-submission, the signed item lands in the
+	// outbox instead of being lost
+	dataItemId, err := submitModuleItem(ctx, turboClient, wasmBinary, tags, wasmPath, network)
+	if err != nil {
+		return err
 	}
+	logging.Println()
 
-	// Upload data item
-	result, err := turboClient.Upload(ctx, uploadRequest)
-	if err != nil {
-		return fmt.Errorf("failed to upload WASM binary: %w", err)
+	// Only report success once the gateway can actually serve the module, so
+	// the printed ID is safe to hand straight to a CU
+	if seedWait > 0 {
+		if err := waitForSeeding(ctx, network.Gateway, dataItemId, seedWait); err != nil {
+			return err
+		}
 	}
 
-	dataItemId := result.ID
-	fmt.Println()
+	logging.Println("✅ UPLOAD SUCCESSFUL!")
+	logging.Println("   ─────────────────")
+	logging.Printf("   • Transaction ID: %s\n", dataItemId)
+	logging.Printf("   • Arweave URL: %s/%s\n", network.Gateway, dataItemId)
+	logging.Printf("   • Module Version: %s\n", version)
+	logging.Printf("   • Git Hash: %s\n", gitHash)
+	logging.Println()
+	logging.Println("🎭 Module successfully deployed to Arweave!")
+	logging.Printf("💡 Give it a friendly name: harlequin arns set <name> %s\n", dataItemId)
+	slog.Info("module uploaded", "id", dataItemId, "version", version, "size", len(wasmBinary))
+
+	recordUpload(func(r *uploadReport) {
+		r.TransactionID = dataItemId
+		r.URL = network.Gateway + "/" + dataItemId
+	})
+
+	// Record the upload so the next dry run can diff against it
+	if err := recordUploadLockEntry(uploadLockEntry{
+		TransactionID: dataItemId,
+		UploadedAt:    time.Now().UTC(),
+		SizeBytes:     len(wasmBinary),
+		Tags:          publishingTags,
+		Exports:       exportedFunctionNames(wasmInfo),
+	}); err != nil {
+		logging.Printf("⚠️  Could not update %s: %v\n", uploadLockFileName, err)
+	}
 
-	fmt.Println("✅ UPLOAD SUCCESSFUL!")
-	fmt.Println("   ─────────────────")
-	fmt.Printf("   • Transaction ID: %s\n", dataItemId)
-	fmt.Printf("   • Arweave URL: https://arweave.net/%s\n", dataItemId)
-	fmt.Printf("   • Module Version: %s\n", version)
-	fmt.Printf("   • Git Hash: %s\n", gitHash)
-	fmt.Println()
-	fmt.Println("🎭 Module successfully deployed to Arweave!")
+	// In quiet mode the transaction ID is the only output; --json emits its
+	// own document instead
+	if logging.Quiet() && uploadJSON == nil {
+		fmt.Println(dataItemId)
+	}
 
 	return nil
 }
 
 // continueUploadWithoutCostCheck performs the upload without prior cost validation
 // Used when the cost estimation API is unavailable or returning incorrect format
-func continueUploadWithoutCostCheck(ctx context.Context, turboClient turbo.TurboAuthenticatedClient, wasmBinary []byte, publishingTags map[string]string) error {
+func continueUploadWithoutCostCheck(ctx context.Context, turboClient turbo.TurboAuthenticatedClient, wasmBinary []byte, publishingTags map[string]string, wasmPath string, network harlequinconfig.NetworkConfig, seedWait time.Duration) error {
 	debug.Printf("Performing upload without cost validation")
 
 	// Convert tags to turbo format
@@ -735,59 +1085,360 @@ func continueUploadWithoutCostCheck(ctx context.Context, turboClient turbo.Turbo
 		})
 	}
 
-	// Create upload request
-	uploadRequest := &types.UploadRequest{
-		Data: wasmBinary,
-		Tags: tags,
-		Events: &types.UploadEvents{
-			OnProgress: func(event types.ProgressEvent) {
-				switch event.Step {
-				case "signing":
-					if event.ProcessedBytes == 0 {
-						fmt.Println("   • Signing data item...")
-					}
-				case "uploading":
-					if event.ProcessedBytes == 0 {
-						fmt.Println("   • Uploading to Arweave...")
-					}
-					if event.ProcessedBytes > 0 && event.ProcessedBytes < event.TotalBytes {
-						percentage := float64(event.ProcessedBytes) / float64(event.TotalBytes) * 100
-						fmt.Printf("   • Upload progress: %.1f%% (%s/%s)\n",
-							percentage,
-							wasm.FormatMemorySize(uint32(event.ProcessedBytes)),
-							wasm.FormatMemorySize(uint32(event.TotalBytes)))
-					}
-				}
-			},
-			OnSigningSuccess: func() {
-				fmt.Println("   • ✅ Data signing completed successfully")
-			},
-			OnUploadSuccess: func(result *types.UploadResult) {
-				fmt.Printf("   • 🎉 Upload completed! Transaction ID: %s\n", result.ID)
+	dataItemId, err := submitModuleItem(ctx, turboClient, wasmBinary, tags, wasmPath, network)
+	if err != nil {
+		return err
+	}
+	logging.Println()
+
+	if seedWait > 0 {
+		if err := waitForSeeding(ctx, network.Gateway, dataItemId, seedWait); err != nil {
+			return err
+		}
+	}
+
+	logging.Println("✅ UPLOAD SUCCESSFUL!")
+	logging.Println("   ─────────────────")
+	logging.Printf("   • Transaction ID: %s\n", dataItemId)
+	logging.Printf("   • Arweave URL: %s/%s\n", network.Gateway, dataItemId)
+	logging.Println()
+	logging.Println("🎭 Module successfully deployed to Arweave!")
+	slog.Info("module uploaded", "id", dataItemId, "size", len(wasmBinary))
+
+	recordUpload(func(r *uploadReport) {
+		r.TransactionID = dataItemId
+		r.URL = network.Gateway + "/" + dataItemId
+	})
+
+	// Record the upload so the next dry run can diff against it
+	if err := recordUploadLockEntry(uploadLockEntry{
+		TransactionID: dataItemId,
+		UploadedAt:    time.Now().UTC(),
+		SizeBytes:     len(wasmBinary),
+		Tags:          publishingTags,
+	}); err != nil {
+		logging.Printf("⚠️  Could not update %s: %v\n", uploadLockFileName, err)
+	}
+
+	// In quiet mode the transaction ID is the only output; --json emits its
+	// own document instead
+	if logging.Quiet() && uploadJSON == nil {
+		fmt.Println(dataItemId)
+	}
+
+	return nil
+}
+
+// submitModuleItem signs the module data item locally, then submits the signed
+// bytes to the bundler. The signed item is queued in the outbox before
+// submission and removed once the bundler accepts it, so a crash or failure
+// anywhere in between leaves it recoverable via harlequin upload resume
+// without paying to sign the data again.
+func submitModuleItem(ctx context.Context, turboClient turbo.TurboAuthenticatedClient, wasmBinary []byte, tags []types.Tag, wasmPath string, network harlequinconfig.NetworkConfig) (string, error) {
+	logging.Println("   • Signing data item...")
+	bundleItem, err := turboClient.GetSigner().SignDataItem(ctx, signers.CreateDataItem(wasmBinary, tags, "", ""))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign data item: %w", err)
+	}
+	logging.Println("   • ✅ Data signing completed successfully")
+
+	// Persist the signed item before the submission is in flight: its ID is
+	// deterministic, so the queued copy stays valid even if the process dies
+	// mid-upload
+	queued := true
+	if queueErr := outbox.Enqueue(bundleItem.ItemBinary, outbox.Entry{
+		ID:          bundleItem.Id,
+		Description: wasmPath,
+		Bundler:     network.Bundler,
+		Gateway:     network.Gateway,
+	}); queueErr != nil {
+		queued = false
+		logging.Printf("   ⚠️  Could not queue the signed item in the outbox: %v\n", queueErr)
+	}
+
+	logging.Println("   • Uploading to Arweave...")
+	result, err := submitSignedItem(ctx, turboClient, "module upload", bundleItem.ItemBinary)
+	if err != nil {
+		logging.Printf("   • ❌ Upload failed: %v\n", err)
+		printExplainHints(err.Error())
+		if queued {
+			logging.Printf("   💾 Signed item %s queued in the outbox - run harlequin upload resume to retry\n", bundleItem.Id)
+		}
+		return "", fmt.Errorf("failed to upload WASM binary: %w", err)
+	}
+
+	if queued {
+		if removeErr := outbox.Remove(bundleItem.Id); removeErr != nil {
+			logging.Printf("   ⚠️  Could not remove the accepted item from the outbox: %v\n", removeErr)
+		}
+	}
+
+	logging.Printf("   • 🎉 Upload completed! Transaction ID: %s\n", result.ID)
+	return result.ID, nil
+}
+
+// submitSignedItem posts an already-signed data item to the bundler behind the
+// shared upload retry policy
+func submitSignedItem(ctx context.Context, client turbo.TurboUnauthenticatedClient, operation string, signedItem []byte) (*types.UploadResult, error) {
+	var result *types.UploadResult
+	err := retry.Uploads.Do(ctx, operation, func() error {
+		var err error
+		result, err = client.UploadSignedDataItem(ctx, &types.SignedDataItemUploadRequest{
+			DataItemStreamFactory: func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(signedItem)), nil
 			},
-			OnUploadError: func(err error) {
-				fmt.Printf("   • ❌ Upload failed: %v\n", err)
+			DataItemSizeFactory: func() int64 {
+				return int64(len(signedItem))
 			},
-		},
+		})
+		return err
+	})
+	return result, err
+}
+
+// handleUploadResume retries submission of signed data items queued in the
+// outbox. The items are already signed, so no wallet is needed - only a
+// bundler to submit to.
+func handleUploadResume(ctx context.Context, args []string) {
+	var gatewayURL string
+	var bundlerURL string
+	var networkPreset string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--gateway":
+			if i+1 < len(args) {
+				gatewayURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--bundler":
+			if i+1 < len(args) {
+				bundlerURL = strings.TrimRight(args[i+1], "/")
+				i++
+			}
+		case "--network":
+			if i+1 < len(args) {
+				networkPreset = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintUploadUsage()
+			return
+		}
 	}
 
-	// Upload data item
-	result, err := turboClient.Upload(ctx, uploadRequest)
+	entries, err := outbox.List()
 	if err != nil {
-		return fmt.Errorf("failed to upload WASM binary: %w", err)
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(entries) == 0 {
+		fmt.Println("✨ Outbox is empty - nothing to resume")
+		return
 	}
 
-	dataItemId := result.ID
-	fmt.Println()
+	fmt.Printf("🎭 Resuming %d queued upload(s)\n", len(entries))
 
-	fmt.Println("✅ UPLOAD SUCCESSFUL!")
-	fmt.Println("   ─────────────────")
-	fmt.Printf("   • Transaction ID: %s\n", dataItemId)
-	fmt.Printf("   • Arweave URL: https://arweave.net/%s\n", dataItemId)
-	fmt.Println()
-	fmt.Println("🎭 Module successfully deployed to Arweave!")
+	failed := 0
+	for _, entry := range entries {
+		signedItem, err := outbox.LoadItem(entry.ID)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", entry.ID, err)
+			failed++
+			continue
+		}
 
-	return nil
+		// Endpoints recorded at queue time win unless overridden by flags
+		network := resolveNetwork(networkFlags{Preset: networkPreset, Gateway: gatewayURL, Bundler: bundlerURL})
+		if networkPreset == "" && bundlerURL == "" && entry.Bundler != "" {
+			network.Bundler = entry.Bundler
+		}
+		if networkPreset == "" && gatewayURL == "" && entry.Gateway != "" {
+			network.Gateway = entry.Gateway
+		}
+
+		fmt.Printf("📡 Submitting %s (%s, queued %s)\n",
+			entry.ID, wasm.FormatMemorySize(uint32(entry.SizeBytes)), entry.QueuedAt.Format(time.RFC3339))
+
+		client := turbo.Unauthenticated(turboConfigForNetwork(network))
+		result, err := submitSignedItem(ctx, client, "queued item upload", signedItem)
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", entry.ID, err)
+			failed++
+			continue
+		}
+
+		fmt.Printf("✅ %s accepted - %s/%s\n", result.ID, network.Gateway, result.ID)
+		if err := outbox.Remove(entry.ID); err != nil {
+			fmt.Printf("⚠️  Could not remove %s from the outbox: %v\n", entry.ID, err)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Printf("⚠️  %d item(s) still queued - run harlequin upload resume to retry\n", failed)
+		os.Exit(1)
+	}
+	fmt.Println("🎭 All queued items submitted successfully!")
+}
+
+// waitForSeeding polls the gateway until the uploaded transaction is
+// retrievable, so the reported module ID can be handed straight to a CU. The
+// bundler accepting an upload only means it is queued; seeding to gateways can
+// lag by minutes.
+func waitForSeeding(ctx context.Context, gatewayURL, dataItemId string, timeout time.Duration) error {
+	logging.Println("⏳ SEEDING CHECK")
+	logging.Println("   ─────────────")
+	logging.Printf("   • Waiting for %s/%s to become retrievable (timeout: %s)\n", gatewayURL, dataItemId, timeout)
+
+	deadline := time.Now().Add(timeout)
+	interval := 2 * time.Second
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, gatewayURL+"/"+dataItemId, nil)
+		if err != nil {
+			return fmt.Errorf("failed to build seeding check request: %w", err)
+		}
+
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				logging.Printf("   • ✅ Transaction retrievable after %d check(s)\n", attempt)
+				logging.Println()
+				return nil
+			}
+			logging.Printf("   • Not retrievable yet (HTTP %d, attempt %d)...\n", resp.StatusCode, attempt)
+		} else {
+			logging.Printf("   • Gateway not reachable (attempt %d): %v\n", attempt, err)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("transaction %s was accepted but is not retrievable from %s after %s - it may still be seeding, check %s/%s before deploying",
+				dataItemId, gatewayURL, timeout, gatewayURL, dataItemId)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		// Back off gradually so long waits don't hammer the gateway
+		if interval < 15*time.Second {
+			interval += interval / 2
+		}
+	}
+}
+
+// turboConfigForNetwork maps the resolved bundler endpoint onto the turbo
+// client config. The mainnet and testnet presets use the library's built-in
+// configs with their separate payment services; any other bundler is assumed
+// to serve both APIs from one endpoint (as ao-localnet does).
+func turboConfigForNetwork(network harlequinconfig.NetworkConfig) *turbo.TurboConfig {
+	mainnet, _ := harlequinconfig.NetworkPreset(harlequinconfig.NetworkMainnet)
+	testnet, _ := harlequinconfig.NetworkPreset(harlequinconfig.NetworkTestnet)
+
+	switch network.Bundler {
+	case mainnet.Bundler:
+		return nil
+	case testnet.Bundler:
+		return turbo.DevConfig()
+	default:
+		return &turbo.TurboConfig{
+			PaymentURL: network.Bundler,
+			UploadURL:  network.Bundler,
+		}
+	}
+}
+
+// parseTagFlag splits a --tag argument of the form key=value
+func parseTagFlag(arg string) (string, string, error) {
+	parts := strings.SplitN(arg, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid tag %q: expected key=value", arg)
+	}
+	return parts[0], parts[1], nil
+}
+
+// locateBuildManifest loads the build manifest next to the WASM file, returning
+// nil when there is none or when it describes a different binary
+func locateBuildManifest(wasmPath string, wasmBinary []byte) (*builders.BuildManifest, string) {
+	manifestPath := filepath.Join(filepath.Dir(wasmPath), builders.ManifestFileName)
+	manifest, err := builders.LoadBuildManifest(manifestPath)
+	if err != nil {
+		return nil, ""
+	}
+
+	wasmHash := sha256.Sum256(wasmBinary)
+	if hex.EncodeToString(wasmHash[:]) != manifest.WasmSHA256 {
+		logging.Printf("   ⚠️  Ignoring %s: it describes a different binary\n", manifestPath)
+		return nil, ""
+	}
+
+	return manifest, manifestPath
+}
+
+// uploadManifestItem uploads the build manifest JSON as its own data item and
+// returns its transaction ID
+func uploadManifestItem(ctx context.Context, turboClient turbo.TurboAuthenticatedClient, manifestPath, manifestHash string) (string, error) {
+	manifestData, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read build manifest %s: %w", manifestPath, err)
+	}
+
+	logging.Println("   • Uploading build manifest...")
+	var result *types.UploadResult
+	err = retry.Uploads.Do(ctx, "build manifest upload", func() error {
+		var err error
+		result, err = turboClient.Upload(ctx, &types.UploadRequest{
+			Data: manifestData,
+			Tags: []types.Tag{
+				{Name: "Content-Type", Value: "application/json"},
+				{Name: "Type", Value: "Build-Manifest"},
+				{Name: "App-Name", Value: "Harlequin-CLI"},
+				{Name: "Manifest-Hash", Value: manifestHash},
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	logging.Printf("   • ✅ Build manifest uploaded: %s\n", result.ID)
+	return result.ID, nil
+}
+
+// uploadProcessABIItem uploads the process ABI JSON as its own data item and
+// returns its transaction ID
+func uploadProcessABIItem(ctx context.Context, turboClient turbo.TurboAuthenticatedClient, abiPath, abiHash string) (string, error) {
+	abiData, err := ioutil.ReadFile(abiPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read process ABI %s: %w", abiPath, err)
+	}
+
+	logging.Println("   • Uploading process ABI...")
+	var result *types.UploadResult
+	err = retry.Uploads.Do(ctx, "process ABI upload", func() error {
+		var err error
+		result, err = turboClient.Upload(ctx, &types.UploadRequest{
+			Data: abiData,
+			Tags: []types.Tag{
+				{Name: "Content-Type", Value: "application/json"},
+				{Name: "Type", Value: "Process-ABI"},
+				{Name: "App-Name", Value: "Harlequin-CLI"},
+				{Name: "ABI-Hash", Value: abiHash},
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	logging.Printf("   • ✅ Process ABI uploaded: %s\n", result.ID)
+	return result.ID, nil
 }
 
 // getOrDefault returns the value if not empty, otherwise returns the default
@@ -800,22 +1451,45 @@ func getOrDefault(value, defaultValue string) string {
 
 // PrintUploadUsage prints usage information for the upload command
 func PrintUploadUsage() {
-	fmt.Println("🎭 Harlequin Upload Module - Upload Built Modules to Arweave")
+	fmt.Println("🎭 Harlequin Upload - Upload Build Artifacts to Arweave")
 	fmt.Println()
 	fmt.Println("USAGE:")
-	fmt.Println("    harlequin upload-module                    # Interactive mode")
-	fmt.Println("    harlequin upload-module [WASM_FILE] [OPTIONS]  # Command-line mode")
+	fmt.Println("    harlequin upload                    # Interactive mode")
+	fmt.Println("    harlequin upload [TARGET] [OPTIONS]  # Command-line mode")
+	fmt.Println("    harlequin upload resume [OPTIONS]    # Retry queued uploads from the outbox")
 	fmt.Println()
 	fmt.Println("ARGUMENTS:")
-	fmt.Println("    WASM_FILE              Path to the WASM file to upload (default: dist/process.wasm)")
+	fmt.Println("    TARGET                 What to upload (default: dist/process.wasm):")
+	fmt.Println("                           • a .wasm file uses the full module tag set")
+	fmt.Println("                           • a .lua file (e.g. bundled.lua) is tagged text/x-lua with source tags")
+	fmt.Println("                           • a directory is published behind an Arweave path manifest")
 	fmt.Println()
 	fmt.Println("OPTIONS:")
 	fmt.Println("    -f, --wasm-file <FILE>   Path to the WASM file to upload")
 	fmt.Println("    -c, --config <FILE>      Path to build configuration file")
-	fmt.Println("    -w, --wallet-file <FILE> Path to Arweave wallet JSON file (default: key.json)")
+	fmt.Println("    -w, --wallet-file <FILE> Arweave wallet JSON file or wallet profile name (default: key.json,")
+	fmt.Println("                             falling back to the active wallet profile)")
 	fmt.Println("    -v, --version <VERSION>  Module version for tagging (default: dev)")
 	fmt.Println("    -g, --git-hash <HASH>    Git commit hash for tagging")
+	fmt.Println("    -p, --package <NAME>     Upload a workspace package's built module (repeatable)")
+	fmt.Println("    --all                    Upload the built modules of all workspace packages")
+	fmt.Println("    -t, --tag <KEY=VALUE>    Add or override an upload tag (repeatable)")
+	fmt.Println("    --gateway <URL>          Arweave gateway URL for result links")
+	fmt.Println("    --bundler <URL>          Bundler / upload service URL")
+	fmt.Println("    --network <PRESET>       Endpoint preset: mainnet, testnet, or localnet")
 	fmt.Println("    --dry-run                Show what would be uploaded without actually uploading")
+	fmt.Println("    --json                   Emit one JSON document (metadata, tags, cost, balance,")
+	fmt.Println("                             transaction ID, timing) instead of the formatted report")
+	fmt.Println("    --force                  Upload even when the module exceeds the size cap")
+	fmt.Println("    --confirm-over-budget    Upload even when the estimated cost exceeds the")
+	fmt.Println("                             max_upload_cost budget declared in the config")
+	fmt.Println("    --l1                     Post the module as a regular Arweave L1 transaction")
+	fmt.Println("                             instead of a Turbo data item (fee paid in AR; for")
+	fmt.Println("                             when Turbo is unavailable)")
+	fmt.Println("    --with-manifest          Also upload the build manifest as a linked data item")
+	fmt.Println("    --wait-for-seed          Poll the gateway until the upload is retrievable before")
+	fmt.Println("                             reporting success")
+	fmt.Println("    --seed-timeout <DUR>     How long to wait for seeding (default: 5m)")
 	fmt.Println("    -h, --help               Show this help message")
 	fmt.Println()
 	fmt.Println("ENVIRONMENT VARIABLES:")
@@ -866,6 +1540,22 @@ func PrintUploadUsage() {
 	fmt.Println("    • Git-Hash: (git commit)")
 	fmt.Println("    • AOS-Git-Hash: (from config)")
 	fmt.Println()
+	fmt.Println("    When a build manifest matching the WASM binary is found next to it,")
+	fmt.Println("    provenance tags are added automatically:")
+	fmt.Println("    • Build-Manifest: (manifest hash, or its transaction ID with --with-manifest)")
+	fmt.Println("    • Source-Hash: (sha256 of the bundled Lua source)")
+	fmt.Println("    • Toolkit-Version: (harlequin version that produced the build)")
+	fmt.Println()
+	fmt.Println("    Custom tags can be added with --tag and saved per project in")
+	fmt.Println("    .harlequin-tags.yaml (a flat key/value map). Preset tags and --tag")
+	fmt.Println("    values override the generated ones.")
+	fmt.Println()
+	fmt.Println("OUTBOX:")
+	fmt.Println("    If an upload fails after signing, the signed data item is queued in the")
+	fmt.Println("    outbox under the user config directory. harlequin upload resume retries")
+	fmt.Println("    submission of queued items without re-signing; --network, --bundler, and")
+	fmt.Println("    --gateway override the endpoints recorded when the item was queued.")
+	fmt.Println()
 	fmt.Println("WASM METADATA:")
 	fmt.Println("    The command automatically parses WASM binaries to extract:")
 	fmt.Println("    • Initial memory configuration")
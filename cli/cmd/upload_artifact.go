@@ -0,0 +1,432 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"mime"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/project-kardeshev/go-ardrive-turbo/pkg/signers"
+	"github.com/project-kardeshev/go-ardrive-turbo/pkg/turbo"
+	"github.com/project-kardeshev/go-ardrive-turbo/pkg/types"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/build/builders"
+	harlequinconfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/logging"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/retry"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/wallet"
+)
+
+// Upload target kinds the upload command dispatches on
+const (
+	uploadKindModule    = "module"
+	uploadKindLua       = "lua"
+	uploadKindDirectory = "directory"
+)
+
+// detectUploadKind classifies the upload target: a directory becomes a path
+// manifest upload, a .lua file a tagged source upload, anything else the
+// standard module flow
+func detectUploadKind(path string) string {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return uploadKindDirectory
+	}
+	if strings.HasSuffix(path, ".lua") {
+		return uploadKindLua
+	}
+	return uploadKindModule
+}
+
+// loadWalletForUpload resolves a wallet the same way the module flow does:
+// the WALLET environment variable wins, then a wallet profile name, then a
+// wallet file on disk, then the active wallet profile
+func loadWalletForUpload(walletPath string) (map[string]interface{}, error) {
+	var jwk map[string]interface{}
+
+	if os.Getenv("WALLET") != "" {
+		if err := json.Unmarshal([]byte(os.Getenv("WALLET")), &jwk); err != nil {
+			return nil, fmt.Errorf("failed to parse WALLET environment variable: %w", err)
+		}
+		return jwk, nil
+	}
+
+	if wallet.Exists(walletPath) {
+		jwk, err := wallet.Load(walletPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load wallet profile %s: %w", walletPath, err)
+		}
+		return jwk, nil
+	}
+
+	walletContent, readErr := ioutil.ReadFile(walletPath)
+	if readErr != nil {
+		active, _ := wallet.Current()
+		if active == "" {
+			return nil, fmt.Errorf("failed to read wallet file %s: %w", walletPath, readErr)
+		}
+		jwk, err := wallet.Load(active)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load wallet profile %s: %w", active, err)
+		}
+		return jwk, nil
+	}
+
+	walletContent, readErr = decryptWalletIfNeeded(walletContent, walletPath)
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	if err := json.Unmarshal(walletContent, &jwk); err != nil {
+		return nil, fmt.Errorf("failed to parse wallet file: %w", err)
+	}
+	return jwk, nil
+}
+
+// turboClientForUpload builds an authenticated Turbo client from a resolved
+// wallet
+func turboClientForUpload(walletPath string, network harlequinconfig.NetworkConfig) (turbo.TurboAuthenticatedClient, error) {
+	jwk, err := loadWalletForUpload(walletPath)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := signers.NewArweaveSigner(jwk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Arweave signer: %w", err)
+	}
+	return turbo.Authenticated(turboConfigForNetwork(network), signer), nil
+}
+
+// contentTypeFor maps a file name to the Content-Type tag of its data item.
+// Types the platform mime database misses but the toolkit cares about are
+// pinned explicitly.
+func contentTypeFor(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".lua":
+		return "text/x-lua"
+	case ".wasm":
+		return "application/wasm"
+	case ".md":
+		return "text/markdown"
+	}
+	if contentType := mime.TypeByExtension(filepath.Ext(name)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// mergeUploadTags layers the per-project tag preset and explicit --tag values
+// over the generated tags (user tags always win) and drops empty values
+func mergeUploadTags(publishingTags, customTags map[string]string) error {
+	presetTags, err := harlequinconfig.LoadUploadTags(".")
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", harlequinconfig.UploadTagsFileName, err)
+	}
+	for key, value := range presetTags {
+		publishingTags[key] = value
+	}
+	for key, value := range customTags {
+		publishingTags[key] = value
+	}
+	for key, value := range publishingTags {
+		if value == "" {
+			delete(publishingTags, key)
+		}
+	}
+	return nil
+}
+
+// printUploadTags renders a flat tag listing for the non-module upload flows
+func printUploadTags(publishingTags map[string]string) {
+	logging.Println("🏷️  UPLOAD TAGS")
+	logging.Println("   ──────────")
+	keys := make([]string, 0, len(publishingTags))
+	for key := range publishingTags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		logging.Printf("   • %s: %s\n", key, publishingTags[key])
+	}
+	logging.Println()
+}
+
+// uploadTaggedItem signs and uploads one data item with the given tags and
+// returns its transaction ID
+func uploadTaggedItem(ctx context.Context, turboClient turbo.TurboAuthenticatedClient, data []byte, publishingTags map[string]string) (string, error) {
+	var tags []types.Tag
+	for key, value := range publishingTags {
+		tags = append(tags, types.Tag{Name: key, Value: value})
+	}
+	var result *types.UploadResult
+	err := retry.Uploads.Do(ctx, "upload", func() error {
+		var err error
+		result, err = turboClient.Upload(ctx, &types.UploadRequest{Data: data, Tags: tags})
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return result.ID, nil
+}
+
+// uploadLuaArtifact uploads a Lua source artifact (typically bundled.lua)
+// with Content-Type and source tags instead of the module tag set
+func uploadLuaArtifact(ctx context.Context, luaPath, walletPath, version, gitHash string, dryRun bool, customTags map[string]string, network harlequinconfig.NetworkConfig, seedWait time.Duration) error {
+	logging.Println("🎭 HARLEQUIN SOURCE UPLOAD")
+	logging.Println("   ═══════════════════════")
+	logging.Println()
+
+	content, err := os.ReadFile(luaPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", luaPath, err)
+	}
+
+	sourceHash := sha256.Sum256(content)
+	publishingTags := map[string]string{
+		"Content-Type":    "text/x-lua",
+		"Type":            "Source",
+		"App-Name":        "Harlequin",
+		"App-Version":     version,
+		"File-Name":       filepath.Base(luaPath),
+		"Source-Hash":     hex.EncodeToString(sourceHash[:]),
+		"Git-Hash":        gitHash,
+		"Toolkit-Version": builders.ToolkitVersion,
+	}
+	if err := mergeUploadTags(publishingTags, customTags); err != nil {
+		return err
+	}
+
+	logging.Println("📄 SOURCE FILE")
+	logging.Println("   ───────────")
+	logging.Printf("   • File: %s (%d bytes)\n", luaPath, len(content))
+	logging.Println()
+	printUploadTags(publishingTags)
+
+	if dryRun {
+		logging.Println("🌵 DRY RUN MODE")
+		logging.Println("   ────────────")
+		logging.Println("   • No actual upload will be performed")
+		logging.Println()
+		logging.Println("✅ Dry run completed successfully!")
+		return nil
+	}
+
+	turboClient, err := turboClientForUpload(walletPath, network)
+	if err != nil {
+		return err
+	}
+
+	logging.Println("🚀 UPLOAD PROCESS")
+	logging.Println("   ──────────────")
+	logging.Printf("   • Uploading %s...\n", filepath.Base(luaPath))
+	dataItemId, err := uploadTaggedItem(ctx, turboClient, content, publishingTags)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", luaPath, err)
+	}
+	logging.Println()
+
+	if seedWait > 0 {
+		if err := waitForSeeding(ctx, network.Gateway, dataItemId, seedWait); err != nil {
+			return err
+		}
+	}
+
+	logging.Println("✅ UPLOAD SUCCESSFUL!")
+	logging.Println("   ─────────────────")
+	logging.Printf("   • Transaction ID: %s\n", dataItemId)
+	logging.Printf("   • Arweave URL: %s/%s\n", network.Gateway, dataItemId)
+	logging.Println()
+
+	if logging.Quiet() {
+		fmt.Println(dataItemId)
+	}
+	return nil
+}
+
+// pathManifest is an Arweave path manifest (arweave/paths) mapping the
+// relative paths of a published directory to their data item IDs
+type pathManifest struct {
+	Manifest string                      `json:"manifest"`
+	Version  string                      `json:"version"`
+	Index    *pathManifestIndex          `json:"index,omitempty"`
+	Paths    map[string]pathManifestItem `json:"paths"`
+}
+
+type pathManifestIndex struct {
+	Path string `json:"path"`
+}
+
+type pathManifestItem struct {
+	ID string `json:"id"`
+}
+
+// buildPathManifest renders an arweave/paths manifest for the uploaded files.
+// index.html becomes the manifest index when present.
+func buildPathManifest(paths map[string]string) ([]byte, error) {
+	manifest := pathManifest{
+		Manifest: "arweave/paths",
+		Version:  "0.2.0",
+		Paths:    make(map[string]pathManifestItem, len(paths)),
+	}
+	for path, id := range paths {
+		manifest.Paths[path] = pathManifestItem{ID: id}
+	}
+	if _, ok := paths["index.html"]; ok {
+		manifest.Index = &pathManifestIndex{Path: "index.html"}
+	}
+	return json.MarshalIndent(manifest, "", "  ")
+}
+
+// listDirectoryFiles returns the uploadable files under dir as sorted
+// slash-separated relative paths, skipping hidden files and directories
+func listDirectoryFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") && path != dir {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", dir, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// publishDirectory uploads every file under dir as its own data item, then
+// uploads an Arweave path manifest pointing at them, returning the manifest's
+// transaction ID. customTags are applied to the manifest item only.
+func publishDirectory(ctx context.Context, turboClient turbo.TurboAuthenticatedClient, dir string, customTags map[string]string) (string, error) {
+	files, err := listDirectoryFiles(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("directory %s contains no files to upload", dir)
+	}
+
+	paths := make(map[string]string, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		fileID, err := uploadTaggedItem(ctx, turboClient, content, map[string]string{
+			"Content-Type": contentTypeFor(file),
+			"App-Name":     "Harlequin",
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to upload %s: %w", file, err)
+		}
+		logging.Printf("   • ✅ %s (%s) -> %s\n", file, contentTypeFor(file), fileID)
+		paths[file] = fileID
+	}
+
+	manifestData, err := buildPathManifest(paths)
+	if err != nil {
+		return "", fmt.Errorf("failed to build path manifest: %w", err)
+	}
+
+	manifestTags := map[string]string{
+		"Content-Type":    "application/x.arweave-manifest+json",
+		"Type":            "Path-Manifest",
+		"App-Name":        "Harlequin",
+		"Toolkit-Version": builders.ToolkitVersion,
+	}
+	if err := mergeUploadTags(manifestTags, customTags); err != nil {
+		return "", err
+	}
+
+	logging.Println("   • Uploading path manifest...")
+	manifestID, err := uploadTaggedItem(ctx, turboClient, manifestData, manifestTags)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload path manifest: %w", err)
+	}
+	return manifestID, nil
+}
+
+// uploadDirectoryArtifact publishes a directory (e.g. a frontend build) as
+// individual data items behind an Arweave path manifest
+func uploadDirectoryArtifact(ctx context.Context, dir, walletPath string, dryRun bool, customTags map[string]string, network harlequinconfig.NetworkConfig, seedWait time.Duration) error {
+	logging.Println("🎭 HARLEQUIN DIRECTORY UPLOAD")
+	logging.Println("   ══════════════════════════")
+	logging.Println()
+
+	files, err := listDirectoryFiles(dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("directory %s contains no files to upload", dir)
+	}
+
+	logging.Println("📂 DIRECTORY CONTENTS")
+	logging.Println("   ──────────────────")
+	for _, file := range files {
+		logging.Printf("   • %s (%s)\n", file, contentTypeFor(file))
+	}
+	logging.Println()
+
+	if dryRun {
+		logging.Println("🌵 DRY RUN MODE")
+		logging.Println("   ────────────")
+		logging.Printf("   • %d file(s) plus a path manifest would be uploaded\n", len(files))
+		logging.Println()
+		logging.Println("✅ Dry run completed successfully!")
+		return nil
+	}
+
+	turboClient, err := turboClientForUpload(walletPath, network)
+	if err != nil {
+		return err
+	}
+
+	logging.Println("🚀 UPLOAD PROCESS")
+	logging.Println("   ──────────────")
+	manifestID, err := publishDirectory(ctx, turboClient, dir, customTags)
+	if err != nil {
+		return err
+	}
+	logging.Println()
+
+	if seedWait > 0 {
+		if err := waitForSeeding(ctx, network.Gateway, manifestID, seedWait); err != nil {
+			return err
+		}
+	}
+
+	logging.Println("✅ UPLOAD SUCCESSFUL!")
+	logging.Println("   ─────────────────")
+	logging.Printf("   • Manifest ID: %s\n", manifestID)
+	logging.Printf("   • Arweave URL: %s/%s\n", network.Gateway, manifestID)
+	logging.Println()
+	logging.Printf("💡 Give it a friendly name: harlequin arns set <name> %s\n", manifestID)
+
+	if logging.Quiet() {
+		fmt.Println(manifestID)
+	}
+	return nil
+}
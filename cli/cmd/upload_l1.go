@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/everFinance/goar"
+	goartypes "github.com/everFinance/goar/types"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/logging"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/wasm"
+)
+
+// l1StatusWait bounds how long the upload waits for the transaction to be
+// confirmed before handing the status URL back to the user
+const l1StatusWait = 2 * time.Minute
+
+// l1StatusInterval is how often the transaction status is polled
+const l1StatusInterval = 10 * time.Second
+
+// uploadModuleL1 posts the module as a regular Arweave layer-1 transaction
+// through goar, for when Turbo is unavailable or the user prefers L1. The
+// publishing tags are the same ones the Turbo path attaches; the fee is paid
+// in AR from the wallet rather than Turbo credits.
+func uploadModuleL1(ctx context.Context, jwk map[string]interface{}, wasmBinary []byte, publishingTags map[string]string, wasmInfo *wasm.WasmInfo, version, gitHash, gatewayURL string, seedWait time.Duration) error {
+	walletBytes, err := json.Marshal(jwk)
+	if err != nil {
+		return fmt.Errorf("failed to encode wallet JWK: %w", err)
+	}
+
+	arWallet, err := goar.NewWallet(walletBytes, gatewayURL)
+	if err != nil {
+		return fmt.Errorf("failed to create Arweave L1 wallet: %w", err)
+	}
+
+	logging.Println("💰 L1 FEE ESTIMATE")
+	logging.Println("   ───────────────")
+
+	reward, err := arWallet.Client.GetTransactionPrice(len(wasmBinary), nil)
+	if err != nil {
+		return fmt.Errorf("failed to estimate L1 transaction fee: %w", err)
+	}
+	logging.Printf("   • Estimated Fee: %s\n", formatCreditsDisplay(strconv.FormatInt(reward, 10)))
+
+	balance, err := arWallet.Client.GetWalletBalance(arWallet.Signer.Address)
+	if err != nil {
+		return fmt.Errorf("failed to check AR balance: %w", err)
+	}
+	logging.Printf("   • AR Balance: %s AR\n", balance.Text('f', 6))
+
+	// The fee is winston; the balance endpoint reports AR
+	feeAR := new(big.Float).Quo(big.NewFloat(float64(reward)), big.NewFloat(1e12))
+	if balance.Cmp(feeAR) < 0 {
+		return fmt.Errorf("insufficient AR balance for L1 upload: need %s AR, have %s AR",
+			feeAR.Text('f', 6), balance.Text('f', 6))
+	}
+	logging.Println("   • ✅ Balance sufficient for upload")
+	logging.Println()
+
+	var tags []goartypes.Tag
+	for key, value := range publishingTags {
+		tags = append(tags, goartypes.Tag{
+			Name:  key,
+			Value: value,
+		})
+	}
+
+	logging.Println("🚀 L1 UPLOAD PROCESS")
+	logging.Println("   ─────────────────")
+	logging.Println("   • Signing transaction...")
+	logging.Println("   • Uploading chunks to Arweave...")
+
+	tx, err := arWallet.SendData(wasmBinary, tags)
+	if err != nil {
+		return fmt.Errorf("failed to post L1 transaction: %w", err)
+	}
+	logging.Printf("   • 🎉 Transaction posted! ID: %s\n", tx.ID)
+
+	// Poll until the transaction lands in a block. L1 confirmation takes a
+	// few minutes, so hand the status URL back once the deadline passes.
+	statusWait := l1StatusWait
+	if seedWait > 0 {
+		statusWait = seedWait
+	}
+	logging.Printf("   • Polling transaction status (up to %s)...\n", statusWait)
+
+	deadline := time.Now().Add(statusWait)
+	for {
+		status, err := arWallet.Client.GetTransactionStatus(tx.ID)
+		if err == nil {
+			logging.Printf("   • ✅ Confirmed in block %d (%d confirmations)\n",
+				status.BlockHeight, status.NumberOfConfirmations)
+			break
+		}
+		if !errors.Is(err, goar.ErrPendingTx) && !errors.Is(err, goar.ErrNotFound) {
+			logging.Printf("   • ⚠️  Status check failed: %v\n", err)
+			break
+		}
+		if time.Now().After(deadline) {
+			logging.Printf("   • ⏳ Still pending - check later at %s/tx/%s/status\n", gatewayURL, tx.ID)
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(l1StatusInterval):
+		}
+	}
+	logging.Println()
+
+	logging.Println("✅ UPLOAD SUCCESSFUL!")
+	logging.Println("   ─────────────────")
+	logging.Printf("   • Transaction ID: %s\n", tx.ID)
+	logging.Printf("   • Arweave URL: %s/%s\n", gatewayURL, tx.ID)
+	logging.Printf("   • Module Version: %s\n", version)
+	logging.Printf("   • Git Hash: %s\n", gitHash)
+	logging.Println()
+	logging.Println("🎭 Module successfully deployed to Arweave!")
+	logging.Printf("💡 Give it a friendly name: harlequin arns set <name> %s\n", tx.ID)
+	slog.Info("module uploaded via L1", "id", tx.ID, "version", version, "size", len(wasmBinary))
+
+	recordUpload(func(r *uploadReport) {
+		r.TransactionID = tx.ID
+		r.URL = gatewayURL + "/" + tx.ID
+	})
+
+	// Record the upload so the next dry run can diff against it
+	if err := recordUploadLockEntry(uploadLockEntry{
+		TransactionID: tx.ID,
+		UploadedAt:    time.Now().UTC(),
+		SizeBytes:     len(wasmBinary),
+		Tags:          publishingTags,
+		Exports:       exportedFunctionNames(wasmInfo),
+	}); err != nil {
+		logging.Printf("⚠️  Could not update %s: %v\n", uploadLockFileName, err)
+	}
+
+	// In quiet mode the transaction ID is the only output; --json emits its
+	// own document instead
+	if logging.Quiet() && uploadJSON == nil {
+		fmt.Println(tx.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/logging"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/wasm"
+)
+
+// uploadLockFileName is the per-project record of uploaded modules. Dry runs
+// diff the candidate against its most recent entry, so reviewers see deltas
+// instead of re-reading the full metadata dump every release.
+const uploadLockFileName = ".harlequin-uploads.json"
+
+// maxUploadLockEntries bounds the lockfile; older uploads roll off the front
+const maxUploadLockEntries = 20
+
+// uploadLockEntry records one uploaded module for later comparison
+type uploadLockEntry struct {
+	TransactionID string            `json:"transaction_id"`
+	UploadedAt    time.Time         `json:"uploaded_at"`
+	SizeBytes     int               `json:"size_bytes"`
+	Tags          map[string]string `json:"tags,omitempty"`
+	Exports       []string          `json:"exports,omitempty"`
+}
+
+// uploadLock is the lockfile document, newest entry last
+type uploadLock struct {
+	Entries []uploadLockEntry `json:"entries"`
+}
+
+// loadUploadLock reads the lockfile; a missing file is an empty lock
+func loadUploadLock() (*uploadLock, error) {
+	content, err := os.ReadFile(uploadLockFileName)
+	if os.IsNotExist(err) {
+		return &uploadLock{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", uploadLockFileName, err)
+	}
+
+	var lock uploadLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", uploadLockFileName, err)
+	}
+	return &lock, nil
+}
+
+// latestUploadEntry returns the most recent recorded upload, or nil
+func latestUploadEntry() *uploadLockEntry {
+	lock, err := loadUploadLock()
+	if err != nil || len(lock.Entries) == 0 {
+		return nil
+	}
+	return &lock.Entries[len(lock.Entries)-1]
+}
+
+// recordUploadLockEntry appends an upload to the lockfile. Failures are not
+// worth failing a completed upload over, so callers surface them as warnings.
+func recordUploadLockEntry(entry uploadLockEntry) error {
+	lock, err := loadUploadLock()
+	if err != nil {
+		return err
+	}
+
+	lock.Entries = append(lock.Entries, entry)
+	if len(lock.Entries) > maxUploadLockEntries {
+		lock.Entries = lock.Entries[len(lock.Entries)-maxUploadLockEntries:]
+	}
+
+	encoded, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(uploadLockFileName, append(encoded, '\n'), 0644)
+}
+
+// exportedFunctionNames lists the sorted function exports of a parsed binary
+func exportedFunctionNames(wasmInfo *wasm.WasmInfo) []string {
+	if wasmInfo == nil {
+		return nil
+	}
+	var names []string
+	for _, export := range wasmInfo.Exports {
+		if export.Type == "function" {
+			names = append(names, export.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// printUploadDiff shows what changed between the last recorded upload and the
+// current candidate: size delta, tag differences, and export changes
+func printUploadDiff(previous *uploadLockEntry, sizeBytes int, tags map[string]string, exports []string) {
+	logging.Println("🔍 DIFF VS LAST UPLOAD")
+	logging.Println("   ───────────────────")
+	logging.Printf("   • Previous: %s (%s)\n", previous.TransactionID, previous.UploadedAt.Format("2006-01-02 15:04"))
+
+	delta := sizeBytes - previous.SizeBytes
+	switch {
+	case delta == 0:
+		logging.Printf("   • Size: unchanged (%s)\n", wasm.FormatMemorySize(uint32(sizeBytes)))
+	case delta > 0:
+		logging.Printf("   • Size: %s -> %s (+%d bytes)\n",
+			wasm.FormatMemorySize(uint32(previous.SizeBytes)), wasm.FormatMemorySize(uint32(sizeBytes)), delta)
+	default:
+		logging.Printf("   • Size: %s -> %s (%d bytes)\n",
+			wasm.FormatMemorySize(uint32(previous.SizeBytes)), wasm.FormatMemorySize(uint32(sizeBytes)), delta)
+	}
+
+	printTagDiff(previous.Tags, tags)
+	printExportDiff(previous.Exports, exports)
+	logging.Println()
+}
+
+// printTagDiff lists added, removed, and changed tags in sorted order
+func printTagDiff(before, after map[string]string) {
+	keys := map[string]bool{}
+	for key := range before {
+		keys[key] = true
+	}
+	for key := range after {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	changes := 0
+	for _, key := range sorted {
+		oldValue, hadOld := before[key]
+		newValue, hasNew := after[key]
+		switch {
+		case !hadOld:
+			logging.Printf("   • Tag added:   %s = %s\n", key, newValue)
+			changes++
+		case !hasNew:
+			logging.Printf("   • Tag removed: %s (was %s)\n", key, oldValue)
+			changes++
+		case oldValue != newValue:
+			logging.Printf("   • Tag changed: %s: %s -> %s\n", key, oldValue, newValue)
+			changes++
+		}
+	}
+	if changes == 0 {
+		logging.Println("   • Tags: unchanged")
+	}
+}
+
+// printExportDiff lists exported functions that appeared or disappeared
+func printExportDiff(before, after []string) {
+	beforeSet := map[string]bool{}
+	for _, name := range before {
+		beforeSet[name] = true
+	}
+	afterSet := map[string]bool{}
+	for _, name := range after {
+		afterSet[name] = true
+	}
+
+	changes := 0
+	for _, name := range after {
+		if !beforeSet[name] {
+			logging.Printf("   • Export added:   %s\n", name)
+			changes++
+		}
+	}
+	for _, name := range before {
+		if !afterSet[name] {
+			logging.Printf("   • Export removed: %s\n", name)
+			changes++
+		}
+	}
+	if changes == 0 {
+		logging.Printf("   • Exports: unchanged (%d function(s))\n", len(after))
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// uploadReport collects everything the upload --json flag emits: one JSON
+// document on stdout that CI pipelines can parse for the module ID instead of
+// scraping the formatted report
+type uploadReport struct {
+	WasmPath          string            `json:"wasm_path"`
+	SizeBytes         int               `json:"size_bytes"`
+	Wasm              *uploadReportWasm `json:"wasm,omitempty"`
+	Tags              map[string]string `json:"tags,omitempty"`
+	BalanceWinc       string            `json:"balance_winc,omitempty"`
+	EstimatedCostWinc string            `json:"estimated_cost_winc,omitempty"`
+	TransactionID     string            `json:"transaction_id,omitempty"`
+	URL               string            `json:"url,omitempty"`
+	DryRun            bool              `json:"dry_run"`
+	DurationMS        int64             `json:"duration_ms"`
+}
+
+// uploadReportWasm is the parsed WASM metadata subset worth exposing
+type uploadReportWasm struct {
+	Target        string `json:"target,omitempty"`
+	InitialMemory uint32 `json:"initial_memory,omitempty"`
+	MaxMemory     uint32 `json:"max_memory,omitempty"`
+	StackSize     uint32 `json:"stack_size,omitempty"`
+	FunctionCount uint32 `json:"function_count,omitempty"`
+	ExportCount   int    `json:"export_count,omitempty"`
+}
+
+// uploadJSON is the active report; nil means --json was not requested
+var uploadJSON *uploadReport
+
+// recordUpload applies fn to the active report, if any. The upload flow calls
+// it at each point of interest so the human and JSON outputs never diverge.
+func recordUpload(fn func(r *uploadReport)) {
+	if uploadJSON != nil {
+		fn(uploadJSON)
+	}
+}
+
+// emitUploadReport prints the collected report as a single JSON document
+func emitUploadReport(start time.Time) error {
+	if uploadJSON == nil {
+		return nil
+	}
+	uploadJSON.DurationMS = time.Since(start).Milliseconds()
+
+	encoded, err := json.MarshalIndent(uploadJSON, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode upload report: %w", err)
+	}
+	fmt.Println(string(encoded))
+	return nil
+}
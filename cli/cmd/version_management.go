@@ -17,6 +17,7 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/build/builders"
 	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
 )
 
@@ -34,10 +35,11 @@ type ReleasesResponse struct {
 
 // VersionManagementConfig holds configuration for version management
 type VersionManagementConfig struct {
-	BaseURL     string
-	InstallDir  string
-	BinaryName  string
-	Timeout     time.Duration
+	BaseURL        string
+	ReleasesAPIURL string
+	InstallDir     string
+	BinaryName     string
+	Timeout        time.Duration
 }
 
 // DefaultVersionManagementConfig returns the default configuration
@@ -49,10 +51,11 @@ func DefaultVersionManagementConfig() *VersionManagementConfig {
 	}
 
 	return &VersionManagementConfig{
-		BaseURL:    "https://install_cli_harlequin.daemongate.io",
-		InstallDir: installDir,
-		BinaryName: "harlequin",
-		Timeout:    30 * time.Second,
+		BaseURL:        "https://install_cli_harlequin.daemongate.io",
+		ReleasesAPIURL: "https://api.github.com/repos/the-permaweb-harlequin/harlequin-toolkit/releases",
+		InstallDir:     installDir,
+		BinaryName:     "harlequin",
+		Timeout:        30 * time.Second,
 	}
 }
 
@@ -110,10 +113,19 @@ func HandleVersionsCommand(ctx context.Context, args []string) {
 	config := DefaultVersionManagementConfig()
 	var showHelp bool
 	var format string = "table" // default format
+	var changelogTag string
 
 	// Parse arguments
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
+		case "--changelog":
+			if i+1 >= len(args) {
+				fmt.Printf("Error: --changelog requires a tag\n\n")
+				printVersionsUsage()
+				os.Exit(1)
+			}
+			changelogTag = args[i+1]
+			i++ // Skip the next argument as it's the value
 		case "--format", "-f":
 			if i+1 >= len(args) {
 				fmt.Printf("Error: --format requires a value (table, json, list)\n\n")
@@ -141,6 +153,14 @@ func HandleVersionsCommand(ctx context.Context, args []string) {
 		return
 	}
 
+	if changelogTag != "" {
+		if err := printChangelog(ctx, config, changelogTag); err != nil {
+			fmt.Printf("Error fetching changelog: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := listAvailableVersions(ctx, config, format); err != nil {
 		fmt.Printf("Error fetching versions: %v\n", err)
 		os.Exit(1)
@@ -175,10 +195,63 @@ func HandleUninstallCommand(ctx context.Context, args []string) {
 	}
 }
 
+// githubRelease is the subset of the GitHub releases API response we use for
+// changelog information
+type githubRelease struct {
+	TagName string `json:"tag_name"`
+	Body    string `json:"body"`
+}
+
+// fetchReleaseNotes fetches changelog bodies from the GitHub releases API,
+// keyed by tag name. Changelogs are best-effort: on any error an empty map is
+// returned so version listing and installation keep working.
+func fetchReleaseNotes(ctx context.Context, config *VersionManagementConfig) map[string]string {
+	notes := make(map[string]string)
+
+	client := &http.Client{
+		Timeout: config.Timeout,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", config.ReleasesAPIURL, nil)
+	if err != nil {
+		return notes
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return notes
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return notes
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return notes
+	}
+
+	var releases []githubRelease
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return notes
+	}
+
+	for _, release := range releases {
+		if release.Body != "" {
+			notes[release.TagName] = strings.TrimSpace(release.Body)
+		}
+	}
+
+	return notes
+}
+
 // fetchAvailableVersions fetches available versions from the releases API
-// TODO: Consider adding GitHub releases API integration for changelog information
-// when GitHub releases are available at: https://api.github.com/repos/the-permaweb-harlequin/harlequin-toolkit/releases
 func fetchAvailableVersions(ctx context.Context, config *VersionManagementConfig) ([]Release, error) {
+	if builders.Offline {
+		return nil, fmt.Errorf("offline mode: version management requires network access")
+	}
+
 	client := &http.Client{
 		Timeout: config.Timeout,
 	}
@@ -219,8 +292,9 @@ func fetchAvailableVersions(ctx context.Context, config *VersionManagementConfig
 
 // VersionItem represents a version in the list
 type VersionItem struct {
-	TagName string
-	Version string
+	TagName   string
+	Version   string
+	Changelog string
 }
 
 func (v VersionItem) FilterValue() string { return v.TagName }
@@ -228,6 +302,11 @@ func (v VersionItem) Title() string {
 	return v.TagName
 }
 func (v VersionItem) Description() string {
+	if v.Changelog != "" {
+		// Show the first changelog line; the detail pane has the rest
+		firstLine, _, _ := strings.Cut(v.Changelog, "\n")
+		return firstLine
+	}
 	if v.Version != "" && v.Version != v.TagName {
 		return fmt.Sprintf("Version %s • No changelog available", v.Version)
 	}
@@ -278,7 +357,30 @@ func (m VersionSelectorModel) View() string {
 	if m.quitting {
 		return "Cancelled.\n"
 	}
-	return "\n" + m.list.View()
+	return "\n" + lipgloss.JoinHorizontal(lipgloss.Top, m.list.View(), m.changelogPane())
+}
+
+// changelogPane renders the release notes for the selected version next to
+// the list
+func (m VersionSelectorModel) changelogPane() string {
+	item, ok := m.list.SelectedItem().(VersionItem)
+	if !ok {
+		return ""
+	}
+
+	notes := item.Changelog
+	if notes == "" {
+		notes = "No changelog available"
+	}
+
+	paneStyle := lipgloss.NewStyle().
+		Width(40).
+		MarginLeft(2).
+		PaddingLeft(1).
+		Border(lipgloss.NormalBorder(), false, false, false, true)
+
+	title := lipgloss.NewStyle().Bold(true).Render("Release notes")
+	return paneStyle.Render(title + "\n\n" + notes)
 }
 
 // interactiveVersionSelection provides a TUI for version selection
@@ -295,12 +397,15 @@ func interactiveVersionSelection(ctx context.Context, config *VersionManagementC
 		return fmt.Errorf("no releases found")
 	}
 
+	notes := fetchReleaseNotes(ctx, config)
+
 	// Convert releases to list items
 	items := make([]list.Item, len(releases))
 	for i, release := range releases {
 		items[i] = VersionItem{
-			TagName: release.TagName,
-			Version: release.Version,
+			TagName:   release.TagName,
+			Version:   release.Version,
+			Changelog: notes[release.TagName],
 		}
 	}
 
@@ -363,6 +468,24 @@ func listAvailableVersions(ctx context.Context, config *VersionManagementConfig,
 	}
 }
 
+// printChangelog prints the release notes for a single tag
+func printChangelog(ctx context.Context, config *VersionManagementConfig, tag string) error {
+	notes := fetchReleaseNotes(ctx, config)
+
+	// Accept tags with or without the v prefix
+	body, ok := notes[tag]
+	if !ok {
+		body, ok = notes["v"+strings.TrimPrefix(tag, "v")]
+	}
+	if !ok {
+		return fmt.Errorf("no changelog found for %s", tag)
+	}
+
+	fmt.Printf("🎭 Changelog for %s\n\n", tag)
+	fmt.Println(body)
+	return nil
+}
+
 // printVersionsJSON outputs versions in JSON format
 func printVersionsJSON(releases []Release) error {
 	encoder := json.NewEncoder(os.Stdout)
@@ -543,6 +666,7 @@ func printVersionsUsage() {
 	fmt.Println()
 	fmt.Println("Flags:")
 	fmt.Println("  -f, --format <format>  Output format: table, json, list (default: table)")
+	fmt.Println("  --changelog <tag>      Show the release notes for a single version")
 	fmt.Println("  -h, --help            Show this help message")
 	fmt.Println()
 	fmt.Println("Output Formats:")
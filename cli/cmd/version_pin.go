@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+)
+
+// VersionFileName pins the toolkit version for a project, like .nvmrc does
+// for Node
+const VersionFileName = ".harlequin-version"
+
+// pinnedExecEnv guards against re-exec loops when dispatching to a pinned
+// version
+const pinnedExecEnv = "HARLEQUIN_PINNED_EXEC"
+
+// MaybeRunPinnedVersion checks the project's .harlequin-version file and, when
+// the running binary differs, downloads and runs the pinned version instead.
+// It returns true when the pinned version handled the command; the caller
+// should exit without dispatching.
+func MaybeRunPinnedVersion(ctx context.Context, currentVersion string, args []string) bool {
+	if os.Getenv(pinnedExecEnv) != "" {
+		return false
+	}
+
+	pinned, err := readPinnedVersion()
+	if err != nil || pinned == "" {
+		return false
+	}
+
+	current := strings.TrimPrefix(currentVersion, "v")
+	if current == pinned {
+		return false
+	}
+
+	// Development builds never match a pin; warn instead of switching so
+	// local builds stay usable
+	if current == "dev" {
+		fmt.Printf("⚠️  Project pins harlequin %s (%s), but this is a dev build\n", pinned, VersionFileName)
+		return false
+	}
+
+	binaryPath, err := ensurePinnedBinary(ctx, pinned)
+	if err != nil {
+		fmt.Printf("❌ Project pins harlequin %s (%s), but it could not be prepared: %v\n", pinned, VersionFileName, err)
+		fmt.Printf("   Install it manually with: harlequin install --version %s\n", pinned)
+		os.Exit(1)
+	}
+
+	debug.Printf("Dispatching to pinned harlequin %s at %s", pinned, binaryPath)
+	fmt.Printf("🎭 Using harlequin %s pinned by %s\n", pinned, VersionFileName)
+
+	cmd := exec.CommandContext(ctx, binaryPath, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), pinnedExecEnv+"=1")
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Printf("❌ Failed to run pinned harlequin %s: %v\n", pinned, err)
+		os.Exit(1)
+	}
+	return true
+}
+
+// readPinnedVersion reads the version pin from the current directory or any
+// parent, so subdirectories of a project resolve the same pin
+func readPinnedVersion() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, VersionFileName))
+		if err == nil {
+			return strings.TrimPrefix(strings.TrimSpace(string(data)), "v"), nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// ensurePinnedBinary returns the cached binary for a pinned version,
+// downloading and verifying it on first use
+func ensurePinnedBinary(ctx context.Context, version string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	versionDir := filepath.Join(homeDir, ".harlequin", "versions", version)
+	binaryName := "harlequin"
+	if runtime.GOOS == "windows" {
+		binaryName += ".exe"
+	}
+	binaryPath := filepath.Join(versionDir, binaryName)
+
+	if _, err := os.Stat(binaryPath); err == nil {
+		return binaryPath, nil
+	}
+
+	config := DefaultVersionManagementConfig()
+	downloadURL := fmt.Sprintf("%s/releases/%s/%s/%s", config.BaseURL, version, runtime.GOOS, runtime.GOARCH)
+	fmt.Printf("📥 Downloading pinned harlequin %s...\n", version)
+
+	binary, err := fetchURL(ctx, config, downloadURL)
+	if err != nil {
+		return "", fmt.Errorf("download failed: %w", err)
+	}
+
+	if err := verifyChecksum(ctx, config, downloadURL, binary); err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(versionDir, 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(binaryPath, binary, 0755); err != nil {
+		return "", err
+	}
+
+	return binaryPath, nil
+}
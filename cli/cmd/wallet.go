@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/wallet"
+)
+
+// HandleWalletCommand handles the wallet command and its subcommands
+func HandleWalletCommand(ctx context.Context, args []string) {
+	if len(args) == 0 {
+		PrintWalletUsage()
+		return
+	}
+
+	switch args[0] {
+	case "add":
+		handleWalletAdd(args[1:])
+	case "create":
+		handleWalletCreate(args[1:])
+	case "address":
+		handleWalletAddress(args[1:])
+	case "list":
+		handleWalletList()
+	case "use":
+		handleWalletUse(args[1:])
+	case "remove":
+		handleWalletRemove(args[1:])
+	case "--help", "-h", "help":
+		PrintWalletUsage()
+	default:
+		fmt.Printf("Unknown wallet subcommand: %s\n\n", args[0])
+		PrintWalletUsage()
+		os.Exit(1)
+	}
+}
+
+// handleWalletAdd stores a wallet file or environment reference as a profile
+func handleWalletAdd(args []string) {
+	var name string
+	var walletPath string
+	var envVar string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--file", "-f":
+			if i+1 < len(args) {
+				walletPath = args[i+1]
+				i++
+			}
+		case "--env", "-e":
+			if i+1 < len(args) {
+				envVar = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintWalletUsage()
+			return
+		default:
+			if name == "" {
+				name = args[i]
+			}
+		}
+	}
+
+	if name == "" {
+		fmt.Println("Error: Profile name is required")
+		PrintWalletUsage()
+		os.Exit(1)
+	}
+
+	if envVar != "" {
+		if err := wallet.AddEnvRef(name, envVar); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Added wallet profile %s (reads $%s at use time)\n", name, envVar)
+	} else {
+		if walletPath == "" {
+			walletPath = "key.json"
+		}
+		if err := wallet.Add(name, walletPath); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Added wallet profile %s (copied from %s)\n", name, walletPath)
+	}
+
+	// The first profile becomes active automatically
+	if active, _ := wallet.Current(); active == "" {
+		if err := wallet.Use(name); err == nil {
+			fmt.Printf("🔑 %s is now the active profile\n", name)
+		}
+	}
+}
+
+// handleWalletCreate generates a fresh JWK and writes it to a file,
+// optionally sealed with a passphrase
+func handleWalletCreate(args []string) {
+	outPath := "wallet.json"
+	encrypt := false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--out", "-o":
+			if i+1 < len(args) {
+				outPath = args[i+1]
+				i++
+			}
+		case "--encrypt":
+			encrypt = true
+		case "--help", "-h":
+			PrintWalletUsage()
+			return
+		default:
+			fmt.Printf("Unknown option: %s\n", args[i])
+			os.Exit(1)
+		}
+	}
+
+	if _, err := os.Stat(outPath); err == nil {
+		fmt.Printf("❌ %s already exists, refusing to overwrite\n", outPath)
+		os.Exit(1)
+	}
+
+	fmt.Println("🔑 Generating a new Arweave wallet (RSA 4096, this takes a moment)...")
+	jwk, err := wallet.GenerateJWK()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	content, err := json.Marshal(jwk)
+	if err != nil {
+		fmt.Printf("❌ Failed to encode wallet: %v\n", err)
+		os.Exit(1)
+	}
+
+	if encrypt {
+		passphrase, err := promptNewPassphrase()
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		content, err = wallet.EncryptJWK(content, passphrase)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if err := os.WriteFile(outPath, content, 0600); err != nil {
+		fmt.Printf("❌ Failed to write %s: %v\n", outPath, err)
+		os.Exit(1)
+	}
+
+	address, err := wallet.AddressFromJWK(jwk)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Wallet written to %s\n", outPath)
+	fmt.Printf("   Address: %s\n", address)
+	if encrypt {
+		fmt.Println("   The file is passphrase-protected; upload commands will prompt for it.")
+	}
+	fmt.Println("⚠️  Back this file up somewhere safe — there is no way to recover it.")
+}
+
+// handleWalletAddress prints the address of a wallet file or stored profile
+func handleWalletAddress(args []string) {
+	if len(args) == 0 || args[0] == "--help" || args[0] == "-h" {
+		fmt.Println("Error: Wallet file or profile name is required")
+		PrintWalletUsage()
+		os.Exit(1)
+	}
+
+	source := args[0]
+	var jwk map[string]interface{}
+
+	if wallet.Exists(source) {
+		loaded, err := wallet.Load(source)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		jwk = loaded
+	} else {
+		content, err := os.ReadFile(source)
+		if err != nil {
+			fmt.Printf("❌ Failed to read %s: %v\n", source, err)
+			os.Exit(1)
+		}
+		content, err = decryptWalletIfNeeded(content, source)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+		if err := json.Unmarshal(content, &jwk); err != nil {
+			fmt.Printf("❌ %s is not a valid wallet JWK: %v\n", source, err)
+			os.Exit(1)
+		}
+	}
+
+	address, err := wallet.AddressFromJWK(jwk)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(address)
+}
+
+// promptNewPassphrase reads a passphrase twice from the terminal and
+// makes sure both entries match
+func promptNewPassphrase() (string, error) {
+	fmt.Print("Passphrase: ")
+	first, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	fmt.Print("Confirm passphrase: ")
+	second, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	if string(first) != string(second) {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+	if len(first) == 0 {
+		return "", fmt.Errorf("passphrase must not be empty")
+	}
+	return string(first), nil
+}
+
+// decryptWalletIfNeeded prompts for a passphrase and opens the envelope when
+// the wallet file is encrypted; plain JWK files pass through untouched
+func decryptWalletIfNeeded(content []byte, path string) ([]byte, error) {
+	if !wallet.IsEncrypted(content) {
+		return content, nil
+	}
+	fmt.Printf("🔑 %s is passphrase-protected\n", path)
+	fmt.Print("Passphrase: ")
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return wallet.DecryptJWK(content, string(passphrase))
+}
+
+// handleWalletList prints all stored profiles
+func handleWalletList() {
+	profiles, err := wallet.List()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(profiles) == 0 {
+		fmt.Println("No wallet profiles stored. Add one with: harlequin wallet add <name> --file key.json")
+		return
+	}
+
+	fmt.Println("🔑 Wallet profiles:")
+	for _, p := range profiles {
+		marker := " "
+		if p.Active {
+			marker = "*"
+		}
+		switch p.Source {
+		case wallet.SourceEnv:
+			fmt.Printf(" %s %-20s env:$%s\n", marker, p.Name, p.EnvVar)
+		default:
+			fmt.Printf(" %s %-20s %s\n", marker, p.Name, p.Address)
+		}
+	}
+}
+
+// handleWalletUse marks a profile as active
+func handleWalletUse(args []string) {
+	if len(args) == 0 {
+		fmt.Println("Error: Profile name is required")
+		os.Exit(1)
+	}
+	if err := wallet.Use(args[0]); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ %s is now the active wallet profile\n", args[0])
+}
+
+// handleWalletRemove deletes a profile
+func handleWalletRemove(args []string) {
+	if len(args) == 0 {
+		fmt.Println("Error: Profile name is required")
+		os.Exit(1)
+	}
+	if err := wallet.Remove(args[0]); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Removed wallet profile %s\n", args[0])
+}
+
+// PrintWalletUsage prints usage information for the wallet command
+func PrintWalletUsage() {
+	fmt.Println("🎭 Harlequin Wallet - Manage Named Wallet Profiles")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Println("    harlequin wallet <SUBCOMMAND> [OPTIONS]")
+	fmt.Println()
+	fmt.Println("SUBCOMMANDS:")
+	fmt.Println("    add <NAME>      Store a wallet as a named profile")
+	fmt.Println("    create          Generate a fresh wallet JWK file")
+	fmt.Println("    address <FILE>  Print the address of a wallet file or profile")
+	fmt.Println("    list            List stored profiles (* marks the active one)")
+	fmt.Println("    use <NAME>      Make a profile the active one")
+	fmt.Println("    remove <NAME>   Delete a profile")
+	fmt.Println()
+	fmt.Println("OPTIONS (add):")
+	fmt.Println("    -f, --file <FILE>   Wallet JSON file to copy (default: key.json)")
+	fmt.Println("    -e, --env <VAR>     Store a reference to an environment variable instead")
+	fmt.Println("                        of copying the wallet (for external secret stores)")
+	fmt.Println()
+	fmt.Println("OPTIONS (create):")
+	fmt.Println("    -o, --out <FILE>    Where to write the wallet (default: wallet.json)")
+	fmt.Println("    --encrypt           Seal the file with a passphrase (AES-256-GCM);")
+	fmt.Println("                        upload commands prompt for it when reading the file")
+	fmt.Println()
+	fmt.Println("STORAGE:")
+	fmt.Println("    Profiles live under the user config directory")
+	fmt.Println("    (~/.config/harlequin/wallets on Linux) with 0600 permissions.")
+	fmt.Println("    Upload flows accept a profile name anywhere a wallet file is expected,")
+	fmt.Println("    and fall back to the active profile when no wallet file is found.")
+	fmt.Println()
+	fmt.Println("EXAMPLES:")
+	fmt.Println("    harlequin wallet create --out key.json --encrypt")
+	fmt.Println("    harlequin wallet address key.json")
+	fmt.Println("    harlequin wallet add deploy --file ~/.arweave/wallet.json")
+	fmt.Println("    harlequin wallet add ci --env CI_WALLET")
+	fmt.Println("    harlequin wallet use deploy")
+	fmt.Println("    harlequin upload-module --wallet-file deploy")
+	fmt.Println()
+}
@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/wasm"
+)
+
+// HandleWasmCommand handles the wasm command and its subcommands
+func HandleWasmCommand(ctx context.Context, args []string) {
+	if len(args) == 0 {
+		fmt.Printf("Error: wasm requires a subcommand\n\n")
+		PrintWasmUsage()
+		os.Exit(1)
+	}
+
+	subcommand := args[0]
+	switch subcommand {
+	case "inspect":
+		HandleWasmInspectCommand(ctx, args[1:])
+	case "diff":
+		HandleWasmDiffCommand(ctx, args[1:])
+	case "help", "--help", "-h":
+		PrintWasmUsage()
+	default:
+		fmt.Printf("Unknown subcommand: %s\n\n", subcommand)
+		PrintWasmUsage()
+		os.Exit(1)
+	}
+}
+
+// HandleWasmInspectCommand handles the inspect subcommand
+func HandleWasmInspectCommand(ctx context.Context, args []string) {
+	var debugMode bool
+	var jsonOutput bool
+	var wasmFile string
+
+	// Process arguments
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch arg {
+		case "--debug", "-d":
+			debugMode = true
+		case "--json":
+			jsonOutput = true
+		case "--help", "-h":
+			PrintWasmInspectUsage()
+			return
+		default:
+			if strings.HasPrefix(arg, "-") {
+				fmt.Printf("Unknown flag: %s\n\n", arg)
+				PrintWasmInspectUsage()
+				os.Exit(1)
+			}
+			if wasmFile != "" {
+				fmt.Printf("Unknown argument: %s\n\n", arg)
+				PrintWasmInspectUsage()
+				os.Exit(1)
+			}
+			wasmFile = arg
+		}
+	}
+
+	// Enable debug mode if flag was provided
+	if debugMode {
+		debug.SetEnabled(true)
+	}
+
+	// Require a wasm file
+	if wasmFile == "" {
+		fmt.Println("Error: a .wasm file path is required")
+		fmt.Println()
+		PrintWasmInspectUsage()
+		os.Exit(1)
+	}
+
+	if err := inspectWasm(wasmFile, jsonOutput); err != nil {
+		fmt.Printf("Inspect failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// wasmInspection is the JSON shape emitted by `harlequin wasm inspect --json`
+type wasmInspection struct {
+	File           string            `json:"file"`
+	Size           int               `json:"size"`
+	Target         string            `json:"target"`
+	InitialMemory  uint32            `json:"initial_memory"`
+	MaximumMemory  uint32            `json:"maximum_memory"`
+	StackSize      uint32            `json:"stack_size,omitempty"`
+	FunctionCount  uint32            `json:"function_count"`
+	GlobalCount    uint32            `json:"global_count"`
+	TableCount     uint32            `json:"table_count"`
+	Exports        []wasm.WasmExport `json:"exports"`
+	Imports        []wasm.WasmImport `json:"imports"`
+	CustomSections map[string]int    `json:"custom_sections"`
+	EstimatedTags  map[string]string `json:"estimated_tags"`
+}
+
+// inspectWasm parses a WASM binary and prints its metadata
+func inspectWasm(wasmFile string, jsonOutput bool) error {
+	wasmBinary, err := os.ReadFile(wasmFile)
+	if err != nil {
+		return fmt.Errorf("failed to read WASM file %s: %w", wasmFile, err)
+	}
+
+	info, err := wasm.ParseWasmBinary(wasmBinary)
+	if err != nil {
+		return fmt.Errorf("failed to parse WASM binary: %w", err)
+	}
+
+	estimatedTags := estimateAOTags(info)
+
+	if jsonOutput {
+		customSections := make(map[string]int, len(info.CustomSections))
+		for name, data := range info.CustomSections {
+			customSections[name] = len(data)
+		}
+
+		inspection := wasmInspection{
+			File:           wasmFile,
+			Size:           len(wasmBinary),
+			Target:         info.Target,
+			InitialMemory:  info.InitialMemory,
+			MaximumMemory:  info.MaxMemory,
+			StackSize:      info.StackSize,
+			FunctionCount:  info.FunctionCount,
+			GlobalCount:    info.GlobalCount,
+			TableCount:     info.TableCount,
+			Exports:        info.Exports,
+			Imports:        info.Imports,
+			CustomSections: customSections,
+			EstimatedTags:  estimatedTags,
+		}
+
+		output, err := json.MarshalIndent(inspection, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal inspection result: %w", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Println("🎭 WASM Module Inspection")
+	fmt.Println()
+	fmt.Printf("📦 File: %s\n", wasmFile)
+	fmt.Printf("   • Size: %s (%d bytes)\n", wasm.FormatMemorySize(uint32(len(wasmBinary))), len(wasmBinary))
+	fmt.Printf("   • Target: %s\n", info.Target)
+	fmt.Println()
+
+	fmt.Println("🧠 Memory Configuration")
+	if info.InitialMemory > 0 {
+		fmt.Printf("   • Initial Memory: %s\n", wasm.FormatMemorySize(info.InitialMemory))
+	}
+	if info.MaxMemory > 0 {
+		fmt.Printf("   • Maximum Memory: %s\n", wasm.FormatMemorySize(info.MaxMemory))
+	}
+	if info.StackSize > 0 {
+		fmt.Printf("   • Stack Size: %s\n", wasm.FormatMemorySize(info.StackSize))
+	}
+	fmt.Println()
+
+	fmt.Printf("📤 Exports (%d)\n", len(info.Exports))
+	for _, exp := range info.Exports {
+		fmt.Printf("   • %s (%s)\n", exp.Name, exp.Type)
+	}
+	fmt.Println()
+
+	fmt.Printf("📥 Imports (%d)\n", len(info.Imports))
+	for _, imp := range info.Imports {
+		fmt.Printf("   • %s.%s (%s)\n", imp.Module, imp.Name, imp.Type)
+	}
+	fmt.Println()
+
+	fmt.Printf("🔧 Runtime Structure\n")
+	fmt.Printf("   • Functions: %d\n", info.FunctionCount)
+	fmt.Printf("   • Globals: %d\n", info.GlobalCount)
+	fmt.Printf("   • Tables: %d\n", info.TableCount)
+	fmt.Println()
+
+	if len(info.CustomSections) > 0 {
+		fmt.Printf("📑 Custom Sections (%d)\n", len(info.CustomSections))
+		for name, data := range info.CustomSections {
+			fmt.Printf("   • %s (%d bytes)\n", name, len(data))
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("🏷️  Estimated AO Tags")
+	for _, key := range []string{"Target", "Memory-Limit", "Initial-Memory", "Stack-Size", "Function-Count", "Export-Count"} {
+		if value, ok := estimatedTags[key]; ok {
+			fmt.Printf("   • %s: %s\n", key, value)
+		}
+	}
+
+	return nil
+}
+
+// estimateAOTags derives the AO publishing tags that an upload would attach
+// based on the module metadata
+func estimateAOTags(info *wasm.WasmInfo) map[string]string {
+	tags := make(map[string]string)
+
+	if info.Target != "" {
+		tags["Target"] = info.Target
+	}
+	if info.MaxMemory > 0 {
+		tags["Memory-Limit"] = strconv.Itoa(int(info.MaxMemory)) + "-b"
+	}
+	if info.InitialMemory > 0 {
+		tags["Initial-Memory"] = strconv.Itoa(int(info.InitialMemory)) + "-b"
+	}
+	if info.StackSize > 0 {
+		tags["Stack-Size"] = strconv.Itoa(int(info.StackSize)) + "-b"
+	}
+	if info.FunctionCount > 0 {
+		tags["Function-Count"] = strconv.Itoa(int(info.FunctionCount))
+	}
+	if len(info.Exports) > 0 {
+		tags["Export-Count"] = strconv.Itoa(len(info.Exports))
+	}
+
+	return tags
+}
+
+// PrintWasmUsage prints the usage information for the wasm command
+func PrintWasmUsage() {
+	fmt.Println("🎭 Harlequin WASM Utils")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  harlequin wasm <subcommand> [flags]")
+	fmt.Println()
+	fmt.Println("Subcommands:")
+	fmt.Println("  inspect   Inspect a .wasm file and print its metadata")
+	fmt.Println("  diff      Compare two .wasm modules (local paths or Arweave txIDs)")
+	fmt.Println("  help      Show this help message")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  harlequin wasm inspect process.wasm")
+	fmt.Println("  harlequin wasm inspect process.wasm --json")
+	fmt.Println("  harlequin wasm diff old.wasm new.wasm")
+	fmt.Println()
+	fmt.Println("For detailed subcommand options, use:")
+	fmt.Println("  harlequin wasm <subcommand> --help")
+}
+
+// PrintWasmInspectUsage prints the usage information for the inspect subcommand
+func PrintWasmInspectUsage() {
+	fmt.Println("🎭 Harlequin WASM Utils - Inspect")
+	fmt.Println()
+	fmt.Println("Inspect a WASM binary and print its memory configuration, exports,")
+	fmt.Println("imports, custom sections, and the AO tags an upload would attach.")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  harlequin wasm inspect <file> [flags]")
+	fmt.Println()
+	fmt.Println("Optional Flags:")
+	fmt.Println("  --json                 Emit machine-readable JSON output")
+	fmt.Println("  -d, --debug            Enable debug logging for detailed output")
+	fmt.Println("  -h, --help             Show this help message")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  harlequin wasm inspect dist/process.wasm")
+	fmt.Println("  harlequin wasm inspect dist/process.wasm --json")
+}
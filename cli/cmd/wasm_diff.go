@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	harlequinconfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/wasm"
+)
+
+// HandleWasmDiffCommand compares two WASM modules and reports what changed
+func HandleWasmDiffCommand(ctx context.Context, args []string) {
+	var inputs []string
+	var gatewayURL string
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--gateway":
+			if i+1 < len(args) {
+				gatewayURL = args[i+1]
+				i++
+			}
+		case "--help", "-h":
+			PrintWasmDiffUsage()
+			return
+		default:
+			if strings.HasPrefix(args[i], "-") {
+				fmt.Printf("Unknown flag: %s\n\n", args[i])
+				PrintWasmDiffUsage()
+				os.Exit(1)
+			}
+			inputs = append(inputs, args[i])
+		}
+	}
+
+	if len(inputs) != 2 {
+		fmt.Println("Error: exactly two modules are required (local paths or Arweave txIDs)")
+		fmt.Println()
+		PrintWasmDiffUsage()
+		os.Exit(1)
+	}
+
+	network := resolveNetwork(networkFlags{Gateway: gatewayURL})
+
+	oldBinary, err := loadWasmInput(ctx, inputs[0], network)
+	if err != nil {
+		fmt.Printf("❌ Failed to load %s: %v\n", inputs[0], err)
+		os.Exit(1)
+	}
+	newBinary, err := loadWasmInput(ctx, inputs[1], network)
+	if err != nil {
+		fmt.Printf("❌ Failed to load %s: %v\n", inputs[1], err)
+		os.Exit(1)
+	}
+
+	oldInfo, err := wasm.ParseWasmBinary(oldBinary)
+	if err != nil {
+		fmt.Printf("❌ Failed to parse %s: %v\n", inputs[0], err)
+		os.Exit(1)
+	}
+	newInfo, err := wasm.ParseWasmBinary(newBinary)
+	if err != nil {
+		fmt.Printf("❌ Failed to parse %s: %v\n", inputs[1], err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("🎭 WASM Diff: %s → %s\n", inputs[0], inputs[1])
+	fmt.Println()
+
+	fmt.Println("📏 SIZE")
+	fmt.Printf("   %d bytes → %d bytes (%s)\n", len(oldBinary), len(newBinary), formatSizeDelta(int64(len(newBinary))-int64(len(oldBinary))))
+	fmt.Println()
+
+	diffMemoryConfig(oldInfo, newInfo)
+	diffNamedItems("📤 EXPORTS", exportKeys(oldInfo), exportKeys(newInfo))
+	diffNamedItems("📥 IMPORTS", importKeys(oldInfo), importKeys(newInfo))
+	diffSectionSizes(oldInfo, newInfo)
+	diffCustomSections(oldInfo, newInfo)
+}
+
+// loadWasmInput reads a module from a local path, or downloads it from the
+// gateway when the input looks like a transaction ID instead
+func loadWasmInput(ctx context.Context, input string, network harlequinconfig.NetworkConfig) ([]byte, error) {
+	if _, err := os.Stat(input); err == nil {
+		return os.ReadFile(input)
+	}
+	if !arweaveTxIDPattern.MatchString(input) {
+		return nil, fmt.Errorf("not a local file and not a valid Arweave transaction ID")
+	}
+
+	url := strings.TrimRight(network.Gateway, "/") + "/" + input
+	fmt.Printf("🌐 Downloading %s\n", url)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Timeout: 120 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gateway unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download failed (HTTP %d)", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// diffMemoryConfig reports memory and target configuration changes
+func diffMemoryConfig(oldInfo, newInfo *wasm.WasmInfo) {
+	fmt.Println("🧠 MEMORY & TARGET")
+	changed := false
+	changed = diffValue("Target", oldInfo.Target, newInfo.Target) || changed
+	changed = diffValue("Initial Memory", wasm.FormatMemorySize(oldInfo.InitialMemory), wasm.FormatMemorySize(newInfo.InitialMemory)) || changed
+	changed = diffValue("Maximum Memory", wasm.FormatMemorySize(oldInfo.MaxMemory), wasm.FormatMemorySize(newInfo.MaxMemory)) || changed
+	changed = diffValue("Stack Size", wasm.FormatMemorySize(oldInfo.StackSize), wasm.FormatMemorySize(newInfo.StackSize)) || changed
+	changed = diffValue("Function Count", fmt.Sprint(oldInfo.FunctionCount), fmt.Sprint(newInfo.FunctionCount)) || changed
+	if !changed {
+		fmt.Println("   (unchanged)")
+	}
+	fmt.Println()
+}
+
+// diffValue prints a single changed value and reports whether it changed
+func diffValue(label, oldValue, newValue string) bool {
+	if oldValue == newValue {
+		return false
+	}
+	fmt.Printf("   • %s: %s → %s\n", label, oldValue, newValue)
+	return true
+}
+
+// exportKeys flattens exports to "name (type)" labels
+func exportKeys(info *wasm.WasmInfo) []string {
+	keys := make([]string, len(info.Exports))
+	for i, export := range info.Exports {
+		keys[i] = fmt.Sprintf("%s (%s)", export.Name, export.Type)
+	}
+	return keys
+}
+
+// importKeys flattens imports to "module.name (type)" labels
+func importKeys(info *wasm.WasmInfo) []string {
+	keys := make([]string, len(info.Imports))
+	for i, imp := range info.Imports {
+		keys[i] = fmt.Sprintf("%s.%s (%s)", imp.Module, imp.Name, imp.Type)
+	}
+	return keys
+}
+
+// diffNamedItems prints added and removed entries between two label sets
+func diffNamedItems(header string, oldKeys, newKeys []string) {
+	oldSet := make(map[string]bool, len(oldKeys))
+	for _, key := range oldKeys {
+		oldSet[key] = true
+	}
+	newSet := make(map[string]bool, len(newKeys))
+	for _, key := range newKeys {
+		newSet[key] = true
+	}
+
+	var added, removed []string
+	for _, key := range newKeys {
+		if !oldSet[key] {
+			added = append(added, key)
+		}
+	}
+	for _, key := range oldKeys {
+		if !newSet[key] {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	fmt.Println(header)
+	if len(added) == 0 && len(removed) == 0 {
+		fmt.Printf("   (unchanged, %d entries)\n", len(newKeys))
+	}
+	for _, key := range added {
+		fmt.Printf("   + %s\n", key)
+	}
+	for _, key := range removed {
+		fmt.Printf("   - %s\n", key)
+	}
+	fmt.Println()
+}
+
+// diffSectionSizes prints the per-section size deltas
+func diffSectionSizes(oldInfo, newInfo *wasm.WasmInfo) {
+	names := make(map[string]bool)
+	for name := range oldInfo.SectionSizes {
+		names[name] = true
+	}
+	for name := range newInfo.SectionSizes {
+		names[name] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	fmt.Println("📦 SECTION SIZES")
+	changed := false
+	for _, name := range sorted {
+		oldSize := int64(oldInfo.SectionSizes[name])
+		newSize := int64(newInfo.SectionSizes[name])
+		if oldSize == newSize {
+			continue
+		}
+		changed = true
+		fmt.Printf("   • %-10s %d → %d bytes (%s)\n", name, oldSize, newSize, formatSizeDelta(newSize-oldSize))
+	}
+	if !changed {
+		fmt.Println("   (unchanged)")
+	}
+	fmt.Println()
+}
+
+// diffCustomSections prints added, removed, and resized custom sections
+func diffCustomSections(oldInfo, newInfo *wasm.WasmInfo) {
+	names := make(map[string]bool)
+	for name := range oldInfo.CustomSections {
+		names[name] = true
+	}
+	for name := range newInfo.CustomSections {
+		names[name] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	fmt.Println("🏷️  CUSTOM SECTIONS")
+	changed := false
+	for _, name := range sorted {
+		oldData, inOld := oldInfo.CustomSections[name]
+		newData, inNew := newInfo.CustomSections[name]
+		switch {
+		case !inOld:
+			fmt.Printf("   + %s (%d bytes)\n", name, len(newData))
+			changed = true
+		case !inNew:
+			fmt.Printf("   - %s (%d bytes)\n", name, len(oldData))
+			changed = true
+		case len(oldData) != len(newData):
+			fmt.Printf("   • %s: %d → %d bytes (%s)\n", name, len(oldData), len(newData), formatSizeDelta(int64(len(newData))-int64(len(oldData))))
+			changed = true
+		}
+	}
+	if !changed {
+		fmt.Printf("   (unchanged, %d sections)\n", len(newInfo.CustomSections))
+	}
+}
+
+// formatSizeDelta renders a signed byte delta
+func formatSizeDelta(delta int64) string {
+	if delta >= 0 {
+		return fmt.Sprintf("+%d bytes", delta)
+	}
+	return fmt.Sprintf("%d bytes", delta)
+}
+
+// PrintWasmDiffUsage prints the usage information for the diff subcommand
+func PrintWasmDiffUsage() {
+	fmt.Println("🎭 Harlequin WASM Utils - Diff")
+	fmt.Println()
+	fmt.Println("Compare two WASM modules and report differences in exports, imports,")
+	fmt.Println("memory configuration, custom sections, and size per section. Inputs")
+	fmt.Println("can be local files or Arweave transaction IDs of released modules.")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  harlequin wasm diff <old> <new> [flags]")
+	fmt.Println()
+	fmt.Println("Optional Flags:")
+	fmt.Println("  --gateway <URL>        Gateway for downloading modules by txID")
+	fmt.Println("  -h, --help             Show this help message")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  harlequin wasm diff dist/process.wasm dist/process-new.wasm")
+	fmt.Println("  harlequin wasm diff <released-txid> dist/process.wasm")
+}
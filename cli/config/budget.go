@@ -0,0 +1,103 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseMaxUploadCost parses a max_upload_cost declaration. Plain integers are
+// winc amounts ("1500000000", optionally suffixed "winc"); dollar values
+// ("$0.25", "0.25 usd") are USD and need the Turbo rates to convert.
+func ParseMaxUploadCost(value string) (winc int64, usd float64, isUSD bool, err error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+
+	switch {
+	case strings.HasPrefix(normalized, "$"):
+		normalized = strings.TrimSpace(strings.TrimPrefix(normalized, "$"))
+		isUSD = true
+	case strings.HasSuffix(normalized, "usd"):
+		normalized = strings.TrimSpace(strings.TrimSuffix(normalized, "usd"))
+		isUSD = true
+	case strings.HasSuffix(normalized, "winc"):
+		normalized = strings.TrimSpace(strings.TrimSuffix(normalized, "winc"))
+	}
+
+	if normalized == "" {
+		return 0, 0, false, fmt.Errorf("max_upload_cost %q has no amount", value)
+	}
+
+	if isUSD {
+		usd, err = strconv.ParseFloat(normalized, 64)
+		if err != nil || usd <= 0 {
+			return 0, 0, false, fmt.Errorf("max_upload_cost %q is not a positive USD amount", value)
+		}
+		return 0, usd, true, nil
+	}
+
+	winc, err = strconv.ParseInt(normalized, 10, 64)
+	if err != nil || winc <= 0 {
+		return 0, 0, false, fmt.Errorf("max_upload_cost %q is not a positive winc amount", value)
+	}
+	return winc, 0, false, nil
+}
+
+// turboRates is the relevant part of the Turbo payment service's /v1/rates
+// response: the price of one GiB in winc and in fiat currencies
+type turboRates struct {
+	Winc string             `json:"winc"`
+	Fiat map[string]float64 `json:"fiat"`
+}
+
+// WincForUSD converts a USD amount to winc using the Turbo payment service's
+// current rates
+func WincForUSD(ctx context.Context, paymentURL string, usd float64) (int64, error) {
+	url := strings.TrimRight(paymentURL, "/") + "/v1/rates"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch Turbo rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to fetch Turbo rates (HTTP %d)", resp.StatusCode)
+	}
+
+	var rates turboRates
+	if err := json.NewDecoder(resp.Body).Decode(&rates); err != nil {
+		return 0, fmt.Errorf("failed to parse Turbo rates: %w", err)
+	}
+
+	wincPerGiB, err := strconv.ParseFloat(rates.Winc, 64)
+	if err != nil || wincPerGiB <= 0 {
+		return 0, fmt.Errorf("Turbo rates returned an invalid winc price %q", rates.Winc)
+	}
+	usdPerGiB, ok := rates.Fiat["usd"]
+	if !ok || usdPerGiB <= 0 {
+		return 0, fmt.Errorf("Turbo rates did not include a USD price")
+	}
+
+	return int64(usd / usdPerGiB * wincPerGiB), nil
+}
+
+// ResolveMaxUploadCost turns a max_upload_cost declaration into a winc
+// budget, consulting the Turbo rates when the budget is in USD
+func ResolveMaxUploadCost(ctx context.Context, paymentURL, value string) (int64, error) {
+	winc, usd, isUSD, err := ParseMaxUploadCost(value)
+	if err != nil {
+		return 0, err
+	}
+	if !isUSD {
+		return winc, nil
+	}
+	return WincForUSD(ctx, paymentURL, usd)
+}
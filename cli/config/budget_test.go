@@ -0,0 +1,75 @@
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseMaxUploadCostWinc(t *testing.T) {
+	for _, value := range []string{"1500000000", " 1500000000 winc "} {
+		winc, _, isUSD, err := ParseMaxUploadCost(value)
+		if err != nil {
+			t.Fatalf("ParseMaxUploadCost(%q) failed: %v", value, err)
+		}
+		if isUSD {
+			t.Errorf("Expected %q to parse as winc, got USD", value)
+		}
+		if winc != 1500000000 {
+			t.Errorf("Expected 1500000000 winc, got %d", winc)
+		}
+	}
+}
+
+func TestParseMaxUploadCostUSD(t *testing.T) {
+	for _, value := range []string{"$0.25", "0.25 usd", "0.25 USD"} {
+		_, usd, isUSD, err := ParseMaxUploadCost(value)
+		if err != nil {
+			t.Fatalf("ParseMaxUploadCost(%q) failed: %v", value, err)
+		}
+		if !isUSD {
+			t.Errorf("Expected %q to parse as USD", value)
+		}
+		if usd != 0.25 {
+			t.Errorf("Expected 0.25 USD, got %v", usd)
+		}
+	}
+}
+
+func TestParseMaxUploadCostRejectsInvalid(t *testing.T) {
+	for _, value := range []string{"", "$", "free", "-5", "$-1", "0"} {
+		if _, _, _, err := ParseMaxUploadCost(value); err == nil {
+			t.Errorf("Expected ParseMaxUploadCost(%q) to fail", value)
+		}
+	}
+}
+
+func TestResolveMaxUploadCostUsesRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/rates" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"winc":"800000000000","fiat":{"usd":8.0}}`))
+	}))
+	defer server.Close()
+
+	// $0.25 at 800000000000 winc / $8 per GiB is 25000000000 winc
+	winc, err := ResolveMaxUploadCost(context.Background(), server.URL, "$0.25")
+	if err != nil {
+		t.Fatalf("ResolveMaxUploadCost failed: %v", err)
+	}
+	if winc != 25000000000 {
+		t.Errorf("Expected 25000000000 winc, got %d", winc)
+	}
+
+	// Winc budgets resolve without touching the rates endpoint
+	winc, err = ResolveMaxUploadCost(context.Background(), "http://invalid.invalid", "42000")
+	if err != nil {
+		t.Fatalf("ResolveMaxUploadCost failed for winc budget: %v", err)
+	}
+	if winc != 42000 {
+		t.Errorf("Expected 42000 winc, got %d", winc)
+	}
+}
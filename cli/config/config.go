@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 )
@@ -14,24 +16,120 @@ const (
 	DefaultStackSize     = 3145728
 	DefaultInitialMemory = 4194304
 	DefaultMaximumMemory = 1073741824
+	DefaultMaxUploadSize = 33554432 // 32MB upload cap; catches unstripped or debug builds
+)
+
+// AOS flavours selectable as build types. The vanilla flavour builds the
+// standard AOS process; the sqlite flavour builds the sqlite-enabled AOS
+// variant, which ships as a 64-bit module with a larger memory envelope.
+const (
+	FlavourAOS       = "aos"
+	FlavourAOSSqlite = "aos-sqlite"
+)
+
+// Defaults for the sqlite-enabled AOS variant. The variant lives in its own
+// repository, so the git ref tracks that repo's default branch rather than a
+// vanilla AOS commit.
+const (
+	SqliteAOSGitHash    = "main"
+	SqliteModuleFormat  = "wasm64-unknown-emscripten-draft_2024_02_15"
+	SqliteTarget        = 64
+	SqliteStackSize     = 6291456
+	SqliteInitialMemory = 8388608
+	SqliteMaximumMemory = 17179869184
 )
 
 type Config struct {
-	StackSize     int    `yaml:"stack_size"`
-	InitialMemory int    `yaml:"initial_memory"`
-	MaximumMemory int    `yaml:"maximum_memory"`
-	Target        int    `yaml:"target"` // 32 or 64
-	ComputeLimit  string `yaml:"compute_limit"`
-	ModuleFormat  string `yaml:"module_format"`
-	AOSGitHash    string `yaml:"aos_git_hash"`
+	StackSize      int    `yaml:"stack_size"`
+	InitialMemory  int    `yaml:"initial_memory"`
+	MaximumMemory  int    `yaml:"maximum_memory"`
+	Target         int    `yaml:"target"` // 32 or 64
+	ComputeLimit   string `yaml:"compute_limit"`
+	ModuleFormat   string `yaml:"module_format"`
+	AOSGitHash     string `yaml:"aos_git_hash"`              // Git ref to build from: commit hash, branch, or tag
+	AOSRepoURL     string `yaml:"aos_repo_url,omitempty"`    // Alternate AOS repository for patched forks (default: upstream AOS)
+	AOSSubdir      string `yaml:"aos_subdir,omitempty"`      // Subdirectory holding the process sources (default: "process")
+	Flavour        string `yaml:"flavour,omitempty"`         // AOS flavour to build: "aos" (default) or "aos-sqlite"
+	Optimize       bool   `yaml:"optimize,omitempty"`        // Run wasm-opt -Oz on the built module
+	MeteringReport bool   `yaml:"metering_report,omitempty"` // Analyze the metered module and report gas metrics
+	MaxUploadSize  int    `yaml:"max_upload_size,omitempty"` // Upload size cap in bytes; larger modules are refused without --force (0 = default cap)
+	MaxUploadCost  string `yaml:"max_upload_cost,omitempty"` // Upload cost budget in winc ("1500000000") or USD ("$0.25"); costlier uploads need --confirm-over-budget
+
+	// ProxyURL routes the toolkit's HTTP traffic through an explicit proxy,
+	// overriding the HTTPS_PROXY / NO_PROXY environment selection. Empty
+	// keeps the environment-based behavior.
+	ProxyURL string `yaml:"proxy_url,omitempty"`
+
+	// CABundle is a PEM file of additional trusted CAs, for environments
+	// with TLS-intercepting proxies
+	CABundle string `yaml:"ca_bundle,omitempty"`
+
+	// DockerImageDigest pins the AO build image to an immutable digest
+	// (sha256:...) so builds stay reproducible even if the versioned tag is
+	// republished. Empty means the toolkit's default tag.
+	DockerImageDigest string `yaml:"docker_image_digest,omitempty"`
+
+	// DockerUser is the uid:gid mapping passed to docker run --user, so
+	// build outputs in the mounted workspace are owned by that user. Empty
+	// means the invoking user on Linux; "root" keeps the container default.
+	DockerUser string `yaml:"docker_user,omitempty"`
+
+	// NativeModules lists compiled C modules a Lua project requires (e.g.
+	// C rocks). Their sources are built in the container and linked into the
+	// module, and each one is registered in package.preload under its name.
+	NativeModules []NativeModule `yaml:"native_modules,omitempty"`
+
+	// Tasks maps task names to pipelines of steps. Each step is either a
+	// harlequin command line (e.g. "build --entrypoint main.lua") or the name
+	// of another task, which runs first as a dependency.
+	Tasks map[string][]string `yaml:"tasks,omitempty"`
+
+	// Workspace lists the process packages of a multi-package repo. Empty for
+	// single-process projects.
+	Workspace []WorkspacePackage `yaml:"workspace,omitempty"`
+
+	// Network selects the AO and Arweave endpoints the toolkit talks to.
+	// Nil means the mainnet defaults.
+	Network *NetworkConfig `yaml:"network,omitempty"`
+
+	// Upload holds the publishing tags attached when a module is uploaded.
+	// Nil means the standard AO module tags.
+	Upload *UploadConfig `yaml:"upload,omitempty"`
+
+	// Patches customizes how the AOS process.lua is modified before the
+	// build: injection points, default-handler removal, and user patch
+	// files. Nil keeps the standard require injection.
+	Patches *ProcessPatchConfig `yaml:"patches,omitempty"`
+}
+
+// NativeModule declares one compiled C module of a mixed Lua/C project. The
+// name doubles as the require() name and the luaopen_<name> entry point the
+// module's sources must export; the bundler leaves the require for runtime
+// resolution through package.preload.
+type NativeModule struct {
+	Name        string   `yaml:"name"`                   // require() name; sources must export luaopen_<name>
+	Sources     []string `yaml:"sources"`                // C files or directories, relative to the config file
+	IncludeDirs []string `yaml:"include_dirs,omitempty"` // Extra include directories, relative to the staged module
+	CFlags      []string `yaml:"cflags,omitempty"`       // Extra compiler flags for this module's sources
+}
+
+// WorkspacePackage describes one process package in a multi-package repo
+type WorkspacePackage struct {
+	Name       string         `yaml:"name"`
+	Entrypoint string         `yaml:"entrypoint"`
+	OutputDir  string         `yaml:"output_dir,omitempty"`
+	DependsOn  []string       `yaml:"depends_on,omitempty"` // Packages whose bundles must be built first
+	Config     *PartialConfig `yaml:"config,omitempty"`     // Overrides of the top-level build config
 }
 
 type PartialConfig struct {
-	StackSize     *int
-	InitialMemory *int
-	MaximumMemory *int
-	Target        *int
-	ComputeLimit  *string
+	StackSize      *int    `yaml:"stack_size,omitempty"`
+	InitialMemory  *int    `yaml:"initial_memory,omitempty"`
+	MaximumMemory  *int    `yaml:"maximum_memory,omitempty"`
+	Target         *int    `yaml:"target,omitempty"`
+	ComputeLimit   *string `yaml:"compute_limit,omitempty"`
+	Optimize       *bool   `yaml:"optimize,omitempty"`
+	MeteringReport *bool   `yaml:"metering_report,omitempty"`
 }
 
 func NewConfig(partialConfig *PartialConfig) *Config {
@@ -61,11 +159,190 @@ func NewConfig(partialConfig *PartialConfig) *Config {
 		if partialConfig.ComputeLimit != nil {
 			config.ComputeLimit = *partialConfig.ComputeLimit
 		}
+		if partialConfig.Optimize != nil {
+			config.Optimize = *partialConfig.Optimize
+		}
+		if partialConfig.MeteringReport != nil {
+			config.MeteringReport = *partialConfig.MeteringReport
+		}
 	}
 
 	return config
 }
 
+// ApplyFlavour selects an AOS flavour, switching any field still at its
+// vanilla default over to the flavour's defaults. Fields the user has
+// explicitly set (anything differing from the vanilla defaults) are kept, so
+// a config can pick the sqlite flavour and still pin its own memory limits.
+func (c *Config) ApplyFlavour(flavour string) {
+	c.Flavour = flavour
+	if flavour != FlavourAOSSqlite {
+		return
+	}
+
+	if c.AOSGitHash == "" || c.AOSGitHash == DefaultAOSGitHash {
+		c.AOSGitHash = SqliteAOSGitHash
+	}
+	if c.ModuleFormat == "" || c.ModuleFormat == DefaultModuleFormat {
+		c.ModuleFormat = SqliteModuleFormat
+	}
+	if c.Target == 0 || c.Target == DefaultTarget {
+		c.Target = SqliteTarget
+	}
+	if c.StackSize == 0 || c.StackSize == DefaultStackSize {
+		c.StackSize = SqliteStackSize
+	}
+	if c.InitialMemory == 0 || c.InitialMemory == DefaultInitialMemory {
+		c.InitialMemory = SqliteInitialMemory
+	}
+	if c.MaximumMemory == 0 || c.MaximumMemory == DefaultMaximumMemory {
+		c.MaximumMemory = SqliteMaximumMemory
+	}
+}
+
+// FindWorkspacePackage looks up a workspace package by name
+func (c *Config) FindWorkspacePackage(name string) (*WorkspacePackage, bool) {
+	for i := range c.Workspace {
+		if c.Workspace[i].Name == name {
+			return &c.Workspace[i], true
+		}
+	}
+	return nil, false
+}
+
+// ResolveWorkspacePackages returns the requested packages (all of them when
+// names is empty) in dependency order: a package's depends_on entries are
+// placed before it, and cycles are reported as an error.
+func (c *Config) ResolveWorkspacePackages(names []string) ([]WorkspacePackage, error) {
+	if len(c.Workspace) == 0 {
+		return nil, fmt.Errorf("no workspace packages defined")
+	}
+
+	if len(names) == 0 {
+		for _, pkg := range c.Workspace {
+			names = append(names, pkg.Name)
+		}
+	}
+
+	var ordered []WorkspacePackage
+	visiting := make(map[string]bool)
+	visited := make(map[string]bool)
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		if visited[name] {
+			return nil
+		}
+		if visiting[name] {
+			return fmt.Errorf("package dependency cycle detected: %s -> %s", strings.Join(path, " -> "), name)
+		}
+
+		pkg, ok := c.FindWorkspacePackage(name)
+		if !ok {
+			return fmt.Errorf("workspace package %q is not defined", name)
+		}
+
+		visiting[name] = true
+		for _, dependency := range pkg.DependsOn {
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		visiting[name] = false
+		visited[name] = true
+
+		ordered = append(ordered, *pkg)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// PackageConfig returns the effective build config for a workspace package:
+// the top-level config with the package's overrides applied
+func (c *Config) PackageConfig(pkg *WorkspacePackage) *Config {
+	merged := *c
+
+	if pkg.Config != nil {
+		if pkg.Config.StackSize != nil {
+			merged.StackSize = *pkg.Config.StackSize
+		}
+		if pkg.Config.InitialMemory != nil {
+			merged.InitialMemory = *pkg.Config.InitialMemory
+		}
+		if pkg.Config.MaximumMemory != nil {
+			merged.MaximumMemory = *pkg.Config.MaximumMemory
+		}
+		if pkg.Config.Target != nil {
+			merged.Target = *pkg.Config.Target
+		}
+		if pkg.Config.ComputeLimit != nil {
+			merged.ComputeLimit = *pkg.Config.ComputeLimit
+		}
+		if pkg.Config.Optimize != nil {
+			merged.Optimize = *pkg.Config.Optimize
+		}
+		if pkg.Config.MeteringReport != nil {
+			merged.MeteringReport = *pkg.Config.MeteringReport
+		}
+	}
+
+	return &merged
+}
+
+// ResolveTaskSteps expands a named task into an ordered list of command
+// lines. Steps naming another task are replaced by that task's steps first
+// (dependency ordering); each task contributes its steps at most once, and
+// cycles are reported as an error.
+func (c *Config) ResolveTaskSteps(name string) ([]string, error) {
+	if _, ok := c.Tasks[name]; !ok {
+		return nil, fmt.Errorf("task %q is not defined", name)
+	}
+
+	var steps []string
+	visiting := make(map[string]bool)
+	visited := make(map[string]bool)
+
+	var expand func(taskName string, path []string) error
+	expand = func(taskName string, path []string) error {
+		if visited[taskName] {
+			return nil
+		}
+		if visiting[taskName] {
+			return fmt.Errorf("task cycle detected: %s -> %s", strings.Join(path, " -> "), taskName)
+		}
+		visiting[taskName] = true
+
+		for _, step := range c.Tasks[taskName] {
+			// A step whose first token names another task is a dependency
+			stepName := strings.Fields(step)
+			if len(stepName) == 1 {
+				if _, ok := c.Tasks[stepName[0]]; ok {
+					if err := expand(stepName[0], append(path, taskName)); err != nil {
+						return err
+					}
+					continue
+				}
+			}
+			steps = append(steps, step)
+		}
+
+		visiting[taskName] = false
+		visited[taskName] = true
+		return nil
+	}
+
+	if err := expand(name, nil); err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
 func ToYAML(config *Config) string {
 	yaml, err := yaml.Marshal(config)
 	if err != nil {
@@ -80,6 +357,10 @@ func FromYAML(yamlString string) *Config {
 	if err != nil {
 		panic(err)
 	}
+	// A flavour declared in the file fills in its flavour-specific defaults
+	if config.Flavour != "" {
+		config.ApplyFlavour(config.Flavour)
+	}
 	return &config
 }
 
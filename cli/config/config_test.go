@@ -130,3 +130,202 @@ func TestWriteConfigFile(t *testing.T) {
 		t.Errorf("Expected AOSGitHash to be %s, got %s", config.AOSGitHash, readConfig.AOSGitHash)
 	}
 }
+
+func TestResolveTaskSteps(t *testing.T) {
+	config := &Config{
+		Tasks: map[string][]string{
+			"build-all": {"build --entrypoint main.lua"},
+			"release":   {"build-all", "upload-module --dry-run"},
+		},
+	}
+
+	steps, err := config.ResolveTaskSteps("release")
+	if err != nil {
+		t.Fatalf("ResolveTaskSteps failed: %v", err)
+	}
+
+	expected := []string{"build --entrypoint main.lua", "upload-module --dry-run"}
+	if len(steps) != len(expected) {
+		t.Fatalf("Expected %d steps, got %d: %v", len(expected), len(steps), steps)
+	}
+	for i, step := range expected {
+		if steps[i] != step {
+			t.Errorf("Expected step %d to be %q, got %q", i, step, steps[i])
+		}
+	}
+}
+
+func TestResolveTaskStepsUndefined(t *testing.T) {
+	config := &Config{Tasks: map[string][]string{}}
+	if _, err := config.ResolveTaskSteps("release"); err == nil {
+		t.Error("Expected an error for an undefined task")
+	}
+}
+
+func TestResolveTaskStepsCycle(t *testing.T) {
+	config := &Config{
+		Tasks: map[string][]string{
+			"a": {"b"},
+			"b": {"a"},
+		},
+	}
+	if _, err := config.ResolveTaskSteps("a"); err == nil {
+		t.Error("Expected an error for a task cycle")
+	}
+}
+
+func TestResolveTaskStepsSharedDependency(t *testing.T) {
+	config := &Config{
+		Tasks: map[string][]string{
+			"build-all": {"build"},
+			"check":     {"build-all", "wasm inspect dist/process.wasm"},
+			"release":   {"build-all", "check", "upload-module"},
+		},
+	}
+
+	steps, err := config.ResolveTaskSteps("release")
+	if err != nil {
+		t.Fatalf("ResolveTaskSteps failed: %v", err)
+	}
+
+	// build-all must only contribute its steps once
+	expected := []string{"build", "wasm inspect dist/process.wasm", "upload-module"}
+	if len(steps) != len(expected) {
+		t.Fatalf("Expected %d steps, got %d: %v", len(expected), len(steps), steps)
+	}
+	for i, step := range expected {
+		if steps[i] != step {
+			t.Errorf("Expected step %d to be %q, got %q", i, step, steps[i])
+		}
+	}
+}
+
+func TestResolveWorkspacePackages(t *testing.T) {
+	config := &Config{
+		Workspace: []WorkspacePackage{
+			{Name: "token", Entrypoint: "token/main.lua"},
+			{Name: "registry", Entrypoint: "registry/main.lua", DependsOn: []string{"token"}},
+			{Name: "router", Entrypoint: "router/main.lua", DependsOn: []string{"registry"}},
+		},
+	}
+
+	ordered, err := config.ResolveWorkspacePackages(nil)
+	if err != nil {
+		t.Fatalf("ResolveWorkspacePackages failed: %v", err)
+	}
+
+	expected := []string{"token", "registry", "router"}
+	if len(ordered) != len(expected) {
+		t.Fatalf("Expected %d packages, got %d", len(expected), len(ordered))
+	}
+	for i, name := range expected {
+		if ordered[i].Name != name {
+			t.Errorf("Expected package %d to be %q, got %q", i, name, ordered[i].Name)
+		}
+	}
+}
+
+func TestResolveWorkspacePackagesSubset(t *testing.T) {
+	config := &Config{
+		Workspace: []WorkspacePackage{
+			{Name: "token", Entrypoint: "token/main.lua"},
+			{Name: "registry", Entrypoint: "registry/main.lua", DependsOn: []string{"token"}},
+			{Name: "unrelated", Entrypoint: "unrelated/main.lua"},
+		},
+	}
+
+	ordered, err := config.ResolveWorkspacePackages([]string{"registry"})
+	if err != nil {
+		t.Fatalf("ResolveWorkspacePackages failed: %v", err)
+	}
+
+	// The dependency is pulled in, the unrelated package is not
+	if len(ordered) != 2 || ordered[0].Name != "token" || ordered[1].Name != "registry" {
+		names := make([]string, len(ordered))
+		for i, pkg := range ordered {
+			names[i] = pkg.Name
+		}
+		t.Errorf("Expected [token registry], got %v", names)
+	}
+}
+
+func TestResolveWorkspacePackagesCycle(t *testing.T) {
+	config := &Config{
+		Workspace: []WorkspacePackage{
+			{Name: "a", DependsOn: []string{"b"}},
+			{Name: "b", DependsOn: []string{"a"}},
+		},
+	}
+	if _, err := config.ResolveWorkspacePackages(nil); err == nil {
+		t.Error("Expected an error for a package dependency cycle")
+	}
+}
+
+func TestPackageConfig(t *testing.T) {
+	maximumMemory := 536870912
+	optimize := true
+	config := NewConfig(nil)
+	config.Workspace = []WorkspacePackage{
+		{
+			Name:       "token",
+			Entrypoint: "token/main.lua",
+			Config: &PartialConfig{
+				MaximumMemory: &maximumMemory,
+				Optimize:      &optimize,
+			},
+		},
+	}
+
+	merged := config.PackageConfig(&config.Workspace[0])
+	if merged.MaximumMemory != maximumMemory {
+		t.Errorf("Expected MaximumMemory override %d, got %d", maximumMemory, merged.MaximumMemory)
+	}
+	if !merged.Optimize {
+		t.Error("Expected Optimize override to be applied")
+	}
+	if merged.StackSize != DefaultStackSize {
+		t.Errorf("Expected StackSize to fall back to default %d, got %d", DefaultStackSize, merged.StackSize)
+	}
+}
+
+func TestApplyFlavourSqlite(t *testing.T) {
+	cfg := NewConfig(nil)
+	cfg.ApplyFlavour(FlavourAOSSqlite)
+
+	if cfg.Flavour != FlavourAOSSqlite {
+		t.Errorf("Expected flavour %q, got %q", FlavourAOSSqlite, cfg.Flavour)
+	}
+	if cfg.Target != SqliteTarget {
+		t.Errorf("Expected target %d, got %d", SqliteTarget, cfg.Target)
+	}
+	if cfg.ModuleFormat != SqliteModuleFormat {
+		t.Errorf("Expected module format %q, got %q", SqliteModuleFormat, cfg.ModuleFormat)
+	}
+	if cfg.MaximumMemory != SqliteMaximumMemory {
+		t.Errorf("Expected maximum memory %d, got %d", SqliteMaximumMemory, cfg.MaximumMemory)
+	}
+}
+
+func TestApplyFlavourKeepsExplicitOverrides(t *testing.T) {
+	cfg := NewConfig(nil)
+	cfg.MaximumMemory = 2147483648 // explicitly pinned, differs from the vanilla default
+	cfg.ApplyFlavour(FlavourAOSSqlite)
+
+	if cfg.MaximumMemory != 2147483648 {
+		t.Errorf("Expected explicit maximum memory to be kept, got %d", cfg.MaximumMemory)
+	}
+	if cfg.Target != SqliteTarget {
+		t.Errorf("Expected target to switch to %d, got %d", SqliteTarget, cfg.Target)
+	}
+}
+
+func TestFromYAMLAppliesFlavour(t *testing.T) {
+	cfg := FromYAML("flavour: aos-sqlite\n")
+
+	if cfg.ModuleFormat != SqliteModuleFormat {
+		t.Errorf("Expected sqlite module format, got %q", cfg.ModuleFormat)
+	}
+	if cfg.AOSGitHash != SqliteAOSGitHash {
+		t.Errorf("Expected sqlite git ref, got %q", cfg.AOSGitHash)
+	}
+}
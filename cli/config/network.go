@@ -0,0 +1,86 @@
+package config
+
+import "fmt"
+
+// Network presets selectable via the config file or --network flags
+const (
+	NetworkMainnet  = "mainnet"
+	NetworkTestnet  = "testnet"
+	NetworkLocalnet = "localnet"
+)
+
+// NetworkConfig is the `network` section of the config file. It selects which
+// AO and Arweave endpoints the toolkit talks to, either via a named preset or
+// by overriding individual URLs (explicit URLs win over the preset).
+type NetworkConfig struct {
+	Preset  string `yaml:"preset,omitempty"`  // mainnet (default), testnet, or localnet
+	Gateway string `yaml:"gateway,omitempty"` // Arweave gateway URL
+	CU      string `yaml:"cu,omitempty"`      // Compute unit URL
+	MU      string `yaml:"mu,omitempty"`      // Messenger unit URL
+	Bundler string `yaml:"bundler,omitempty"` // Bundler / upload service URL
+}
+
+// networkPresets holds the endpoint sets behind each named preset. The
+// localnet entries match the default ports of ao-localnet.
+var networkPresets = map[string]NetworkConfig{
+	NetworkMainnet: {
+		Gateway: "https://arweave.net",
+		CU:      "https://cu.ao-testnet.xyz",
+		MU:      "https://mu.ao-testnet.xyz",
+		Bundler: "https://upload.ardrive.io",
+	},
+	NetworkTestnet: {
+		Gateway: "https://arweave.net",
+		CU:      "https://cu.ao-testnet.xyz",
+		MU:      "https://mu.ao-testnet.xyz",
+		Bundler: "https://upload.ardrive.dev",
+	},
+	NetworkLocalnet: {
+		Gateway: "http://localhost:4000",
+		CU:      "http://localhost:6363",
+		MU:      "http://localhost:3004",
+		Bundler: "http://localhost:4000",
+	},
+}
+
+// NetworkPreset returns the endpoint set of a named preset
+func NetworkPreset(name string) (NetworkConfig, error) {
+	preset, ok := networkPresets[name]
+	if !ok {
+		return NetworkConfig{}, fmt.Errorf("unknown network preset %q: expected mainnet, testnet, or localnet", name)
+	}
+	return preset, nil
+}
+
+// Resolve returns the effective endpoints: the selected preset (mainnet when
+// none is set) overlaid with any explicitly configured URLs. A nil receiver
+// yields the mainnet defaults.
+func (n *NetworkConfig) Resolve() (NetworkConfig, error) {
+	if n == nil {
+		return networkPresets[NetworkMainnet], nil
+	}
+
+	presetName := n.Preset
+	if presetName == "" {
+		presetName = NetworkMainnet
+	}
+	resolved, err := NetworkPreset(presetName)
+	if err != nil {
+		return NetworkConfig{}, err
+	}
+	resolved.Preset = presetName
+
+	if n.Gateway != "" {
+		resolved.Gateway = n.Gateway
+	}
+	if n.CU != "" {
+		resolved.CU = n.CU
+	}
+	if n.MU != "" {
+		resolved.MU = n.MU
+	}
+	if n.Bundler != "" {
+		resolved.Bundler = n.Bundler
+	}
+	return resolved, nil
+}
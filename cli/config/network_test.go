@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestResolveNilNetworkYieldsMainnet(t *testing.T) {
+	var network *NetworkConfig
+	resolved, err := network.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if resolved.Gateway != "https://arweave.net" {
+		t.Errorf("Expected mainnet gateway, got %q", resolved.Gateway)
+	}
+}
+
+func TestResolvePresetWithOverrides(t *testing.T) {
+	network := &NetworkConfig{
+		Preset: NetworkLocalnet,
+		CU:     "http://cu.example:1234",
+	}
+	resolved, err := network.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if resolved.CU != "http://cu.example:1234" {
+		t.Errorf("Expected explicit CU to win, got %q", resolved.CU)
+	}
+	if resolved.Gateway != "http://localhost:4000" {
+		t.Errorf("Expected localnet gateway, got %q", resolved.Gateway)
+	}
+}
+
+func TestResolveUnknownPreset(t *testing.T) {
+	network := &NetworkConfig{Preset: "devnet"}
+	if _, err := network.Resolve(); err == nil {
+		t.Error("Expected error for unknown preset")
+	}
+}
+
+func TestFromYAMLNetworkSection(t *testing.T) {
+	cfg := FromYAML("network:\n  preset: localnet\n  mu: http://mu.example\n")
+	if cfg.Network == nil {
+		t.Fatal("Expected network section to be parsed")
+	}
+	resolved, err := cfg.Network.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if resolved.MU != "http://mu.example" || resolved.CU != "http://localhost:6363" {
+		t.Errorf("Unexpected resolved endpoints: %+v", resolved)
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+// ProcessPatchConfig customizes how the build modifies the AOS checkout's
+// process.lua before compiling, beyond the standard bundled-code require.
+// Every patched result is syntax-checked before the build continues.
+type ProcessPatchConfig struct {
+	// InjectionPoint selects where the bundled-code require lands:
+	// "last-handler" (default: after the last Handlers.append), "before-init"
+	// (before the first Handlers.append, i.e. before the default handlers are
+	// installed), "after-boot" (after the .boot require), or "marker"
+	// (replacing the marker comment line).
+	InjectionPoint string `yaml:"injection_point,omitempty"`
+
+	// Marker is the comment line replaced when injection_point is "marker",
+	// e.g. "-- HARLEQUIN:BUNDLE"
+	Marker string `yaml:"marker,omitempty"`
+
+	// RemoveHandlers lists default AOS handlers to remove at load time
+	// (e.g. "_default"), via Handlers.remove calls appended to the process
+	RemoveHandlers []string `yaml:"remove_handlers,omitempty"`
+
+	// Files are user-provided Lua patch files applied to process.lua, each at
+	// its own injection point (appended to the end of the file by default)
+	Files []ProcessPatchFile `yaml:"files,omitempty"`
+}
+
+// ProcessPatchFile is one Lua patch file and where it is inserted
+type ProcessPatchFile struct {
+	Path   string `yaml:"path"`             // Lua file, relative to the config file
+	Point  string `yaml:"point,omitempty"`  // Injection point; same options as injection_point, plus "append" (default)
+	Marker string `yaml:"marker,omitempty"` // Marker comment when point is "marker"
+}
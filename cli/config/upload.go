@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// UploadConfig is the publishing section of .harlequin.yaml: the protocol and
+// application tags attached when a module is uploaded. Zero values fall back
+// to the standard AO module tags at upload time.
+type UploadConfig struct {
+	DataProtocol   string `yaml:"data_protocol,omitempty"`
+	Variant        string `yaml:"variant,omitempty"`
+	Type           string `yaml:"type,omitempty"`
+	InputEncoding  string `yaml:"input_encoding,omitempty"`
+	OutputEncoding string `yaml:"output_encoding,omitempty"`
+	ContentType    string `yaml:"content_type,omitempty"`
+	AppName        string `yaml:"app_name,omitempty"`
+	AppVersion     string `yaml:"app_version,omitempty"`
+	Author         string `yaml:"author,omitempty"`
+}
+
+// ReadUploadConfig reads a config file for an upload, accepting both the
+// current schema (publishing keys under an upload: section) and the retired
+// ao-build-config.yml schema, which kept the same keys at the top level.
+// The returned config always has a non-nil Upload section.
+func ReadUploadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if config.Flavour != "" {
+		config.ApplyFlavour(config.Flavour)
+	}
+
+	if config.Upload == nil {
+		// Legacy files carry the publishing keys at the top level
+		var legacy UploadConfig
+		if err := yaml.Unmarshal(content, &legacy); err == nil {
+			config.Upload = &legacy
+		} else {
+			config.Upload = &UploadConfig{}
+		}
+	}
+
+	return &config, nil
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// UploadTagsFileName is the per-project preset of custom upload tags. It holds
+// a flat key/value map that is merged over the automatically generated tags
+// whenever a module from the project is uploaded.
+const UploadTagsFileName = ".harlequin-tags.yaml"
+
+// LoadUploadTags reads the upload tag preset from dir. A missing preset file
+// is not an error - it simply yields an empty map.
+func LoadUploadTags(dir string) (map[string]string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, UploadTagsFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+
+	tags := map[string]string{}
+	if err := yaml.Unmarshal(content, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// SaveUploadTags writes the upload tag preset to dir, replacing any existing
+// preset file.
+func SaveUploadTags(dir string, tags map[string]string) error {
+	content, err := yaml.Marshal(tags)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, UploadTagsFileName), content, 0644)
+}
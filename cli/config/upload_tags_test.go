@@ -0,0 +1,31 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestUploadTagsRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	// A project without a preset yields an empty map
+	tags, err := LoadUploadTags(dir)
+	if err != nil {
+		t.Fatalf("LoadUploadTags failed: %v", err)
+	}
+	if len(tags) != 0 {
+		t.Errorf("Expected empty map for missing preset, got %v", tags)
+	}
+
+	saved := map[string]string{"Team": "Protocol", "App-Name": "My-App"}
+	if err := SaveUploadTags(dir, saved); err != nil {
+		t.Fatalf("SaveUploadTags failed: %v", err)
+	}
+
+	loaded, err := LoadUploadTags(dir)
+	if err != nil {
+		t.Fatalf("LoadUploadTags failed: %v", err)
+	}
+	if loaded["Team"] != "Protocol" || loaded["App-Name"] != "My-App" {
+		t.Errorf("Preset did not round-trip: %v", loaded)
+	}
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadUploadConfigNestedSection(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".harlequin.yaml")
+
+	content := `stack_size: 1024
+upload:
+  app_name: My-App
+  variant: ao.TN.2
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := ReadUploadConfig(path)
+	if err != nil {
+		t.Fatalf("ReadUploadConfig failed: %v", err)
+	}
+	if config.StackSize != 1024 {
+		t.Errorf("Expected stack size 1024, got %d", config.StackSize)
+	}
+	if config.Upload == nil {
+		t.Fatal("Expected non-nil upload section")
+	}
+	if config.Upload.AppName != "My-App" {
+		t.Errorf("Expected app name My-App, got %q", config.Upload.AppName)
+	}
+	if config.Upload.Variant != "ao.TN.2" {
+		t.Errorf("Expected variant ao.TN.2, got %q", config.Upload.Variant)
+	}
+}
+
+func TestReadUploadConfigLegacyFlatKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ao-build-config.yml")
+
+	content := `compute_limit: "12000"
+app_name: Legacy-App
+author: Someone
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := ReadUploadConfig(path)
+	if err != nil {
+		t.Fatalf("ReadUploadConfig failed: %v", err)
+	}
+	if config.ComputeLimit != "12000" {
+		t.Errorf("Expected compute limit 12000, got %q", config.ComputeLimit)
+	}
+	if config.Upload == nil {
+		t.Fatal("Expected non-nil upload section")
+	}
+	if config.Upload.AppName != "Legacy-App" {
+		t.Errorf("Expected app name Legacy-App, got %q", config.Upload.AppName)
+	}
+	if config.Upload.Author != "Someone" {
+		t.Errorf("Expected author Someone, got %q", config.Upload.Author)
+	}
+}
+
+func TestReadUploadConfigMissingFile(t *testing.T) {
+	if _, err := ReadUploadConfig(filepath.Join(t.TempDir(), "nope.yaml")); err == nil {
+		t.Error("Expected an error for a missing config file")
+	}
+}
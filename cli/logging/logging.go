@@ -0,0 +1,73 @@
+// Package logging provides the CLI's leveled, structured logger on top of
+// log/slog, together with the quiet mode that suppresses progress output.
+// Structured records go to stderr so they never mix with command results on
+// stdout; by default only warnings and errors are emitted, and --log-level
+// surfaces the rest.
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+var quiet bool
+
+// Setup configures the default slog logger from the global CLI flags. An
+// empty level keeps the default of only emitting warnings and errors.
+func Setup(level string, jsonOutput, quietMode bool) error {
+	quiet = quietMode
+
+	var lvl slog.Level
+	switch strings.ToLower(level) {
+	case "":
+		lvl = slog.LevelWarn
+	case "debug":
+		lvl = slog.LevelDebug
+	case "info":
+		lvl = slog.LevelInfo
+	case "warn":
+		lvl = slog.LevelWarn
+	case "error":
+		lvl = slog.LevelError
+	default:
+		return fmt.Errorf("unknown log level %q: expected debug, info, warn, or error", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	var handler slog.Handler
+	if jsonOutput {
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	} else {
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	}
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// Quiet reports whether progress output should be suppressed, leaving only
+// final results on stdout
+func Quiet() bool {
+	return quiet
+}
+
+// SetQuiet toggles quiet mode after setup. Machine-readable output modes use
+// it so progress output cannot mix with the document on stdout.
+func SetQuiet(on bool) {
+	quiet = on
+}
+
+// Printf prints human progress output, suppressed in quiet mode
+func Printf(format string, args ...interface{}) {
+	if !quiet {
+		fmt.Printf(format, args...)
+	}
+}
+
+// Println prints human progress output, suppressed in quiet mode
+func Println(args ...interface{}) {
+	if !quiet {
+		fmt.Println(args...)
+	}
+}
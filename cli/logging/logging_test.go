@@ -0,0 +1,31 @@
+package logging
+
+import "testing"
+
+func TestSetupLevels(t *testing.T) {
+	for _, level := range []string{"", "debug", "info", "warn", "error", "WARN"} {
+		if err := Setup(level, false, false); err != nil {
+			t.Errorf("Setup(%q) returned error: %v", level, err)
+		}
+	}
+
+	if err := Setup("verbose", false, false); err == nil {
+		t.Error("expected error for unknown log level")
+	}
+}
+
+func TestQuietSuppressesOutput(t *testing.T) {
+	if err := Setup("", false, true); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if !Quiet() {
+		t.Error("expected Quiet() to be true after quiet setup")
+	}
+
+	if err := Setup("", false, false); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if Quiet() {
+		t.Error("expected Quiet() to be false after normal setup")
+	}
+}
@@ -0,0 +1,126 @@
+package luautils
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// cachedFile holds everything the bundler learned about one file on a previous
+// walk: its content, the child modules parsed from its require() statements,
+// and enough metadata to detect when the file changed on disk
+type cachedFile struct {
+	modTime  time.Time
+	size     int64
+	hash     [sha256.Size]byte
+	content  *string
+	children []Module
+}
+
+// BundleCache memoizes file contents and parsed dependencies across bundles so
+// repeated builds (e.g. a watch loop) only re-read files that changed. Files
+// are validated with a cheap mtime/size check first, then a content hash, so
+// touching a file without editing it does not invalidate its subtree.
+// A BundleCache is safe for use from a single goroutine at a time per bundle;
+// the internal map is locked so concurrent bundles sharing one cache are safe.
+type BundleCache struct {
+	mu    sync.Mutex
+	files map[string]*cachedFile
+}
+
+// NewBundleCache creates an empty bundle cache
+func NewBundleCache() *BundleCache {
+	return &BundleCache{files: make(map[string]*cachedFile)}
+}
+
+// BundleTo streams the bundled executable to a writer, reusing cached file
+// contents and dependency lists for files that have not changed since the
+// previous bundle
+func (c *BundleCache) BundleTo(w io.Writer, entryLuaPath string, opts BundleOptions) error {
+	project, err := createProjectStructureCached(entryLuaPath, opts.AllowCycles, c)
+	if err != nil {
+		return fmt.Errorf("failed to create project structure: %w", err)
+	}
+
+	if err := writeExecutableFromProject(w, project); err != nil {
+		return fmt.Errorf("failed to create executable: %w", err)
+	}
+	return nil
+}
+
+// BundleToFile streams the bundled executable to an output file, reusing the
+// cache like BundleTo
+func (c *BundleCache) BundleToFile(entryLuaPath, outputPath string, opts BundleOptions) error {
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	writer := bufio.NewWriter(file)
+	if err := c.BundleTo(writer, entryLuaPath, opts); err != nil {
+		file.Close()
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return file.Close()
+}
+
+// load returns the content and child dependencies for a module, re-reading and
+// re-parsing the file only when it changed on disk. Missing files return a nil
+// content pointer, matching the uncached walk.
+func (c *BundleCache) load(node Module, cwd string) (*string, []Module, error) {
+	info, err := os.Stat(node.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to stat file %s: %w", node.Path, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.files[node.Path]
+	if entry != nil && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+		// Unchanged metadata: trust the cached subtree without touching the file
+		return entry.content, entry.children, nil
+	}
+
+	content, err := os.ReadFile(node.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read file %s: %w", node.Path, err)
+	}
+
+	hash := sha256.Sum256(content)
+	if entry != nil && entry.hash == hash {
+		// Same content under new metadata (e.g. a touch): keep the parsed
+		// dependencies, just refresh the fast-path check
+		entry.modTime = info.ModTime()
+		entry.size = info.Size()
+		return entry.content, entry.children, nil
+	}
+
+	contentStr := string(content)
+
+	// Assets are leaf nodes; their content is not scanned for require() statements
+	var children []Module
+	if !node.Asset {
+		children = parseRequires(contentStr, cwd)
+	}
+
+	c.files[node.Path] = &cachedFile{
+		modTime:  info.ModTime(),
+		size:     info.Size(),
+		hash:     hash,
+		content:  &contentStr,
+		children: children,
+	}
+	return &contentStr, children, nil
+}
@@ -0,0 +1,138 @@
+package luautils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeCacheTestProject creates a small entrypoint + dependency pair and
+// returns the entrypoint path
+func writeCacheTestProject(t *testing.T, tempDir string) string {
+	t.Helper()
+
+	helperContent := `local helper = {}
+function helper.greet()
+	return "hello"
+end
+return helper
+`
+	if err := os.WriteFile(filepath.Join(tempDir, "helper.lua"), []byte(helperContent), 0644); err != nil {
+		t.Fatalf("Failed to write helper file: %v", err)
+	}
+
+	mainContent := `local helper = require("helper")
+print(helper.greet())
+`
+	mainFile := filepath.Join(tempDir, "main.lua")
+	if err := os.WriteFile(mainFile, []byte(mainContent), 0644); err != nil {
+		t.Fatalf("Failed to write main file: %v", err)
+	}
+
+	return mainFile
+}
+
+func TestBundleCacheMatchesBundle(t *testing.T) {
+	tempDir := t.TempDir()
+	mainFile := writeCacheTestProject(t, tempDir)
+
+	expected, err := Bundle(mainFile)
+	if err != nil {
+		t.Fatalf("Bundle failed: %v", err)
+	}
+
+	cache := NewBundleCache()
+	var first strings.Builder
+	if err := cache.BundleTo(&first, mainFile, BundleOptions{}); err != nil {
+		t.Fatalf("Cached bundle failed: %v", err)
+	}
+	if first.String() != expected {
+		t.Error("Cached bundle output differs from uncached bundle")
+	}
+
+	// A second bundle from the same cache should produce identical output
+	var second strings.Builder
+	if err := cache.BundleTo(&second, mainFile, BundleOptions{}); err != nil {
+		t.Fatalf("Second cached bundle failed: %v", err)
+	}
+	if second.String() != expected {
+		t.Error("Repeated cached bundle output differs from uncached bundle")
+	}
+}
+
+func TestBundleCacheDetectsChangedFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	mainFile := writeCacheTestProject(t, tempDir)
+
+	cache := NewBundleCache()
+	var first strings.Builder
+	if err := cache.BundleTo(&first, mainFile, BundleOptions{}); err != nil {
+		t.Fatalf("Cached bundle failed: %v", err)
+	}
+
+	// Rewrite the dependency and make sure the next bundle picks it up
+	updatedHelper := `local helper = {}
+function helper.greet()
+	return "goodbye"
+end
+return helper
+`
+	helperFile := filepath.Join(tempDir, "helper.lua")
+	if err := os.WriteFile(helperFile, []byte(updatedHelper), 0644); err != nil {
+		t.Fatalf("Failed to rewrite helper file: %v", err)
+	}
+
+	var second strings.Builder
+	if err := cache.BundleTo(&second, mainFile, BundleOptions{}); err != nil {
+		t.Fatalf("Cached bundle after edit failed: %v", err)
+	}
+
+	if !strings.Contains(second.String(), "goodbye") {
+		t.Error("Cached bundle did not pick up the edited dependency")
+	}
+	if first.String() == second.String() {
+		t.Error("Cached bundle output unchanged after dependency edit")
+	}
+}
+
+func TestBundleCacheReusesUntouchedContent(t *testing.T) {
+	tempDir := t.TempDir()
+	mainFile := writeCacheTestProject(t, tempDir)
+
+	cache := NewBundleCache()
+	var first strings.Builder
+	if err := cache.BundleTo(&first, mainFile, BundleOptions{}); err != nil {
+		t.Fatalf("Cached bundle failed: %v", err)
+	}
+
+	helperFile := filepath.Join(tempDir, "helper.lua")
+	before := cache.files[helperFile]
+	if before == nil {
+		t.Fatal("Expected helper file to be cached after first bundle")
+	}
+
+	// Bump the mtime without changing the content; the hash check should keep
+	// the parsed entry instead of rebuilding it
+	newTime := time.Now().Add(time.Hour)
+	if err := os.Chtimes(helperFile, newTime, newTime); err != nil {
+		t.Fatalf("Failed to update file times: %v", err)
+	}
+
+	var second strings.Builder
+	if err := cache.BundleTo(&second, mainFile, BundleOptions{}); err != nil {
+		t.Fatalf("Cached bundle after touch failed: %v", err)
+	}
+
+	after := cache.files[helperFile]
+	if after != before {
+		t.Error("Expected touched-but-unchanged file to keep its cache entry")
+	}
+	if after.content != before.content {
+		t.Error("Expected touched-but-unchanged file to reuse its cached content")
+	}
+	if first.String() != second.String() {
+		t.Error("Cached bundle output changed after touch without edit")
+	}
+}
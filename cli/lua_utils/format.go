@@ -0,0 +1,156 @@
+package luautils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// formatIndent is the indentation unit applied to formatted Lua source
+const formatIndent = "    "
+
+// maxBlankLines caps how many consecutive blank lines survive formatting
+const maxBlankLines = 2
+
+// ProjectLuaFiles returns the Lua source files in the bundle dependency
+// graph of an entrypoint, in dependency order. Embedded assets are not Lua
+// and are excluded.
+func ProjectLuaFiles(entryLuaPath string) ([]string, error) {
+	project, err := createProjectStructure(entryLuaPath, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project structure: %w", err)
+	}
+
+	var files []string
+	seen := make(map[string]bool)
+	for _, mod := range project {
+		if mod.Asset || seen[mod.Path] {
+			continue
+		}
+		seen[mod.Path] = true
+		files = append(files, mod.Path)
+	}
+	return files, nil
+}
+
+// blockOpeners are keywords that open a Lua block and indent what follows.
+// "then" rather than "if" so single-line conditionals balance with their
+// trailing "end", and "if ... then return x end" stays flat.
+var blockOpeners = regexp.MustCompile(`\b(function|then|do|repeat)\b`)
+
+// blockClosers are keywords that close a Lua block
+var blockClosers = regexp.MustCompile(`\b(end|until)\b`)
+
+// elseifPattern cancels the "then" an elseif clause carries, since the
+// branch continues the block the original "if ... then" already opened
+var elseifPattern = regexp.MustCompile(`\belseif\b`)
+
+// dedentPrefixes start lines that render one level shallower than the
+// current block depth
+var dedentPrefixes = regexp.MustCompile(`^(end\b|until\b|else\b|elseif\b|\}|\))`)
+
+// longBracketOpen matches the start of a Lua long string or long comment,
+// capturing its level so [[ ]] and [==[ ]==] close correctly
+var longBracketOpen = regexp.MustCompile(`(?:--)?\[(=*)\[`)
+
+// FormatLua applies a conservative, StyLua-compatible subset of formatting
+// rules to Lua source: consistent block indentation, trailing whitespace
+// removal, collapsed blank-line runs, and a single trailing newline. Long
+// strings and long comments are passed through untouched.
+func FormatLua(content string) string {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	var formatted []string
+	depth := 0
+	blanks := 0
+	longBracketLevel := -1 // -1 means not inside a long string/comment
+
+	for _, line := range lines {
+		// Inside a long string or comment: emit verbatim until it closes
+		if longBracketLevel >= 0 {
+			formatted = append(formatted, strings.TrimRight(line, " \t"))
+			if strings.Contains(line, "]"+strings.Repeat("=", longBracketLevel)+"]") {
+				longBracketLevel = -1
+			}
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+
+		// Collapse runs of blank lines
+		if trimmed == "" {
+			blanks++
+			if blanks <= maxBlankLines {
+				formatted = append(formatted, "")
+			}
+			continue
+		}
+		blanks = 0
+
+		code := stripStringsAndComments(trimmed)
+
+		// Lines that begin with a closer render one level shallower
+		indent := depth
+		if dedentPrefixes.MatchString(code) && indent > 0 {
+			indent--
+		}
+		formatted = append(formatted, strings.Repeat(formatIndent, indent)+trimmed)
+
+		// Update block depth from the code-only view of the line
+		depth += len(blockOpeners.FindAllString(code, -1))
+		depth -= len(blockClosers.FindAllString(code, -1))
+		depth -= len(elseifPattern.FindAllString(code, -1))
+		depth += strings.Count(code, "{") - strings.Count(code, "}")
+		depth += strings.Count(code, "(") - strings.Count(code, ")")
+		if depth < 0 {
+			depth = 0
+		}
+
+		// Track long strings/comments that stay open past this line
+		if match := longBracketOpen.FindStringSubmatch(trimmed); match != nil {
+			level := len(match[1])
+			if !strings.Contains(trimmed[strings.Index(trimmed, match[0])+len(match[0]):], "]"+match[1]+"]") {
+				longBracketLevel = level
+			}
+		}
+	}
+
+	// Trim leading/trailing blank lines and end with exactly one newline
+	result := strings.Trim(strings.Join(formatted, "\n"), "\n")
+	return result + "\n"
+}
+
+// stripStringsAndComments removes string literals and trailing comments from
+// a single line so block keywords inside them never affect indentation
+func stripStringsAndComments(line string) string {
+	var out strings.Builder
+	inString := byte(0)
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+
+		if inString != 0 {
+			if c == '\\' {
+				i++
+			} else if c == inString {
+				inString = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '"', '\'':
+			inString = c
+		case '-':
+			if i+1 < len(line) && line[i+1] == '-' {
+				// Rest of the line is a comment
+				return out.String()
+			}
+			out.WriteByte(c)
+		default:
+			out.WriteByte(c)
+		}
+	}
+
+	return out.String()
+}
@@ -0,0 +1,115 @@
+package luautils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFormatLua(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "reindents blocks",
+			input:    "local function add(a, b)\nreturn a + b\nend\n",
+			expected: "local function add(a, b)\n    return a + b\nend\n",
+		},
+		{
+			name:     "handles if else chains",
+			input:    "if x then\nprint(1)\nelseif y then\nprint(2)\nelse\nprint(3)\nend\n",
+			expected: "if x then\n    print(1)\nelseif y then\n    print(2)\nelse\n    print(3)\nend\n",
+		},
+		{
+			name:     "strips trailing whitespace",
+			input:    "local x = 1   \n",
+			expected: "local x = 1\n",
+		},
+		{
+			name:     "collapses blank line runs",
+			input:    "local a = 1\n\n\n\n\nlocal b = 2\n",
+			expected: "local a = 1\n\n\nlocal b = 2\n",
+		},
+		{
+			name:     "keywords inside strings do not indent",
+			input:    "local s = \"function then do\"\nlocal t = 1\n",
+			expected: "local s = \"function then do\"\nlocal t = 1\n",
+		},
+		{
+			name:     "keywords inside comments do not indent",
+			input:    "local a = 1 -- if x then\nlocal b = 2\n",
+			expected: "local a = 1 -- if x then\nlocal b = 2\n",
+		},
+		{
+			name:     "single line blocks stay flat",
+			input:    "if x then return 1 end\nlocal y = 2\n",
+			expected: "if x then return 1 end\nlocal y = 2\n",
+		},
+		{
+			name:     "tables indent their fields",
+			input:    "local t = {\na = 1,\nb = 2,\n}\n",
+			expected: "local t = {\n    a = 1,\n    b = 2,\n}\n",
+		},
+		{
+			name:     "long strings are untouched",
+			input:    "local s = [[\n  raw   content\n]]\nlocal x = 1\n",
+			expected: "local s = [[\n  raw   content\n]]\nlocal x = 1\n",
+		},
+		{
+			name:     "ends with exactly one newline",
+			input:    "local x = 1",
+			expected: "local x = 1\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := FormatLua(test.input)
+			if result != test.expected {
+				t.Errorf("FormatLua(%q) = %q, expected %q", test.input, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestFormatLuaIdempotent(t *testing.T) {
+	input := "local function handler(msg)\nif msg.Tags.Action == \"Ping\" then\nreturn \"Pong\"\nend\nend\n"
+
+	once := FormatLua(input)
+	twice := FormatLua(once)
+	if once != twice {
+		t.Errorf("FormatLua is not idempotent:\nfirst:  %q\nsecond: %q", once, twice)
+	}
+}
+
+func TestProjectLuaFiles(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lua-fmt-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	mainFile := filepath.Join(tempDir, "main.lua")
+	utilsFile := filepath.Join(tempDir, "utils.lua")
+
+	if err := os.WriteFile(mainFile, []byte("local utils = require(\"utils\")\nprint(utils.greet())\n"), 0644); err != nil {
+		t.Fatalf("Failed to write main file: %v", err)
+	}
+	if err := os.WriteFile(utilsFile, []byte("local M = {}\nfunction M.greet() return \"hi\" end\nreturn M\n"), 0644); err != nil {
+		t.Fatalf("Failed to write utils file: %v", err)
+	}
+
+	files, err := ProjectLuaFiles(mainFile)
+	if err != nil {
+		t.Fatalf("ProjectLuaFiles failed: %v", err)
+	}
+
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 files in dependency graph, got %d: %v", len(files), files)
+	}
+	if files[len(files)-1] != mainFile {
+		t.Errorf("Expected entrypoint last (dependency order), got %v", files)
+	}
+}
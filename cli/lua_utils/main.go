@@ -1,7 +1,9 @@
 package luautils
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -13,93 +15,196 @@ type Module struct {
 	Name    string
 	Path    string
 	Content *string // Use pointer to represent undefined (nil)
+	Lazy    bool    // Part of a dependency cycle; loaded via package.preload instead of eagerly
+	Asset   bool    // Non-Lua asset embedded as a Lua string module
 }
 
-// Bundle creates a bundled Lua executable from an entry Lua file
+// assetExtensions maps require() name suffixes to the file extensions they
+// resolve to, e.g. require("data.routes_json") -> data/routes.json
+var assetExtensions = map[string]bool{
+	"json": true,
+	"txt":  true,
+	"md":   true,
+	"csv":  true,
+}
+
+// BundleOptions controls how the bundler handles the dependency graph
+type BundleOptions struct {
+	// AllowCycles emits lazy-loading stubs for modules that participate in a
+	// dependency cycle instead of failing the bundle
+	AllowCycles bool
+}
+
+// CycleError reports a circular dependency found while walking require() statements
+type CycleError struct {
+	// Path is the chain of module names forming the cycle, e.g. [a, b, a]
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("circular dependency detected: %s (re-run with --allow-cycles to bundle with lazy-loading stubs)",
+		strings.Join(e.Path, " -> "))
+}
+
+// Bundle creates a bundled Lua executable from an entry Lua file.
+// Circular dependencies are reported as an error; use BundleWithOptions
+// with AllowCycles to bundle them via lazy-loading stubs.
 func Bundle(entryLuaPath string) (string, error) {
-	project, err := createProjectStructure(entryLuaPath)
+	return BundleWithOptions(entryLuaPath, BundleOptions{})
+}
+
+// BundleWithOptions creates a bundled Lua executable from an entry Lua file
+// using the provided options
+func BundleWithOptions(entryLuaPath string, opts BundleOptions) (string, error) {
+	var result strings.Builder
+	if err := BundleTo(&result, entryLuaPath, opts); err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+// BundleTo streams the bundled executable to a writer instead of materializing
+// it as one string, which matters for projects embedding large generated data
+// files
+func BundleTo(w io.Writer, entryLuaPath string, opts BundleOptions) error {
+	project, err := createProjectStructure(entryLuaPath, opts.AllowCycles)
 	if err != nil {
-		return "", fmt.Errorf("failed to create project structure: %w", err)
+		return fmt.Errorf("failed to create project structure: %w", err)
 	}
 
-	bundledLua, err := createExecutableFromProject(project)
+	if err := writeExecutableFromProject(w, project); err != nil {
+		return fmt.Errorf("failed to create executable: %w", err)
+	}
+	return nil
+}
+
+// BundleToFile streams the bundled executable directly to an output file
+func BundleToFile(entryLuaPath, outputPath string, opts BundleOptions) error {
+	file, err := os.Create(outputPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to create executable: %w", err)
+		return fmt.Errorf("failed to create output file: %w", err)
 	}
 
-	return bundledLua, nil
+	writer := bufio.NewWriter(file)
+	if err := BundleTo(writer, entryLuaPath, opts); err != nil {
+		file.Close()
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return file.Close()
 }
 
-// createExecutableFromProject converts a project structure into executable Lua code
-func createExecutableFromProject(project []Module) (string, error) {
+// writeExecutableFromProject streams a project structure as executable Lua
+// code, emitting one module at a time
+func writeExecutableFromProject(w io.Writer, project []Module) error {
 	if len(project) == 0 {
-		return "", fmt.Errorf("empty project")
+		return fmt.Errorf("empty project")
 	}
 
-	var contents []Module
+	// First module name emitted for each path, so duplicate requires map to
+	// the already-registered module function
+	firstNameForPath := make(map[string]string)
 
 	// Process all modules except the main file (last one)
 	for i := 0; i < len(project)-1; i++ {
 		mod := project[i]
 
-		// Check if we already have this module path
-		var existing *Module
-		for j := range contents {
-			if contents[j].Path == mod.Path {
-				existing = &contents[j]
-				break
-			}
+		existingName, duplicate := firstNameForPath[mod.Path]
+		if !duplicate {
+			firstNameForPath[mod.Path] = mod.Name
+		}
+
+		if _, err := io.WriteString(w, "\n\n"); err != nil {
+			return err
 		}
 
-		var moduleContent string
-		if existing == nil && mod.Content != nil {
+		if !duplicate && mod.Content != nil {
 			// Create the module function
 			modFnName := getModFnName(mod.Name)
-			moduleContent = fmt.Sprintf("-- module: \"%s\"\nlocal function _loaded_mod_%s()\n%s\nend\n",
-				mod.Name, modFnName, *mod.Content)
+			if mod.Asset {
+				// Embed non-Lua assets as string modules so require() returns
+				// the raw file content
+				if _, err := fmt.Fprintf(w, "-- asset: \"%s\"\nlocal function _loaded_mod_%s()\nreturn %s\nend\n",
+					mod.Name, modFnName, luaLongString(*mod.Content)); err != nil {
+					return err
+				}
+			} else {
+				if _, err := fmt.Fprintf(w, "-- module: \"%s\"\nlocal function _loaded_mod_%s()\n%s\nend\n",
+					mod.Name, modFnName, *mod.Content); err != nil {
+					return err
+				}
+			}
 		}
 
 		// Create the require mapper
-		var targetModName string
-		if existing != nil {
-			targetModName = existing.Name
-		} else {
-			targetModName = mod.Name
+		targetModName := mod.Name
+		if duplicate {
+			targetModName = existingName
 		}
 
-		requireMapper := fmt.Sprintf("\n_G.package.loaded[\"%s\"] = _loaded_mod_%s()",
-			mod.Name, getModFnName(targetModName))
-
-		finalContent := moduleContent + requireMapper
-		contents = append(contents, Module{
-			Name:    mod.Name,
-			Path:    mod.Path,
-			Content: &finalContent,
-		})
+		// Cycle members are registered lazily via package.preload so require()
+		// resolves them on first use instead of at load time
+		if mod.Lazy {
+			if _, err := fmt.Fprintf(w, "\n_G.package.preload[\"%s\"] = _loaded_mod_%s",
+				mod.Name, getModFnName(targetModName)); err != nil {
+				return err
+			}
+		} else {
+			if _, err := fmt.Fprintf(w, "\n_G.package.loaded[\"%s\"] = _loaded_mod_%s()",
+				mod.Name, getModFnName(targetModName)); err != nil {
+				return err
+			}
+		}
 	}
 
-	// Add the main file
-	contents = append(contents, project[len(project)-1])
-
-	// Combine all content
-	var result strings.Builder
-	for _, mod := range contents {
-		if mod.Content != nil {
-			result.WriteString("\n\n")
-			result.WriteString(*mod.Content)
+	// The main file runs last, once every module is registered
+	mainModule := project[len(project)-1]
+	if mainModule.Content != nil {
+		if _, err := io.WriteString(w, "\n\n"); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, *mainModule.Content); err != nil {
+			return err
 		}
 	}
 
-	return result.String(), nil
+	return nil
 }
 
-// createProjectStructure builds the project dependency tree from the main file
-func createProjectStructure(mainFile string) ([]Module, error) {
+// createProjectStructure builds the project dependency tree from the main file.
+// When allowCycles is false, a dependency cycle aborts the walk with a
+// CycleError describing the cycle path; otherwise cycle members are marked
+// Lazy so the executable can register them via package.preload.
+func createProjectStructure(mainFile string, allowCycles bool) ([]Module, error) {
+	return createProjectStructureCached(mainFile, allowCycles, NewBundleCache())
+}
+
+// createProjectStructureCached builds the project dependency tree, reading each
+// file through the cache so unchanged files from a previous bundle are not
+// re-read or re-parsed
+func createProjectStructureCached(mainFile string, allowCycles bool, cache *BundleCache) ([]Module, error) {
 	var sorted []Module
 	cwd := filepath.Dir(mainFile)
 
-	// Track visited nodes to avoid cycles
+	// Track fully-visited nodes plus the current DFS stack for cycle detection
 	visited := make(map[string]bool)
+	onStack := make(map[string]bool)
+	var stack []Module
+
+	// moduleLabel returns a human-readable name for error reporting
+	moduleLabel := func(mod Module) string {
+		if mod.Name != "" {
+			return mod.Name
+		}
+		return filepath.Base(mod.Path)
+	}
+
+	// lazyPaths collects cycle members discovered while the nodes are still on
+	// the stack, so they can be marked once they are appended to sorted
+	lazyPaths := make(map[string]bool)
 
 	// isSorted checks if the module is already in sorted list
 	isSorted := func(nodePath string) bool {
@@ -114,25 +219,54 @@ func createProjectStructure(mainFile string) ([]Module, error) {
 	// DFS traversal
 	var dfs func(Module) error
 	dfs = func(currentNode Module) error {
-		if visited[currentNode.Path] {
-			return nil // Avoid cycles
-		}
-		visited[currentNode.Path] = true
+		if onStack[currentNode.Path] {
+			// Found a cycle: report the chain from the first occurrence back to itself
+			var cyclePath []string
+			recording := false
+			for _, stackNode := range stack {
+				if stackNode.Path == currentNode.Path {
+					recording = true
+				}
+				if recording {
+					cyclePath = append(cyclePath, moduleLabel(stackNode))
+				}
+			}
+			cyclePath = append(cyclePath, moduleLabel(currentNode))
 
-		// Read the content of current node if it exists
-		if _, err := os.Stat(currentNode.Path); err == nil {
-			content, err := os.ReadFile(currentNode.Path)
-			if err != nil {
-				return fmt.Errorf("failed to read file %s: %w", currentNode.Path, err)
+			if !allowCycles {
+				return &CycleError{Path: cyclePath}
 			}
-			contentStr := string(content)
-			currentNode.Content = &contentStr
-		}
 
-		childNodes, err := exploreNodes(currentNode, cwd)
+			// Mark every member of the cycle for lazy loading
+			recording = false
+			for _, stackNode := range stack {
+				if stackNode.Path == currentNode.Path {
+					recording = true
+				}
+				if recording {
+					lazyPaths[stackNode.Path] = true
+				}
+			}
+			return nil
+		}
+		if visited[currentNode.Path] {
+			return nil // Already processed via another path
+		}
+		visited[currentNode.Path] = true
+		onStack[currentNode.Path] = true
+		stack = append(stack, currentNode)
+		defer func() {
+			onStack[currentNode.Path] = false
+			stack = stack[:len(stack)-1]
+		}()
+
+		// Read the content and child dependencies through the cache; files
+		// unchanged since the previous bundle are served from memory
+		content, childNodes, err := cache.load(currentNode, cwd)
 		if err != nil {
 			return fmt.Errorf("failed to explore nodes for %s: %w", currentNode.Path, err)
 		}
+		currentNode.Content = content
 
 		// Visit unvisited child nodes
 		for _, childNode := range childNodes {
@@ -144,6 +278,7 @@ func createProjectStructure(mainFile string) ([]Module, error) {
 		}
 
 		if !isSorted(currentNode.Path) {
+			currentNode.Lazy = lazyPaths[currentNode.Path]
 			sorted = append(sorted, currentNode)
 		}
 
@@ -183,8 +318,15 @@ func exploreNodes(node Module, cwd string) ([]Module, error) {
 	contentStr := string(content)
 	// Note: We don't modify the input node here, the content will be set in the DFS
 
-	// Find require statements using regex
-	requirePattern := regexp.MustCompile(`(?:require\s*\(\s*["'])([^"']+)(?:["']\s*\))`)
+	return parseRequires(contentStr, cwd), nil
+}
+
+// requirePattern matches require("name") and require 'name' statements
+var requirePattern = regexp.MustCompile(`(?:require\s*\(\s*["'])([^"']+)(?:["']\s*\))`)
+
+// parseRequires resolves the require() statements in Lua content to child
+// modules relative to the project root
+func parseRequires(contentStr, cwd string) []Module {
 	matches := requirePattern.FindAllStringSubmatch(contentStr, -1)
 
 	var requiredModules []Module
@@ -194,15 +336,77 @@ func exploreNodes(node Module, cwd string) ([]Module, error) {
 			// Convert dot notation to file path
 			modulePath := filepath.Join(cwd, strings.ReplaceAll(moduleName, ".", string(filepath.Separator))+".lua")
 
+			// If no Lua file matches, try resolving an embedded asset marker
+			// such as require("data.routes_json") -> data/routes.json, then
+			// the vendored dependency tree under lua_modules/
+			isAsset := false
+			if _, err := os.Stat(modulePath); os.IsNotExist(err) {
+				if assetPath, ok := resolveAssetPath(moduleName, cwd); ok {
+					modulePath = assetPath
+					isAsset = true
+				} else if vendoredPath, ok := resolveVendoredPath(moduleName, cwd); ok {
+					modulePath = vendoredPath
+				}
+			}
+
 			requiredModules = append(requiredModules, Module{
 				Name:    moduleName,
 				Path:    modulePath,
 				Content: nil, // Will be set when the node is explored
+				Asset:   isAsset,
 			})
 		}
 	}
 
-	return requiredModules, nil
+	return requiredModules
+}
+
+// resolveAssetPath maps a require() name with an extension suffix to an asset
+// file on disk, e.g. "data.routes_json" -> <cwd>/data/routes.json. It returns
+// false when the name has no recognized suffix or the file does not exist.
+func resolveAssetPath(moduleName, cwd string) (string, bool) {
+	idx := strings.LastIndex(moduleName, "_")
+	if idx <= 0 || idx == len(moduleName)-1 {
+		return "", false
+	}
+
+	ext := moduleName[idx+1:]
+	if !assetExtensions[ext] {
+		return "", false
+	}
+
+	base := strings.ReplaceAll(moduleName[:idx], ".", string(filepath.Separator))
+	assetPath := filepath.Join(cwd, base+"."+ext)
+	if _, err := os.Stat(assetPath); err != nil {
+		return "", false
+	}
+
+	return assetPath, true
+}
+
+// resolveVendoredPath maps a require() name to a module vendored under
+// lua_modules/ (managed by `harlequin add`). It returns false when no
+// vendored file exists.
+func resolveVendoredPath(moduleName, cwd string) (string, bool) {
+	base := strings.ReplaceAll(moduleName, ".", string(filepath.Separator))
+	vendoredPath := filepath.Join(cwd, "lua_modules", base+".lua")
+	if _, err := os.Stat(vendoredPath); err != nil {
+		return "", false
+	}
+	return vendoredPath, true
+}
+
+// luaLongString quotes content as a Lua long-bracket string, picking a bracket
+// level that does not occur in the content itself
+func luaLongString(content string) string {
+	level := 1
+	for strings.Contains(content, "]"+strings.Repeat("=", level)+"]") {
+		level++
+	}
+	eq := strings.Repeat("=", level)
+	// A leading newline after the opening bracket is ignored by Lua, so the
+	// content round-trips exactly
+	return "[" + eq + "[\n" + content + "]" + eq + "]"
 }
 
 // getModFnName converts a module name to a valid function name
@@ -1,6 +1,9 @@
 package luautils
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -110,7 +113,7 @@ print("Hello world")
 	}
 
 	// Test createProjectStructure
-	project, err := createProjectStructure(mainFile)
+	project, err := createProjectStructure(mainFile, false)
 	if err != nil {
 		t.Fatalf("createProjectStructure failed: %v", err)
 	}
@@ -187,8 +190,8 @@ func TestBundleNonExistentFile(t *testing.T) {
 	}
 }
 
-func TestCreateExecutableFromProjectEmpty(t *testing.T) {
-	_, err := createExecutableFromProject([]Module{})
+func TestWriteExecutableFromProjectEmpty(t *testing.T) {
+	err := writeExecutableFromProject(io.Discard, []Module{})
 	if err == nil {
 		t.Error("Expected error for empty project")
 	}
@@ -248,7 +251,7 @@ print("Result:", utils.calculate(5, 3))
 	}
 
 	// Test the traversal
-	project, err := createProjectStructure(mainFile)
+	project, err := createProjectStructure(mainFile, false)
 	if err != nil {
 		t.Fatalf("createProjectStructure failed: %v", err)
 	}
@@ -322,6 +325,88 @@ print("Result:", utils.calculate(5, 3))
 	}
 }
 
+func TestBundleEmbeddedAssets(t *testing.T) {
+	// Create a temporary directory for testing
+	tempDir, err := os.MkdirTemp("", "lua-asset-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a JSON asset in a subdirectory
+	dataDir := filepath.Join(tempDir, "data")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("Failed to create data directory: %v", err)
+	}
+
+	routesContent := `{"routes": ["a", "b"]}`
+	routesFile := filepath.Join(dataDir, "routes.json")
+	if err := os.WriteFile(routesFile, []byte(routesContent), 0644); err != nil {
+		t.Fatalf("Failed to write routes.json: %v", err)
+	}
+
+	// main.lua requires the asset via the marker naming convention
+	mainContent := `
+local routes = require("data.routes_json")
+print(routes)
+`
+	mainFile := filepath.Join(tempDir, "main.lua")
+	if err := os.WriteFile(mainFile, []byte(mainContent), 0644); err != nil {
+		t.Fatalf("Failed to write main file: %v", err)
+	}
+
+	bundledCode, err := Bundle(mainFile)
+	if err != nil {
+		t.Fatalf("Bundle failed: %v", err)
+	}
+
+	if !strings.Contains(bundledCode, `-- asset: "data.routes_json"`) {
+		t.Error("Bundle should contain asset module comment")
+	}
+	if !strings.Contains(bundledCode, `_G.package.loaded["data.routes_json"]`) {
+		t.Error("Bundle should contain package.loaded mapping for the asset")
+	}
+	if !strings.Contains(bundledCode, routesContent) {
+		t.Error("Bundle should embed the raw asset content")
+	}
+}
+
+func TestResolveAssetPath(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "lua-asset-resolve-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := os.WriteFile(filepath.Join(tempDir, "notes.md"), []byte("# notes"), 0644); err != nil {
+		t.Fatalf("Failed to write notes.md: %v", err)
+	}
+
+	if path, ok := resolveAssetPath("notes_md", tempDir); !ok || !strings.HasSuffix(path, "notes.md") {
+		t.Errorf("Expected notes_md to resolve to notes.md, got %q (ok=%v)", path, ok)
+	}
+
+	// Unrecognized suffixes and missing files should not resolve
+	if _, ok := resolveAssetPath("notes_xyz", tempDir); ok {
+		t.Error("Expected unrecognized suffix to not resolve")
+	}
+	if _, ok := resolveAssetPath("missing_json", tempDir); ok {
+		t.Error("Expected missing file to not resolve")
+	}
+}
+
+func TestLuaLongString(t *testing.T) {
+	content := `some ]=] tricky ] content`
+	quoted := luaLongString(content)
+	if !strings.Contains(quoted, content) {
+		t.Error("Long string should contain the original content")
+	}
+	// The chosen bracket level must not terminate early
+	if strings.Contains(quoted[2:len(quoted)-2], quoted[:2]) {
+		t.Error("Long string delimiter collides with content")
+	}
+}
+
 func TestCircularDependency(t *testing.T) {
 	// Create a temporary directory for testing
 	tempDir, err := os.MkdirTemp("", "lua-circular-test-*")
@@ -368,10 +453,31 @@ print(a.from_a)
 		t.Fatalf("Failed to write main.lua: %v", err)
 	}
 
-	// Test that circular dependency is handled gracefully
-	project, err := createProjectStructure(mainFile)
+	// By default a circular dependency should fail with the cycle path
+	_, err = createProjectStructure(mainFile, false)
+	if err == nil {
+		t.Fatal("Expected createProjectStructure to fail on circular dependency")
+	}
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("Expected CycleError, got %T: %v", err, err)
+	}
+	if len(cycleErr.Path) < 3 {
+		t.Errorf("Expected cycle path to contain the full cycle, got %v", cycleErr.Path)
+	}
+	if !strings.Contains(cycleErr.Error(), "a -> b -> a") {
+		t.Errorf("Expected cycle error to report the cycle path, got: %v", cycleErr)
+	}
+
+	// Bundle should surface the same failure
+	if _, err := Bundle(mainFile); err == nil {
+		t.Fatal("Expected Bundle to fail on circular dependency")
+	}
+
+	// With AllowCycles the cycle members are bundled as lazy-loading stubs
+	project, err := createProjectStructure(mainFile, true)
 	if err != nil {
-		t.Fatalf("createProjectStructure should handle circular dependencies: %v", err)
+		t.Fatalf("createProjectStructure with AllowCycles failed: %v", err)
 	}
 
 	// Should have 3 modules (main, a, b) despite the circular dependency
@@ -379,13 +485,162 @@ print(a.from_a)
 		t.Errorf("Expected 3 modules despite circular dependency, got %d", len(project))
 	}
 
-	// Should still be able to bundle
-	bundledCode, err := Bundle(mainFile)
+	bundledCode, err := BundleWithOptions(mainFile, BundleOptions{AllowCycles: true})
+	if err != nil {
+		t.Fatalf("BundleWithOptions should handle circular dependencies: %v", err)
+	}
+
+	if !strings.Contains(bundledCode, `_G.package.preload["a"]`) {
+		t.Error("Expected cycle member to be registered via package.preload")
+	}
+	if !strings.Contains(bundledCode, `_G.package.preload["b"]`) {
+		t.Error("Expected cycle member to be registered via package.preload")
+	}
+}
+
+func TestBundleVendoredModules(t *testing.T) {
+	// Create a temporary directory for testing
+	tempDir, err := os.MkdirTemp("", "lua-vendor-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// Create a vendored module under lua_modules/ (managed by `harlequin add`)
+	vendorDir := filepath.Join(tempDir, "lua_modules", "ao")
+	if err := os.MkdirAll(vendorDir, 0755); err != nil {
+		t.Fatalf("Failed to create vendor directory: %v", err)
+	}
+	vendoredContent := `
+local function trim(s)
+    return s:match("^%s*(.-)%s*$")
+end
+return { trim = trim }
+`
+	vendoredFile := filepath.Join(vendorDir, "utils.lua")
+	if err := os.WriteFile(vendoredFile, []byte(vendoredContent), 0644); err != nil {
+		t.Fatalf("Failed to write vendored file: %v", err)
+	}
+
+	// Create main file that requires the vendored module
+	mainContent := `
+local utils = require("ao.utils")
+print(utils.trim("  hello  "))
+`
+	mainFile := filepath.Join(tempDir, "main.lua")
+	if err := os.WriteFile(mainFile, []byte(mainContent), 0644); err != nil {
+		t.Fatalf("Failed to write main file: %v", err)
+	}
+
+	// Test Bundle function
+	bundledLua, err := Bundle(mainFile)
+	if err != nil {
+		t.Fatalf("Bundle failed: %v", err)
+	}
+
+	// The vendored module must be bundled under its require() name
+	if !strings.Contains(bundledLua, `-- module: "ao.utils"`) {
+		t.Error("Bundle should contain the vendored module")
+	}
+
+	if !strings.Contains(bundledLua, `_G.package.loaded["ao.utils"]`) {
+		t.Error("Bundle should contain package.loaded mapping for the vendored module")
+	}
+
+	// A local file takes precedence over the vendored tree
+	localDir := filepath.Join(tempDir, "ao")
+	if err := os.MkdirAll(localDir, 0755); err != nil {
+		t.Fatalf("Failed to create local directory: %v", err)
+	}
+	localContent := `return { trim = function(s) return s end }`
+	if err := os.WriteFile(filepath.Join(localDir, "utils.lua"), []byte(localContent), 0644); err != nil {
+		t.Fatalf("Failed to write local file: %v", err)
+	}
+
+	bundledLua, err = Bundle(mainFile)
+	if err != nil {
+		t.Fatalf("Bundle failed: %v", err)
+	}
+	if !strings.Contains(bundledLua, "return s end") {
+		t.Error("Local module should take precedence over the vendored tree")
+	}
+}
+
+// TestBundleToFileMatchesBundle ensures the streamed output is byte-identical
+// to the in-memory bundle
+func TestBundleToFileMatchesBundle(t *testing.T) {
+	tempDir := t.TempDir()
+
+	mainFile := filepath.Join(tempDir, "main.lua")
+	if err := os.WriteFile(mainFile, []byte(`local helper = require("helper")
+print(helper.greet())`), 0644); err != nil {
+		t.Fatalf("Failed to write main file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "helper.lua"), []byte(`return { greet = function() return "hi" end }`), 0644); err != nil {
+		t.Fatalf("Failed to write helper file: %v", err)
+	}
+
+	inMemory, err := Bundle(mainFile)
+	if err != nil {
+		t.Fatalf("Bundle failed: %v", err)
+	}
+
+	outputPath := filepath.Join(tempDir, "bundled.lua")
+	if err := BundleToFile(mainFile, outputPath, BundleOptions{}); err != nil {
+		t.Fatalf("BundleToFile failed: %v", err)
+	}
+	streamed, err := os.ReadFile(outputPath)
 	if err != nil {
-		t.Fatalf("Bundle should handle circular dependencies: %v", err)
+		t.Fatalf("Failed to read streamed bundle: %v", err)
 	}
 
-	if bundledCode == "" {
-		t.Error("Bundle should produce output even with circular dependencies")
+	if string(streamed) != inMemory {
+		t.Error("Streamed bundle differs from the in-memory bundle")
+	}
+}
+
+// benchmarkProject writes a project with the given number of modules, all
+// required by the entry file, and returns the entry file path
+func benchmarkProject(b *testing.B, moduleCount int) string {
+	b.Helper()
+	tempDir := b.TempDir()
+
+	var mainContent strings.Builder
+	for i := 0; i < moduleCount; i++ {
+		name := fmt.Sprintf("mod%d", i)
+		content := fmt.Sprintf("local M = {}\nfunction M.value() return %d end\nreturn M\n", i)
+		if err := os.WriteFile(filepath.Join(tempDir, name+".lua"), []byte(content), 0644); err != nil {
+			b.Fatalf("Failed to write module: %v", err)
+		}
+		fmt.Fprintf(&mainContent, "local %s = require(\"%s\")\n", name, name)
+	}
+	mainContent.WriteString("print(mod0.value())\n")
+
+	mainFile := filepath.Join(tempDir, "main.lua")
+	if err := os.WriteFile(mainFile, []byte(mainContent.String()), 0644); err != nil {
+		b.Fatalf("Failed to write main file: %v", err)
+	}
+	return mainFile
+}
+
+func BenchmarkBundle10kModules(b *testing.B) {
+	mainFile := benchmarkProject(b, 10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Bundle(mainFile); err != nil {
+			b.Fatalf("Bundle failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkBundleTo10kModules(b *testing.B) {
+	mainFile := benchmarkProject(b, 10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := BundleTo(io.Discard, mainFile, BundleOptions{}); err != nil {
+			b.Fatalf("BundleTo failed: %v", err)
+		}
 	}
 }
@@ -2,12 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/build/builders"
 	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/cmd"
+	harlequinconfig "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
 	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/logging"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/i18n"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/netconfig"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/plugin"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/telemetry"
 )
 
 // Version information (injected by GoReleaser)
@@ -22,9 +33,80 @@ func main() {
 	// Ensure debug log file is closed on exit
 	defer debug.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	// Stamp build manifests and telemetry events with the release version
+	builders.ToolkitVersion = version
+	telemetry.Version = version
+
+	// Global logging flags can appear anywhere on the command line; strip
+	// them so subcommands never see them
+	args, logLevel, logJSON, quiet, offline, plain, timeout, proxyURL, caBundle, locale := extractGlobalFlags(os.Args[1:])
+	if err := logging.Setup(logLevel, logJSON, quiet); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// An explicit --locale must exist; an unsupported LANG just renders
+	// English rather than failing every command on the machine
+	if locale != "" {
+		if err := i18n.SetLocale(locale); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	} else if err := i18n.SetLocale(i18n.Detect("")); err != nil {
+		debug.Printf("Falling back to English: %v", err)
+	}
+	builders.Offline = offline
+	cmd.PlainMode = plain
+	os.Args = append(os.Args[:1], args...)
+
+	// Proxy and CA settings: explicit flags win over the project config,
+	// which wins over the environment defaults baked into the transport
+	configProxy, configCA := projectNetworkSettings()
+	if proxyURL == "" {
+		proxyURL = configProxy
+	}
+	if caBundle == "" {
+		caBundle = configCA
+	}
+	if err := netconfig.Apply(proxyURL, caBundle); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// No deadline unless the user asks for one with --timeout; a hard global
+	// limit would kill legitimately long Docker builds
+	ctx := context.Background()
+	var cancel context.CancelFunc
+	if timeout != "" {
+		duration, err := time.ParseDuration(timeout)
+		if err != nil {
+			fmt.Printf("Error: invalid --timeout value %q (use e.g. 90s, 10m)\n", timeout)
+			os.Exit(1)
+		}
+		ctx, cancel = context.WithTimeout(ctx, duration)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
 	defer cancel()
 
+	// Ctrl-C cancels the context instead of killing the process outright,
+	// giving builders a chance to remove their temp workspaces; a second
+	// Ctrl-C forces an immediate exit
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		cancel()
+		<-sigCh
+		os.Exit(130)
+	}()
+
+	// Honor a project .harlequin-version pin before dispatching, so the whole
+	// team builds with the same toolkit version
+	if cmd.MaybeRunPinnedVersion(ctx, version, os.Args[1:]) {
+		return
+	}
+
 	// If no arguments provided, launch interactive TUI
 	if len(os.Args) < 2 {
 		if err := cmd.RunInteractiveTUI(ctx); err != nil {
@@ -36,6 +118,13 @@ func main() {
 
 	command := os.Args[1]
 
+	// Ask the telemetry consent question once, except when the user is
+	// already on their way to answer it
+	if command != "telemetry" && !quiet {
+		cmd.MaybePromptTelemetryConsent()
+	}
+	commandStart := time.Now()
+
 	switch command {
 	case "init":
 		cmd.HandleInitCommand(ctx, os.Args[2:])
@@ -43,14 +132,70 @@ func main() {
 		cmd.HandleTemplatesCommand(ctx, os.Args[2:])
 	case "build":
 		cmd.HandleBuildCommand(ctx, os.Args[2:])
-	case "upload-module":
+	case "upload", "upload-module":
 		cmd.HandleUploadCommand(ctx, os.Args[2:])
+	case "publish-site":
+		cmd.HandlePublishSiteCommand(ctx, os.Args[2:])
+	case "arns":
+		cmd.HandleArnsCommand(ctx, os.Args[2:])
+	case "modules":
+		cmd.HandleModulesCommand(ctx, os.Args[2:])
+	case "processes":
+		cmd.HandleProcessesCommand(ctx, os.Args[2:])
 	case "lua-utils":
 		cmd.HandleLuaUtilsCommand(ctx, os.Args[2:])
+	case "fmt":
+		cmd.HandleFmtCommand(ctx, os.Args[2:])
+	case "generate":
+		cmd.HandleGenerateCommand(ctx, os.Args[2:])
+	case "wasm":
+		cmd.HandleWasmCommand(ctx, os.Args[2:])
 	case "remote-signing":
 		cmd.HandleRemoteSigningCommand(ctx, os.Args[2:])
+	case "sign":
+		cmd.HandleSignCommand(ctx, os.Args[2:])
+	case "message":
+		cmd.HandleMessageCommand(ctx, os.Args[2:])
+	case "eval":
+		cmd.HandleEvalCommand(ctx, os.Args[2:])
+	case "e2e":
+		cmd.HandleE2ECommand(ctx, os.Args[2:])
+	case "test":
+		cmd.HandleTestCommand(ctx, os.Args[2:])
+	case "fuzz":
+		cmd.HandleFuzzCommand(ctx, os.Args[2:])
+	case "gas":
+		cmd.HandleGasCommand(ctx, os.Args[2:])
+	case "explain":
+		cmd.HandleExplainCommand(ctx, os.Args[2:])
+	case "state":
+		cmd.HandleStateCommand(ctx, os.Args[2:])
+	case "config":
+		cmd.HandleConfigCommand(ctx, os.Args[2:])
+	case "run-task":
+		cmd.HandleRunTaskCommand(ctx, os.Args[2:])
+	case "add":
+		cmd.HandleAddCommand(ctx, os.Args[2:])
+	case "wallet":
+		cmd.HandleWalletCommand(ctx, os.Args[2:])
+	case "localnet":
+		cmd.HandleLocalnetCommand(ctx, os.Args[2:])
+	case "ci":
+		cmd.HandleCICommand(ctx, os.Args[2:])
+	case "debug":
+		cmd.HandleDebugCommand(ctx, os.Args[2:])
+	case "prefetch":
+		cmd.HandlePrefetchCommand(ctx, os.Args[2:])
+	case "clean":
+		cmd.HandleCleanCommand(ctx, os.Args[2:])
 	case "install":
 		cmd.HandleInstallCommand(ctx, os.Args[2:])
+	case "self-update":
+		cmd.HandleSelfUpdateCommand(ctx, os.Args[2:])
+	case "telemetry":
+		cmd.HandleTelemetryCommand(ctx, os.Args[2:])
+	case "setup":
+		cmd.HandleSetupCommand(ctx, os.Args[2:])
 	case "uninstall":
 		cmd.HandleUninstallCommand(ctx, os.Args[2:])
 	case "versions":
@@ -60,10 +205,99 @@ func main() {
 	case "help", "--help", "-h":
 		printUsage()
 	default:
+		// Fall back to git-style external plugins (harlequin-<name> on PATH)
+		if p, found := plugin.Find(command); found {
+			runPlugin(ctx, p)
+			return
+		}
+
 		fmt.Printf("Unknown command: %s\n\n", command)
 		printUsage()
 		os.Exit(1)
 	}
+
+	// Handlers that fail exit directly above, so only completed commands are
+	// recorded here; build failures are captured inside runBuild itself
+	if command != "telemetry" {
+		telemetry.Record(command, time.Since(commandStart), "")
+		if !offline {
+			if err := telemetry.Flush(ctx); err != nil {
+				debug.Printf("Telemetry flush failed: %v", err)
+			}
+		}
+	}
+}
+
+// extractGlobalFlags strips the logging flags out of the argument list so
+// subcommands never see them
+func extractGlobalFlags(args []string) (remaining []string, level string, jsonOutput, quiet, offline, plain bool, timeout, proxyURL, caBundle, locale string) {
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--log-level":
+			if i+1 < len(args) {
+				level = args[i+1]
+				i++
+			}
+		case "--log-json":
+			jsonOutput = true
+		case "--quiet", "-q":
+			quiet = true
+		case "--offline":
+			offline = true
+		case "--plain":
+			plain = true
+		case "--timeout":
+			if i+1 < len(args) {
+				timeout = args[i+1]
+				i++
+			}
+		case "--proxy":
+			if i+1 < len(args) {
+				proxyURL = args[i+1]
+				i++
+			}
+		case "--ca-bundle":
+			if i+1 < len(args) {
+				caBundle = args[i+1]
+				i++
+			}
+		case "--locale":
+			if i+1 < len(args) {
+				locale = args[i+1]
+				i++
+			}
+		default:
+			remaining = append(remaining, args[i])
+		}
+	}
+	return remaining, level, jsonOutput, quiet, offline, plain, timeout, proxyURL, caBundle, locale
+}
+
+// projectNetworkSettings reads the proxy_url and ca_bundle keys from the
+// project config when one exists. A broken config must not take down
+// unrelated commands here - the command that actually needs it will report
+// the parse error.
+func projectNetworkSettings() (proxyURL, caBundle string) {
+	defer func() { _ = recover() }()
+	if _, err := os.Stat(".harlequin.yaml"); err != nil {
+		return "", ""
+	}
+	cfg := harlequinconfig.ReadConfigFile(".harlequin.yaml")
+	return cfg.ProxyURL, cfg.CABundle
+}
+
+// runPlugin executes an external plugin, propagating its exit code
+func runPlugin(ctx context.Context, p plugin.Plugin) {
+	debug.Printf("Running plugin %s (%s)", p.Name, p.Path)
+
+	if err := plugin.Run(ctx, p, os.Args[2:]); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Printf("Failed to run plugin %s: %v\n", p.Name, err)
+		os.Exit(1)
+	}
 }
 
 func printVersion() {
@@ -73,26 +307,84 @@ func printVersion() {
 	fmt.Printf("  built by: %s\n", builtBy)
 }
 
+// usageLine prints one command row of the help output, translating the
+// description through the message catalog
+func usageLine(name, description string) {
+	fmt.Printf("    %-15s %s\n", name, i18n.T(description))
+}
+
 func printUsage() {
-	fmt.Println("🎭 Harlequin - Arweave Development Toolkit")
+	fmt.Println(i18n.T("🎭 Harlequin - Arweave Development Toolkit"))
 	fmt.Println()
-	fmt.Println("USAGE:")
+	fmt.Println(i18n.T("USAGE:"))
 	fmt.Println("    harlequin [COMMAND] [OPTIONS]")
 	fmt.Println()
-	fmt.Println("COMMANDS:")
-	fmt.Println("    init            Create a new AO process project from template")
-	fmt.Println("    templates       List and manage AO process templates")
-	fmt.Println("    build           Build AO process (launches TUI if no args)")
-	fmt.Println("    upload-module   Upload built modules to Arweave")
-	fmt.Println("    lua-utils       Lua utilities for bundling and processing")
-	fmt.Println("    remote-signing  Remote signing server operations")
-	fmt.Println("    install         Install or upgrade harlequin")
-	fmt.Println("    uninstall       Remove harlequin from system")
-	fmt.Println("    versions        List available harlequin versions")
-	fmt.Println("    version         Show version information")
-	fmt.Println("    help            Show this help message")
+	fmt.Println(i18n.T("COMMANDS:"))
+	usageLine("init", "Create a new AO process project from template")
+	usageLine("templates", "List and manage AO process templates")
+	usageLine("build", "Build AO process (launches TUI if no args)")
+	usageLine("upload", "Upload modules, Lua sources, or directories to Arweave")
+	usageLine("", "(upload-module is kept as an alias)")
+	usageLine("publish-site", "Publish a web frontend directory behind an Arweave path manifest")
+	usageLine("arns", "Point an ArNS name at a deployed module or site")
+	usageLine("modules", "Promote and roll back module versions across channels")
+	usageLine("processes", "Track processes deployed from this project")
+	usageLine("lua-utils", "Lua utilities for bundling and processing")
+	usageLine("fmt", "Format Lua sources in the bundle dependency graph")
+	usageLine("generate", "Generate handler scaffolding for the project")
+	usageLine("wasm", "Inspect WASM binaries")
+	usageLine("remote-signing", "Remote signing server operations")
+	usageLine("sign", "Sign and upload a file via browser wallet")
+	usageLine("message", "Send a message to an AO process")
+	usageLine("eval", "Dry-run a message against a local Lua module")
+	usageLine("e2e", "Run YAML scenarios against a deployed process")
+	usageLine("test", "Snapshot-test handler responses in the local emulator")
+	usageLine("fuzz", "Fuzz handlers with malformed messages")
+	usageLine("gas", "Estimate compute units consumed per handler")
+	usageLine("explain", "Diagnose a build or upload error")
+	usageLine("state", "Download state snapshots for a deployed process")
+	usageLine("config", "Migrate legacy build configs to the current schema")
+	usageLine("run-task", "Run a task pipeline defined in .harlequin.yaml")
+	usageLine("add", "Vendor a Lua dependency from Arweave or git")
+	usageLine("wallet", "Manage named wallet profiles")
+	usageLine("localnet", "Run a local Arweave + AO stack for development")
+	usageLine("ci", "Generate CI pipelines for the project")
+	usageLine("debug", "Export diagnostics for bug reports")
+	usageLine("prefetch", "Populate offline build caches while online")
+	usageLine("clean", "Remove stale build workspaces, containers, and caches")
+	usageLine("install", "Install or upgrade harlequin")
+	usageLine("self-update", "Update harlequin in place with checksum verification")
+	usageLine("telemetry", "Control opt-in anonymous usage metrics")
+	usageLine("setup", "Guided first-run setup for a new machine")
+	usageLine("uninstall", "Remove harlequin from system")
+	usageLine("versions", "List available harlequin versions")
+	usageLine("version", "Show version information")
+	usageLine("help", "Show this help message")
+	fmt.Println()
+	fmt.Println(i18n.T("GLOBAL OPTIONS:"))
+	fmt.Println("    --log-level <LEVEL>    Structured log level: debug, info, warn, error")
+	fmt.Println("    --log-json             Emit structured logs as JSON (to stderr)")
+	fmt.Println("    -q, --quiet            Suppress progress output, print only final results")
+	fmt.Println("    --offline              Use local caches only; never touch the network")
+	fmt.Println("    --plain                Sequential prompts instead of the full-screen TUI")
+	fmt.Println("    --timeout <DURATION>   Abort the command after this long (e.g. 90s, 10m)")
+	fmt.Println("    --proxy <URL>          Route HTTP traffic through a proxy (also: HTTPS_PROXY,")
+	fmt.Println("                           NO_PROXY, or proxy_url in .harlequin.yaml)")
+	fmt.Println("    --ca-bundle <FILE>     Extra trusted CA certificates in PEM form (also:")
+	fmt.Println("                           HARLEQUIN_CA_BUNDLE or ca_bundle in .harlequin.yaml)")
+	fmt.Println("    --locale <TAG>         Language for messages, e.g. zh (default: from LANG)")
+
+	// External plugins (harlequin-<name> executables on PATH)
+	if plugins := plugin.Discover(); len(plugins) > 0 {
+		fmt.Println()
+		fmt.Println(i18n.T("PLUGINS:"))
+		for _, p := range plugins {
+			fmt.Printf("    %-15s %s\n", p.Name, p.Manifest.Description)
+		}
+	}
+
 	fmt.Println()
-	fmt.Println("EXAMPLES:")
+	fmt.Println(i18n.T("EXAMPLES:"))
 	fmt.Println("    harlequin                    # Launch interactive TUI")
 	fmt.Println("    harlequin init               # Create new project (interactive)")
 	fmt.Println("    harlequin init lua --name my-project")
@@ -102,5 +394,5 @@ func printUsage() {
 	fmt.Println("    harlequin upload-module      # Upload built module to Arweave")
 	fmt.Println("    harlequin versions --format table")
 	fmt.Println()
-	fmt.Println("For command-specific help, use: harlequin [COMMAND] --help")
+	fmt.Println(i18n.T("For command-specific help, use: harlequin [COMMAND] --help"))
 }
@@ -0,0 +1,81 @@
+package e2e
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// StepResult is the outcome of one executed step, for reporting
+type StepResult struct {
+	Name     string
+	Duration time.Duration
+	Failures []string
+	Err      error // Infrastructure error (signing, delivery, polling)
+}
+
+// junitTestSuite is the JUnit XML document root
+type junitTestSuite struct {
+	XMLName  xml.Name        `xml:"testsuite"`
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is one step in the JUnit report
+type junitTestCase struct {
+	Name    string        `xml:"name,attr"`
+	Time    string        `xml:"time,attr"`
+	Failure *junitMessage `xml:"failure,omitempty"`
+	Error   *junitMessage `xml:"error,omitempty"`
+}
+
+// junitMessage carries a failure or error body
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit writes a JUnit XML report for the executed steps, in the
+// format CI systems ingest for test summaries
+func WriteJUnit(path, suiteName string, results []StepResult) error {
+	suite := junitTestSuite{Name: suiteName, Tests: len(results)}
+
+	var total time.Duration
+	for _, result := range results {
+		total += result.Duration
+		testCase := junitTestCase{
+			Name: result.Name,
+			Time: fmt.Sprintf("%.3f", result.Duration.Seconds()),
+		}
+		switch {
+		case result.Err != nil:
+			suite.Errors++
+			testCase.Error = &junitMessage{Message: "step could not run", Body: result.Err.Error()}
+		case len(result.Failures) > 0:
+			suite.Failures++
+			testCase.Failure = &junitMessage{
+				Message: result.Failures[0],
+				Body:    strings.Join(result.Failures, "\n"),
+			}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	suite.Time = fmt.Sprintf("%.3f", total.Seconds())
+
+	content, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+
+	document := append([]byte(xml.Header), content...)
+	if err := os.WriteFile(path, append(document, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+// Package e2e runs expect-style scenarios against deployed AO processes.
+// A scenario is a YAML file of steps; each step sends one message and
+// asserts on the compute unit result (output text, spawned messages,
+// spawned processes). The e2e command drives the network side and this
+// package owns parsing, assertion evaluation, and JUnit reporting.
+package e2e
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario is one YAML scenario file
+type Scenario struct {
+	Name    string `yaml:"name"`
+	Process string `yaml:"process"` // Default target; steps may override
+	Steps   []Step `yaml:"steps"`
+}
+
+// Step sends one message and asserts on its result
+type Step struct {
+	Name    string `yaml:"name"`
+	Process string `yaml:"process,omitempty"` // Overrides the scenario process
+	Send    Send   `yaml:"send"`
+	Expect  Expect `yaml:"expect"`
+}
+
+// Send describes the message a step delivers
+type Send struct {
+	Action string            `yaml:"action"`
+	Data   string            `yaml:"data,omitempty"`
+	Tags   map[string]string `yaml:"tags,omitempty"`
+}
+
+// Expect describes the assertions a step makes against the result
+type Expect struct {
+	Output        string        `yaml:"output,omitempty"`         // Substring of the output
+	OutputMatches string        `yaml:"output_matches,omitempty"` // Regexp over the output
+	Error         string        `yaml:"error,omitempty"`          // Substring of an expected process error
+	Messages      []ExpectedMsg `yaml:"messages,omitempty"`       // Each must match an outgoing message
+	Spawns        []ExpectedMsg `yaml:"spawns,omitempty"`         // Each must match a spawn
+}
+
+// ExpectedMsg matches an outgoing message or spawn by target and tag subset
+type ExpectedMsg struct {
+	Target string            `yaml:"target,omitempty"`
+	Tags   map[string]string `yaml:"tags,omitempty"`
+}
+
+// LoadScenario reads and validates a scenario file
+func LoadScenario(path string) (*Scenario, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+
+	var scenario Scenario
+	if err := yaml.Unmarshal(content, &scenario); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario: %w", err)
+	}
+	if scenario.Name == "" {
+		scenario.Name = path
+	}
+
+	if len(scenario.Steps) == 0 {
+		return nil, fmt.Errorf("scenario has no steps")
+	}
+	for i := range scenario.Steps {
+		step := &scenario.Steps[i]
+		if step.Name == "" {
+			step.Name = fmt.Sprintf("step %d", i+1)
+		}
+		if step.Send.Action == "" {
+			return nil, fmt.Errorf("%s: send.action is required", step.Name)
+		}
+		if step.Expect.OutputMatches != "" {
+			if _, err := regexp.Compile(step.Expect.OutputMatches); err != nil {
+				return nil, fmt.Errorf("%s: invalid output_matches pattern: %w", step.Name, err)
+			}
+		}
+	}
+
+	return &scenario, nil
+}
+
+// Result is the step outcome the runner evaluates assertions against
+type Result struct {
+	Output   string
+	Error    string
+	Messages []Packet
+	Spawns   []Packet
+}
+
+// Packet is one outgoing message or spawn from a result
+type Packet struct {
+	Target string
+	Tags   map[string]string
+}
+
+// Evaluate checks a step's expectations against a result, returning one
+// failure description per unmet assertion
+func Evaluate(step Step, result Result) []string {
+	var failures []string
+
+	if step.Expect.Error != "" {
+		if result.Error == "" {
+			failures = append(failures, fmt.Sprintf("expected a process error containing %q, but the message succeeded", step.Expect.Error))
+		} else if !strings.Contains(result.Error, step.Expect.Error) {
+			failures = append(failures, fmt.Sprintf("expected error to contain %q, got %q", step.Expect.Error, result.Error))
+		}
+	} else if result.Error != "" {
+		failures = append(failures, fmt.Sprintf("process returned an error: %s", result.Error))
+	}
+
+	if step.Expect.Output != "" && !strings.Contains(result.Output, step.Expect.Output) {
+		failures = append(failures, fmt.Sprintf("expected output to contain %q, got %q", step.Expect.Output, result.Output))
+	}
+	if step.Expect.OutputMatches != "" {
+		// Pattern validity is checked at load time
+		if matched, _ := regexp.MatchString(step.Expect.OutputMatches, result.Output); !matched {
+			failures = append(failures, fmt.Sprintf("expected output to match /%s/, got %q", step.Expect.OutputMatches, result.Output))
+		}
+	}
+
+	for _, expected := range step.Expect.Messages {
+		if !anyPacketMatches(result.Messages, expected) {
+			failures = append(failures, fmt.Sprintf("no outgoing message matched %s", describeExpected(expected)))
+		}
+	}
+	for _, expected := range step.Expect.Spawns {
+		if !anyPacketMatches(result.Spawns, expected) {
+			failures = append(failures, fmt.Sprintf("no spawn matched %s", describeExpected(expected)))
+		}
+	}
+
+	return failures
+}
+
+// anyPacketMatches reports whether any packet satisfies the expectation
+func anyPacketMatches(packets []Packet, expected ExpectedMsg) bool {
+	for _, packet := range packets {
+		if packetMatches(packet, expected) {
+			return true
+		}
+	}
+	return false
+}
+
+// packetMatches checks target equality (when expected) and tag subset
+func packetMatches(packet Packet, expected ExpectedMsg) bool {
+	if expected.Target != "" && packet.Target != expected.Target {
+		return false
+	}
+	for name, value := range expected.Tags {
+		if packet.Tags[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// describeExpected renders an expectation for failure messages
+func describeExpected(expected ExpectedMsg) string {
+	parts := ""
+	if expected.Target != "" {
+		parts = fmt.Sprintf("target=%s", expected.Target)
+	}
+	for name, value := range expected.Tags {
+		if parts != "" {
+			parts += " "
+		}
+		parts += fmt.Sprintf("%s=%s", name, value)
+	}
+	if parts == "" {
+		return "(any)"
+	}
+	return parts
+}
@@ -0,0 +1,152 @@
+package e2e
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeScenario(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write scenario: %v", err)
+	}
+	return path
+}
+
+func TestLoadScenario(t *testing.T) {
+	path := writeScenario(t, `
+name: counter smoke test
+process: proc-abc
+steps:
+  - name: ping responds
+    send:
+      action: Ping
+      data: hello
+      tags:
+        Foo: Bar
+    expect:
+      output: pong
+  - send:
+      action: Info
+    expect:
+      output_matches: "^\\d+$"
+`)
+
+	scenario, err := LoadScenario(path)
+	if err != nil {
+		t.Fatalf("LoadScenario failed: %v", err)
+	}
+	if scenario.Name != "counter smoke test" || scenario.Process != "proc-abc" {
+		t.Errorf("scenario header not parsed: %+v", scenario)
+	}
+	if len(scenario.Steps) != 2 {
+		t.Fatalf("expected 2 steps, got %d", len(scenario.Steps))
+	}
+	if scenario.Steps[0].Send.Tags["Foo"] != "Bar" {
+		t.Errorf("send tags not parsed: %+v", scenario.Steps[0].Send)
+	}
+	if scenario.Steps[1].Name != "step 2" {
+		t.Errorf("expected unnamed step to be defaulted, got %q", scenario.Steps[1].Name)
+	}
+}
+
+func TestLoadScenarioValidation(t *testing.T) {
+	cases := map[string]string{
+		"no steps":       "name: empty\n",
+		"missing action": "steps:\n  - name: bad\n    send:\n      data: x\n",
+		"bad pattern":    "steps:\n  - send:\n      action: Ping\n    expect:\n      output_matches: \"[\"\n",
+	}
+	for name, content := range cases {
+		if _, err := LoadScenario(writeScenario(t, content)); err == nil {
+			t.Errorf("expected %s to fail validation", name)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	step := Step{
+		Expect: Expect{
+			Output:        "count: 3",
+			OutputMatches: "count: \\d+",
+			Messages:      []ExpectedMsg{{Tags: map[string]string{"Action": "Credit-Notice"}}},
+			Spawns:        []ExpectedMsg{{Tags: map[string]string{"Name": "child"}}},
+		},
+	}
+	result := Result{
+		Output: "count: 3",
+		Messages: []Packet{
+			{Target: "proc-other", Tags: map[string]string{"Action": "Credit-Notice"}},
+		},
+		Spawns: []Packet{
+			{Tags: map[string]string{"Name": "child"}},
+		},
+	}
+
+	if failures := Evaluate(step, result); len(failures) != 0 {
+		t.Errorf("expected a passing step, got failures: %v", failures)
+	}
+
+	result.Output = "count: three"
+	result.Messages = nil
+	failures := Evaluate(step, result)
+	if len(failures) != 3 {
+		t.Fatalf("expected 3 failures, got %v", failures)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	// An unexpected process error always fails the step
+	failures := Evaluate(Step{}, Result{Error: "attempt to index nil"})
+	if len(failures) != 1 || !strings.Contains(failures[0], "attempt to index nil") {
+		t.Errorf("expected the process error to fail the step, got %v", failures)
+	}
+
+	// An expected error passes when the substring matches
+	step := Step{Expect: Expect{Error: "index nil"}}
+	if failures := Evaluate(step, Result{Error: "attempt to index nil"}); len(failures) != 0 {
+		t.Errorf("expected the matching error to pass, got %v", failures)
+	}
+	if failures := Evaluate(step, Result{Output: "ok"}); len(failures) != 1 {
+		t.Errorf("expected a missing error to fail, got %v", failures)
+	}
+}
+
+func TestEvaluateTargetMatching(t *testing.T) {
+	step := Step{Expect: Expect{Messages: []ExpectedMsg{{Target: "proc-a", Tags: map[string]string{"Action": "Ping"}}}}}
+	result := Result{Messages: []Packet{{Target: "proc-b", Tags: map[string]string{"Action": "Ping"}}}}
+	if failures := Evaluate(step, result); len(failures) != 1 {
+		t.Errorf("expected target mismatch to fail, got %v", failures)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.xml")
+	results := []StepResult{
+		{Name: "ping responds", Duration: 1200 * time.Millisecond},
+		{Name: "bad output", Duration: 800 * time.Millisecond, Failures: []string{"expected output to contain \"pong\""}},
+		{Name: "unreachable", Err: os.ErrDeadlineExceeded},
+	}
+
+	if err := WriteJUnit(path, "counter smoke test", results); err != nil {
+		t.Fatalf("WriteJUnit failed: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	report := string(content)
+	for _, want := range []string{
+		`tests="3"`, `failures="1"`, `errors="1"`,
+		`name="counter smoke test"`, `name="ping responds"`,
+		"expected output to contain", "<error ",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+}
@@ -0,0 +1,372 @@
+// Package emulator provides a lightweight local AO runtime for dry-run
+// evaluation of Lua handlers. It emulates the aos Handlers library and the ao
+// message primitives on top of a pure-Go Lua VM, so handlers can be exercised
+// without Docker, a deployed process, or any network access.
+//
+// The emulation is intentionally a subset: it covers Handlers.add with
+// string/table/function patterns, ao.send/ao.spawn collection, and a json
+// library. It is a development aid, not a consensus-faithful replica of the
+// AO WASM runtime.
+package emulator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// gasUnitsPerNanosecond converts emulator wall-clock time into the same
+// order of magnitude as CU-reported gas. It was calibrated by comparing
+// emulator timings of simple AOS handlers (around a millisecond) against
+// the gas a CU reports for the same messages (around 1e9 units). It is a
+// rough calibration constant, not a measurement - treat estimates built
+// on it as relative weights, not billable compute.
+const gasUnitsPerNanosecond = 1000
+
+// Message represents a message sent or spawned by a handler during evaluation
+type Message struct {
+	Target string            `json:"target,omitempty"`
+	Action string            `json:"action,omitempty"`
+	Data   string            `json:"data,omitempty"`
+	Tags   map[string]string `json:"tags,omitempty"`
+}
+
+// Options configures a dry-run evaluation
+type Options struct {
+	ProcessID string            // Emulated process ID (default: "HARLEQUIN_EVAL_PROCESS")
+	Owner     string            // Emulated message sender (default: "HARLEQUIN_EVAL_OWNER")
+	Action    string            // Action tag of the evaluated message
+	Data      string            // Data of the evaluated message
+	Tags      map[string]string // Additional tags of the evaluated message
+	Timeout   time.Duration     // Evaluation timeout (default: 30s)
+	Profile   bool              // Sample memory usage during evaluation
+}
+
+// Result represents the outcome of a dry-run evaluation
+type Result struct {
+	Handled     bool           // Whether any handler matched the message
+	HandlerName string         // Name of the handler that ran
+	Output      string         // Captured print output
+	Messages    []Message      // Messages sent via ao.send
+	Spawns      []Message      // Processes spawned via ao.spawn
+	Duration    time.Duration  // Wall-clock evaluation time
+	GasEstimate int64          // Wall-clock time scaled by gasUnitsPerNanosecond; a rough proxy, not metered compute
+	Memory      *MemoryProfile // Set when Options.Profile is enabled
+}
+
+// handlersPrelude emulates the aos Handlers library: enough for handlers
+// registered via Handlers.add with string, table, or function patterns
+const handlersPrelude = `
+Handlers = Handlers or {}
+Handlers.list = Handlers.list or {}
+Handlers.utils = {
+	hasMatchingTag = function(name, value)
+		return function(msg) return msg.Tags[name] == value end
+	end,
+	hasMatchingData = function(value)
+		return function(msg) return msg.Data == value end
+	end,
+	reply = function(input)
+		return function(msg)
+			if type(input) == 'string' then input = { Data = input } end
+			input.Target = msg.From
+			ao.send(input)
+		end
+	end,
+}
+function Handlers.add(name, pattern, handle)
+	table.insert(Handlers.list, { name = name, pattern = pattern, handle = handle })
+end
+Handlers.append = Handlers.add
+function Handlers.prepend(name, pattern, handle)
+	table.insert(Handlers.list, 1, { name = name, pattern = pattern, handle = handle })
+end
+
+local function matches(pattern, msg)
+	local kind = type(pattern)
+	if kind == 'function' then
+		local ok, result = pcall(pattern, msg)
+		return ok and result ~= nil and result ~= false and result ~= 0
+	elseif kind == 'string' then
+		return msg.Action == pattern
+	elseif kind == 'table' then
+		for name, value in pairs(pattern) do
+			if msg.Tags[name] ~= value and msg[name] ~= value then return false end
+		end
+		return true
+	end
+	return false
+end
+
+function __harlequin_evaluate(msg, env)
+	for _, handler in ipairs(Handlers.list) do
+		if matches(handler.pattern, msg) then
+			handler.handle(msg, env)
+			return handler.name
+		end
+	end
+	return nil
+end
+`
+
+// Eval loads bundled Lua code into a fresh emulated runtime and evaluates a
+// single message against its registered handlers
+func Eval(ctx context.Context, bundledLua string, opts Options) (*Result, error) {
+	if opts.ProcessID == "" {
+		opts.ProcessID = "HARLEQUIN_EVAL_PROCESS"
+	}
+	if opts.Owner == "" {
+		opts.Owner = "HARLEQUIN_EVAL_OWNER"
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 30 * time.Second
+	}
+
+	L := lua.NewState()
+	defer L.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	L.SetContext(ctx)
+
+	result := &Result{}
+
+	// Capture print output instead of writing to stdout
+	var output strings.Builder
+	L.SetGlobal("print", L.NewFunction(func(L *lua.LState) int {
+		parts := make([]string, L.GetTop())
+		for i := 1; i <= L.GetTop(); i++ {
+			parts[i-1] = L.ToStringMeta(L.Get(i)).String()
+		}
+		output.WriteString(strings.Join(parts, "\t"))
+		output.WriteString("\n")
+		return 0
+	}))
+
+	registerJSONLibrary(L)
+	registerAO(L, opts.ProcessID, result)
+
+	if err := L.DoString(handlersPrelude); err != nil {
+		return nil, fmt.Errorf("failed to initialize handler emulation: %w", err)
+	}
+
+	start := time.Now()
+
+	if opts.Profile {
+		result.Memory = &MemoryProfile{BaselineBytes: liveHeapBytes()}
+	}
+
+	// Load the bundled module so it registers its handlers
+	if err := L.DoString(bundledLua); err != nil {
+		return nil, fmt.Errorf("failed to load module: %w", err)
+	}
+
+	var sampler *memorySampler
+	if opts.Profile {
+		result.Memory.LoadedBytes = liveHeapBytes()
+		sampler = startMemorySampler(result.Memory)
+	}
+
+	msg, env := buildMessage(L, opts)
+
+	// Evaluate the message against the registered handlers
+	evaluate := L.GetGlobal("__harlequin_evaluate")
+	callErr := L.CallByParam(lua.P{Fn: evaluate, NRet: 1, Protect: true}, msg, env)
+	if sampler != nil {
+		sampler.Stop()
+	}
+	if callErr != nil {
+		return nil, fmt.Errorf("handler execution failed: %w", callErr)
+	}
+
+	handlerName := L.Get(-1)
+	L.Pop(1)
+	if handlerName != lua.LNil {
+		result.Handled = true
+		result.HandlerName = lua.LVAsString(handlerName)
+	}
+
+	result.Duration = time.Since(start)
+	// Coarse proxy only: the real runtime meters WASM instructions, which the
+	// emulator cannot observe, so the estimate is wall-clock time scaled by a
+	// fixed calibration constant.
+	result.GasEstimate = result.Duration.Nanoseconds() * gasUnitsPerNanosecond
+	result.Output = strings.TrimRight(output.String(), "\n")
+
+	return result, nil
+}
+
+// registerAO installs the ao global with send/spawn collectors
+func registerAO(L *lua.LState, processID string, result *Result) {
+	ao := L.NewTable()
+	ao.RawSetString("id", lua.LString(processID))
+	ao.RawSetString("send", L.NewFunction(func(L *lua.LState) int {
+		tbl := L.CheckTable(1)
+		result.Messages = append(result.Messages, messageFromTable(tbl))
+		L.Push(tbl)
+		return 1
+	}))
+	ao.RawSetString("spawn", L.NewFunction(func(L *lua.LState) int {
+		tbl := L.CheckTable(1)
+		result.Spawns = append(result.Spawns, messageFromTable(tbl))
+		L.Push(tbl)
+		return 1
+	}))
+	L.SetGlobal("ao", ao)
+}
+
+// buildMessage constructs the msg and env tables for a handler invocation
+func buildMessage(L *lua.LState, opts Options) (*lua.LTable, *lua.LTable) {
+	tags := L.NewTable()
+	tags.RawSetString("Action", lua.LString(opts.Action))
+	for name, value := range opts.Tags {
+		tags.RawSetString(name, lua.LString(value))
+	}
+
+	msg := L.NewTable()
+	msg.RawSetString("Id", lua.LString("HARLEQUIN_EVAL_MESSAGE"))
+	msg.RawSetString("Target", lua.LString(opts.ProcessID))
+	msg.RawSetString("Owner", lua.LString(opts.Owner))
+	msg.RawSetString("From", lua.LString(opts.Owner))
+	msg.RawSetString("Action", lua.LString(opts.Action))
+	msg.RawSetString("Data", lua.LString(opts.Data))
+	msg.RawSetString("Tags", tags)
+
+	process := L.NewTable()
+	process.RawSetString("Id", lua.LString(opts.ProcessID))
+	process.RawSetString("Owner", lua.LString(opts.Owner))
+
+	env := L.NewTable()
+	env.RawSetString("Process", process)
+
+	return msg, env
+}
+
+// messageFromTable converts a Lua message table into a Message
+func messageFromTable(tbl *lua.LTable) Message {
+	msg := Message{Tags: make(map[string]string)}
+
+	tbl.ForEach(func(key, value lua.LValue) {
+		name, ok := key.(lua.LString)
+		if !ok {
+			return
+		}
+		switch string(name) {
+		case "Target":
+			msg.Target = lua.LVAsString(value)
+		case "Action":
+			msg.Action = lua.LVAsString(value)
+		case "Data":
+			msg.Data = lua.LVAsString(value)
+		case "Tags":
+			if tagsTbl, ok := value.(*lua.LTable); ok {
+				tagsTbl.ForEach(func(tagKey, tagValue lua.LValue) {
+					// Accept both map-style and array-of-{name,value} tags
+					if tagName, ok := tagKey.(lua.LString); ok {
+						msg.Tags[string(tagName)] = lua.LVAsString(tagValue)
+					} else if entry, ok := tagValue.(*lua.LTable); ok {
+						entryName := lua.LVAsString(entry.RawGetString("name"))
+						if entryName != "" {
+							msg.Tags[entryName] = lua.LVAsString(entry.RawGetString("value"))
+						}
+					}
+				})
+			}
+		default:
+			// Top-level fields other than the known ones are treated as tags,
+			// mirroring how aos flattens message tags
+			msg.Tags[string(name)] = lua.LVAsString(value)
+		}
+	})
+
+	if msg.Action == "" {
+		msg.Action = msg.Tags["Action"]
+	}
+
+	return msg
+}
+
+// registerJSONLibrary installs a json global with encode/decode, which aos
+// modules commonly rely on
+func registerJSONLibrary(L *lua.LState) {
+	jsonTable := L.NewTable()
+	jsonTable.RawSetString("encode", L.NewFunction(func(L *lua.LState) int {
+		value := luaToGo(L.Get(1))
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			L.RaiseError("json.encode: %v", err)
+			return 0
+		}
+		L.Push(lua.LString(encoded))
+		return 1
+	}))
+	jsonTable.RawSetString("decode", L.NewFunction(func(L *lua.LState) int {
+		var value interface{}
+		if err := json.Unmarshal([]byte(L.CheckString(1)), &value); err != nil {
+			L.RaiseError("json.decode: %v", err)
+			return 0
+		}
+		L.Push(goToLua(L, value))
+		return 1
+	}))
+	L.SetGlobal("json", jsonTable)
+}
+
+// luaToGo converts a Lua value into a Go value suitable for JSON encoding
+func luaToGo(value lua.LValue) interface{} {
+	switch v := value.(type) {
+	case lua.LBool:
+		return bool(v)
+	case lua.LNumber:
+		return float64(v)
+	case lua.LString:
+		return string(v)
+	case *lua.LTable:
+		// Array-like tables become slices, everything else becomes maps
+		maxN := v.MaxN()
+		if maxN > 0 {
+			arr := make([]interface{}, 0, maxN)
+			for i := 1; i <= maxN; i++ {
+				arr = append(arr, luaToGo(v.RawGetInt(i)))
+			}
+			return arr
+		}
+		obj := make(map[string]interface{})
+		v.ForEach(func(key, item lua.LValue) {
+			obj[lua.LVAsString(key)] = luaToGo(item)
+		})
+		return obj
+	default:
+		return nil
+	}
+}
+
+// goToLua converts a decoded JSON value into a Lua value
+func goToLua(L *lua.LState, value interface{}) lua.LValue {
+	switch v := value.(type) {
+	case bool:
+		return lua.LBool(v)
+	case float64:
+		return lua.LNumber(v)
+	case string:
+		return lua.LString(v)
+	case []interface{}:
+		tbl := L.NewTable()
+		for _, item := range v {
+			tbl.Append(goToLua(L, item))
+		}
+		return tbl
+	case map[string]interface{}:
+		tbl := L.NewTable()
+		for key, item := range v {
+			tbl.RawSetString(key, goToLua(L, item))
+		}
+		return tbl
+	default:
+		return lua.LNil
+	}
+}
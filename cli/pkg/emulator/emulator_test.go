@@ -0,0 +1,122 @@
+package emulator
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+const counterModule = `
+local count = 0
+
+Handlers.add("increment", "Increment", function(msg)
+	count = count + tonumber(msg.Tags.Quantity or "1")
+	ao.send({ Target = msg.From, Action = "Incremented", Data = tostring(count) })
+end)
+
+Handlers.add("info", Handlers.utils.hasMatchingTag("Action", "Info"), function(msg)
+	print("count is " .. tostring(count))
+end)
+`
+
+func TestEvalMatchesStringPattern(t *testing.T) {
+	result, err := Eval(context.Background(), counterModule, Options{
+		Action: "Increment",
+		Tags:   map[string]string{"Quantity": "5"},
+	})
+	if err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+
+	if !result.Handled {
+		t.Fatal("Expected a handler to match")
+	}
+	if result.HandlerName != "increment" {
+		t.Errorf("Expected handler 'increment', got %q", result.HandlerName)
+	}
+	if len(result.Messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(result.Messages))
+	}
+	if result.Messages[0].Data != "5" {
+		t.Errorf("Expected message data '5', got %q", result.Messages[0].Data)
+	}
+	if result.Messages[0].Action != "Incremented" {
+		t.Errorf("Expected message action 'Incremented', got %q", result.Messages[0].Action)
+	}
+}
+
+func TestEvalMatchesFunctionPattern(t *testing.T) {
+	result, err := Eval(context.Background(), counterModule, Options{Action: "Info"})
+	if err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+
+	if result.HandlerName != "info" {
+		t.Errorf("Expected handler 'info', got %q", result.HandlerName)
+	}
+	if result.Output != "count is 0" {
+		t.Errorf("Expected captured print output, got %q", result.Output)
+	}
+}
+
+func TestEvalUnmatchedMessage(t *testing.T) {
+	result, err := Eval(context.Background(), counterModule, Options{Action: "Unknown"})
+	if err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+
+	if result.Handled {
+		t.Errorf("Expected no handler to match, got %q", result.HandlerName)
+	}
+}
+
+func TestEvalJSONLibrary(t *testing.T) {
+	module := `
+Handlers.add("balance", "Balance", function(msg)
+	local request = json.decode(msg.Data)
+	ao.send({ Target = msg.From, Data = json.encode({ account = request.account, balance = 100 }) })
+end)
+`
+	result, err := Eval(context.Background(), module, Options{
+		Action: "Balance",
+		Data:   `{"account":"abc"}`,
+	})
+	if err != nil {
+		t.Fatalf("Eval failed: %v", err)
+	}
+
+	if len(result.Messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(result.Messages))
+	}
+	data := result.Messages[0].Data
+	if data != `{"account":"abc","balance":100}` && data != `{"balance":100,"account":"abc"}` {
+		t.Errorf("Unexpected json round-trip result: %q", data)
+	}
+}
+
+func TestEvalRuntimeError(t *testing.T) {
+	module := `
+Handlers.add("boom", "Boom", function(msg)
+	error("handler exploded")
+end)
+`
+	_, err := Eval(context.Background(), module, Options{Action: "Boom"})
+	if err == nil {
+		t.Fatal("Expected handler error to surface")
+	}
+}
+
+func TestEvalTimeout(t *testing.T) {
+	module := `
+Handlers.add("spin", "Spin", function(msg)
+	while true do end
+end)
+`
+	_, err := Eval(context.Background(), module, Options{
+		Action:  "Spin",
+		Timeout: 100 * time.Millisecond,
+	})
+	if err == nil {
+		t.Fatal("Expected evaluation to time out")
+	}
+}
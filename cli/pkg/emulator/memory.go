@@ -0,0 +1,104 @@
+package emulator
+
+import (
+	"runtime"
+	"time"
+)
+
+// growthEventStep is the heap increase that records a new growth event
+const growthEventStep = 1 << 20 // 1 MB
+
+// memorySampleInterval is how often the sampler polls the heap during
+// handler evaluation
+const memorySampleInterval = 2 * time.Millisecond
+
+// MemoryProfile captures heap usage around an evaluation. The emulator runs
+// handlers in a Go-hosted Lua VM, so these figures are a host-heap proxy for
+// the linear memory a WASM build would consume, not an exact measurement.
+type MemoryProfile struct {
+	BaselineBytes int64         // Live heap before the module was loaded
+	LoadedBytes   int64         // Live heap after the module registered its handlers
+	PeakBytes     int64         // Highest heap observed during evaluation
+	GrowthEvents  []GrowthEvent // Each time the heap grew by another step
+}
+
+// GrowthEvent is one observed heap growth during evaluation
+type GrowthEvent struct {
+	At    time.Duration // Offset from the start of evaluation
+	Bytes int64         // Heap size when the growth was observed
+}
+
+// liveHeapBytes reports retained heap after a collection, so baselines are
+// not inflated by garbage from previous work
+func liveHeapBytes() int64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return int64(stats.HeapInuse)
+}
+
+// allocatedHeapBytes reports the current heap without forcing a collection;
+// used while sampling so the sampler does not mask growth
+func allocatedHeapBytes() int64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return int64(stats.HeapAlloc)
+}
+
+// memorySampler polls the heap on an interval while a handler runs
+type memorySampler struct {
+	profile *MemoryProfile
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// startMemorySampler begins sampling into the profile until Stop is called
+func startMemorySampler(profile *MemoryProfile) *memorySampler {
+	s := &memorySampler{
+		profile: profile,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	start := time.Now()
+	profile.PeakBytes = allocatedHeapBytes()
+	lastEvent := profile.PeakBytes
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(memorySampleInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				current := allocatedHeapBytes()
+				if current > s.profile.PeakBytes {
+					s.profile.PeakBytes = current
+				}
+				if current >= lastEvent+growthEventStep {
+					s.profile.GrowthEvents = append(s.profile.GrowthEvents, GrowthEvent{
+						At:    time.Since(start),
+						Bytes: current,
+					})
+					lastEvent = current
+				}
+			}
+		}
+	}()
+
+	return s
+}
+
+// Stop ends sampling, taking one final sample so short handlers still
+// register their peak
+func (s *memorySampler) Stop() {
+	close(s.stop)
+	<-s.done
+
+	if current := allocatedHeapBytes(); current > s.profile.PeakBytes {
+		s.profile.PeakBytes = current
+	}
+}
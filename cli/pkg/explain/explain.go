@@ -0,0 +1,141 @@
+// Package explain recognizes common build and upload failure signatures and
+// maps them to targeted remediation steps. The explain command runs it over
+// pasted error text, and failure paths in other commands consult it so users
+// see what to do next instead of only a wrapped error chain.
+package explain
+
+import "regexp"
+
+// Issue is one recognized failure with its remediation
+type Issue struct {
+	ID     string   // Stable identifier, e.g. "docker-not-running"
+	Title  string   // One-line summary of what actually went wrong
+	Detail string   // Why this happens
+	Steps  []string // Remediation, in order
+	DocURL string   // Where to read more
+}
+
+// entry pairs an issue with the error-text patterns that reveal it
+type entry struct {
+	issue    Issue
+	patterns []*regexp.Regexp
+}
+
+// knowledgeBase is ordered from most to least specific so the first match is
+// the most actionable one
+var knowledgeBase = []entry{
+	{
+		issue: Issue{
+			ID:    "docker-not-running",
+			Title: "Docker is not running",
+			Detail: "Builds run inside a Docker container, and the Docker daemon " +
+				"could not be reached.",
+			Steps: []string{
+				"Start Docker Desktop (macOS/Windows) or `sudo systemctl start docker` (Linux)",
+				"Verify with `docker ps`",
+				"If Docker is not installed, run `harlequin setup` for guided installation",
+			},
+			DocURL: "https://docs.docker.com/config/daemon/start/",
+		},
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)cannot connect to the docker daemon`),
+			regexp.MustCompile(`(?i)is the docker daemon running`),
+			regexp.MustCompile(`(?i)docker(\.sock)?: (connect: )?(no such file|connection refused|permission denied)`),
+			regexp.MustCompile(`(?i)docker: command not found`),
+		},
+	},
+	{
+		issue: Issue{
+			ID:    "platform-emulation-missing",
+			Title: "Build image has no arm64 variant (Apple Silicon)",
+			Detail: "The build container image is amd64-only, and Docker on Apple " +
+				"Silicon needs emulation to run it.",
+			Steps: []string{
+				"Enable Rosetta emulation: Docker Desktop → Settings → General → \"Use Rosetta for x86_64/amd64 emulation\"",
+				"Or force the platform: export DOCKER_DEFAULT_PLATFORM=linux/amd64",
+				"Retry the build - first runs are slower under emulation",
+			},
+			DocURL: "https://docs.docker.com/build/building/multi-platform/",
+		},
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)no matching manifest for linux/arm64`),
+			regexp.MustCompile(`(?i)platform \(linux/amd64\) does not match.*host platform`),
+			regexp.MustCompile(`(?i)exec format error`),
+			regexp.MustCompile(`(?i)rosetta.*(error|not installed)`),
+		},
+	},
+	{
+		issue: Issue{
+			ID:    "turbo-payment-required",
+			Title: "Turbo rejected the upload: insufficient credits (HTTP 402)",
+			Detail: "The upload is larger than Turbo's free tier and the wallet " +
+				"has no Turbo credits to cover it.",
+			Steps: []string{
+				"Check the wallet's balance and the upload cost: `harlequin upload --dry-run <file>`",
+				"Top up credits at https://turbo-topup.com with the same wallet",
+				"Or upload through a different bundler: `harlequin upload --bundler <URL> <file>`",
+			},
+			DocURL: "https://docs.ardrive.io/docs/turbo/what-is-turbo.html",
+		},
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)http 402`),
+			regexp.MustCompile(`(?i)status(code)?[:= ]+402`),
+			regexp.MustCompile(`(?i)payment required`),
+			regexp.MustCompile(`(?i)insufficient (balance|funds|credits)`),
+		},
+	},
+	{
+		issue: Issue{
+			ID:    "handlers-append-missing",
+			Title: "Handlers library is not loaded (Handlers.append is nil)",
+			Detail: "The module calls Handlers.append or Handlers.add before the aos " +
+				"Handlers library exists - usually code written for an aos REPL " +
+				"running in a bare module, or a base module without aos.",
+			Steps: []string{
+				"Build on an aos base module so Handlers is provided by the runtime",
+				"Or guard the registration: `Handlers = Handlers or require('.handlers')`",
+				"Dry-run locally to confirm: `harlequin eval --action <YourAction>`",
+			},
+			DocURL: "https://cookbook_ao.g8way.io/references/handlers.html",
+		},
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)attempt to (call|index) a nil value \(global 'Handlers'\)`),
+			regexp.MustCompile(`(?i)attempt to call a nil value \(field '(append|add|prepend)'\)`),
+		},
+	},
+	{
+		issue: Issue{
+			ID:    "signing-max-data-size",
+			Title: "Payload exceeds the signing server's MaxDataSize",
+			Detail: "The remote signing server caps the data it will relay to the " +
+				"browser wallet, and this payload is over that cap.",
+			Steps: []string{
+				"Raise the cap when starting the server: `harlequin remote-signing start --max-size <BYTES>`",
+				"Or sign large files with a local keyfile instead: `harlequin upload -w key.json <file>`",
+				"Check the payload size - WASM modules can often shrink with a release build",
+			},
+			DocURL: "https://github.com/the-permaweb-harlequin/harlequin-toolkit",
+		},
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)max(imum)?[ _-]?data[ _-]?size`),
+			regexp.MustCompile(`(?i)data too large`),
+			regexp.MustCompile(`(?i)http 413`),
+			regexp.MustCompile(`(?i)request entity too large`),
+		},
+	},
+}
+
+// Match returns the recognized issues in the error text, most specific first.
+// Unrecognized text returns nil.
+func Match(errText string) []Issue {
+	var issues []Issue
+	for _, candidate := range knowledgeBase {
+		for _, pattern := range candidate.patterns {
+			if pattern.MatchString(errText) {
+				issues = append(issues, candidate.issue)
+				break
+			}
+		}
+	}
+	return issues
+}
@@ -0,0 +1,63 @@
+package explain
+
+import "testing"
+
+func TestMatchRecognizesKnownSignatures(t *testing.T) {
+	cases := map[string]string{
+		"Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?": "docker-not-running",
+		"docker: command not found": "docker-not-running",
+		"no matching manifest for linux/arm64/v8 in the manifest list entries":          "platform-emulation-missing",
+		"standard_init_linux.go:228: exec user process caused: exec format error":       "platform-emulation-missing",
+		"failed to upload file: upload failed with status 402: Payment Required":        "turbo-payment-required",
+		"insufficient balance for upload of 12582912 bytes":                             "turbo-payment-required",
+		"<string>:12: attempt to call a nil value (field 'append')":                     "handlers-append-missing",
+		"main.lua:3: attempt to index a nil value (global 'Handlers')":                  "handlers-append-missing",
+		`signing server error (HTTP 413): {"error":"Data too large","max_size":104857}`: "signing-max-data-size",
+		"request entity too large": "signing-max-data-size",
+	}
+
+	for errText, wantID := range cases {
+		issues := Match(errText)
+		if len(issues) == 0 {
+			t.Errorf("expected %q to match %s, got nothing", errText, wantID)
+			continue
+		}
+		if issues[0].ID != wantID {
+			t.Errorf("expected %q to match %s, got %s", errText, wantID, issues[0].ID)
+		}
+	}
+}
+
+func TestMatchUnknownTextReturnsNil(t *testing.T) {
+	for _, errText := range []string{
+		"",
+		"some totally novel failure",
+		"connection reset by peer",
+	} {
+		if issues := Match(errText); issues != nil {
+			t.Errorf("expected %q to match nothing, got %v", errText, issues)
+		}
+	}
+}
+
+func TestMatchReportsEveryIssueInAChain(t *testing.T) {
+	errText := "build failed: Cannot connect to the Docker daemon\n" +
+		"caused by: no matching manifest for linux/arm64"
+
+	issues := Match(errText)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %v", issues)
+	}
+	if issues[0].ID != "docker-not-running" || issues[1].ID != "platform-emulation-missing" {
+		t.Errorf("unexpected issue order: %v", issues)
+	}
+}
+
+func TestIssuesAreActionable(t *testing.T) {
+	for _, candidate := range knowledgeBase {
+		issue := candidate.issue
+		if issue.Title == "" || issue.Detail == "" || len(issue.Steps) == 0 || issue.DocURL == "" {
+			t.Errorf("issue %s is missing remediation content", issue.ID)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Package fuzz generates malformed and edge-case AO messages for hardening
+// handlers before they face mainnet traffic. Generation is seeded and
+// deterministic so any finding can be reproduced by re-running with the
+// reported seed; the fuzz command feeds the cases to the local emulator.
+package fuzz
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Case is one generated input message
+type Case struct {
+	Name     string
+	Strategy string
+	Action   string
+	Data     string
+	Tags     map[string]string
+}
+
+// strategy produces one case from a seeded random source
+type strategy struct {
+	name     string
+	generate func(r *rand.Rand, actions []string) Case
+}
+
+// strategies are cycled in order, so every run covers each class of
+// malformed input regardless of count
+var strategies = []strategy{
+	{"missing-action", missingAction},
+	{"bad-json", badJSON},
+	{"huge-tag-value", hugeTagValue},
+	{"many-tags", manyTags},
+	{"unicode", unicode},
+	{"binary-data", binaryData},
+	{"nested-json", nestedJSON},
+	{"numeric-edge", numericEdge},
+	{"empty-fields", emptyFields},
+	{"random-action", randomAction},
+}
+
+// Generate produces count cases from the seed, cycling through every
+// strategy. The same seed and count always yield the same cases.
+func Generate(seed int64, count int, actions []string) []Case {
+	r := rand.New(rand.NewSource(seed))
+	if len(actions) == 0 {
+		actions = []string{"Info"}
+	}
+
+	cases := make([]Case, 0, count)
+	for i := 0; i < count; i++ {
+		s := strategies[i%len(strategies)]
+		c := s.generate(r, actions)
+		c.Strategy = s.name
+		c.Name = fmt.Sprintf("%s #%d", s.name, i+1)
+		cases = append(cases, c)
+	}
+	return cases
+}
+
+// Strategies lists the strategy names, for documentation output
+func Strategies() []string {
+	names := make([]string, len(strategies))
+	for i, s := range strategies {
+		names[i] = s.name
+	}
+	return names
+}
+
+// pick selects a random known action so handlers actually match
+func pick(r *rand.Rand, actions []string) string {
+	return actions[r.Intn(len(actions))]
+}
+
+// missingAction omits the Action tag entirely
+func missingAction(r *rand.Rand, actions []string) Case {
+	return Case{Action: "", Data: randomASCII(r, 32)}
+}
+
+// badJSON sends data that almost parses: truncated, trailing commas,
+// unbalanced braces
+func badJSON(r *rand.Rand, actions []string) Case {
+	variants := []string{
+		`{"qty":`,
+		`{"qty":100,}`,
+		`{"a":{"b":}`,
+		`[1,2,`,
+		`{"key":"val"`,
+		"not json at all",
+		`{"dup":1,"dup":2}`,
+	}
+	return Case{Action: pick(r, actions), Data: variants[r.Intn(len(variants))]}
+}
+
+// hugeTagValue attaches a tag far beyond typical gateway limits
+func hugeTagValue(r *rand.Rand, actions []string) Case {
+	size := 1 << (12 + r.Intn(5)) // 4KB..64KB
+	return Case{
+		Action: pick(r, actions),
+		Tags:   map[string]string{"Payload": strings.Repeat("A", size)},
+	}
+}
+
+// manyTags attaches an excessive number of tags
+func manyTags(r *rand.Rand, actions []string) Case {
+	tags := make(map[string]string)
+	for i := 0; i < 200+r.Intn(300); i++ {
+		tags[fmt.Sprintf("Tag-%d", i)] = randomASCII(r, 16)
+	}
+	return Case{Action: pick(r, actions), Tags: tags}
+}
+
+// unicode exercises multi-byte, combining, bidi, and zero-width input
+func unicode(r *rand.Rand, actions []string) Case {
+	variants := []string{
+		"🎭🃏🎪 emoji payload 🎠",
+		"é́́ combining marks",
+		"‮reversed‬ bidi override",
+		"zero​width‌joiners‍",
+		"null\x00byte",
+		"ＡＢＣ fullwidth",
+		strings.Repeat("字", 512),
+	}
+	payload := variants[r.Intn(len(variants))]
+	return Case{
+		Action: pick(r, actions),
+		Data:   payload,
+		Tags:   map[string]string{"Note": payload},
+	}
+}
+
+// binaryData sends non-UTF8 bytes as data
+func binaryData(r *rand.Rand, actions []string) Case {
+	raw := make([]byte, 64+r.Intn(192))
+	r.Read(raw)
+	return Case{Action: pick(r, actions), Data: string(raw)}
+}
+
+// nestedJSON sends deeply nested structures that can blow recursive parsers
+func nestedJSON(r *rand.Rand, actions []string) Case {
+	depth := 50 + r.Intn(150)
+	data := strings.Repeat(`{"a":`, depth) + "1" + strings.Repeat("}", depth)
+	return Case{Action: pick(r, actions), Data: data}
+}
+
+// numericEdge sends boundary numbers where handlers often expect quantities
+func numericEdge(r *rand.Rand, actions []string) Case {
+	variants := []string{
+		"-1", "0", "9223372036854775807", "-9223372036854775808",
+		"1e308", "NaN", "Infinity", "0.1", "1.7976931348623157e+309",
+	}
+	value := variants[r.Intn(len(variants))]
+	return Case{
+		Action: pick(r, actions),
+		Data:   fmt.Sprintf(`{"qty":%q}`, value),
+		Tags:   map[string]string{"Quantity": value},
+	}
+}
+
+// emptyFields sends a message with everything empty except the action
+func emptyFields(r *rand.Rand, actions []string) Case {
+	return Case{
+		Action: pick(r, actions),
+		Tags:   map[string]string{"": "", "Recipient": ""},
+	}
+}
+
+// randomAction sends actions no handler should know
+func randomAction(r *rand.Rand, actions []string) Case {
+	return Case{Action: randomASCII(r, 8+r.Intn(24)), Data: randomASCII(r, 64)}
+}
+
+// randomASCII builds a printable random string
+func randomASCII(r *rand.Rand, length int) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_"
+	var b strings.Builder
+	for i := 0; i < length; i++ {
+		b.WriteByte(alphabet[r.Intn(len(alphabet))])
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package fuzz
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	first := Generate(42, 30, []string{"Info", "Transfer"})
+	second := Generate(42, 30, []string{"Info", "Transfer"})
+
+	if len(first) != 30 {
+		t.Fatalf("expected 30 cases, got %d", len(first))
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Error("expected the same seed to generate identical cases")
+	}
+
+	different := Generate(43, 30, []string{"Info", "Transfer"})
+	if reflect.DeepEqual(first, different) {
+		t.Error("expected a different seed to generate different cases")
+	}
+}
+
+func TestGenerateCoversAllStrategies(t *testing.T) {
+	cases := Generate(1, len(Strategies()), []string{"Info"})
+
+	seen := make(map[string]bool)
+	for _, c := range cases {
+		seen[c.Strategy] = true
+	}
+	for _, name := range Strategies() {
+		if !seen[name] {
+			t.Errorf("strategy %s not covered in one full cycle", name)
+		}
+	}
+}
+
+func TestStrategyShapes(t *testing.T) {
+	cases := Generate(7, 100, []string{"Transfer"})
+
+	for _, c := range cases {
+		switch c.Strategy {
+		case "missing-action":
+			if c.Action != "" {
+				t.Errorf("%s: expected an empty action, got %q", c.Name, c.Action)
+			}
+		case "huge-tag-value":
+			if len(c.Tags["Payload"]) < 4096 {
+				t.Errorf("%s: expected a huge tag value, got %d bytes", c.Name, len(c.Tags["Payload"]))
+			}
+		case "many-tags":
+			if len(c.Tags) < 200 {
+				t.Errorf("%s: expected at least 200 tags, got %d", c.Name, len(c.Tags))
+			}
+		case "nested-json":
+			if len(c.Data) < 300 {
+				t.Errorf("%s: expected deeply nested data, got %d bytes", c.Name, len(c.Data))
+			}
+		}
+	}
+}
+
+func TestGenerateDefaultsActions(t *testing.T) {
+	cases := Generate(1, 10, nil)
+	for _, c := range cases {
+		if c.Strategy == "bad-json" && c.Action != "Info" {
+			t.Errorf("expected the default action set, got %q", c.Action)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Package i18n provides the message catalog for user-facing strings. English
+// is the source language: T looks translations up by the English string
+// itself, so untranslated messages fall back to English instead of breaking.
+// The locale comes from --locale or the LANG/LC_ALL environment, and catalogs
+// are embedded JSON files mapping English strings to their translations.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalog is the active translation table; nil means English passthrough
+var catalog map[string]string
+
+// loadedCatalogs caches parsed locale files by normalized tag
+var loadedCatalogs map[string]map[string]string
+
+// Detect resolves the locale to use: an explicit --locale value wins,
+// otherwise the standard LC_ALL/LANG environment variables decide
+func Detect(explicit string) string {
+	if explicit != "" {
+		return explicit
+	}
+	for _, envVar := range []string{"LC_ALL", "LANG"} {
+		if value := os.Getenv(envVar); value != "" {
+			return value
+		}
+	}
+	return "en"
+}
+
+// SetLocale activates the catalog for a locale tag. Tags are normalized from
+// environment forms like "zh_CN.UTF-8", falling back from "zh-cn" to "zh".
+// English (or an empty tag) clears the catalog; an unknown locale is an
+// error so a typo in --locale does not silently render English.
+func SetLocale(locale string) error {
+	tag := normalize(locale)
+	if tag == "" || tag == "en" || strings.HasPrefix(tag, "en-") || tag == "c" || tag == "posix" {
+		catalog = nil
+		return nil
+	}
+
+	catalogs, err := load()
+	if err != nil {
+		return err
+	}
+
+	for _, candidate := range []string{tag, baseLanguage(tag)} {
+		if translations, ok := catalogs[candidate]; ok {
+			catalog = translations
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported locale %q (available: en, %s)", locale, strings.Join(Locales(), ", "))
+}
+
+// T translates an English message, formatting it with args when given.
+// Messages without a translation in the active catalog render in English.
+func T(message string, args ...interface{}) string {
+	translated := message
+	if catalog != nil {
+		if replacement, ok := catalog[message]; ok && replacement != "" {
+			translated = replacement
+		}
+	}
+	if len(args) == 0 {
+		return translated
+	}
+	return fmt.Sprintf(translated, args...)
+}
+
+// Locales lists the locale tags with embedded catalogs, sorted
+func Locales() []string {
+	catalogs, err := load()
+	if err != nil {
+		return nil
+	}
+	tags := make([]string, 0, len(catalogs))
+	for tag := range catalogs {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// normalize lowers a locale tag and strips encoding and modifier suffixes:
+// "zh_CN.UTF-8@pinyin" becomes "zh-cn"
+func normalize(locale string) string {
+	tag := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	return strings.ReplaceAll(tag, "_", "-")
+}
+
+// baseLanguage strips the region: "zh-cn" becomes "zh"
+func baseLanguage(tag string) string {
+	if i := strings.Index(tag, "-"); i >= 0 {
+		return tag[:i]
+	}
+	return tag
+}
+
+// load parses the embedded catalogs once
+func load() (map[string]map[string]string, error) {
+	if loadedCatalogs != nil {
+		return loadedCatalogs, nil
+	}
+
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list embedded locales: %w", err)
+	}
+
+	catalogs := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		content, err := localeFiles.ReadFile(path.Join("locales", name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read locale %s: %w", name, err)
+		}
+		var translations map[string]string
+		if err := json.Unmarshal(content, &translations); err != nil {
+			return nil, fmt.Errorf("failed to parse locale %s: %w", name, err)
+		}
+		catalogs[strings.TrimSuffix(name, ".json")] = translations
+	}
+
+	loadedCatalogs = catalogs
+	return catalogs, nil
+}
@@ -0,0 +1,78 @@
+package i18n
+
+import (
+	"testing"
+)
+
+// resetLocale restores English after a test changes the active catalog
+func resetLocale(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		if err := SetLocale("en"); err != nil {
+			t.Fatalf("Failed to reset locale: %v", err)
+		}
+	})
+}
+
+func TestDetectPrefersExplicitLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "zh_CN.UTF-8")
+	if got := Detect("en"); got != "en" {
+		t.Errorf("Detect with explicit locale = %q, want en", got)
+	}
+	if got := Detect(""); got != "zh_CN.UTF-8" {
+		t.Errorf("Detect from environment = %q, want zh_CN.UTF-8", got)
+	}
+}
+
+func TestSetLocaleNormalizesEnvironmentTags(t *testing.T) {
+	resetLocale(t)
+
+	// Full environment form falls back through zh-cn to the zh catalog
+	if err := SetLocale("zh_CN.UTF-8"); err != nil {
+		t.Fatalf("SetLocale(zh_CN.UTF-8) failed: %v", err)
+	}
+	if got := T("Show version information"); got == "Show version information" {
+		t.Error("Expected a Chinese translation after activating zh")
+	}
+}
+
+func TestSetLocaleRejectsUnknownLocale(t *testing.T) {
+	resetLocale(t)
+
+	if err := SetLocale("tlh"); err == nil {
+		t.Error("Expected an error for an unsupported locale")
+	}
+}
+
+func TestTFallsBackToEnglish(t *testing.T) {
+	resetLocale(t)
+
+	if err := SetLocale("zh"); err != nil {
+		t.Fatalf("SetLocale(zh) failed: %v", err)
+	}
+	untranslated := "This message has no catalog entry"
+	if got := T(untranslated); got != untranslated {
+		t.Errorf("Expected English fallback, got %q", got)
+	}
+}
+
+func TestTFormatsArguments(t *testing.T) {
+	resetLocale(t)
+
+	if got := T("hello %s", "world"); got != "hello world" {
+		t.Errorf("T with args = %q, want %q", got, "hello world")
+	}
+}
+
+func TestEnglishVariantsClearCatalog(t *testing.T) {
+	resetLocale(t)
+
+	for _, tag := range []string{"", "en", "en_US.UTF-8", "C", "POSIX"} {
+		if err := SetLocale(tag); err != nil {
+			t.Errorf("SetLocale(%q) failed: %v", tag, err)
+		}
+		if got := T("Show version information"); got != "Show version information" {
+			t.Errorf("SetLocale(%q): expected English passthrough, got %q", tag, got)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Package luadeps manages vendored Lua dependencies for AO processes.
+// Packages fetched from Arweave or git are vendored under lua_modules/ and
+// pinned in harlequin-lock.yaml, and the bundler resolves require() calls
+// against the vendored tree.
+package luadeps
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// LockfileName is the lockfile pinning vendored dependencies
+	LockfileName = "harlequin-lock.yaml"
+
+	// VendorDir is the directory the bundler resolves vendored requires from
+	VendorDir = "lua_modules"
+)
+
+// LockedPackage pins one vendored dependency
+type LockedPackage struct {
+	Source    string `yaml:"source"`              // Where the package came from: "arweave", "git", or "registry"
+	Reference string `yaml:"reference"`           // Arweave txID or git commit hash
+	URL       string `yaml:"url,omitempty"`       // Git URL or registry process ID, when applicable
+	SHA256    string `yaml:"sha256"`              // Checksum of the vendored file
+}
+
+// Lockfile records every vendored dependency of a project
+type Lockfile struct {
+	Packages map[string]LockedPackage `yaml:"packages"`
+}
+
+// LoadLockfile reads the lockfile in dir, returning an empty lockfile when
+// none exists yet
+func LoadLockfile(dir string) (*Lockfile, error) {
+	lockfile := &Lockfile{Packages: make(map[string]LockedPackage)}
+
+	content, err := os.ReadFile(filepath.Join(dir, LockfileName))
+	if os.IsNotExist(err) {
+		return lockfile, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+
+	if err := yaml.Unmarshal(content, lockfile); err != nil {
+		return nil, fmt.Errorf("failed to parse lockfile: %w", err)
+	}
+	if lockfile.Packages == nil {
+		lockfile.Packages = make(map[string]LockedPackage)
+	}
+	return lockfile, nil
+}
+
+// Save writes the lockfile to dir
+func (l *Lockfile) Save(dir string) error {
+	content, err := yaml.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("failed to marshal lockfile: %w", err)
+	}
+	return os.WriteFile(filepath.Join(dir, LockfileName), content, 0644)
+}
+
+// Names returns the locked package names, sorted
+func (l *Lockfile) Names() []string {
+	names := make([]string, 0, len(l.Packages))
+	for name := range l.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// VendorPath maps a package name (dot notation allowed, as in require()) to
+// its vendored file path under dir
+func VendorPath(dir, name string) string {
+	relative := strings.ReplaceAll(name, ".", string(filepath.Separator)) + ".lua"
+	return filepath.Join(dir, VendorDir, relative)
+}
+
+// Vendor writes package content to the vendored tree and returns the
+// checksum recorded in the lockfile
+func Vendor(dir, name string, content []byte) (string, error) {
+	path := VendorPath(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create vendor directory: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return "", fmt.Errorf("failed to write vendored module: %w", err)
+	}
+	return Checksum(content), nil
+}
+
+// Checksum returns the hex SHA-256 of the vendored content
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify checks every locked package against its vendored file, returning the
+// names of packages that are missing or modified
+func (l *Lockfile) Verify(dir string) []string {
+	var broken []string
+	for _, name := range l.Names() {
+		content, err := os.ReadFile(VendorPath(dir, name))
+		if err != nil || Checksum(content) != l.Packages[name].SHA256 {
+			broken = append(broken, name)
+		}
+	}
+	return broken
+}
@@ -0,0 +1,85 @@
+package luadeps
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLockfileRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	lockfile, err := LoadLockfile(dir)
+	if err != nil {
+		t.Fatalf("LoadLockfile failed: %v", err)
+	}
+	if len(lockfile.Packages) != 0 {
+		t.Fatalf("Expected empty lockfile, got %d packages", len(lockfile.Packages))
+	}
+
+	lockfile.Packages["utils"] = LockedPackage{
+		Source:    "arweave",
+		Reference: "abc123def456abc123def456abc123def456abc1234",
+		SHA256:    "deadbeef",
+	}
+	if err := lockfile.Save(dir); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded, err := LoadLockfile(dir)
+	if err != nil {
+		t.Fatalf("LoadLockfile failed: %v", err)
+	}
+	pkg, ok := reloaded.Packages["utils"]
+	if !ok {
+		t.Fatal("Expected 'utils' package in reloaded lockfile")
+	}
+	if pkg.Source != "arweave" || pkg.SHA256 != "deadbeef" {
+		t.Errorf("Unexpected package contents: %+v", pkg)
+	}
+}
+
+func TestVendorPath(t *testing.T) {
+	path := VendorPath("/project", "ao.utils")
+	expected := filepath.Join("/project", VendorDir, "ao", "utils.lua")
+	if path != expected {
+		t.Errorf("Expected %q, got %q", expected, path)
+	}
+}
+
+func TestVendorAndVerify(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte("return { hello = 'world' }\n")
+
+	checksum, err := Vendor(dir, "utils", content)
+	if err != nil {
+		t.Fatalf("Vendor failed: %v", err)
+	}
+	if checksum != Checksum(content) {
+		t.Errorf("Checksum mismatch: %s vs %s", checksum, Checksum(content))
+	}
+
+	vendored, err := os.ReadFile(VendorPath(dir, "utils"))
+	if err != nil {
+		t.Fatalf("Vendored file missing: %v", err)
+	}
+	if string(vendored) != string(content) {
+		t.Errorf("Vendored content mismatch: %q", vendored)
+	}
+
+	lockfile := &Lockfile{Packages: map[string]LockedPackage{
+		"utils": {Source: "arweave", Reference: "tx", SHA256: checksum},
+	}}
+	if broken := lockfile.Verify(dir); len(broken) != 0 {
+		t.Errorf("Expected no broken packages, got %v", broken)
+	}
+
+	// Tampering with the vendored file must be detected
+	if err := os.WriteFile(VendorPath(dir, "utils"), []byte("tampered"), 0644); err != nil {
+		t.Fatalf("Failed to tamper with file: %v", err)
+	}
+	broken := lockfile.Verify(dir)
+	if len(broken) != 1 || broken[0] != "utils" {
+		t.Errorf("Expected [utils] to be broken, got %v", broken)
+	}
+}
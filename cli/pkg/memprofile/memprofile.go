@@ -0,0 +1,109 @@
+// Package memprofile persists observed emulator memory usage in a
+// per-project file and assesses it against the configured InitialMemory and
+// MaximumMemory build limits. Profiled eval runs record observations; the
+// build command reads them back to warn when limits look far off from what
+// the module actually uses.
+package memprofile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileName is the per-project observation file
+const FileName = ".harlequin-memory.json"
+
+// Observation is the high-water memory usage seen across profiled runs
+type Observation struct {
+	PeakBytes  int64     `json:"peak_bytes"`
+	LoadBytes  int64     `json:"load_bytes,omitempty"` // Heap retained by loading the module
+	Action     string    `json:"action,omitempty"`     // Message that produced the peak
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// Load reads the stored observation; ok is false when none exists
+func Load(dir string) (Observation, bool, error) {
+	content, err := os.ReadFile(filepath.Join(dir, FileName))
+	if os.IsNotExist(err) {
+		return Observation{}, false, nil
+	}
+	if err != nil {
+		return Observation{}, false, fmt.Errorf("failed to read %s: %w", FileName, err)
+	}
+
+	var obs Observation
+	if err := json.Unmarshal(content, &obs); err != nil {
+		return Observation{}, false, fmt.Errorf("failed to parse %s: %w", FileName, err)
+	}
+	return obs, true, nil
+}
+
+// Record stores an observation, keeping the existing one when it saw a
+// higher peak so the file tracks the high-water mark
+func Record(dir string, obs Observation) error {
+	if existing, ok, err := Load(dir); err != nil {
+		return err
+	} else if ok && existing.PeakBytes >= obs.PeakBytes {
+		return nil
+	}
+
+	if obs.RecordedAt.IsZero() {
+		obs.RecordedAt = time.Now().UTC()
+	}
+
+	content, err := json.MarshalIndent(obs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", FileName, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, FileName), append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", FileName, err)
+	}
+	return nil
+}
+
+// Assess compares an observation against the configured memory limits,
+// returning one warning per limit that looks off. Thresholds are generous:
+// the emulator measures host heap, a proxy for WASM linear memory.
+func Assess(initialMemory, maximumMemory int, obs Observation) []string {
+	var warnings []string
+	if obs.PeakBytes <= 0 {
+		return nil
+	}
+
+	if maximumMemory > 0 && obs.PeakBytes >= int64(maximumMemory) {
+		warnings = append(warnings, fmt.Sprintf(
+			"observed peak (%s) reaches maximum_memory (%s) - the process will run out of memory; raise maximum_memory",
+			FormatBytes(obs.PeakBytes), FormatBytes(int64(maximumMemory))))
+	}
+
+	if initialMemory > 0 && obs.PeakBytes > 2*int64(initialMemory) {
+		warnings = append(warnings, fmt.Sprintf(
+			"observed peak (%s) is well above initial_memory (%s) - raising initial_memory avoids repeated memory growth",
+			FormatBytes(obs.PeakBytes), FormatBytes(int64(initialMemory))))
+	}
+
+	if maximumMemory > 0 && int64(maximumMemory) >= 64*obs.PeakBytes {
+		warnings = append(warnings, fmt.Sprintf(
+			"maximum_memory (%s) is %dx the observed peak (%s) - a lower limit bounds the damage of a memory leak",
+			FormatBytes(int64(maximumMemory)), int64(maximumMemory)/obs.PeakBytes, FormatBytes(obs.PeakBytes)))
+	}
+
+	return warnings
+}
+
+// FormatBytes renders a byte count for reports
+func FormatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,100 @@
+package memprofile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecordKeepsHighWaterMark(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, ok, err := Load(dir); err != nil || ok {
+		t.Fatalf("expected an empty project to have no observation, got ok=%t err=%v", ok, err)
+	}
+
+	if err := Record(dir, Observation{PeakBytes: 10 << 20, Action: "Info"}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	// A lower peak must not overwrite the recorded high-water mark
+	if err := Record(dir, Observation{PeakBytes: 5 << 20, Action: "Ping"}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	obs, ok, err := Load(dir)
+	if err != nil || !ok {
+		t.Fatalf("Load failed: ok=%t err=%v", ok, err)
+	}
+	if obs.PeakBytes != 10<<20 || obs.Action != "Info" {
+		t.Errorf("expected the high-water observation to be kept, got %+v", obs)
+	}
+	if obs.RecordedAt.IsZero() {
+		t.Error("expected RecordedAt to be defaulted")
+	}
+
+	// A higher peak replaces it
+	if err := Record(dir, Observation{PeakBytes: 20 << 20, Action: "Transfer"}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	obs, _, _ = Load(dir)
+	if obs.PeakBytes != 20<<20 || obs.Action != "Transfer" {
+		t.Errorf("expected the higher observation to replace, got %+v", obs)
+	}
+}
+
+func TestAssess(t *testing.T) {
+	const mb = 1 << 20
+
+	// Peak at the maximum: hard warning
+	warnings := Assess(4*mb, 16*mb, Observation{PeakBytes: 16 * mb})
+	if len(warnings) == 0 || !strings.Contains(warnings[0], "maximum_memory") {
+		t.Errorf("expected a maximum_memory warning, got %v", warnings)
+	}
+
+	// Peak well above initial: recommend raising initial_memory
+	warnings = Assess(4*mb, 1024*mb, Observation{PeakBytes: 12 * mb})
+	found := false
+	for _, w := range warnings {
+		if strings.Contains(w, "initial_memory") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an initial_memory warning, got %v", warnings)
+	}
+
+	// Maximum far above the peak: recommend lowering
+	warnings = Assess(4*mb, 1024*mb, Observation{PeakBytes: 8 * mb})
+	found = false
+	for _, w := range warnings {
+		if strings.Contains(w, "lower limit") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an oversized maximum warning, got %v", warnings)
+	}
+
+	// Sensible limits: no warnings
+	if warnings = Assess(8*mb, 64*mb, Observation{PeakBytes: 10 * mb}); len(warnings) != 0 {
+		t.Errorf("expected no warnings for sensible limits, got %v", warnings)
+	}
+
+	// No observation: nothing to say
+	if warnings = Assess(4*mb, 1024*mb, Observation{}); warnings != nil {
+		t.Errorf("expected no warnings without an observation, got %v", warnings)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	cases := map[int64]string{
+		512:            "512 B",
+		2 << 10:        "2.0 KB",
+		12<<20 + 1<<19: "12.5 MB",
+		3 << 30:        "3.0 GB",
+	}
+	for input, want := range cases {
+		if got := FormatBytes(input); got != want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Package netconfig configures the process-wide HTTP transport: proxy
+// selection and the trusted CA set. Every HTTP client in the toolkit (and in
+// the libraries it uses) builds on http.DefaultTransport, so applying the
+// settings there makes them take effect everywhere - including environments
+// with corporate proxies and TLS interception.
+package netconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// CABundleEnvVar names an alternative to the --ca-bundle flag
+const CABundleEnvVar = "HARLEQUIN_CA_BUNDLE"
+
+// Apply installs the proxy and CA bundle settings on the default transport.
+// An empty proxyURL keeps the standard environment-based selection
+// (HTTPS_PROXY / HTTP_PROXY / NO_PROXY); an empty caBundle falls back to the
+// HARLEQUIN_CA_BUNDLE environment variable, then the system roots.
+func Apply(proxyURL, caBundle string) error {
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("default HTTP transport has been replaced with an incompatible type")
+	}
+	transport = transport.Clone()
+
+	if proxyURL != "" {
+		parsed, err := url.Parse(proxyURL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("invalid proxy URL %q (expected e.g. http://proxy.corp:3128)", proxyURL)
+		}
+		transport.Proxy = http.ProxyURL(parsed)
+	}
+
+	if caBundle == "" {
+		caBundle = os.Getenv(CABundleEnvVar)
+	}
+	if caBundle != "" {
+		pool, err := certPoolWithBundle(caBundle)
+		if err != nil {
+			return err
+		}
+		tlsConfig := transport.TLSClientConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		} else {
+			tlsConfig = tlsConfig.Clone()
+		}
+		tlsConfig.RootCAs = pool
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	http.DefaultTransport = transport
+	return nil
+}
+
+// certPoolWithBundle returns the system roots extended with the PEM
+// certificates in the bundle file, so intercepting proxies work without
+// breaking normally-trusted endpoints
+func certPoolWithBundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle %s: %w", path, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA bundle %s", path)
+	}
+	return pool, nil
+}
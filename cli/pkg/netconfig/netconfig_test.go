@@ -0,0 +1,116 @@
+package netconfig
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// withRestoredTransport saves and restores the default transport so tests
+// don't leak settings into each other
+func withRestoredTransport(t *testing.T) {
+	t.Helper()
+	original := http.DefaultTransport
+	t.Cleanup(func() { http.DefaultTransport = original })
+}
+
+func TestApplyRejectsInvalidProxy(t *testing.T) {
+	withRestoredTransport(t)
+
+	for _, proxyURL := range []string{"://bad", "proxy.corp:3128", "http://"} {
+		if err := Apply(proxyURL, ""); err == nil {
+			t.Errorf("Expected error for proxy URL %q", proxyURL)
+		}
+	}
+}
+
+func TestApplySetsProxy(t *testing.T) {
+	withRestoredTransport(t)
+
+	if err := Apply("http://proxy.corp:3128", ""); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", http.DefaultTransport)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "https://arweave.net/info", nil)
+	proxyURL, err := transport.Proxy(request)
+	if err != nil {
+		t.Fatalf("Proxy selection failed: %v", err)
+	}
+	if proxyURL == nil || proxyURL.Host != "proxy.corp:3128" {
+		t.Errorf("Expected proxy.corp:3128, got %v", proxyURL)
+	}
+}
+
+func TestApplyCABundle(t *testing.T) {
+	withRestoredTransport(t)
+
+	bundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(bundlePath, selfSignedCertPEM(t), 0644); err != nil {
+		t.Fatalf("Failed to write CA bundle: %v", err)
+	}
+
+	if err := Apply("", bundlePath); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport)
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.RootCAs == nil {
+		t.Error("Expected a custom root CA pool on the transport")
+	}
+}
+
+func TestApplyRejectsBundleWithoutCertificates(t *testing.T) {
+	withRestoredTransport(t)
+
+	bundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(bundlePath, []byte("not a certificate\n"), 0644); err != nil {
+		t.Fatalf("Failed to write CA bundle: %v", err)
+	}
+
+	if err := Apply("", bundlePath); err == nil {
+		t.Error("Expected error for a bundle without certificates")
+	}
+
+	if err := Apply("", filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Error("Expected error for a missing bundle file")
+	}
+}
+
+// selfSignedCertPEM generates a throwaway CA certificate for the bundle tests
+func selfSignedCertPEM(t *testing.T) []byte {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Corp Intercepting CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
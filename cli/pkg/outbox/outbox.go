@@ -0,0 +1,150 @@
+// Package outbox persists signed-but-unsubmitted data items under the user
+// config directory (~/.config/harlequin/outbox on Linux). When an upload dies
+// after signing but before the bundler accepts the item, the signed bytes are
+// queued here so harlequin upload resume can retry the submission instead of
+// paying to sign the data again.
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Entry describes one queued signed data item. The item bytes live in
+// <id>.bin next to the <id>.json metadata file.
+type Entry struct {
+	ID          string    `json:"id"`                    // Data item ID (deterministic, safe to resubmit)
+	Description string    `json:"description,omitempty"` // What was uploaded, e.g. the source file path
+	Bundler     string    `json:"bundler"`               // Bundler the item was destined for
+	Gateway     string    `json:"gateway,omitempty"`     // Gateway for result links
+	SizeBytes   int       `json:"size_bytes"`            // Size of the signed item
+	QueuedAt    time.Time `json:"queued_at"`             // When the item was queued
+}
+
+// validID rejects IDs that would escape the outbox directory. Data item IDs
+// are base64url, so anything else is suspect.
+var validID = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Dir returns the outbox directory, creating it on first use
+func Dir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	dir := filepath.Join(configDir, "harlequin", "outbox")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+	return dir, nil
+}
+
+// Enqueue stores a signed data item and its metadata in the outbox
+func Enqueue(signedItem []byte, entry Entry) error {
+	if !validID.MatchString(entry.ID) {
+		return fmt.Errorf("invalid data item ID %q", entry.ID)
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+
+	entry.SizeBytes = len(signedItem)
+	if entry.QueuedAt.IsZero() {
+		entry.QueuedAt = time.Now().UTC()
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, entry.ID+".bin"), signedItem, 0600); err != nil {
+		return fmt.Errorf("failed to write signed item: %w", err)
+	}
+
+	metadata, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox entry: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, entry.ID+".json"), metadata, 0600); err != nil {
+		return fmt.Errorf("failed to write outbox entry: %w", err)
+	}
+
+	return nil
+}
+
+// List returns all queued entries, oldest first
+func List() ([]Entry, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox directory: %w", err)
+	}
+
+	entries := make([]Entry, 0)
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read outbox entry %s: %w", file.Name(), err)
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse outbox entry %s: %w", file.Name(), err)
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].QueuedAt.Before(entries[j].QueuedAt)
+	})
+	return entries, nil
+}
+
+// LoadItem returns the signed item bytes for a queued entry
+func LoadItem(id string) ([]byte, error) {
+	if !validID.MatchString(id) {
+		return nil, fmt.Errorf("invalid data item ID %q", id)
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, id+".bin"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signed item %s: %w", id, err)
+	}
+	return data, nil
+}
+
+// Remove deletes a queued entry and its signed item bytes
+func Remove(id string) error {
+	if !validID.MatchString(id) {
+		return fmt.Errorf("invalid data item ID %q", id)
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(dir, id+".bin")); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove signed item %s: %w", id, err)
+	}
+	if err := os.Remove(filepath.Join(dir, id+".json")); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove outbox entry %s: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEnqueueListRemove(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	first := Entry{
+		ID:          "item-one_ABC123",
+		Description: "dist/process.wasm",
+		Bundler:     "https://upload.ardrive.io",
+		QueuedAt:    time.Now().UTC().Add(-time.Minute),
+	}
+	second := Entry{
+		ID:      "item-two_DEF456",
+		Bundler: "https://upload.ardrive.io",
+	}
+
+	if err := Enqueue([]byte("signed-item-one"), first); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if err := Enqueue([]byte("signed-item-two"), second); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].ID != first.ID {
+		t.Errorf("expected oldest entry first, got %s", entries[0].ID)
+	}
+	if entries[0].Description != "dist/process.wasm" {
+		t.Errorf("description not round-tripped: %q", entries[0].Description)
+	}
+	if entries[1].SizeBytes != len("signed-item-two") {
+		t.Errorf("expected size to be recorded, got %d", entries[1].SizeBytes)
+	}
+	if entries[1].QueuedAt.IsZero() {
+		t.Error("expected QueuedAt to be defaulted")
+	}
+
+	item, err := LoadItem(first.ID)
+	if err != nil {
+		t.Fatalf("LoadItem failed: %v", err)
+	}
+	if string(item) != "signed-item-one" {
+		t.Errorf("unexpected item bytes: %q", item)
+	}
+
+	if err := Remove(first.ID); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	entries, err = List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ID != second.ID {
+		t.Fatalf("expected only the second entry to remain, got %v", entries)
+	}
+
+	if _, err := LoadItem(first.ID); err == nil {
+		t.Error("expected LoadItem to fail for a removed entry")
+	}
+}
+
+func TestInvalidIDsAreRejected(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	for _, id := range []string{"", "../escape", "a/b", "a.b"} {
+		if err := Enqueue([]byte("data"), Entry{ID: id}); err == nil {
+			t.Errorf("expected Enqueue to reject ID %q", id)
+		}
+		if _, err := LoadItem(id); err == nil {
+			t.Errorf("expected LoadItem to reject ID %q", id)
+		}
+		if err := Remove(id); err == nil {
+			t.Errorf("expected Remove to reject ID %q", id)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Package plugin discovers git-style external subcommands: executables named
+// harlequin-<name> on PATH are surfaced as `harlequin <name>`. A JSON manifest
+// next to the executable (harlequin-<name>.json) can provide a description for
+// help text and the TUI menu.
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Prefix is the executable name prefix that marks a harlequin plugin
+const Prefix = "harlequin-"
+
+// Manifest describes a plugin for help text and TUI menu integration
+type Manifest struct {
+	Description string `json:"description"`       // One-line summary shown in help and the TUI menu
+	Help        string `json:"help,omitempty"`    // Longer help text shown in the TUI description panel
+	Version     string `json:"version,omitempty"` // Plugin version, informational only
+}
+
+// Plugin represents an external subcommand discovered on PATH
+type Plugin struct {
+	Name     string   // Subcommand name (executable name without the prefix)
+	Path     string   // Absolute path to the executable
+	Manifest Manifest // Manifest contents, or defaults if no manifest exists
+}
+
+// Discover returns the plugins found on PATH, sorted by name. When the same
+// plugin name appears in multiple PATH entries, the first one wins.
+func Discover() []Plugin {
+	seen := make(map[string]bool)
+	var plugins []Plugin
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			name := pluginName(entry.Name())
+			if name == "" || seen[name] {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, Plugin{
+				Name:     name,
+				Path:     path,
+				Manifest: loadManifest(path),
+			})
+		}
+	}
+
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+// Find looks up a single plugin by subcommand name
+func Find(name string) (Plugin, bool) {
+	executable := Prefix + name
+	if runtime.GOOS == "windows" {
+		executable += ".exe"
+	}
+	path, err := exec.LookPath(executable)
+	if err != nil {
+		return Plugin{}, false
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return Plugin{}, false
+	}
+	return Plugin{
+		Name:     name,
+		Path:     path,
+		Manifest: loadManifest(path),
+	}, true
+}
+
+// Run executes the plugin with the given arguments, passing through stdio.
+// The HARLEQUIN environment variable carries the path of the invoking
+// harlequin binary so plugins can call back into the toolkit.
+func Run(ctx context.Context, p Plugin, args []string) error {
+	cmd := exec.CommandContext(ctx, p.Path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	cmd.Env = os.Environ()
+	if self, err := os.Executable(); err == nil {
+		cmd.Env = append(cmd.Env, "HARLEQUIN="+self)
+	}
+
+	return cmd.Run()
+}
+
+// pluginName extracts the subcommand name from an executable filename,
+// returning "" when the file is not a plugin
+func pluginName(filename string) string {
+	if runtime.GOOS == "windows" {
+		filename = strings.TrimSuffix(filename, ".exe")
+	}
+	if !strings.HasPrefix(filename, Prefix) {
+		return ""
+	}
+	name := strings.TrimPrefix(filename, Prefix)
+	// Ignore manifest files sitting next to the executable
+	if name == "" || strings.HasSuffix(name, ".json") {
+		return ""
+	}
+	return name
+}
+
+// isExecutable reports whether the file at path can be executed
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true // Discovery already filtered on the .exe suffix
+	}
+	return info.Mode()&0111 != 0
+}
+
+// loadManifest reads the manifest next to the executable, falling back to an
+// empty default when none exists
+func loadManifest(executablePath string) Manifest {
+	manifest := Manifest{Description: "External plugin"}
+
+	manifestPath := strings.TrimSuffix(executablePath, ".exe") + ".json"
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return manifest
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return Manifest{Description: "External plugin"}
+	}
+	if manifest.Description == "" {
+		manifest.Description = "External plugin"
+	}
+	return manifest
+}
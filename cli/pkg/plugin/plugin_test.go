@@ -0,0 +1,96 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func writePlugin(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, Prefix+name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatalf("Failed to write plugin: %v", err)
+	}
+	return path
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Plugin discovery test uses unix executable bits")
+	}
+
+	dir := t.TempDir()
+	writePlugin(t, dir, "deploy")
+	writePlugin(t, dir, "registry")
+
+	// A non-executable file with the prefix must be ignored
+	notExecutable := filepath.Join(dir, Prefix+"ignored")
+	if err := os.WriteFile(notExecutable, []byte("data"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	t.Setenv("PATH", dir)
+
+	plugins := Discover()
+	if len(plugins) != 2 {
+		t.Fatalf("Expected 2 plugins, got %d: %v", len(plugins), plugins)
+	}
+	if plugins[0].Name != "deploy" || plugins[1].Name != "registry" {
+		t.Errorf("Expected sorted plugins [deploy registry], got [%s %s]", plugins[0].Name, plugins[1].Name)
+	}
+	if plugins[0].Manifest.Description != "External plugin" {
+		t.Errorf("Expected default description, got %q", plugins[0].Manifest.Description)
+	}
+}
+
+func TestDiscoverManifest(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Plugin discovery test uses unix executable bits")
+	}
+
+	dir := t.TempDir()
+	writePlugin(t, dir, "deploy")
+
+	manifest := `{"description":"Deploy to the internal registry","version":"1.2.0"}`
+	manifestPath := filepath.Join(dir, Prefix+"deploy.json")
+	if err := os.WriteFile(manifestPath, []byte(manifest), 0644); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	t.Setenv("PATH", dir)
+
+	plugins := Discover()
+	if len(plugins) != 1 {
+		t.Fatalf("Expected 1 plugin, got %d: %v", len(plugins), plugins)
+	}
+	if plugins[0].Manifest.Description != "Deploy to the internal registry" {
+		t.Errorf("Unexpected description: %q", plugins[0].Manifest.Description)
+	}
+	if plugins[0].Manifest.Version != "1.2.0" {
+		t.Errorf("Unexpected version: %q", plugins[0].Manifest.Version)
+	}
+}
+
+func TestFind(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Plugin discovery test uses unix executable bits")
+	}
+
+	dir := t.TempDir()
+	writePlugin(t, dir, "deploy")
+	t.Setenv("PATH", dir)
+
+	plugin, found := Find("deploy")
+	if !found {
+		t.Fatal("Expected to find plugin 'deploy'")
+	}
+	if plugin.Name != "deploy" {
+		t.Errorf("Expected name 'deploy', got %q", plugin.Name)
+	}
+
+	if _, found := Find("missing"); found {
+		t.Error("Expected 'missing' to not be found")
+	}
+}
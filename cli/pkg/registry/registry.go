@@ -0,0 +1,121 @@
+// Package registry tracks the AO processes deployed from a project in a
+// per-project .harlequin-processes.json file. The processes command manages
+// entries, the message command stamps last-message times, and the TUI process
+// dashboard renders the registry.
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileName is the per-project process registry file
+const FileName = ".harlequin-processes.json"
+
+// Process is one tracked AO process
+type Process struct {
+	ProcessID     string    `json:"process_id"`
+	Name          string    `json:"name,omitempty"`           // Friendly name for display
+	ModuleID      string    `json:"module_id,omitempty"`      // Module the process was spawned from
+	ModuleVersion string    `json:"module_version,omitempty"` // Module version at deploy time
+	AddedAt       time.Time `json:"added_at"`
+	LastMessageAt time.Time `json:"last_message_at,omitempty"` // Stamped by harlequin message
+}
+
+// document is the on-disk registry layout
+type document struct {
+	Processes []Process `json:"processes"`
+}
+
+// Load reads the registry in dir; a missing file is an empty registry
+func Load(dir string) ([]Process, error) {
+	content, err := os.ReadFile(filepath.Join(dir, FileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", FileName, err)
+	}
+
+	var doc document
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", FileName, err)
+	}
+	return doc.Processes, nil
+}
+
+// Save writes the registry in dir
+func Save(dir string, processes []Process) error {
+	content, err := json.MarshalIndent(document{Processes: processes}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", FileName, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, FileName), append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", FileName, err)
+	}
+	return nil
+}
+
+// Add upserts a process by ID, preserving AddedAt and LastMessageAt on update
+func Add(dir string, p Process) error {
+	if p.ProcessID == "" {
+		return fmt.Errorf("process ID is required")
+	}
+
+	processes, err := Load(dir)
+	if err != nil {
+		return err
+	}
+
+	if p.AddedAt.IsZero() {
+		p.AddedAt = time.Now().UTC()
+	}
+
+	for i := range processes {
+		if processes[i].ProcessID == p.ProcessID {
+			p.AddedAt = processes[i].AddedAt
+			if p.LastMessageAt.IsZero() {
+				p.LastMessageAt = processes[i].LastMessageAt
+			}
+			processes[i] = p
+			return Save(dir, processes)
+		}
+	}
+
+	return Save(dir, append(processes, p))
+}
+
+// Remove deletes a process by ID
+func Remove(dir, processID string) error {
+	processes, err := Load(dir)
+	if err != nil {
+		return err
+	}
+
+	for i := range processes {
+		if processes[i].ProcessID == processID {
+			return Save(dir, append(processes[:i], processes[i+1:]...))
+		}
+	}
+	return fmt.Errorf("process %s is not in %s", processID, FileName)
+}
+
+// Touch stamps the last-message time of a tracked process. Untracked
+// processes are a no-op so casual messaging does not pollute the registry.
+func Touch(dir, processID string, at time.Time) error {
+	processes, err := Load(dir)
+	if err != nil {
+		return err
+	}
+
+	for i := range processes {
+		if processes[i].ProcessID == processID {
+			processes[i].LastMessageAt = at.UTC()
+			return Save(dir, processes)
+		}
+	}
+	return nil
+}
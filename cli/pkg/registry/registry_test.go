@@ -0,0 +1,104 @@
+package registry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddLoadRemove(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := Add(dir, Process{
+		ProcessID:     "proc-one",
+		Name:          "counter",
+		ModuleID:      "module-abc",
+		ModuleVersion: "1.2.0",
+	}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if err := Add(dir, Process{ProcessID: "proc-two"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	processes, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(processes) != 2 {
+		t.Fatalf("expected 2 processes, got %d", len(processes))
+	}
+	if processes[0].Name != "counter" || processes[0].ModuleVersion != "1.2.0" {
+		t.Errorf("process fields not round-tripped: %+v", processes[0])
+	}
+	if processes[0].AddedAt.IsZero() {
+		t.Error("expected AddedAt to be defaulted")
+	}
+
+	if err := Remove(dir, "proc-one"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	processes, err = Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(processes) != 1 || processes[0].ProcessID != "proc-two" {
+		t.Fatalf("expected only proc-two to remain, got %v", processes)
+	}
+
+	if err := Remove(dir, "proc-one"); err == nil {
+		t.Error("expected Remove to fail for an unknown process")
+	}
+}
+
+func TestAddUpsertsAndPreservesTimestamps(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := Add(dir, Process{ProcessID: "proc-one", Name: "before"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	stamped := time.Now().UTC().Add(-time.Hour)
+	if err := Touch(dir, "proc-one", stamped); err != nil {
+		t.Fatalf("Touch failed: %v", err)
+	}
+
+	first, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if err := Add(dir, Process{ProcessID: "proc-one", Name: "after", ModuleVersion: "2.0.0"}); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+
+	processes, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(processes) != 1 {
+		t.Fatalf("expected upsert to keep a single entry, got %d", len(processes))
+	}
+	if processes[0].Name != "after" || processes[0].ModuleVersion != "2.0.0" {
+		t.Errorf("expected fields to be updated, got %+v", processes[0])
+	}
+	if !processes[0].AddedAt.Equal(first[0].AddedAt) {
+		t.Error("expected AddedAt to be preserved on upsert")
+	}
+	if !processes[0].LastMessageAt.Equal(stamped) {
+		t.Error("expected LastMessageAt to be preserved on upsert")
+	}
+}
+
+func TestTouchIgnoresUntrackedProcesses(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := Touch(dir, "never-registered", time.Now()); err != nil {
+		t.Fatalf("Touch failed: %v", err)
+	}
+	processes, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(processes) != 0 {
+		t.Errorf("expected registry to stay empty, got %v", processes)
+	}
+}
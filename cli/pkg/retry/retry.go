@@ -0,0 +1,112 @@
+// Package retry provides the shared retry policy for the toolkit's network
+// operations: exponential backoff with jitter under a per-operation attempt
+// budget. Transient failures (timeouts, resets, 5xx responses) are retried
+// with each attempt surfaced in the progress output; failures wrapped with
+// Permanent are returned immediately.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/logging"
+)
+
+// Policy describes how often and how patiently an operation class is retried
+type Policy struct {
+	Attempts     int           // Total attempts, including the first
+	InitialDelay time.Duration // Backoff before the second attempt
+	MaxDelay     time.Duration // Upper bound for the backoff
+}
+
+// Per-operation retry budgets. Uploads are the most expensive to give up on,
+// queries are cheap to repeat, and clones back off slowly to be kind to git
+// hosts.
+var (
+	Uploads = Policy{Attempts: 4, InitialDelay: 2 * time.Second, MaxDelay: 30 * time.Second}
+	Queries = Policy{Attempts: 3, InitialDelay: time.Second, MaxDelay: 10 * time.Second}
+	Clones  = Policy{Attempts: 3, InitialDelay: 3 * time.Second, MaxDelay: 30 * time.Second}
+)
+
+// Do runs fn under the policy, backing off between attempts. The operation
+// name labels the retry messages in the progress output.
+func (p Policy) Do(ctx context.Context, operation string, fn func() error) error {
+	attempts := p.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		if ctx.Err() != nil || attempt >= attempts {
+			break
+		}
+
+		delay := p.backoff(attempt)
+		logging.Printf("   ⏳ %s failed (attempt %d/%d), retrying in %s: %v\n", operation, attempt, attempts, delay.Round(100*time.Millisecond), err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	if attempts > 1 {
+		return fmt.Errorf("%s failed after %d attempts: %w", operation, attempts, err)
+	}
+	return err
+}
+
+// backoff returns the delay before the next attempt: exponential growth
+// capped at MaxDelay, with half the window randomized so simultaneous
+// clients don't retry in lockstep
+func (p Policy) backoff(attempt int) time.Duration {
+	base := p.InitialDelay
+	if base <= 0 {
+		base = time.Second
+	}
+	for i := 1; i < attempt; i++ {
+		base *= 2
+		if p.MaxDelay > 0 && base >= p.MaxDelay {
+			base = p.MaxDelay
+			break
+		}
+	}
+	half := base / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// Permanent marks an error as not worth retrying; Do returns the wrapped
+// error immediately
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// IsRetryableStatus reports whether an HTTP status is worth retrying:
+// rate limits and server-side failures are, client errors are not
+func IsRetryableStatus(status int) bool {
+	return status == 429 || status >= 500
+}
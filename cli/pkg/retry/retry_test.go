@@ -0,0 +1,106 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fastPolicy keeps test backoffs in the microsecond range
+var fastPolicy = Policy{Attempts: 3, InitialDelay: time.Microsecond, MaxDelay: time.Millisecond}
+
+func TestDoSucceedsAfterTransientFailures(t *testing.T) {
+	calls := 0
+	err := fastPolicy.Do(context.Background(), "test op", func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestDoExhaustsBudget(t *testing.T) {
+	calls := 0
+	failure := errors.New("still down")
+	err := fastPolicy.Do(context.Background(), "test op", func() error {
+		calls++
+		return failure
+	})
+	if calls != fastPolicy.Attempts {
+		t.Errorf("Expected %d attempts, got %d", fastPolicy.Attempts, calls)
+	}
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected wrapped original error, got %v", err)
+	}
+	if err == nil || !errors.Is(err, failure) || err.Error() == failure.Error() {
+		t.Errorf("Expected the attempt count in the error, got %v", err)
+	}
+}
+
+func TestDoStopsOnPermanentError(t *testing.T) {
+	calls := 0
+	original := errors.New("HTTP 403")
+	err := fastPolicy.Do(context.Background(), "test op", func() error {
+		calls++
+		return Permanent(fmt.Errorf("request rejected: %w", original))
+	})
+	if calls != 1 {
+		t.Errorf("Expected 1 attempt for a permanent error, got %d", calls)
+	}
+	if err == nil || !errors.Is(err, original) {
+		t.Errorf("Expected the unwrapped permanent error, got %v", err)
+	}
+}
+
+func TestDoHonorsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := Policy{Attempts: 10, InitialDelay: time.Hour}.Do(ctx, "test op", func() error {
+		calls++
+		cancel()
+		return errors.New("transient")
+	})
+	if calls != 1 {
+		t.Errorf("Expected no retries after cancellation, got %d attempts", calls)
+	}
+	if !errors.Is(err, context.Canceled) && err == nil {
+		t.Errorf("Expected an error after cancellation, got %v", err)
+	}
+}
+
+func TestBackoffGrowsAndCaps(t *testing.T) {
+	policy := Policy{Attempts: 5, InitialDelay: time.Second, MaxDelay: 4 * time.Second}
+	for attempt := 1; attempt <= 5; attempt++ {
+		delay := policy.backoff(attempt)
+		if delay <= 0 {
+			t.Errorf("Attempt %d: expected positive delay, got %s", attempt, delay)
+		}
+		if delay > policy.MaxDelay {
+			t.Errorf("Attempt %d: delay %s exceeds cap %s", attempt, delay, policy.MaxDelay)
+		}
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	for status, retryable := range map[int]bool{
+		200: false,
+		400: false,
+		404: false,
+		429: true,
+		500: true,
+		503: true,
+	} {
+		if IsRetryableStatus(status) != retryable {
+			t.Errorf("IsRetryableStatus(%d) = %v, want %v", status, !retryable, retryable)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Package snapshot records emulator handler responses for a set of input
+// messages and diffs later runs against the stored recordings. Snapshots
+// capture the deterministic parts of a result (handler, output, messages,
+// spawns) and live under .harlequin-snapshots so they can be committed
+// alongside the module they describe.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/emulator"
+	"gopkg.in/yaml.v3"
+)
+
+// Dir is the directory snapshots are stored in, relative to the project
+const Dir = ".harlequin-snapshots"
+
+// Case is one input message to snapshot
+type Case struct {
+	Name   string            `yaml:"name"`
+	Action string            `yaml:"action"`
+	Data   string            `yaml:"data,omitempty"`
+	Tags   map[string]string `yaml:"tags,omitempty"`
+}
+
+// casesFile is the YAML layout of a cases file
+type casesFile struct {
+	Cases []Case `yaml:"cases"`
+}
+
+// LoadCases reads and validates a snapshot cases file
+func LoadCases(path string) ([]Case, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cases file: %w", err)
+	}
+
+	var file casesFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse cases file: %w", err)
+	}
+	if len(file.Cases) == 0 {
+		return nil, fmt.Errorf("cases file has no cases")
+	}
+
+	seen := make(map[string]bool, len(file.Cases))
+	for i := range file.Cases {
+		c := &file.Cases[i]
+		if c.Action == "" {
+			return nil, fmt.Errorf("case %d: action is required", i+1)
+		}
+		if c.Name == "" {
+			c.Name = c.Action
+		}
+		if seen[c.Name] {
+			return nil, fmt.Errorf("duplicate case name %q", c.Name)
+		}
+		seen[c.Name] = true
+	}
+
+	return file.Cases, nil
+}
+
+// Snapshot is the deterministic portion of an emulator result. Duration and
+// gas are excluded so recordings are stable across machines.
+type Snapshot struct {
+	Handled  bool               `json:"handled"`
+	Handler  string             `json:"handler,omitempty"`
+	Output   string             `json:"output,omitempty"`
+	Messages []emulator.Message `json:"messages,omitempty"`
+	Spawns   []emulator.Message `json:"spawns,omitempty"`
+}
+
+// FromResult extracts the snapshot-worthy fields of an emulator result
+func FromResult(result *emulator.Result) Snapshot {
+	return Snapshot{
+		Handled:  result.Handled,
+		Handler:  result.HandlerName,
+		Output:   result.Output,
+		Messages: result.Messages,
+		Spawns:   result.Spawns,
+	}
+}
+
+// fileName derives a stable snapshot file name from a case name
+func fileName(caseName string) string {
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, caseName)
+	return strings.Trim(slug, "-") + ".json"
+}
+
+// Save writes a snapshot for a case, creating the snapshot directory on first
+// use
+func Save(dir, caseName string, snap Snapshot) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	content, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	path := filepath.Join(dir, fileName(caseName))
+	if err := os.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load reads the stored snapshot for a case; ok is false when none exists
+func Load(dir, caseName string) (Snapshot, bool, error) {
+	content, err := os.ReadFile(filepath.Join(dir, fileName(caseName)))
+	if os.IsNotExist(err) {
+		return Snapshot{}, false, nil
+	}
+	if err != nil {
+		return Snapshot{}, false, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(content, &snap); err != nil {
+		return Snapshot{}, false, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	return snap, true, nil
+}
+
+// Diff compares a stored snapshot against a current result, returning one
+// description per behavioral change
+func Diff(stored, current Snapshot) []string {
+	var changes []string
+
+	if stored.Handled != current.Handled {
+		changes = append(changes, fmt.Sprintf("handled changed: %t -> %t", stored.Handled, current.Handled))
+	}
+	if stored.Handler != current.Handler {
+		changes = append(changes, fmt.Sprintf("handler changed: %q -> %q", stored.Handler, current.Handler))
+	}
+	if stored.Output != current.Output {
+		changes = append(changes, fmt.Sprintf("output changed:\n  - %q\n  + %q", stored.Output, current.Output))
+	}
+	changes = append(changes, diffPackets("messages", stored.Messages, current.Messages)...)
+	changes = append(changes, diffPackets("spawns", stored.Spawns, current.Spawns)...)
+
+	return changes
+}
+
+// diffPackets compares recorded message lists structurally
+func diffPackets(kind string, stored, current []emulator.Message) []string {
+	if len(stored) != len(current) {
+		return []string{fmt.Sprintf("%s count changed: %d -> %d", kind, len(stored), len(current))}
+	}
+
+	var changes []string
+	for i := range stored {
+		before, _ := json.Marshal(stored[i])
+		after, _ := json.Marshal(current[i])
+		if string(before) != string(after) {
+			changes = append(changes, fmt.Sprintf("%s[%d] changed:\n  - %s\n  + %s", kind, i, before, after))
+		}
+	}
+	return changes
+}
@@ -0,0 +1,127 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/emulator"
+)
+
+func TestLoadCases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cases.yaml")
+	content := `
+cases:
+  - name: info responds
+    action: Info
+  - action: Ping
+    data: hello
+    tags:
+      Foo: Bar
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write cases: %v", err)
+	}
+
+	cases, err := LoadCases(path)
+	if err != nil {
+		t.Fatalf("LoadCases failed: %v", err)
+	}
+	if len(cases) != 2 {
+		t.Fatalf("expected 2 cases, got %d", len(cases))
+	}
+	if cases[0].Name != "info responds" {
+		t.Errorf("case name not parsed: %+v", cases[0])
+	}
+	if cases[1].Name != "Ping" {
+		t.Errorf("expected unnamed case to default to its action, got %q", cases[1].Name)
+	}
+	if cases[1].Tags["Foo"] != "Bar" {
+		t.Errorf("case tags not parsed: %+v", cases[1])
+	}
+}
+
+func TestLoadCasesValidation(t *testing.T) {
+	cases := map[string]string{
+		"no cases":       "cases: []\n",
+		"missing action": "cases:\n  - name: bad\n",
+		"duplicate name": "cases:\n  - action: Info\n  - action: Info\n",
+	}
+	for name, content := range cases {
+		path := filepath.Join(t.TempDir(), "cases.yaml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write cases: %v", err)
+		}
+		if _, err := LoadCases(path); err == nil {
+			t.Errorf("expected %s to fail validation", name)
+		}
+	}
+}
+
+func TestSaveLoadRoundtrip(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), Dir)
+	snap := Snapshot{
+		Handled: true,
+		Handler: "info",
+		Output:  "count: 3",
+		Messages: []emulator.Message{
+			{Target: "proc-a", Action: "Reply", Tags: map[string]string{"Kind": "info"}},
+		},
+	}
+
+	if err := Save(dir, "Info Responds!", snap); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	// Case names are slugged into stable file names
+	if _, err := os.Stat(filepath.Join(dir, "info-responds.json")); err != nil {
+		t.Errorf("expected slugged snapshot file: %v", err)
+	}
+
+	loaded, ok, err := Load(dir, "Info Responds!")
+	if err != nil || !ok {
+		t.Fatalf("Load failed: ok=%t err=%v", ok, err)
+	}
+	if diff := Diff(snap, loaded); len(diff) != 0 {
+		t.Errorf("roundtrip changed the snapshot: %v", diff)
+	}
+
+	if _, ok, err := Load(dir, "never recorded"); err != nil || ok {
+		t.Errorf("expected a missing snapshot to report ok=false, got ok=%t err=%v", ok, err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	stored := Snapshot{
+		Handled: true,
+		Handler: "info",
+		Output:  "count: 3",
+		Messages: []emulator.Message{
+			{Target: "proc-a", Action: "Reply"},
+		},
+	}
+
+	if diff := Diff(stored, stored); len(diff) != 0 {
+		t.Errorf("expected identical snapshots to produce no diff, got %v", diff)
+	}
+
+	current := stored
+	current.Output = "count: 4"
+	current.Messages = []emulator.Message{
+		{Target: "proc-b", Action: "Reply"},
+	}
+	diff := Diff(stored, current)
+	if len(diff) != 2 {
+		t.Fatalf("expected 2 changes, got %v", diff)
+	}
+	if !strings.Contains(diff[0], "output changed") || !strings.Contains(diff[1], "messages[0] changed") {
+		t.Errorf("unexpected diff descriptions: %v", diff)
+	}
+
+	current = stored
+	current.Messages = nil
+	diff = Diff(stored, current)
+	if len(diff) != 1 || !strings.Contains(diff[0], "messages count changed: 1 -> 0") {
+		t.Errorf("expected a count change, got %v", diff)
+	}
+}
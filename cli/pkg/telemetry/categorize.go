@@ -0,0 +1,38 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// Categorize maps an error to one of a fixed set of coarse category labels.
+// Only the label is ever recorded - never the error message itself, which can
+// contain file paths and URLs.
+func Categorize(err error) string {
+	if err == nil {
+		return ""
+	}
+	if errors.Is(err, context.Canceled) {
+		return "cancelled"
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+
+	message := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(message, "docker"):
+		return "docker"
+	case strings.Contains(message, "wallet") || strings.Contains(message, "balance"):
+		return "wallet"
+	case strings.Contains(message, "config") || strings.Contains(message, "yaml"):
+		return "config"
+	case strings.Contains(message, "connection") || strings.Contains(message, "dial") ||
+		strings.Contains(message, "tls") || strings.Contains(message, "proxy") ||
+		strings.Contains(message, "timeout") || strings.Contains(message, "status 5"):
+		return "network"
+	default:
+		return "other"
+	}
+}
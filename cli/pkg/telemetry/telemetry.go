@@ -0,0 +1,306 @@
+// Package telemetry implements the opt-in anonymous usage metrics: which
+// commands run, how long builds take, and coarse error categories. Nothing is
+// sent without recorded consent, events are queued locally first, and the
+// queued payload can be inspected verbatim with `harlequin telemetry show`.
+// Events never contain file names, wallet data, or source code.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Version is the toolkit version stamped into events. It is set from the
+// release version at startup, like builders.ToolkitVersion.
+var Version = "dev"
+
+// EndpointEnvVar overrides the telemetry endpoint, mainly for testing
+const EndpointEnvVar = "HARLEQUIN_TELEMETRY_URL"
+
+// defaultEndpoint is the collection endpoint on the install service
+const defaultEndpoint = "https://install_cli_harlequin.daemongate.io/telemetry"
+
+// maxQueuedEvents caps the local queue so an always-offline machine never
+// accumulates an unbounded backlog; the oldest events are dropped first
+const maxQueuedEvents = 500
+
+// Event is one queued telemetry record. Timestamps are truncated to the hour
+// in UTC so the queue cannot double as an activity log.
+type Event struct {
+	Time          string `json:"time"`
+	Command       string `json:"command"`
+	DurationMS    int64  `json:"duration_ms"`
+	ErrorCategory string `json:"error_category,omitempty"`
+	Version       string `json:"version"`
+	OS            string `json:"os"`
+	Arch          string `json:"arch"`
+}
+
+// Payload is exactly what Flush sends: a random install identifier (so
+// events from one machine can be deduplicated) and the queued events
+type Payload struct {
+	InstallID string  `json:"install_id"`
+	Events    []Event `json:"events"`
+}
+
+// telemetryDir returns the directory holding consent, queue, and install ID
+func telemetryDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	dir := filepath.Join(configDir, "harlequin")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+	return dir, nil
+}
+
+func consentPath() (string, error) {
+	dir, err := telemetryDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "telemetry-consent"), nil
+}
+
+func queuePath() (string, error) {
+	dir, err := telemetryDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "telemetry-queue.jsonl"), nil
+}
+
+func installIDPath() (string, error) {
+	dir, err := telemetryDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "telemetry-id"), nil
+}
+
+// ConsentRecorded reports whether the user has answered the consent prompt,
+// in either direction
+func ConsentRecorded() bool {
+	path, err := consentPath()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// Enabled reports whether the user has opted in
+func Enabled() bool {
+	path, err := consentPath()
+	if err != nil {
+		return false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(content)) == "on"
+}
+
+// SetEnabled records the consent decision. Opting out also discards any
+// queued events, so nothing lingers from an earlier opt-in.
+func SetEnabled(on bool) error {
+	path, err := consentPath()
+	if err != nil {
+		return err
+	}
+
+	value := "off"
+	if on {
+		value = "on"
+	}
+	if err := os.WriteFile(path, []byte(value+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to record telemetry consent: %w", err)
+	}
+
+	if !on {
+		if queueFile, err := queuePath(); err == nil {
+			if err := os.Remove(queueFile); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to clear telemetry queue: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// installID returns the random per-installation identifier, generating one on
+// first use. It carries no machine or user information.
+func installID() (string, error) {
+	path, err := installIDPath()
+	if err != nil {
+		return "", err
+	}
+
+	if content, err := os.ReadFile(path); err == nil {
+		if id := strings.TrimSpace(string(content)); id != "" {
+			return id, nil
+		}
+	}
+
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate install ID: %w", err)
+	}
+	id := hex.EncodeToString(raw)
+	if err := os.WriteFile(path, []byte(id+"\n"), 0644); err != nil {
+		return "", fmt.Errorf("failed to persist install ID: %w", err)
+	}
+	return id, nil
+}
+
+// Record appends an event to the local queue. It is a no-op without opt-in
+// and never fails a command: queue errors are silently dropped.
+func Record(command string, duration time.Duration, errorCategory string) {
+	if !Enabled() {
+		return
+	}
+
+	event := Event{
+		Time:          time.Now().UTC().Truncate(time.Hour).Format(time.RFC3339),
+		Command:       command,
+		DurationMS:    duration.Milliseconds(),
+		ErrorCategory: errorCategory,
+		Version:       Version,
+		OS:            runtime.GOOS,
+		Arch:          runtime.GOARCH,
+	}
+
+	events, err := Pending()
+	if err != nil {
+		return
+	}
+	events = append(events, event)
+	if len(events) > maxQueuedEvents {
+		events = events[len(events)-maxQueuedEvents:]
+	}
+	writeQueue(events)
+}
+
+// Pending returns the queued events in order. Lines that fail to parse are
+// skipped rather than poisoning the whole queue.
+func Pending() ([]Event, error) {
+	path, err := queuePath()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var event Event
+		if json.Unmarshal([]byte(line), &event) == nil {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// writeQueue rewrites the queue file from the event slice
+func writeQueue(events []Event) {
+	path, err := queuePath()
+	if err != nil {
+		return
+	}
+	var buffer bytes.Buffer
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			continue
+		}
+		buffer.Write(line)
+		buffer.WriteByte('\n')
+	}
+	_ = os.WriteFile(path, buffer.Bytes(), 0644)
+}
+
+// PendingPayload returns exactly what Flush would send, for inspection
+func PendingPayload() (*Payload, error) {
+	events, err := Pending()
+	if err != nil {
+		return nil, err
+	}
+	id, err := installID()
+	if err != nil {
+		return nil, err
+	}
+	return &Payload{InstallID: id, Events: events}, nil
+}
+
+// Flush sends the queued events and clears the queue on success. It is
+// best-effort: callers treat failures as debug-level noise, and the queue is
+// kept for the next attempt.
+func Flush(ctx context.Context) error {
+	if !Enabled() {
+		return nil
+	}
+	payload, err := PendingPayload()
+	if err != nil {
+		return err
+	}
+	if len(payload.Events) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	endpoint := defaultEndpoint
+	if override := os.Getenv(EndpointEnvVar); override != "" {
+		endpoint = override
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry endpoint returned status %d", resp.StatusCode)
+	}
+
+	queueFile, err := queuePath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(queueFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
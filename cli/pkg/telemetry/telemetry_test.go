@@ -0,0 +1,177 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// useTempConfigDir points os.UserConfigDir at a scratch directory so tests
+// never touch the real consent file
+func useTempConfigDir(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+}
+
+func TestConsentLifecycle(t *testing.T) {
+	useTempConfigDir(t)
+
+	if ConsentRecorded() {
+		t.Fatal("Expected no consent recorded in a fresh config dir")
+	}
+	if Enabled() {
+		t.Fatal("Expected telemetry disabled without consent")
+	}
+
+	if err := SetEnabled(true); err != nil {
+		t.Fatalf("SetEnabled(true) failed: %v", err)
+	}
+	if !ConsentRecorded() || !Enabled() {
+		t.Error("Expected consent recorded and telemetry enabled after opt-in")
+	}
+
+	Record("build", 3*time.Second, "")
+	events, err := Pending()
+	if err != nil {
+		t.Fatalf("Pending failed: %v", err)
+	}
+	if len(events) != 1 || events[0].Command != "build" || events[0].DurationMS != 3000 {
+		t.Errorf("Unexpected queued events: %+v", events)
+	}
+
+	if err := SetEnabled(false); err != nil {
+		t.Fatalf("SetEnabled(false) failed: %v", err)
+	}
+	if !ConsentRecorded() || Enabled() {
+		t.Error("Expected consent recorded but telemetry disabled after opt-out")
+	}
+	events, err = Pending()
+	if err != nil {
+		t.Fatalf("Pending failed after opt-out: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected opt-out to clear the queue, got %d events", len(events))
+	}
+}
+
+func TestRecordRequiresOptIn(t *testing.T) {
+	useTempConfigDir(t)
+
+	Record("build", time.Second, "")
+	events, err := Pending()
+	if err != nil {
+		t.Fatalf("Pending failed: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no events without opt-in, got %d", len(events))
+	}
+}
+
+func TestQueueIsCapped(t *testing.T) {
+	useTempConfigDir(t)
+
+	if err := SetEnabled(true); err != nil {
+		t.Fatalf("SetEnabled failed: %v", err)
+	}
+	for i := 0; i < maxQueuedEvents+10; i++ {
+		Record(fmt.Sprintf("command-%d", i), 0, "")
+	}
+
+	events, err := Pending()
+	if err != nil {
+		t.Fatalf("Pending failed: %v", err)
+	}
+	if len(events) != maxQueuedEvents {
+		t.Errorf("Expected queue capped at %d events, got %d", maxQueuedEvents, len(events))
+	}
+	// The oldest events are dropped first
+	if events[0].Command != "command-10" {
+		t.Errorf("Expected oldest events dropped, queue starts with %s", events[0].Command)
+	}
+}
+
+func TestFlushSendsPayloadAndClearsQueue(t *testing.T) {
+	useTempConfigDir(t)
+
+	var received Payload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	t.Setenv(EndpointEnvVar, server.URL)
+
+	if err := SetEnabled(true); err != nil {
+		t.Fatalf("SetEnabled failed: %v", err)
+	}
+	Record("upload", 2*time.Second, "network")
+
+	if err := Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if received.InstallID == "" {
+		t.Error("Expected an install ID in the payload")
+	}
+	if len(received.Events) != 1 || received.Events[0].ErrorCategory != "network" {
+		t.Errorf("Unexpected payload events: %+v", received.Events)
+	}
+
+	events, err := Pending()
+	if err != nil {
+		t.Fatalf("Pending failed after flush: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected queue cleared after flush, got %d events", len(events))
+	}
+}
+
+func TestFlushKeepsQueueOnServerError(t *testing.T) {
+	useTempConfigDir(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	t.Setenv(EndpointEnvVar, server.URL)
+
+	if err := SetEnabled(true); err != nil {
+		t.Fatalf("SetEnabled failed: %v", err)
+	}
+	Record("build", time.Second, "")
+
+	if err := Flush(context.Background()); err == nil {
+		t.Error("Expected Flush to report the server error")
+	}
+	events, err := Pending()
+	if err != nil {
+		t.Fatalf("Pending failed: %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("Expected queue kept for the next attempt, got %d events", len(events))
+	}
+}
+
+func TestCategorize(t *testing.T) {
+	for err, category := range map[error]string{
+		nil:                      "",
+		context.Canceled:         "cancelled",
+		context.DeadlineExceeded: "timeout",
+		errors.New("failed to pull docker image"):  "docker",
+		errors.New("insufficient wallet balance"):  "wallet",
+		errors.New("failed to parse yaml"):         "config",
+		errors.New("dial tcp: connection refused"): "network",
+		errors.New("something unexpected"):         "other",
+	} {
+		if got := Categorize(err); got != category {
+			t.Errorf("Categorize(%v) = %q, want %q", err, got, category)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package wallet
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// AddressFromJWK derives the Arweave address of a wallet: the base64url
+// encoded SHA-256 hash of the RSA modulus
+func AddressFromJWK(jwk map[string]interface{}) (string, error) {
+	owner, ok := jwk["n"].(string)
+	if !ok || owner == "" {
+		return "", fmt.Errorf("wallet JWK has no modulus field")
+	}
+
+	modulus, err := base64.RawURLEncoding.DecodeString(owner)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode wallet modulus: %w", err)
+	}
+
+	hash := sha256.Sum256(modulus)
+	return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,77 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	jwkJSON := []byte(testJWK)
+
+	sealed, err := EncryptJWK(jwkJSON, "correct horse")
+	if err != nil {
+		t.Fatalf("EncryptJWK failed: %v", err)
+	}
+	if !IsEncrypted(sealed) {
+		t.Error("Expected the envelope to be recognized as encrypted")
+	}
+	if bytes.Contains(sealed, []byte("AQAB")) {
+		t.Error("Envelope leaks plaintext JWK material")
+	}
+
+	opened, err := DecryptJWK(sealed, "correct horse")
+	if err != nil {
+		t.Fatalf("DecryptJWK failed: %v", err)
+	}
+	if !bytes.Equal(opened, jwkJSON) {
+		t.Errorf("Round trip mismatch: got %s", opened)
+	}
+}
+
+func TestDecryptRejectsWrongPassphrase(t *testing.T) {
+	sealed, err := EncryptJWK([]byte(testJWK), "right")
+	if err != nil {
+		t.Fatalf("EncryptJWK failed: %v", err)
+	}
+	if _, err := DecryptJWK(sealed, "wrong"); err == nil {
+		t.Error("Expected an error for the wrong passphrase")
+	}
+}
+
+func TestDecryptRejectsWrongLengthNonce(t *testing.T) {
+	sealed, err := EncryptJWK([]byte(testJWK), "pass")
+	if err != nil {
+		t.Fatalf("EncryptJWK failed: %v", err)
+	}
+
+	// Valid base64, wrong length: must error, not panic inside GCM
+	var envelope encryptedWallet
+	if err := json.Unmarshal(sealed, &envelope); err != nil {
+		t.Fatalf("Failed to parse envelope: %v", err)
+	}
+	envelope.Nonce = "c2hvcnQ="
+	mangled, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("Failed to re-marshal envelope: %v", err)
+	}
+
+	if _, err := DecryptJWK(mangled, "pass"); err == nil {
+		t.Error("Expected an error for a wrong-length nonce")
+	}
+}
+
+func TestEncryptRequiresPassphrase(t *testing.T) {
+	if _, err := EncryptJWK([]byte(testJWK), ""); err == nil {
+		t.Error("Expected an error for an empty passphrase")
+	}
+}
+
+func TestIsEncryptedRejectsPlainJWK(t *testing.T) {
+	if IsEncrypted([]byte(testJWK)) {
+		t.Error("Plain JWK misdetected as encrypted")
+	}
+	if IsEncrypted([]byte("not json")) {
+		t.Error("Garbage misdetected as encrypted")
+	}
+}
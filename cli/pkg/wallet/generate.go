@@ -0,0 +1,71 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+)
+
+// arweaveKeyBits is the RSA modulus size Arweave wallets use
+const arweaveKeyBits = 4096
+
+// GenerateJWK creates a fresh Arweave wallet key and returns it in JWK form
+func GenerateJWK() (map[string]interface{}, error) {
+	key, err := rsa.GenerateKey(rand.Reader, arweaveKeyBits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate RSA key: %w", err)
+	}
+	key.Precompute()
+
+	encode := func(value *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(value.Bytes())
+	}
+	return map[string]interface{}{
+		"kty": "RSA",
+		"e":   encode(big.NewInt(int64(key.E))),
+		"n":   encode(key.N),
+		"d":   encode(key.D),
+		"p":   encode(key.Primes[0]),
+		"q":   encode(key.Primes[1]),
+		"dp":  encode(key.Precomputed.Dp),
+		"dq":  encode(key.Precomputed.Dq),
+		"qi":  encode(key.Precomputed.Qinv),
+	}, nil
+}
+
+// Generate creates a fresh Arweave wallet, stores it as a named profile, and
+// returns its address. The JWK only exists in the profile directory, so the
+// caller should tell the user to back it up.
+func Generate(name string) (string, error) {
+	if err := validName(name); err != nil {
+		return "", err
+	}
+	if Exists(name) {
+		return "", fmt.Errorf("wallet profile %q already exists", name)
+	}
+
+	jwk, err := GenerateJWK()
+	if err != nil {
+		return "", err
+	}
+
+	content, err := json.Marshal(jwk)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode wallet JWK: %w", err)
+	}
+
+	dir, err := ProfilesDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".json"), content, 0600); err != nil {
+		return "", fmt.Errorf("failed to store wallet profile: %w", err)
+	}
+
+	return AddressFromJWK(jwk)
+}
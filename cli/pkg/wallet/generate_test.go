@@ -0,0 +1,45 @@
+package wallet
+
+import (
+	"testing"
+)
+
+func TestGenerateCreatesLoadableProfile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("RSA key generation is slow")
+	}
+	setupProfilesDir(t)
+
+	address, err := Generate("fresh")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if address == "" {
+		t.Error("Expected a derived address for the generated wallet")
+	}
+	if !Exists("fresh") {
+		t.Error("Expected the generated profile to exist")
+	}
+
+	jwk, err := Load("fresh")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	for _, field := range []string{"kty", "n", "e", "d", "p", "q"} {
+		if value, ok := jwk[field].(string); field != "kty" && (!ok || value == "") {
+			t.Errorf("Generated JWK is missing field %q", field)
+		}
+	}
+
+	derived, err := AddressFromJWK(jwk)
+	if err != nil {
+		t.Fatalf("AddressFromJWK failed: %v", err)
+	}
+	if derived != address {
+		t.Errorf("Address mismatch: Generate returned %s, JWK derives %s", address, derived)
+	}
+
+	if _, err := Generate("fresh"); err == nil {
+		t.Error("Expected an error when the profile already exists")
+	}
+}
@@ -0,0 +1,249 @@
+// Package wallet manages named wallet profiles stored under the user config
+// directory (~/.config/harlequin/wallets on Linux). A profile either holds a
+// copy of the wallet JWK itself (written with 0600 permissions) or a reference
+// to an environment variable for wallets kept in an external secret store.
+// Upload and deploy flows can select profiles by name instead of browsing the
+// filesystem for key.json.
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Profile source kinds
+const (
+	SourceFile = "file" // JWK stored in the profile directory
+	SourceEnv  = "env"  // JWK read from an environment variable at use time
+)
+
+// currentFileName records the name of the active profile
+const currentFileName = "current"
+
+// envRefSuffix marks profiles that reference an environment variable
+const envRefSuffix = ".env"
+
+// Profile describes a stored wallet profile
+type Profile struct {
+	Name    string // Profile name
+	Source  string // SourceFile or SourceEnv
+	EnvVar  string // Environment variable name for SourceEnv profiles
+	Address string // Arweave address, empty when it cannot be derived
+	Active  bool   // Whether this is the active profile
+}
+
+// ProfilesDir returns the directory holding wallet profiles, creating it on
+// first use
+func ProfilesDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	dir := filepath.Join(configDir, "harlequin", "wallets")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create wallet directory: %w", err)
+	}
+	return dir, nil
+}
+
+// validName rejects profile names that would escape the profile directory or
+// collide with the bookkeeping files
+func validName(name string) error {
+	if name == "" || name == currentFileName || strings.ContainsAny(name, "/\\.") {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, and dashes", name)
+	}
+	return nil
+}
+
+// Add stores a copy of the wallet file as a named profile
+func Add(name, walletPath string) error {
+	if err := validName(name); err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(walletPath)
+	if err != nil {
+		return fmt.Errorf("failed to read wallet file %s: %w", walletPath, err)
+	}
+
+	var jwk map[string]interface{}
+	if err := json.Unmarshal(content, &jwk); err != nil {
+		return fmt.Errorf("%s is not a valid JWK wallet: %w", walletPath, err)
+	}
+
+	dir, err := ProfilesDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name+".json"), content, 0600); err != nil {
+		return fmt.Errorf("failed to store wallet profile: %w", err)
+	}
+	return nil
+}
+
+// AddEnvRef stores a profile that reads its JWK from an environment variable
+// at use time, for wallets kept in an external secret store or CI secret
+func AddEnvRef(name, envVar string) error {
+	if err := validName(name); err != nil {
+		return err
+	}
+	if envVar == "" {
+		return fmt.Errorf("environment variable name is required")
+	}
+
+	dir, err := ProfilesDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name+envRefSuffix), []byte(envVar+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to store wallet profile: %w", err)
+	}
+	return nil
+}
+
+// List returns all stored profiles sorted by name
+func List() ([]Profile, error) {
+	dir, err := ProfilesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wallet directory: %w", err)
+	}
+
+	active, _ := Current()
+
+	var profiles []Profile
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == currentFileName {
+			continue
+		}
+
+		switch {
+		case strings.HasSuffix(entry.Name(), ".json"):
+			name := strings.TrimSuffix(entry.Name(), ".json")
+			address := ""
+			if jwk, err := Load(name); err == nil {
+				address, _ = AddressFromJWK(jwk)
+			}
+			profiles = append(profiles, Profile{
+				Name:    name,
+				Source:  SourceFile,
+				Address: address,
+				Active:  name == active,
+			})
+		case strings.HasSuffix(entry.Name(), envRefSuffix):
+			name := strings.TrimSuffix(entry.Name(), envRefSuffix)
+			envVar, _ := os.ReadFile(filepath.Join(dir, entry.Name()))
+			profiles = append(profiles, Profile{
+				Name:   name,
+				Source: SourceEnv,
+				EnvVar: strings.TrimSpace(string(envVar)),
+				Active: name == active,
+			})
+		}
+	}
+
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, nil
+}
+
+// Exists reports whether a profile with the given name is stored
+func Exists(name string) bool {
+	dir, err := ProfilesDir()
+	if err != nil {
+		return false
+	}
+	if _, err := os.Stat(filepath.Join(dir, name+".json")); err == nil {
+		return true
+	}
+	if _, err := os.Stat(filepath.Join(dir, name+envRefSuffix)); err == nil {
+		return true
+	}
+	return false
+}
+
+// Use marks the named profile as active
+func Use(name string) error {
+	if !Exists(name) {
+		return fmt.Errorf("no wallet profile named %q", name)
+	}
+	dir, err := ProfilesDir()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, currentFileName), []byte(name+"\n"), 0600)
+}
+
+// Current returns the name of the active profile, or an empty string when
+// none is set
+func Current() (string, error) {
+	dir, err := ProfilesDir()
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(filepath.Join(dir, currentFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// Remove deletes the named profile, clearing the active marker if it pointed
+// at it
+func Remove(name string) error {
+	if !Exists(name) {
+		return fmt.Errorf("no wallet profile named %q", name)
+	}
+	dir, err := ProfilesDir()
+	if err != nil {
+		return err
+	}
+
+	os.Remove(filepath.Join(dir, name+".json"))
+	os.Remove(filepath.Join(dir, name+envRefSuffix))
+
+	if active, _ := Current(); active == name {
+		os.Remove(filepath.Join(dir, currentFileName))
+	}
+	return nil
+}
+
+// Load returns the JWK of the named profile
+func Load(name string) (map[string]interface{}, error) {
+	dir, err := ProfilesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	if raw, err := os.ReadFile(filepath.Join(dir, name+".json")); err == nil {
+		content = raw
+	} else if ref, err := os.ReadFile(filepath.Join(dir, name+envRefSuffix)); err == nil {
+		envVar := strings.TrimSpace(string(ref))
+		value := os.Getenv(envVar)
+		if value == "" {
+			return nil, fmt.Errorf("wallet profile %q references unset environment variable %s", name, envVar)
+		}
+		content = []byte(value)
+	} else {
+		return nil, fmt.Errorf("no wallet profile named %q", name)
+	}
+
+	var jwk map[string]interface{}
+	if err := json.Unmarshal(content, &jwk); err != nil {
+		return nil, fmt.Errorf("wallet profile %q is not a valid JWK: %w", name, err)
+	}
+	return jwk, nil
+}
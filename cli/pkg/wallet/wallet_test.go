@@ -0,0 +1,109 @@
+package wallet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testJWK is a minimal wallet JSON with a known modulus
+const testJWK = `{"kty":"RSA","n":"AQAB","e":"AQAB"}`
+
+func setupProfilesDir(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+}
+
+func writeTestWallet(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(path, []byte(testJWK), 0600); err != nil {
+		t.Fatalf("Failed to write test wallet: %v", err)
+	}
+	return path
+}
+
+func TestAddUseRemove(t *testing.T) {
+	setupProfilesDir(t)
+	walletPath := writeTestWallet(t)
+
+	if err := Add("deploy", walletPath); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if !Exists("deploy") {
+		t.Error("Expected profile to exist after Add")
+	}
+
+	jwk, err := Load("deploy")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if jwk["n"] != "AQAB" {
+		t.Errorf("Unexpected JWK contents: %v", jwk)
+	}
+
+	if err := Use("deploy"); err != nil {
+		t.Fatalf("Use failed: %v", err)
+	}
+	if active, _ := Current(); active != "deploy" {
+		t.Errorf("Expected active profile deploy, got %q", active)
+	}
+
+	if err := Remove("deploy"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if Exists("deploy") {
+		t.Error("Expected profile to be gone after Remove")
+	}
+	if active, _ := Current(); active != "" {
+		t.Errorf("Expected active profile to be cleared, got %q", active)
+	}
+}
+
+func TestEnvRefProfile(t *testing.T) {
+	setupProfilesDir(t)
+
+	if err := AddEnvRef("ci", "TEST_CI_WALLET"); err != nil {
+		t.Fatalf("AddEnvRef failed: %v", err)
+	}
+
+	// Unset variable is an error at load time, not at add time
+	if _, err := Load("ci"); err == nil {
+		t.Error("Expected error when referenced variable is unset")
+	}
+
+	t.Setenv("TEST_CI_WALLET", testJWK)
+	jwk, err := Load("ci")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if jwk["kty"] != "RSA" {
+		t.Errorf("Unexpected JWK contents: %v", jwk)
+	}
+}
+
+func TestInvalidProfileNames(t *testing.T) {
+	setupProfilesDir(t)
+	walletPath := writeTestWallet(t)
+
+	for _, name := range []string{"", "current", "../escape", "a.b"} {
+		if err := Add(name, walletPath); err == nil {
+			t.Errorf("Expected Add(%q) to fail", name)
+		}
+	}
+}
+
+func TestAddressFromJWK(t *testing.T) {
+	jwk := map[string]interface{}{"n": "AQAB"}
+	address, err := AddressFromJWK(jwk)
+	if err != nil {
+		t.Fatalf("AddressFromJWK failed: %v", err)
+	}
+	if len(address) != 43 {
+		t.Errorf("Expected 43 character address, got %q (%d)", address, len(address))
+	}
+
+	if _, err := AddressFromJWK(map[string]interface{}{}); err == nil {
+		t.Error("Expected error for JWK without modulus")
+	}
+}
@@ -0,0 +1,138 @@
+package wasm
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Bounds for the compute-limit recommendation. The upper bound matches the
+// widely copied default of 9000000000000.
+const (
+	MinRecommendedComputeLimit = int64(100000000000)
+	MaxRecommendedComputeLimit = int64(9000000000000)
+)
+
+// FunctionHotspot identifies a function whose body dominates the code section
+type FunctionHotspot struct {
+	Index    uint32 `json:"index"`     // Index into the module's function index space
+	Name     string `json:"name"`      // Export name if the function is exported, "" otherwise
+	BodySize uint32 `json:"body_size"` // Size of the function body in bytes
+}
+
+// MeteringReport summarizes the gas-relevant characteristics of a metered module
+type MeteringReport struct {
+	ModuleSize              int               `json:"module_size"`
+	FunctionCount           uint32            `json:"function_count"`
+	ImportedFunctions       int               `json:"imported_functions"`
+	TableCount              uint32            `json:"table_count"`
+	CodeSize                uint32            `json:"code_size"` // Total bytes of function bodies
+	Hotspots                []FunctionHotspot `json:"hotspots"`  // Largest function bodies, descending
+	RecommendedComputeLimit string            `json:"recommended_compute_limit"`
+}
+
+// AnalyzeMetering produces a metering report for a parsed WASM module.
+// The compute-limit recommendation is a rough heuristic scaled from the code
+// section size: a starting point to tune from, not a guarantee.
+func AnalyzeMetering(info *WasmInfo, moduleSize int) *MeteringReport {
+	importedFunctions := 0
+	for _, imp := range info.Imports {
+		if imp.Type == "function" {
+			importedFunctions++
+		}
+	}
+
+	var codeSize uint32
+	hotspots := make([]FunctionHotspot, 0, len(info.CodeBodySizes))
+	for i, bodySize := range info.CodeBodySizes {
+		codeSize += bodySize
+		// Function index space starts with imported functions
+		functionIndex := uint32(importedFunctions + i)
+		hotspots = append(hotspots, FunctionHotspot{
+			Index:    functionIndex,
+			Name:     exportNameForFunction(info, functionIndex),
+			BodySize: bodySize,
+		})
+	}
+
+	sort.Slice(hotspots, func(i, j int) bool {
+		return hotspots[i].BodySize > hotspots[j].BodySize
+	})
+	if len(hotspots) > 10 {
+		hotspots = hotspots[:10]
+	}
+
+	return &MeteringReport{
+		ModuleSize:              moduleSize,
+		FunctionCount:           info.FunctionCount,
+		ImportedFunctions:       importedFunctions,
+		TableCount:              info.TableCount,
+		CodeSize:                codeSize,
+		Hotspots:                hotspots,
+		RecommendedComputeLimit: recommendComputeLimit(codeSize),
+	}
+}
+
+// recommendComputeLimit scales a compute-limit recommendation from the code
+// section size, clamped to a sane range
+func recommendComputeLimit(codeSize uint32) string {
+	limit := int64(codeSize) * 2000000
+	if limit < MinRecommendedComputeLimit {
+		limit = MinRecommendedComputeLimit
+	}
+	if limit > MaxRecommendedComputeLimit {
+		limit = MaxRecommendedComputeLimit
+	}
+	return strconv.FormatInt(limit, 10)
+}
+
+// exportNameForFunction returns the export name for a function index, or ""
+// when the function is not exported
+func exportNameForFunction(info *WasmInfo, functionIndex uint32) string {
+	for _, exp := range info.Exports {
+		if exp.Type == "function" && exp.Index == functionIndex {
+			return exp.Name
+		}
+	}
+	return ""
+}
+
+// HasMeteringImport reports whether the module imports a gas accounting hook.
+// Metered module formats inject one at build time (conventionally a usegas
+// function); a metered format without it loads fine but never charges gas.
+func HasMeteringImport(info *WasmInfo) bool {
+	for _, imp := range info.Imports {
+		if imp.Type != "function" {
+			continue
+		}
+		if strings.Contains(strings.ToLower(imp.Name), "usegas") ||
+			strings.Contains(strings.ToLower(imp.Module), "metering") {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatMeteringReport renders a human-readable version of the report
+func FormatMeteringReport(report *MeteringReport) string {
+	out := "⛽ Metering Report\n"
+	out += fmt.Sprintf("   • Module Size: %s\n", FormatMemorySize(uint32(report.ModuleSize)))
+	out += fmt.Sprintf("   • Functions: %d (%d imported)\n", report.FunctionCount, report.ImportedFunctions)
+	out += fmt.Sprintf("   • Tables: %d\n", report.TableCount)
+	out += fmt.Sprintf("   • Code Size: %s\n", FormatMemorySize(report.CodeSize))
+
+	if len(report.Hotspots) > 0 {
+		out += "   • Largest Functions:\n"
+		for _, hotspot := range report.Hotspots {
+			name := hotspot.Name
+			if name == "" {
+				name = fmt.Sprintf("func[%d]", hotspot.Index)
+			}
+			out += fmt.Sprintf("     - %s: %s\n", name, FormatMemorySize(hotspot.BodySize))
+		}
+	}
+
+	out += fmt.Sprintf("   • Recommended compute_limit: %s\n", report.RecommendedComputeLimit)
+	return out
+}
@@ -33,6 +33,8 @@ type WasmInfo struct {
 	FunctionCount   uint32
 	GlobalCount     uint32
 	TableCount      uint32
+	CodeBodySizes   []uint32 // Per-function body sizes from the code section
+	SectionSizes    map[string]uint32 // Byte size of each section, keyed by section name
 }
 
 // WASM binary format constants
@@ -63,6 +65,7 @@ func ParseWasmBinary(data []byte) (*WasmInfo, error) {
 		Exports:        make([]WasmExport, 0),
 		Imports:        make([]WasmImport, 0),
 		Target:         "wasm32", // default assumption
+		SectionSizes:   make(map[string]uint32),
 	}
 
 	// Read magic number
@@ -104,6 +107,9 @@ func ParseWasmBinary(data []byte) (*WasmInfo, error) {
 			return nil, fmt.Errorf("failed to read section data: %w", err)
 		}
 
+		// Custom sections are sized individually by name via CustomSections
+		info.SectionSizes[SectionTypeName(sectionType)] += sectionSize
+
 		switch sectionType {
 		case SECTION_IMPORT:
 			if err := parseImportSection(sectionData, info); err != nil {
@@ -129,6 +135,10 @@ func ParseWasmBinary(data []byte) (*WasmInfo, error) {
 			if err := parseExportSection(sectionData, info); err != nil {
 				return nil, fmt.Errorf("failed to parse export section: %w", err)
 			}
+		case SECTION_CODE:
+			if err := parseCodeSection(sectionData, info); err != nil {
+				return nil, fmt.Errorf("failed to parse code section: %w", err)
+			}
 		case SECTION_CUSTOM:
 			if err := parseCustomSection(sectionData, info); err != nil {
 				return nil, fmt.Errorf("failed to parse custom section: %w", err)
@@ -139,6 +149,37 @@ func ParseWasmBinary(data []byte) (*WasmInfo, error) {
 	return info, nil
 }
 
+// SectionTypeName returns the spec name for a section type byte
+func SectionTypeName(sectionType byte) string {
+	switch sectionType {
+	case SECTION_TYPE:
+		return "type"
+	case SECTION_IMPORT:
+		return "import"
+	case SECTION_FUNCTION:
+		return "function"
+	case SECTION_TABLE:
+		return "table"
+	case SECTION_MEMORY:
+		return "memory"
+	case SECTION_GLOBAL:
+		return "global"
+	case SECTION_EXPORT:
+		return "export"
+	case SECTION_START:
+		return "start"
+	case SECTION_ELEMENT:
+		return "element"
+	case SECTION_CODE:
+		return "code"
+	case SECTION_DATA:
+		return "data"
+	case SECTION_CUSTOM:
+		return "custom"
+	}
+	return fmt.Sprintf("unknown (%d)", sectionType)
+}
+
 // parseMemorySection extracts memory configuration
 func parseMemorySection(data []byte, info *WasmInfo) error {
 	reader := bytes.NewReader(data)
@@ -370,6 +411,34 @@ func parseExportSection(data []byte, info *WasmInfo) error {
 	return nil
 }
 
+// parseCodeSection records the body size of each function, a useful proxy
+// for the relative gas cost of the functions in a metered module
+func parseCodeSection(data []byte, info *WasmInfo) error {
+	reader := bytes.NewReader(data)
+
+	// Read number of function bodies
+	count, err := readLEB128(reader)
+	if err != nil {
+		return err
+	}
+
+	info.CodeBodySizes = make([]uint32, 0, count)
+	for i := uint32(0); i < count; i++ {
+		bodySize, err := readLEB128(reader)
+		if err != nil {
+			return err
+		}
+		info.CodeBodySizes = append(info.CodeBodySizes, bodySize)
+
+		// Skip the function body itself
+		if _, err := reader.Seek(int64(bodySize), io.SeekCurrent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // parseCustomSection extracts custom section data
 func parseCustomSection(data []byte, info *WasmInfo) error {
 	reader := bytes.NewReader(data)
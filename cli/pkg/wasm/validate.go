@@ -0,0 +1,112 @@
+package wasm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WASM32_MAX_PAGES is the hard limit of 64KB pages addressable by a wasm32 module (4GB)
+const WASM32_MAX_PAGES = 65536
+
+// knownImportModules are the host modules the AO emscripten runtime provides.
+// Imports referencing anything else will fail when the module is instantiated.
+var knownImportModules = map[string]bool{
+	"env":                   true,
+	"wasi_snapshot_preview1": true,
+}
+
+// ValidationError aggregates all the reasons a module cannot be loaded by AO
+type ValidationError struct {
+	ModuleFormat string
+	Problems     []string
+}
+
+func (e *ValidationError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("WASM module is not loadable as %s:", e.ModuleFormat))
+	for _, problem := range e.Problems {
+		sb.WriteString("\n  • ")
+		sb.WriteString(problem)
+	}
+	return sb.String()
+}
+
+// ValidateForAO checks that a parsed WASM module satisfies the constraints AO
+// places on modules of the given module format (e.g. "wasm32-unknown-emscripten-metering").
+// It verifies the required exports (handle, memory), the import modules, and
+// the memory limits, and returns a ValidationError describing every problem
+// found. A module that fails these checks would otherwise only fail once the
+// first message is evaluated on-chain.
+func ValidateForAO(info *WasmInfo, moduleFormat string) error {
+	var problems []string
+
+	// AO invokes the exported handle function for every message
+	if !hasExport(info, "handle", "function") {
+		problems = append(problems, "missing required function export \"handle\" (AO's message entry point)")
+	}
+
+	// The runtime reads process state out of the exported memory
+	if !hasExport(info, "memory", "memory") && !hasExportOfType(info, "memory") && !hasImportOfType(info, "memory") {
+		problems = append(problems, "module neither exports nor imports a memory; AO cannot read process state")
+	}
+
+	// The target baked into the binary must match the declared module format
+	if strings.Contains(moduleFormat, "wasm32") && info.Target != "wasm32" {
+		problems = append(problems, fmt.Sprintf("module targets %s but the module format is %s", info.Target, moduleFormat))
+	}
+	if strings.Contains(moduleFormat, "wasm64") && info.Target != "wasm64" {
+		problems = append(problems, fmt.Sprintf("module targets %s but the module format is %s", info.Target, moduleFormat))
+	}
+
+	// Memory limits must stay within the wasm32 address space for 32-bit formats
+	if strings.Contains(moduleFormat, "wasm32") && info.MaxMemory > 0 {
+		maxPages := info.MaxMemory / 65536
+		if maxPages > WASM32_MAX_PAGES {
+			problems = append(problems, fmt.Sprintf("maximum memory %s exceeds the 4GB wasm32 address space", FormatMemorySize(info.MaxMemory)))
+		}
+	}
+
+	// Emscripten builds may only import from host modules the AO runtime provides
+	if strings.Contains(moduleFormat, "emscripten") {
+		for _, imp := range info.Imports {
+			if !knownImportModules[imp.Module] {
+				problems = append(problems, fmt.Sprintf("import %s.%s references host module %q which the AO runtime does not provide", imp.Module, imp.Name, imp.Module))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{ModuleFormat: moduleFormat, Problems: problems}
+	}
+	return nil
+}
+
+// hasExport reports whether the module exports the given name with the given type
+func hasExport(info *WasmInfo, name, exportType string) bool {
+	for _, exp := range info.Exports {
+		if exp.Name == name && exp.Type == exportType {
+			return true
+		}
+	}
+	return false
+}
+
+// hasExportOfType reports whether the module has any export of the given type
+func hasExportOfType(info *WasmInfo, exportType string) bool {
+	for _, exp := range info.Exports {
+		if exp.Type == exportType {
+			return true
+		}
+	}
+	return false
+}
+
+// hasImportOfType reports whether the module has any import of the given type
+func hasImportOfType(info *WasmInfo, importType string) bool {
+	for _, imp := range info.Imports {
+		if imp.Type == importType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package wasm
+
+import (
+	"strings"
+	"testing"
+)
+
+func validInfo() *WasmInfo {
+	return &WasmInfo{
+		Target:        "wasm32",
+		InitialMemory: 4194304,
+		MaxMemory:     1073741824,
+		Exports: []WasmExport{
+			{Name: "handle", Type: "function", Index: 0},
+			{Name: "memory", Type: "memory", Index: 0},
+		},
+		Imports: []WasmImport{
+			{Module: "env", Name: "invoke_ii", Type: "function"},
+			{Module: "wasi_snapshot_preview1", Name: "fd_write", Type: "function"},
+		},
+	}
+}
+
+func TestValidateForAOValidModule(t *testing.T) {
+	if err := ValidateForAO(validInfo(), "wasm32-unknown-emscripten-metering"); err != nil {
+		t.Errorf("Expected valid module to pass validation, got: %v", err)
+	}
+}
+
+func TestValidateForAOMissingHandle(t *testing.T) {
+	info := validInfo()
+	info.Exports = []WasmExport{{Name: "memory", Type: "memory", Index: 0}}
+
+	err := ValidateForAO(info, "wasm32-unknown-emscripten-metering")
+	if err == nil {
+		t.Fatal("Expected validation to fail without a handle export")
+	}
+	if !strings.Contains(err.Error(), "handle") {
+		t.Errorf("Expected error to mention the missing handle export, got: %v", err)
+	}
+}
+
+func TestValidateForAOMissingMemory(t *testing.T) {
+	info := validInfo()
+	info.Exports = []WasmExport{{Name: "handle", Type: "function", Index: 0}}
+	info.Imports = nil
+
+	err := ValidateForAO(info, "wasm32-unknown-emscripten-metering")
+	if err == nil {
+		t.Fatal("Expected validation to fail without a memory")
+	}
+	if !strings.Contains(err.Error(), "memory") {
+		t.Errorf("Expected error to mention the missing memory, got: %v", err)
+	}
+}
+
+func TestValidateForAOTargetMismatch(t *testing.T) {
+	info := validInfo()
+	info.Target = "wasm64"
+
+	err := ValidateForAO(info, "wasm32-unknown-emscripten-metering")
+	if err == nil {
+		t.Fatal("Expected validation to fail on target mismatch")
+	}
+}
+
+func TestValidateForAOUnknownImportModule(t *testing.T) {
+	info := validInfo()
+	info.Imports = append(info.Imports, WasmImport{Module: "wasi_unstable", Name: "fd_read", Type: "function"})
+
+	err := ValidateForAO(info, "wasm32-unknown-emscripten-metering")
+	if err == nil {
+		t.Fatal("Expected validation to fail on unknown import module")
+	}
+	if !strings.Contains(err.Error(), "wasi_unstable") {
+		t.Errorf("Expected error to name the unknown import module, got: %v", err)
+	}
+}
+
+func TestValidateForAOCollectsAllProblems(t *testing.T) {
+	info := validInfo()
+	info.Exports = nil
+	info.Imports = nil
+	info.Target = "wasm64"
+
+	err := ValidateForAO(info, "wasm32-unknown-emscripten-metering")
+	if err == nil {
+		t.Fatal("Expected validation to fail")
+	}
+
+	var validationErr *ValidationError
+	if ve, ok := err.(*ValidationError); ok {
+		validationErr = ve
+	} else {
+		t.Fatalf("Expected ValidationError, got %T", err)
+	}
+
+	if len(validationErr.Problems) < 3 {
+		t.Errorf("Expected all problems to be reported, got %d: %v", len(validationErr.Problems), validationErr.Problems)
+	}
+}
+
+func TestHasMeteringImport(t *testing.T) {
+	info := validInfo()
+	if HasMeteringImport(info) {
+		t.Error("Expected module without a gas hook to report no metering import")
+	}
+
+	info.Imports = append(info.Imports, WasmImport{Module: "env", Name: "usegas", Type: "function"})
+	if !HasMeteringImport(info) {
+		t.Error("Expected module importing env.usegas to report a metering import")
+	}
+
+	info = validInfo()
+	info.Imports = append(info.Imports, WasmImport{Module: "metering", Name: "gas", Type: "function"})
+	if !HasMeteringImport(info) {
+		t.Error("Expected module importing from a metering host module to report a metering import")
+	}
+}
@@ -1,6 +1,8 @@
 package components
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -15,6 +17,16 @@ type FilePickerComponent struct {
 	selectedFile string
 	width        int
 	height       int
+
+	// Pinned section: per-project favorites and recently used paths
+	projectDir string
+	pinned     []string
+
+	// Fuzzy filter mode, entered with "/"
+	filtering     bool
+	filterInput   string
+	filterMatches []string
+	filterCursor  int
 }
 
 // NewFilePicker creates a new file picker component
@@ -30,11 +42,22 @@ func NewFilePicker(width, height int) *FilePickerComponent {
 	fp.Styles.Selected = fp.Styles.Selected.Foreground(lipgloss.Color("#902f17")).Bold(true)
 	fp.Styles.FileSize = fp.Styles.FileSize.Foreground(lipgloss.Color("#564f41"))
 
-	return &FilePickerComponent{
+	// Honor user keymap config for navigation and selection
+	applyUserFilePickerKeys(&fp)
+
+	projectDir, err := os.Getwd()
+	if err != nil {
+		projectDir = "."
+	}
+
+	picker := &FilePickerComponent{
 		filepicker: fp,
 		width:      width,
 		height:     height,
+		projectDir: projectDir,
 	}
+	picker.refreshPinned()
+	return picker
 }
 
 // SetCurrentDirectory sets the starting directory for the file picker
@@ -68,19 +91,154 @@ func (fpc *FilePickerComponent) Init() tea.Cmd {
 	return fpc.filepicker.Init()
 }
 
+// refreshPinned rebuilds the pinned section from the persisted favorites and
+// recents, dropping paths that no longer exist
+func (fpc *FilePickerComponent) refreshPinned() {
+	history := loadPickerHistory(fpc.projectDir)
+
+	fpc.pinned = fpc.pinned[:0]
+	seen := make(map[string]bool)
+	for _, path := range append(history.Favorites, history.Recent...) {
+		if seen[path] || len(fpc.pinned) >= 9 {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		seen[path] = true
+		fpc.pinned = append(fpc.pinned, path)
+	}
+}
+
+// hasAllowedType reports whether a path matches the picker's allowed types
+func (fpc *FilePickerComponent) hasAllowedType(path string) bool {
+	if len(fpc.filepicker.AllowedTypes) == 0 {
+		return true
+	}
+	lower := strings.ToLower(path)
+	for _, ext := range fpc.filepicker.AllowedTypes {
+		if strings.HasSuffix(lower, strings.ToLower(ext)) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectFile records a selection and remembers it for the pinned section
+func (fpc *FilePickerComponent) selectFile(path string) {
+	fpc.selectedFile = path
+	rememberRecentFile(fpc.projectDir, path)
+}
+
+// collectFilterMatches walks the current directory tree for files matching
+// the allowed types and the fuzzy filter input
+func (fpc *FilePickerComponent) collectFilterMatches() {
+	const maxMatches = 30
+
+	fpc.filterMatches = fpc.filterMatches[:0]
+	fpc.filterCursor = 0
+
+	root := fpc.filepicker.CurrentDirectory
+	_ = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := info.Name()
+		if info.IsDir() {
+			if name == ".git" || name == "node_modules" {
+				return filepath.SkipDir
+			}
+			if !fpc.filepicker.ShowHidden && strings.HasPrefix(name, ".") && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if len(fpc.filterMatches) >= maxMatches {
+			return filepath.SkipAll
+		}
+		if !fpc.filepicker.ShowHidden && strings.HasPrefix(name, ".") {
+			return nil
+		}
+		if fpc.hasAllowedType(name) && fuzzyMatch(fpc.filterInput, name) {
+			fpc.filterMatches = append(fpc.filterMatches, path)
+		}
+		return nil
+	})
+}
+
+// updateFiltering handles key presses while the fuzzy filter is active
+func (fpc *FilePickerComponent) updateFiltering(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		fpc.filtering = false
+	case "enter":
+		if fpc.filterCursor < len(fpc.filterMatches) {
+			fpc.selectFile(fpc.filterMatches[fpc.filterCursor])
+			fpc.filtering = false
+		}
+	case "up":
+		if fpc.filterCursor > 0 {
+			fpc.filterCursor--
+		}
+	case "down":
+		if fpc.filterCursor < len(fpc.filterMatches)-1 {
+			fpc.filterCursor++
+		}
+	case "backspace":
+		if len(fpc.filterInput) > 0 {
+			fpc.filterInput = fpc.filterInput[:len(fpc.filterInput)-1]
+			fpc.collectFilterMatches()
+		}
+	default:
+		if msg.Type == tea.KeyRunes {
+			fpc.filterInput += string(msg.Runes)
+			fpc.collectFilterMatches()
+		}
+	}
+	return fpc, nil
+}
+
 // Update handles Bubble Tea messages
 func (fpc *FilePickerComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if fpc.filtering {
+			return fpc.updateFiltering(msg)
+		}
+
 		switch msg.String() {
 		case "enter":
-			// Check if a Lua file is selected
+			// Check if an allowed file is selected
 			if ok, selectedFile := fpc.filepicker.DidSelectFile(msg); ok {
-				if strings.HasSuffix(strings.ToLower(selectedFile), ".lua") {
-					fpc.selectedFile = selectedFile
+				if fpc.hasAllowedType(selectedFile) {
+					fpc.selectFile(selectedFile)
 					return fpc, nil
 				}
 			}
+		case "/":
+			// Enter fuzzy filter mode
+			fpc.filtering = true
+			fpc.filterInput = ""
+			fpc.collectFilterMatches()
+			return fpc, nil
+		case ".":
+			// Toggle hidden files and re-read the directory
+			fpc.filepicker.ShowHidden = !fpc.filepicker.ShowHidden
+			return fpc, fpc.filepicker.Init()
+		case "*":
+			// Favorite (or unfavorite) the highlighted path
+			if path := fpc.GetCurrentPath(); path != "" && fpc.hasAllowedType(path) {
+				toggleFavoriteFile(fpc.projectDir, path)
+				fpc.refreshPinned()
+			}
+			return fpc, nil
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			// Jump straight to a pinned path
+			index := int(msg.String()[0] - '1')
+			if index < len(fpc.pinned) {
+				fpc.selectFile(fpc.pinned[index])
+			}
+			return fpc, nil
 		}
 	}
 
@@ -89,9 +247,67 @@ func (fpc *FilePickerComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return fpc, cmd
 }
 
+// viewPinned renders the pinned favorites/recents section
+func (fpc *FilePickerComponent) viewPinned() string {
+	if len(fpc.pinned) == 0 {
+		return ""
+	}
+
+	history := loadPickerHistory(fpc.projectDir)
+	favorites := make(map[string]bool, len(history.Favorites))
+	for _, path := range history.Favorites {
+		favorites[path] = true
+	}
+
+	lines := make([]string, 0, len(fpc.pinned)+1)
+	lines = append(lines, lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#93513a")).
+		Bold(true).
+		Render("Pinned"))
+	for i, path := range fpc.pinned {
+		marker := " "
+		if favorites[path] {
+			marker = "★"
+		}
+		lines = append(lines, fmt.Sprintf("%d) %s %s", i+1, marker, path))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// viewFiltering renders the fuzzy filter overlay
+func (fpc *FilePickerComponent) viewFiltering() string {
+	lines := []string{fmt.Sprintf("Filter: %s▌", fpc.filterInput), ""}
+
+	if len(fpc.filterMatches) == 0 {
+		lines = append(lines, "No matches")
+	}
+	for i, match := range fpc.filterMatches {
+		cursor := "  "
+		if i == fpc.filterCursor {
+			cursor = "> "
+		}
+		lines = append(lines, cursor+match)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 // View renders the file picker
 func (fpc *FilePickerComponent) View() string {
-	content := fpc.filepicker.View()
+	var content string
+	var instructions string
+
+	if fpc.filtering {
+		content = fpc.viewFiltering()
+		instructions = "Type to filter, ↑/↓ to move, Enter to select, Esc to cancel"
+	} else {
+		content = fpc.filepicker.View()
+		if pinned := fpc.viewPinned(); pinned != "" {
+			content = pinned + "\n\n" + content
+		}
+		instructions = "↑/↓ Navigate, → Enter dir, Enter Select, / Filter, . Hidden, * Favorite, 1-9 Pinned"
+	}
 
 	// Add title and instructions
 	title := lipgloss.NewStyle().
@@ -99,15 +315,15 @@ func (fpc *FilePickerComponent) View() string {
 		Foreground(lipgloss.Color("#902f17")).
 		Render("Select Entrypoint File")
 
-	instructions := lipgloss.NewStyle().
+	styledInstructions := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#564f41")).
-		Render("Navigate with ↑/↓, Enter directories with →, Select .lua files with Enter")
+		Render(instructions)
 
 	// Combine title, instructions, and file picker
 	fullContent := lipgloss.JoinVertical(lipgloss.Left,
 		title,
 		"",
-		instructions,
+		styledInstructions,
 		"",
 		content,
 	)
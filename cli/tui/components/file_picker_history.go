@@ -0,0 +1,147 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// pickerHistory holds the pinned paths for one project
+type pickerHistory struct {
+	Favorites []string `yaml:"favorites"`
+	Recent    []string `yaml:"recent"`
+}
+
+// maxRecentFiles caps how many recently used paths are remembered per project
+const maxRecentFiles = 5
+
+// pickerHistoryFile returns the path to the per-project picker history
+func pickerHistoryFile() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "harlequin", "file-picker.yaml"), nil
+}
+
+// loadPickerHistoryMap reads the full history keyed by project directory
+func loadPickerHistoryMap() map[string]pickerHistory {
+	path, err := pickerHistoryFile()
+	if err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	history := make(map[string]pickerHistory)
+	if err := yaml.Unmarshal(data, &history); err != nil {
+		return nil
+	}
+	return history
+}
+
+// savePickerHistoryMap writes the full history back; failures are silent
+func savePickerHistoryMap(history map[string]pickerHistory) {
+	path, err := pickerHistoryFile()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+
+	data, err := yaml.Marshal(history)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
+
+// loadPickerHistory returns the favorites and recents for a project
+func loadPickerHistory(projectDir string) pickerHistory {
+	absDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return pickerHistory{}
+	}
+	return loadPickerHistoryMap()[absDir]
+}
+
+// rememberRecentFile records a selected path at the front of the recents
+func rememberRecentFile(projectDir, path string) {
+	absDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return
+	}
+
+	history := loadPickerHistoryMap()
+	if history == nil {
+		history = make(map[string]pickerHistory)
+	}
+
+	entry := history[absDir]
+	recent := []string{path}
+	for _, existing := range entry.Recent {
+		if existing != path && len(recent) < maxRecentFiles {
+			recent = append(recent, existing)
+		}
+	}
+	entry.Recent = recent
+	history[absDir] = entry
+
+	savePickerHistoryMap(history)
+}
+
+// toggleFavoriteFile adds a path to the favorites, or removes it when
+// already present, and reports whether it is now favorited
+func toggleFavoriteFile(projectDir, path string) bool {
+	absDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return false
+	}
+
+	history := loadPickerHistoryMap()
+	if history == nil {
+		history = make(map[string]pickerHistory)
+	}
+
+	entry := history[absDir]
+	favorites := make([]string, 0, len(entry.Favorites)+1)
+	removed := false
+	for _, existing := range entry.Favorites {
+		if existing == path {
+			removed = true
+			continue
+		}
+		favorites = append(favorites, existing)
+	}
+	if !removed {
+		favorites = append(favorites, path)
+	}
+	entry.Favorites = favorites
+	history[absDir] = entry
+
+	savePickerHistoryMap(history)
+	return !removed
+}
+
+// fuzzyMatch reports whether every rune of pattern appears in order within
+// candidate, ignoring case - the usual subsequence fuzzy match
+func fuzzyMatch(pattern, candidate string) bool {
+	pattern = strings.ToLower(pattern)
+	candidate = strings.ToLower(candidate)
+
+	index := 0
+	for _, r := range pattern {
+		rest := strings.IndexRune(candidate[index:], r)
+		if rest < 0 {
+			return false
+		}
+		index += rest + 1
+	}
+	return true
+}
@@ -2,6 +2,7 @@ package components
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
@@ -17,9 +18,21 @@ const (
 	StateProjectName
 	StateAuthorName
 	StateGitHubUser
+	StateVariables
+	StatePreview
+	StateConflict
 	StateComplete
 )
 
+// WizardVariable is a template-declared variable the wizard prompts for,
+// mirroring the template manifest without importing the cmd package
+type WizardVariable struct {
+	Name    string
+	Prompt  string
+	Default string
+	Pattern string
+}
+
 type InitWizardComponent struct {
 	state         InitWizardState
 	projectInput  textinput.Model
@@ -28,6 +41,18 @@ type InitWizardComponent struct {
 	templateList  list.Model
 	width         int
 	height        int
+	previewFiles  []string
+
+	// Capability filter over the template list: an index into
+	// capabilityFilters, where entry 0 ("") shows everything
+	capabilityFilters []string
+	capabilityIndex   int
+
+	// Template-declared variable prompting
+	variables     []WizardVariable
+	variableIndex int
+	variableInput textinput.Model
+	variableError string
 
 	// Results
 	ProjectName  string
@@ -35,9 +60,18 @@ type InitWizardComponent struct {
 	AuthorName   string
 	GitHubUser   string
 	TargetDir    string
+	ConflictMode string // "", "merge", or "overwrite"
+	Variables    map[string]string
+
+	// Hooks supplied by the caller, keeping the component decoupled from the
+	// template registry
+	ListTemplateFiles     func(templateLang string) []string
+	TargetDirConflict     func(targetDir string) bool
+	ListTemplateVariables func(templateLang string) []WizardVariable
+	ValidateVariable      func(variable WizardVariable, value string) error
 
 	// Completion callback
-	OnComplete func(projectName, templateLang, authorName, githubUser, targetDir string)
+	OnComplete func(projectName, templateLang, authorName, githubUser, targetDir, conflictMode string, variables map[string]string)
 }
 
 type templateItem struct {
@@ -118,12 +152,20 @@ func NewInitWizardComponent() *InitWizardComponent {
 		Bold(true).
 		Padding(0, 0, 1, 0)
 
+	// Template variable input, reused for each declared variable
+	variableInput := textinput.New()
+	variableInput.CharLimit = 100
+	variableInput.Width = 40
+
 	return &InitWizardComponent{
-		state:        StateTemplateSelection,
-		projectInput: projectInput,
-		authorInput:  authorInput,
-		githubInput:  githubInput,
-		templateList: templateList,
+		state:             StateTemplateSelection,
+		projectInput:      projectInput,
+		authorInput:       authorInput,
+		githubInput:       githubInput,
+		variableInput:     variableInput,
+		templateList:      templateList,
+		capabilityFilters: append([]string{""}, templateCapabilities()...),
+		Variables:         make(map[string]string),
 	}
 }
 
@@ -165,6 +207,10 @@ func (iwc *InitWizardComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						return iwc, textinput.Blink
 					}
 				}
+			case "f":
+				// Cycle the capability filter and refilter the list
+				iwc.capabilityIndex = (iwc.capabilityIndex + 1) % len(iwc.capabilityFilters)
+				return iwc, iwc.templateList.SetItems(templateItemsWithCapability(iwc.capabilityFilters[iwc.capabilityIndex]))
 			case "ctrl+c", "esc":
 				return iwc, tea.Quit
 			}
@@ -204,20 +250,80 @@ func (iwc *InitWizardComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch msg.String() {
 			case "enter":
 				iwc.GitHubUser = strings.TrimSpace(iwc.githubInput.Value())
-				iwc.state = StateComplete
 				iwc.githubInput.Blur()
 
-				// Immediately create the project
-				if iwc.OnComplete != nil {
-					iwc.OnComplete(iwc.ProjectName, iwc.TemplateLang, iwc.AuthorName, iwc.GitHubUser, iwc.TargetDir)
+				// Prompt for template-declared variables before the preview
+				if iwc.ListTemplateVariables != nil {
+					iwc.variables = iwc.ListTemplateVariables(iwc.TemplateLang)
 				}
-				return iwc, tea.Quit
+				if len(iwc.variables) > 0 {
+					iwc.variableIndex = 0
+					iwc.prepareVariableInput()
+					return iwc, textinput.Blink
+				}
+				return iwc.showPreview()
 			case "ctrl+c", "esc":
 				return iwc, tea.Quit
 			}
 			iwc.githubInput, cmd = iwc.githubInput.Update(msg)
 			return iwc, cmd
 
+		case StateVariables:
+			switch msg.String() {
+			case "enter":
+				variable := iwc.variables[iwc.variableIndex]
+				value := strings.TrimSpace(iwc.variableInput.Value())
+				if value == "" {
+					value = variable.Default
+				}
+				if iwc.ValidateVariable != nil {
+					if err := iwc.ValidateVariable(variable, value); err != nil {
+						iwc.variableError = err.Error()
+						return iwc, nil
+					}
+				}
+				iwc.Variables[variable.Name] = value
+				iwc.variableIndex++
+				if iwc.variableIndex < len(iwc.variables) {
+					iwc.prepareVariableInput()
+					return iwc, textinput.Blink
+				}
+				iwc.variableInput.Blur()
+				return iwc.showPreview()
+			case "ctrl+c", "esc":
+				return iwc, tea.Quit
+			}
+			iwc.variableInput, cmd = iwc.variableInput.Update(msg)
+			return iwc, cmd
+
+		case StatePreview:
+			switch msg.String() {
+			case "enter":
+				if iwc.TargetDirConflict != nil && iwc.TargetDirConflict(iwc.targetDir()) {
+					iwc.state = StateConflict
+					return iwc, nil
+				}
+				return iwc.finish("")
+			case "esc":
+				iwc.state = StateGitHubUser
+				iwc.githubInput.Focus()
+				return iwc, textinput.Blink
+			case "ctrl+c":
+				return iwc, tea.Quit
+			}
+			return iwc, nil
+
+		case StateConflict:
+			switch msg.String() {
+			case "m":
+				return iwc.finish("merge")
+			case "o":
+				return iwc.finish("overwrite")
+			case "a", "esc", "ctrl+c":
+				return iwc, tea.Quit
+			}
+			return iwc, nil
+
 		case StateComplete:
 			return iwc, tea.Quit
 		}
@@ -236,6 +342,12 @@ func (iwc *InitWizardComponent) View() string {
 		return iwc.renderAuthorInput()
 	case StateGitHubUser:
 		return iwc.renderGitHubInput()
+	case StateVariables:
+		return iwc.renderVariableInput()
+	case StatePreview:
+		return iwc.renderPreview()
+	case StateConflict:
+		return iwc.renderConflict()
 	case StateComplete:
 		return iwc.renderComplete()
 	}
@@ -282,9 +394,13 @@ func (iwc *InitWizardComponent) renderTemplateSelection() string {
 		Bold(true).
 		Render("🎭 Select Template")
 
+	subtitle := "Choose a template for your project • f: filter by capability"
+	if filter := iwc.capabilityFilters[iwc.capabilityIndex]; filter != "" {
+		subtitle = fmt.Sprintf("Showing templates with: %s • f: next filter", filter)
+	}
 	listSubtitle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#564f41")).
-		Render("Choose a template for your project")
+		Render(subtitle)
 
 	leftPanel := lipgloss.JoinVertical(lipgloss.Left,
 		listTitle,
@@ -311,6 +427,23 @@ func (iwc *InitWizardComponent) renderTemplateSelection() string {
 				Foreground(lipgloss.Color("#666")).
 				Render(fmt.Sprintf("Build System: %s", item.buildSystem))
 
+			comparisonTitle := lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#902f17")).
+				Bold(true).
+				Render("At a Glance:")
+
+			facts := languageFacts[item.name]
+			comparisonRows := []string{
+				fmt.Sprintf("Binary Size: %s", facts.BinarySize),
+				fmt.Sprintf("Build Time:  %s", facts.BuildTime),
+				fmt.Sprintf("Maturity:    %s", facts.Maturity),
+				fmt.Sprintf("JSON:        %s", facts.JSONHandling),
+				fmt.Sprintf("Metering:    %s", facts.Metering),
+			}
+			comparison := lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#564f41")).
+				Render(strings.Join(comparisonRows, "\n"))
+
 			featuresTitle := lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#902f17")).
 				Bold(true).
@@ -331,15 +464,23 @@ func (iwc *InitWizardComponent) renderTemplateSelection() string {
 				"",
 				buildSystem,
 				"",
+				comparisonTitle,
+				"",
+				comparison,
+				"",
 				featuresTitle,
 				"",
 				features,
 			)
 		}
 	} else {
+		placeholder := "Select a template to see details"
+		if filter := iwc.capabilityFilters[iwc.capabilityIndex]; filter != "" {
+			placeholder = fmt.Sprintf("No templates support %q yet\nPress f to change the filter", filter)
+		}
 		rightPanel = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#666")).
-			Render("Select a template to see details")
+			Render(placeholder)
 	}
 
 	// Calculate panel dimensions (matching main TUI's getPanelWidth logic)
@@ -457,6 +598,196 @@ func (iwc *InitWizardComponent) renderGitHubInput() string {
 		Render(content)
 }
 
+// prepareVariableInput resets the shared input for the current variable
+func (iwc *InitWizardComponent) prepareVariableInput() {
+	iwc.state = StateVariables
+	iwc.variableError = ""
+	iwc.variableInput.SetValue("")
+	iwc.variableInput.Placeholder = iwc.variables[iwc.variableIndex].Default
+	iwc.variableInput.Focus()
+}
+
+// showPreview loads the template file list and moves to the preview step
+func (iwc *InitWizardComponent) showPreview() (tea.Model, tea.Cmd) {
+	// Show what would be generated before touching the disk
+	if iwc.ListTemplateFiles != nil {
+		iwc.previewFiles = iwc.ListTemplateFiles(iwc.TemplateLang)
+	}
+	iwc.state = StatePreview
+	return iwc, nil
+}
+
+func (iwc *InitWizardComponent) renderVariableInput() string {
+	variable := iwc.variables[iwc.variableIndex]
+
+	title := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#902f17")).
+		Bold(true).
+		Render(fmt.Sprintf("🎭 Project: %s (%s)", iwc.ProjectName, iwc.TemplateLang))
+
+	prompt := variable.Prompt
+	if prompt == "" {
+		prompt = fmt.Sprintf("Enter a value for %s", variable.Name)
+	}
+	subtitle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#564f41")).
+		Render(fmt.Sprintf("%s (%d/%d)", prompt, iwc.variableIndex+1, len(iwc.variables)))
+
+	input := iwc.variableInput.View()
+
+	sections := []string{title, "", subtitle, "", input}
+	if iwc.variableError != "" {
+		errorText := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#902f17")).
+			Render(fmt.Sprintf("❌ %s", iwc.variableError))
+		sections = append(sections, "", errorText)
+	}
+
+	instructions := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#666")).
+		Render("Press Enter to continue • Ctrl+C to cancel")
+	sections = append(sections, "", instructions)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#902f17")).
+		Padding(2).
+		Render(content)
+}
+
+// targetDir resolves the directory the project will be created in
+func (iwc *InitWizardComponent) targetDir() string {
+	if iwc.TargetDir != "" {
+		return iwc.TargetDir
+	}
+	return iwc.ProjectName
+}
+
+// finish records the conflict resolution, runs the completion callback, and
+// quits the wizard
+func (iwc *InitWizardComponent) finish(conflictMode string) (tea.Model, tea.Cmd) {
+	iwc.ConflictMode = conflictMode
+	iwc.state = StateComplete
+
+	if iwc.OnComplete != nil {
+		iwc.OnComplete(iwc.ProjectName, iwc.TemplateLang, iwc.AuthorName, iwc.GitHubUser, iwc.TargetDir, conflictMode, iwc.Variables)
+	}
+	return iwc, tea.Quit
+}
+
+func (iwc *InitWizardComponent) renderPreview() string {
+	title := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#902f17")).
+		Bold(true).
+		Render(fmt.Sprintf("🎭 Project Preview: %s (%s)", iwc.ProjectName, iwc.TemplateLang))
+
+	subtitle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#564f41")).
+		Render(fmt.Sprintf("The following files will be generated in %s/", iwc.targetDir()))
+
+	tree := "Template file list unavailable"
+	if len(iwc.previewFiles) > 0 {
+		tree = renderFileTree(iwc.previewFiles)
+	}
+	treeStyled := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#564f41")).
+		Render(tree)
+
+	instructions := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#666")).
+		Render("Press Enter to create • Esc to go back • Ctrl+C to cancel")
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		subtitle,
+		"",
+		treeStyled,
+		"",
+		instructions,
+	)
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#902f17")).
+		Padding(2).
+		Render(content)
+}
+
+func (iwc *InitWizardComponent) renderConflict() string {
+	title := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#902f17")).
+		Bold(true).
+		Render("⚠️  Directory Not Empty")
+
+	message := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#564f41")).
+		Render(fmt.Sprintf("The directory %s/ already exists and contains files.", iwc.targetDir()))
+
+	options := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#564f41")).
+		Render(strings.Join([]string{
+			"M  Merge: add template files, keeping existing ones",
+			"O  Overwrite: delete the directory and start fresh",
+			"A  Abort: cancel project creation",
+		}, "\n"))
+
+	instructions := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#666")).
+		Render("Press M, O, or A • Esc to abort")
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		message,
+		"",
+		options,
+		"",
+		instructions,
+	)
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#902f17")).
+		Padding(2).
+		Render(content)
+}
+
+// renderFileTree renders a sorted, indented tree from slash-separated paths
+func renderFileTree(paths []string) string {
+	sorted := make([]string, len(paths))
+	copy(sorted, paths)
+	sort.Strings(sorted)
+
+	const maxLines = 16
+	var lines []string
+	seen := make(map[string]bool)
+	for _, path := range sorted {
+		parts := strings.Split(path, "/")
+		for depth := 0; depth < len(parts); depth++ {
+			prefix := strings.Join(parts[:depth+1], "/")
+			if seen[prefix] {
+				continue
+			}
+			seen[prefix] = true
+
+			name := parts[depth]
+			if depth < len(parts)-1 {
+				name += "/"
+			}
+			lines = append(lines, strings.Repeat("  ", depth)+name)
+		}
+	}
+
+	if len(lines) > maxLines {
+		hidden := len(lines) - maxLines
+		lines = append(lines[:maxLines], fmt.Sprintf("… and %d more", hidden))
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (iwc *InitWizardComponent) renderConfirmation() string {
 	title := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#902f17")).
@@ -0,0 +1,215 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/filepicker"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"gopkg.in/yaml.v3"
+)
+
+// keymapSettings is the keymap section of the user-level config file
+// (~/.config/harlequin/config.yaml). A preset picks a base layout and
+// bindings override individual actions with explicit key lists, e.g.:
+//
+//	keymap:
+//	  preset: emacs
+//	  bindings:
+//	    select: ["enter"]
+//	    quit: ["q", "ctrl+q"]
+type keymapSettings struct {
+	Preset   string              `yaml:"preset"`
+	Bindings map[string][]string `yaml:"bindings"`
+}
+
+var (
+	userKeyMapOnce sync.Once
+	userKeyMap     KeyMap
+)
+
+// UserKeyMap returns the key bindings with the user's keymap config applied.
+// It falls back to DefaultKeyMap when no config file exists or the keymap
+// section is absent; load failures are silent so a broken config never makes
+// the TUI unusable.
+func UserKeyMap() KeyMap {
+	userKeyMapOnce.Do(func() {
+		settings := loadKeymapSettings()
+		userKeyMap = presetKeyMap(settings.Preset)
+		applyBindingOverrides(&userKeyMap, settings.Bindings)
+	})
+	return userKeyMap
+}
+
+// userConfigFile returns the path to the user-level config file
+func userConfigFile() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "harlequin", "config.yaml"), nil
+}
+
+// loadKeymapSettings reads the keymap section from the user config file,
+// returning zero settings on any failure
+func loadKeymapSettings() keymapSettings {
+	path, err := userConfigFile()
+	if err != nil {
+		return keymapSettings{}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return keymapSettings{}
+	}
+
+	var config struct {
+		Keymap keymapSettings `yaml:"keymap"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return keymapSettings{}
+	}
+	return config.Keymap
+}
+
+// presetKeyMap resolves a preset name to its base key map
+func presetKeyMap(preset string) KeyMap {
+	switch strings.ToLower(preset) {
+	case "vim":
+		return VimKeyMap()
+	case "emacs":
+		return EmacsKeyMap()
+	default:
+		return DefaultKeyMap()
+	}
+}
+
+// VimKeyMap returns vim-style key bindings. The defaults already navigate
+// with hjkl alongside the arrow keys; this preset additionally treats
+// ctrl+[ as escape for going back and cancelling.
+func VimKeyMap() KeyMap {
+	km := DefaultKeyMap()
+
+	km.Back = key.NewBinding(
+		key.WithKeys("esc", "ctrl+["),
+		key.WithHelp("esc", "go back"),
+	)
+	km.Cancel = key.NewBinding(
+		key.WithKeys("esc", "ctrl+[", "ctrl+c"),
+		key.WithHelp("esc", "cancel"),
+	)
+
+	return km
+}
+
+// EmacsKeyMap returns emacs-style key bindings: ctrl+p/ctrl+n for vertical
+// movement, ctrl+b/ctrl+f for horizontal movement, and ctrl+g to back out
+func EmacsKeyMap() KeyMap {
+	km := DefaultKeyMap()
+
+	km.Up = key.NewBinding(
+		key.WithKeys("up", "ctrl+p"),
+		key.WithHelp("↑/C-p", "move up"),
+	)
+	km.Down = key.NewBinding(
+		key.WithKeys("down", "ctrl+n"),
+		key.WithHelp("↓/C-n", "move down"),
+	)
+	km.Left = key.NewBinding(
+		key.WithKeys("left", "ctrl+b"),
+		key.WithHelp("←/C-b", "move left"),
+	)
+	km.Right = key.NewBinding(
+		key.WithKeys("right", "ctrl+f"),
+		key.WithHelp("→/C-f", "move right"),
+	)
+	km.Back = key.NewBinding(
+		key.WithKeys("esc", "ctrl+g"),
+		key.WithHelp("C-g", "go back"),
+	)
+	km.Cancel = key.NewBinding(
+		key.WithKeys("esc", "ctrl+g", "ctrl+c"),
+		key.WithHelp("C-g", "cancel"),
+	)
+
+	return km
+}
+
+// applyBindingOverrides replaces individual bindings with user-configured
+// key lists, keeping each action's help description
+func applyBindingOverrides(km *KeyMap, bindings map[string][]string) {
+	for action, keys := range bindings {
+		if len(keys) == 0 {
+			continue
+		}
+
+		var target *key.Binding
+		switch strings.ToLower(action) {
+		case "up":
+			target = &km.Up
+		case "down":
+			target = &km.Down
+		case "left":
+			target = &km.Left
+		case "right":
+			target = &km.Right
+		case "enter":
+			target = &km.Enter
+		case "select":
+			target = &km.Select
+		case "back":
+			target = &km.Back
+		case "tab":
+			target = &km.Tab
+		case "edit":
+			target = &km.Edit
+		case "save":
+			target = &km.Save
+		case "cancel":
+			target = &km.Cancel
+		case "help":
+			target = &km.Help
+		case "quit":
+			target = &km.Quit
+		case "debug":
+			target = &km.Debug
+		default:
+			continue
+		}
+
+		*target = key.NewBinding(
+			key.WithKeys(keys...),
+			key.WithHelp(strings.Join(keys, "/"), target.Help().Desc),
+		)
+	}
+}
+
+// applyUserListKeys rebinds a Bubbles list's cursor movement to the user's
+// navigation keys. The list's own short-help descriptions are kept so the
+// footer reads the same as before for unconfigured users.
+func applyUserListKeys(l *list.Model) {
+	km := UserKeyMap()
+	l.KeyMap.CursorUp = key.NewBinding(
+		key.WithKeys(km.Up.Keys()...),
+		key.WithHelp(km.Up.Help().Key, l.KeyMap.CursorUp.Help().Desc),
+	)
+	l.KeyMap.CursorDown = key.NewBinding(
+		key.WithKeys(km.Down.Keys()...),
+		key.WithHelp(km.Down.Help().Key, l.KeyMap.CursorDown.Help().Desc),
+	)
+}
+
+// applyUserFilePickerKeys rebinds a Bubbles file picker's navigation to the
+// user's keys: up/down move the cursor, left backs out of a directory,
+// right enters one, and enter selects
+func applyUserFilePickerKeys(fp *filepicker.Model) {
+	km := UserKeyMap()
+	fp.KeyMap.Up = km.Up
+	fp.KeyMap.Down = km.Down
+	fp.KeyMap.Back = km.Left
+	fp.KeyMap.Open = km.Right
+	fp.KeyMap.Select = km.Enter
+}
@@ -113,7 +113,7 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 
 // ConfigEditKeyMap returns key bindings specific to config editing
 func ConfigEditKeyMap() KeyMap {
-	km := DefaultKeyMap()
+	km := UserKeyMap()
 
 	// Override some bindings for config editing context
 	km.Save = key.NewBinding(
@@ -126,7 +126,7 @@ func ConfigEditKeyMap() KeyMap {
 
 // BuildRunningKeyMap returns key bindings for the build running state
 func BuildRunningKeyMap() KeyMap {
-	km := DefaultKeyMap()
+	km := UserKeyMap()
 
 	// Disable most actions during build
 	km.Up = key.NewBinding(key.WithDisabled())
@@ -0,0 +1,77 @@
+package components
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// Language comparison data shown next to the template list, kept in a data
+// file so new templates only have to add an entry rather than touch the
+// rendering code
+//
+//go:embed language_facts.json
+var languageFactsJSON []byte
+
+// LanguageFacts is the structured comparison for one template language
+type LanguageFacts struct {
+	BinarySize   string   `json:"binary_size"`
+	BuildTime    string   `json:"build_time"`
+	Maturity     string   `json:"maturity"`
+	JSONHandling string   `json:"json_handling"`
+	Metering     string   `json:"metering"`
+	Capabilities []string `json:"capabilities"` // Tags the capability filter matches against
+}
+
+var languageFacts = mustLoadLanguageFacts()
+
+func mustLoadLanguageFacts() map[string]LanguageFacts {
+	facts := make(map[string]LanguageFacts)
+	if err := json.Unmarshal(languageFactsJSON, &facts); err != nil {
+		panic(fmt.Sprintf("invalid language_facts.json: %v", err))
+	}
+	return facts
+}
+
+// templateCapabilities returns the distinct capability tags across all
+// languages, sorted so the filter cycles in a stable order
+func templateCapabilities() []string {
+	seen := make(map[string]bool)
+	var capabilities []string
+	for _, facts := range languageFacts {
+		for _, capability := range facts.Capabilities {
+			if !seen[capability] {
+				seen[capability] = true
+				capabilities = append(capabilities, capability)
+			}
+		}
+	}
+	sort.Strings(capabilities)
+	return capabilities
+}
+
+// templateItemsWithCapability filters the available templates down to the
+// ones whose language declares the capability; "" means no filter
+func templateItemsWithCapability(capability string) []list.Item {
+	if capability == "" {
+		return availableTemplateItems
+	}
+
+	var items []list.Item
+	for _, candidate := range availableTemplateItems {
+		item, ok := candidate.(templateItem)
+		if !ok {
+			continue
+		}
+		for _, declared := range languageFacts[item.name].Capabilities {
+			if declared == capability {
+				items = append(items, candidate)
+				break
+			}
+		}
+	}
+	return items
+}
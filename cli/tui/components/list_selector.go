@@ -2,10 +2,12 @@ package components
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/plugin"
 )
 
 // ListItem represents an item in our selector list
@@ -32,8 +34,10 @@ func (i ListItem) Value() string       { return i.value }
 
 // ListSelectorComponent provides a feature-rich selector using Bubbles list
 type ListSelectorComponent struct {
-	list   list.Model
-	choice string
+	list        list.Model
+	choice      string
+	multiSelect bool
+	toggled     map[string]bool
 }
 
 // NewListSelector creates a new list-based selector
@@ -76,6 +80,9 @@ func NewListSelector(title string, items []ListItem, width, height int) *ListSel
 	listModel.Styles.PaginationStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#564f41"))
 
+	// Honor user keymap config for cursor movement
+	applyUserListKeys(&listModel)
+
 	return &ListSelectorComponent{
 		list: listModel,
 	}
@@ -125,6 +132,12 @@ func (ls *ListSelectorComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if selected := ls.GetSelected(); selected != nil {
 				ls.choice = selected.Value()
 			}
+		case " ":
+			// Toggle the highlighted item in multi-select mode
+			if ls.multiSelect {
+				ls.toggleSelected()
+				return ls, nil
+			}
 		case "up", "down", "j", "k":
 			// Arrow keys should be passed through to the list
 		}
@@ -151,6 +164,50 @@ func (ls *ListSelectorComponent) HasChoice() bool {
 	return ls.choice != ""
 }
 
+// EnableMultiSelect lets the user toggle several items with space before
+// confirming with enter
+func (ls *ListSelectorComponent) EnableMultiSelect() {
+	ls.multiSelect = true
+	ls.toggled = make(map[string]bool)
+}
+
+// toggleSelected flips the checkmark on the highlighted item
+func (ls *ListSelectorComponent) toggleSelected() {
+	index := ls.list.Index()
+	item, ok := ls.list.SelectedItem().(ListItem)
+	if !ok || item.value == "" {
+		return
+	}
+
+	if ls.toggled[item.value] {
+		delete(ls.toggled, item.value)
+		item.title = strings.TrimPrefix(item.title, "✓ ")
+	} else {
+		ls.toggled[item.value] = true
+		item.title = "✓ " + item.title
+	}
+	ls.list.SetItem(index, item)
+}
+
+// GetChoices returns every toggled item in list order, falling back to the
+// single highlighted choice when nothing was toggled
+func (ls *ListSelectorComponent) GetChoices() []string {
+	if len(ls.toggled) == 0 {
+		if ls.choice != "" {
+			return []string{ls.choice}
+		}
+		return nil
+	}
+
+	var choices []string
+	for _, item := range ls.list.Items() {
+		if listItem, ok := item.(ListItem); ok && ls.toggled[listItem.value] {
+			choices = append(choices, listItem.value)
+		}
+	}
+	return choices
+}
+
 // CreateBuildTypeSelector creates a selector for build types
 func CreateBuildTypeSelector(width, height int) *ListSelectorComponent {
 	items := []ListItem{
@@ -159,6 +216,11 @@ func CreateBuildTypeSelector(width, height int) *ListSelectorComponent {
 			description: "Builds a WASM binary with your Lua injected into the base AOS process",
 			value:       "aos",
 		},
+		{
+			title:       "AOS SQLite Flavour",
+			description: "Builds against the sqlite-enabled AOS variant (64-bit module with larger memory)",
+			value:       "aos-sqlite",
+		},
 	}
 
 	return NewListSelector("Select Build Configuration", items, width, height)
@@ -198,7 +260,20 @@ func CreateEntrypointSelectorWithDiscovery(rootDir string, width, height int) (*
 		return NewListSelector("Select Entrypoint File", items, width, height), nil
 	}
 
-	return CreateEntrypointSelector(luaFiles, width, height), nil
+	// Rank likely entrypoints first and explain why each one ranks
+	ranked := RankLuaFiles(rootDir, luaFiles)
+	items := make([]ListItem, len(ranked))
+	for i, file := range ranked {
+		items[i] = ListItem{
+			title:       file.Path,
+			description: file.Reason,
+			value:       file.Path,
+		}
+	}
+
+	selector := NewListSelector("Select Entrypoint File (space to toggle multiple)", items, width, height)
+	selector.EnableMultiSelect()
+	return selector, nil
 }
 
 // CreateOutputDirSelector creates a selector for output directories
@@ -270,6 +345,29 @@ func CreateCommandSelector(width, height int) *ListSelectorComponent {
 			description: "Lua bundling and utilities",
 			value:       "lua-utils",
 		},
+		{
+			title:       "Modules",
+			description: "Browse and manage modules recorded in the upload lockfile",
+			value:       "modules",
+		},
+		{
+			title:       "Processes",
+			description: "Dashboard for processes registered in this project",
+			value:       "processes",
+		},
+	}
+
+	// Surface external plugins (harlequin-<name> on PATH) in the menu
+	for _, p := range plugin.Discover() {
+		description := p.Manifest.Description
+		if p.Manifest.Help != "" {
+			description = p.Manifest.Help
+		}
+		items = append(items, ListItem{
+			title:       p.Name,
+			description: description,
+			value:       "plugin:" + p.Name,
+		})
 	}
 
 	return NewListSelector("Welcome to Harlequin", items, width, height)
@@ -417,6 +515,25 @@ func CreateUploadDryRunSelector(width, height int) *ListSelectorComponent {
 	return NewListSelector("Select Upload Mode", items, width, height)
 }
 
+// CreateUploadFastPathSelector creates a selector offering to reuse settings
+// detected from the project instead of walking the file-picker screens
+func CreateUploadFastPathSelector(width, height int) *ListSelectorComponent {
+	items := []ListItem{
+		{
+			title:       "⚡ Use Detected Settings",
+			description: "Skip the file pickers and jump straight to version entry",
+			value:       "detected",
+		},
+		{
+			title:       "🛠️  Configure Manually",
+			description: "Pick the WASM, config, and wallet files step by step",
+			value:       "manual",
+		},
+	}
+
+	return NewListSelector("Upload Module", items, width, height)
+}
+
 // CreateUploadConfirmationSelector creates a selector for upload confirmation
 func CreateUploadConfirmationSelector(width, height int) *ListSelectorComponent {
 	items := []ListItem{
@@ -435,6 +552,50 @@ func CreateUploadConfirmationSelector(width, height int) *ListSelectorComponent
 	return NewListSelector("Confirm Upload", items, width, height)
 }
 
+// CreateUploadConfirmationSelectorWithRetry creates a selector for upload
+// confirmation when the balance check failed, offering to retry it
+func CreateUploadConfirmationSelectorWithRetry(width, height int) *ListSelectorComponent {
+	items := []ListItem{
+		{
+			title:       "🔄 Retry Balance Check",
+			description: "Check wallet balance and upload cost again",
+			value:       "retry",
+		},
+		{
+			title:       "✅ Confirm Upload",
+			description: "Proceed with the upload without balance information",
+			value:       "confirm",
+		},
+		{
+			title:       "❌ Cancel",
+			description: "Go back to modify settings",
+			value:       "cancel",
+		},
+	}
+
+	return NewListSelector("Confirm Upload", items, width, height)
+}
+
+// CreateUploadConfirmationSelectorOverBudget creates a selector for upload
+// confirmation when the estimated cost exceeds the project's cost budget,
+// making the overage explicit before the user commits
+func CreateUploadConfirmationSelectorOverBudget(width, height int, budget, overage string) *ListSelectorComponent {
+	items := []ListItem{
+		{
+			title:       "⚠️  Upload Over Budget",
+			description: fmt.Sprintf("Exceeds the %s budget by %s", budget, overage),
+			value:       "confirm",
+		},
+		{
+			title:       "❌ Cancel",
+			description: "Go back to modify settings",
+			value:       "cancel",
+		},
+	}
+
+	return NewListSelector("Confirm Upload", items, width, height)
+}
+
 // CreateUploadConfirmationSelectorWithBalance creates a selector for upload confirmation with balance checking
 func CreateUploadConfirmationSelectorWithBalance(width, height int, hasSufficientBalance bool, balance, cost string) *ListSelectorComponent {
 	var items []ListItem
@@ -3,7 +3,10 @@ package components
 import (
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // LuaFileDiscovery provides utilities for finding Lua files
@@ -109,3 +112,156 @@ func FindLuaFilesQuick(rootDir string) ([]string, error) {
 	discovery := NewLuaFileDiscovery()
 	return discovery.FindLuaFiles(rootDir)
 }
+
+// RankedLuaFile pairs a discovered Lua file with why it ranks as a likely
+// entrypoint
+type RankedLuaFile struct {
+	Path   string
+	Reason string
+	score  int
+}
+
+// RankLuaFiles orders discovered Lua files by how likely they are to be an
+// entrypoint: files referenced by the project config rank first, then the
+// last choice for this project, conventional names like main.lua, and files
+// that register AO handlers. Shallower paths win ties.
+func RankLuaFiles(rootDir string, files []string) []RankedLuaFile {
+	configEntrypoints := configReferencedEntrypoints(rootDir)
+	lastChoice := LoadLastEntrypoint(rootDir)
+
+	ranked := make([]RankedLuaFile, len(files))
+	for i, file := range files {
+		entry := RankedLuaFile{Path: file, Reason: "Lua file"}
+
+		switch {
+		case configEntrypoints[filepath.ToSlash(file)]:
+			entry.score = 400
+			entry.Reason = "Referenced by .harlequin.yaml"
+		case file == lastChoice:
+			entry.score = 300
+			entry.Reason = "Last used for this project"
+		case strings.EqualFold(filepath.Base(file), "main.lua"):
+			entry.score = 200
+			entry.Reason = "Conventional entrypoint name"
+		case registersHandlers(filepath.Join(rootDir, file)):
+			entry.score = 100
+			entry.Reason = "Registers AO handlers"
+		}
+
+		// Prefer files closer to the project root
+		entry.score -= strings.Count(filepath.ToSlash(file), "/")
+		ranked[i] = entry
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].Path < ranked[j].Path
+	})
+	return ranked
+}
+
+// configReferencedEntrypoints reads the entrypoints named by the project's
+// .harlequin.yaml, including workspace packages
+func configReferencedEntrypoints(rootDir string) map[string]bool {
+	entrypoints := make(map[string]bool)
+
+	data, err := os.ReadFile(filepath.Join(rootDir, ".harlequin.yaml"))
+	if err != nil {
+		return entrypoints
+	}
+
+	var cfg struct {
+		Workspace []struct {
+			Entrypoint string `yaml:"entrypoint"`
+		} `yaml:"workspace"`
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return entrypoints
+	}
+
+	for _, pkg := range cfg.Workspace {
+		if pkg.Entrypoint != "" {
+			entrypoints[filepath.ToSlash(pkg.Entrypoint)] = true
+		}
+	}
+	return entrypoints
+}
+
+// registersHandlers reports whether a Lua file calls Handlers.add, the usual
+// marker of an AO process entrypoint
+func registersHandlers(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > 512*1024 {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(data), "Handlers.add")
+}
+
+// lastEntrypointsFile stores the last entrypoint chosen per project directory
+func lastEntrypointsFile() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "harlequin", "last-entrypoints.yaml"), nil
+}
+
+// LoadLastEntrypoint returns the entrypoint last selected for a project, or
+// an empty string
+func LoadLastEntrypoint(rootDir string) string {
+	path, err := lastEntrypointsFile()
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	choices := make(map[string]string)
+	if err := yaml.Unmarshal(data, &choices); err != nil {
+		return ""
+	}
+
+	absRoot, err := filepath.Abs(rootDir)
+	if err != nil {
+		return ""
+	}
+	return choices[absRoot]
+}
+
+// SaveLastEntrypoint remembers the entrypoint selected for a project so the
+// next session can rank it first. Failures are silently ignored; this is
+// only a convenience.
+func SaveLastEntrypoint(rootDir, entrypoint string) {
+	path, err := lastEntrypointsFile()
+	if err != nil {
+		return
+	}
+
+	choices := make(map[string]string)
+	if data, err := os.ReadFile(path); err == nil {
+		_ = yaml.Unmarshal(data, &choices)
+	}
+
+	absRoot, err := filepath.Abs(rootDir)
+	if err != nil {
+		return
+	}
+	choices[absRoot] = entrypoint
+
+	data, err := yaml.Marshal(choices)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
@@ -2,6 +2,8 @@ package components
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/harmonica"
@@ -24,6 +26,12 @@ type ProgressStep struct {
 	Status    StepStatus
 	Spinner   harmonica.Spring
 	SpinPhase float64
+
+	// Timing: StartedAt/Duration track this run, Estimate comes from
+	// previous runs and drives the ETA bar
+	StartedAt time.Time
+	Duration  time.Duration
+	Estimate  time.Duration
 }
 
 // ProgressComponent provides a reusable progress display with animated steps
@@ -64,14 +72,62 @@ func (p *ProgressComponent) SetStepStatus(stepName string, status StepStatus) {
 	for i := range p.steps {
 		if p.steps[i].Name == stepName {
 			p.steps[i].Status = status
-			if status == StepRunning {
+			switch status {
+			case StepRunning:
 				p.steps[i].SpinPhase = 0 // Reset spin phase
+				p.steps[i].StartedAt = time.Now()
+			case StepSuccess, StepFailed:
+				if !p.steps[i].StartedAt.IsZero() && p.steps[i].Duration == 0 {
+					p.steps[i].Duration = time.Since(p.steps[i].StartedAt)
+				}
 			}
 			break
 		}
 	}
 }
 
+// SetStepEstimates seeds expected durations from previous runs so running
+// steps render a determinate bar with time remaining
+func (p *ProgressComponent) SetStepEstimates(estimates map[string]time.Duration) {
+	for i := range p.steps {
+		if estimate, ok := estimates[p.steps[i].Name]; ok {
+			p.steps[i].Estimate = estimate
+		}
+	}
+}
+
+// StepDurations returns how long each completed step took this run, for
+// persisting as the next run's estimates
+func (p *ProgressComponent) StepDurations() map[string]time.Duration {
+	durations := make(map[string]time.Duration)
+	for _, step := range p.steps {
+		if step.Status == StepSuccess && step.Duration > 0 {
+			durations[step.Name] = step.Duration
+		}
+	}
+	return durations
+}
+
+// etaLine renders a small progress bar with estimated time remaining for a
+// running step that has a historical estimate
+func etaLine(elapsed, estimate time.Duration) string {
+	const barWidth = 12
+
+	fraction := float64(elapsed) / float64(estimate)
+	if fraction > 0.99 {
+		fraction = 0.99 // Never show a full bar while still running
+	}
+
+	filled := int(fraction * barWidth)
+	bar := strings.Repeat("▰", filled) + strings.Repeat("▱", barWidth-filled)
+
+	remaining := estimate - elapsed
+	if remaining < time.Second {
+		remaining = time.Second
+	}
+	return fmt.Sprintf("  %s ~%s\n", bar, remaining.Round(time.Second))
+}
+
 // UpdateAnimations updates spinner animations for running steps
 func (p *ProgressComponent) UpdateAnimations() {
 	for i := range p.steps {
@@ -119,6 +175,11 @@ func (p *ProgressComponent) View() string {
 			icon = "✗" // X for failed
 		}
 		content += fmt.Sprintf("%s %s\n", icon, step.Name)
+
+		// Show a determinate bar with ETA when a previous run timed this step
+		if step.Status == StepRunning && step.Estimate > 0 && !step.StartedAt.IsZero() {
+			content += etaLine(time.Since(step.StartedAt), step.Estimate)
+		}
 	}
 
 	// Create bordered panel
@@ -182,6 +243,11 @@ func (p *ProgressComponent) ViewContent() string {
 			icon = "✗" // X for failed
 		}
 		content += fmt.Sprintf("%s %s\n", icon, step.Name)
+
+		// Show a determinate bar with ETA when a previous run timed this step
+		if step.Status == StepRunning && step.Estimate > 0 && !step.StartedAt.IsZero() {
+			content += etaLine(time.Since(step.StartedAt), step.Estimate)
+		}
 	}
 
 	// Return content without border/sizing for layout containers to handle
@@ -255,6 +255,11 @@ func formatBuildDetails(result interface{}, success bool) string {
 				}
 			}
 		}
+
+		// Report wasm-opt before/after sizes when the optimize step ran
+		if optSizes := v.FieldByName("OptimizeSizes"); optSizes.IsValid() && optSizes.String() != "" {
+			details += fmt.Sprintf("• Optimized WASM: %s\n", optSizes.String())
+		}
 	}
 
 	details += "\n"
@@ -0,0 +1,102 @@
+package components
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// stepTimingsFile returns the path to the per-project step timing history
+func stepTimingsFile() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "harlequin", "step-timings.yaml"), nil
+}
+
+// loadStepTimingsMap reads the full timing history keyed by project directory
+func loadStepTimingsMap() map[string]map[string]float64 {
+	path, err := stepTimingsFile()
+	if err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	timings := make(map[string]map[string]float64)
+	if err := yaml.Unmarshal(data, &timings); err != nil {
+		return nil
+	}
+	return timings
+}
+
+// LoadStepTimings returns the remembered duration of each step for a project,
+// used to estimate time remaining on subsequent runs. Failures are silent: no
+// history just means indeterminate spinners.
+func LoadStepTimings(projectDir string) map[string]time.Duration {
+	absDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return nil
+	}
+
+	seconds := loadStepTimingsMap()[absDir]
+	if len(seconds) == 0 {
+		return nil
+	}
+
+	estimates := make(map[string]time.Duration, len(seconds))
+	for step, s := range seconds {
+		estimates[step] = time.Duration(s * float64(time.Second))
+	}
+	return estimates
+}
+
+// SaveStepTimings records step durations for a project, blending them with
+// any previous run so estimates smooth out rather than jumping around.
+// Failures are silent; timing history is best-effort.
+func SaveStepTimings(projectDir string, durations map[string]time.Duration) {
+	if len(durations) == 0 {
+		return
+	}
+
+	absDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return
+	}
+
+	timings := loadStepTimingsMap()
+	if timings == nil {
+		timings = make(map[string]map[string]float64)
+	}
+	if timings[absDir] == nil {
+		timings[absDir] = make(map[string]float64)
+	}
+
+	for step, duration := range durations {
+		seconds := duration.Seconds()
+		if previous, ok := timings[absDir][step]; ok {
+			seconds = (previous + seconds) / 2
+		}
+		timings[absDir][step] = seconds
+	}
+
+	path, err := stepTimingsFile()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+
+	data, err := yaml.Marshal(timings)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
@@ -3,11 +3,14 @@ package components
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
 )
 
 // UploadWizardState represents the current step in the upload wizard
@@ -19,6 +22,7 @@ const (
 	UploadStateWalletFile
 	UploadStateVersion
 	UploadStateGitHash
+	UploadStateTags
 	UploadStateDryRun
 	UploadStateConfirmation
 	UploadStateCompleted
@@ -38,6 +42,7 @@ type UploadWizardComponent struct {
 	// Text inputs
 	versionInput textinput.Model
 	gitHashInput textinput.Model
+	tagInput     textinput.Model
 
 	// List selector for dry run
 	dryRunSelector *ListSelectorComponent
@@ -49,9 +54,11 @@ type UploadWizardComponent struct {
 	Version    string
 	GitHash    string
 	DryRun     bool
+	Tags       map[string]string
+	SavePreset bool
 
 	// Callback for completion
-	OnComplete func(wasmFile, configFile, walletFile, version, gitHash string, dryRun bool)
+	OnComplete func(wasmFile, configFile, walletFile, version, gitHash string, dryRun bool, tags map[string]string)
 
 	err error
 }
@@ -87,9 +94,20 @@ func NewUploadWizardComponent() *UploadWizardComponent {
 	gitHashInput.CharLimit = 40
 	gitHashInput.Width = 50
 
+	tagInput := textinput.New()
+	tagInput.Placeholder = "e.g., Team=Protocol (leave empty to continue)"
+	tagInput.CharLimit = 100
+	tagInput.Width = 50
+
 	// Create dry run selector
 	dryRunSelector := CreateDryRunSelector(50, 10)
 
+	// Preload the per-project tag preset so saved tags show up for review
+	tags, err := config.LoadUploadTags(".")
+	if err != nil {
+		tags = map[string]string{}
+	}
+
 	return &UploadWizardComponent{
 		state:            UploadStateWasmFile,
 		wasmFilePicker:   wasmPicker,
@@ -97,7 +115,9 @@ func NewUploadWizardComponent() *UploadWizardComponent {
 		walletFilePicker: walletPicker,
 		versionInput:     versionInput,
 		gitHashInput:     gitHashInput,
+		tagInput:         tagInput,
 		dryRunSelector:   dryRunSelector,
+		Tags:             tags,
 		ConfigFile:       "build_configs/ao-build-config.yml", // Default
 		DryRun:           true, // Default to dry run
 	}
@@ -172,6 +192,11 @@ func (m *UploadWizardComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleEnter()
 		case "esc":
 			return m.handleBack()
+		case "ctrl+s":
+			if m.state == UploadStateTags {
+				m.SavePreset = !m.SavePreset
+				return m, nil
+			}
 		}
 	}
 
@@ -225,6 +250,10 @@ func (m *UploadWizardComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.gitHashInput, cmd = m.gitHashInput.Update(msg)
 		cmds = append(cmds, cmd)
 
+	case UploadStateTags:
+		m.tagInput, cmd = m.tagInput.Update(msg)
+		cmds = append(cmds, cmd)
+
 	case UploadStateDryRun:
 		if m.dryRunSelector != nil {
 			model, cmd := m.dryRunSelector.Update(msg)
@@ -252,7 +281,22 @@ func (m *UploadWizardComponent) handleEnter() (tea.Model, tea.Cmd) {
 
 	case UploadStateGitHash:
 		m.GitHash = m.gitHashInput.Value()
-		m.state = UploadStateDryRun
+		m.state = UploadStateTags
+		m.tagInput.Focus()
+
+	case UploadStateTags:
+		// An empty input continues to the next step, otherwise add the tag
+		if m.tagInput.Value() == "" {
+			m.state = UploadStateDryRun
+			break
+		}
+		parts := strings.SplitN(m.tagInput.Value(), "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			m.err = fmt.Errorf("invalid tag %q: expected Key=Value", m.tagInput.Value())
+			return m, nil
+		}
+		m.Tags[parts[0]] = parts[1]
+		m.tagInput.SetValue("")
 
 	case UploadStateDryRun:
 		if m.dryRunSelector != nil {
@@ -263,8 +307,14 @@ func (m *UploadWizardComponent) handleEnter() (tea.Model, tea.Cmd) {
 		}
 
 	case UploadStateConfirmation:
+		if m.SavePreset {
+			if err := config.SaveUploadTags(".", m.Tags); err != nil {
+				m.err = fmt.Errorf("failed to save tag preset: %w", err)
+				return m, nil
+			}
+		}
 		if m.OnComplete != nil {
-			m.OnComplete(m.WasmFile, m.ConfigFile, m.WalletFile, m.Version, m.GitHash, m.DryRun)
+			m.OnComplete(m.WasmFile, m.ConfigFile, m.WalletFile, m.Version, m.GitHash, m.DryRun, m.Tags)
 		}
 		m.state = UploadStateCompleted
 		return m, tea.Quit
@@ -288,9 +338,13 @@ func (m *UploadWizardComponent) handleBack() (tea.Model, tea.Cmd) {
 		m.state = UploadStateVersion
 		m.gitHashInput.Blur()
 		m.versionInput.Focus()
-	case UploadStateDryRun:
+	case UploadStateTags:
 		m.state = UploadStateGitHash
+		m.tagInput.Blur()
 		m.gitHashInput.Focus()
+	case UploadStateDryRun:
+		m.state = UploadStateTags
+		m.tagInput.Focus()
 	case UploadStateConfirmation:
 		m.state = UploadStateDryRun
 	default:
@@ -364,6 +418,8 @@ func (m *UploadWizardComponent) getStateTitle() string {
 		return "Enter Module Version"
 	case UploadStateGitHash:
 		return "Enter Git Hash"
+	case UploadStateTags:
+		return "Edit Upload Tags"
 	case UploadStateDryRun:
 		return "Choose Upload Mode"
 	case UploadStateConfirmation:
@@ -425,15 +481,37 @@ func (m *UploadWizardComponent) renderStateContent() string {
 		content.WriteString("Git Hash: " + m.gitHashInput.View())
 		content.WriteString("\n\nLeave empty for auto-detection")
 
+	case UploadStateTags:
+		content.WriteString("Step 6: Add or override upload tags (optional)\n\n")
+		if len(m.Tags) > 0 {
+			keys := make([]string, 0, len(m.Tags))
+			for key := range m.Tags {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			content.WriteString("Custom tags:\n")
+			for _, key := range keys {
+				content.WriteString(fmt.Sprintf("  • %s: %s\n", key, m.Tags[key]))
+			}
+			content.WriteString("\n")
+		}
+		content.WriteString("Tag: " + m.tagInput.View())
+		content.WriteString("\n\nCustom tags override the generated ones.")
+		savePreset := "no"
+		if m.SavePreset {
+			savePreset = "yes"
+		}
+		content.WriteString(fmt.Sprintf("\nSave as project preset (%s): %s", config.UploadTagsFileName, savePreset))
+
 	case UploadStateDryRun:
-		content.WriteString("Step 6: Choose upload mode\n\n")
+		content.WriteString("Step 7: Choose upload mode\n\n")
 		if m.dryRunSelector != nil {
 			selectorContent := lipgloss.NewStyle().Width(contentWidth).Render(m.dryRunSelector.View())
 			content.WriteString(selectorContent)
 		}
 
 	case UploadStateConfirmation:
-		content.WriteString("Step 7: Confirm your settings\n\n")
+		content.WriteString("Step 8: Confirm your settings\n\n")
 		content.WriteString(fmt.Sprintf("WASM File: %s\n", m.WasmFile))
 		content.WriteString(fmt.Sprintf("Config File: %s\n", m.ConfigFile))
 		content.WriteString(fmt.Sprintf("Wallet File: %s\n", m.WalletFile))
@@ -441,6 +519,13 @@ func (m *UploadWizardComponent) renderStateContent() string {
 		if m.GitHash != "" {
 			content.WriteString(fmt.Sprintf("Git Hash: %s\n", m.GitHash))
 		}
+		if len(m.Tags) > 0 {
+			content.WriteString(fmt.Sprintf("Custom Tags: %d", len(m.Tags)))
+			if m.SavePreset {
+				content.WriteString(" (saved as preset)")
+			}
+			content.WriteString("\n")
+		}
 		mode := "Actual Upload"
 		if m.DryRun {
 			mode = "Dry Run"
@@ -471,6 +556,8 @@ func (m *UploadWizardComponent) createControls() string {
 		controls = []string{"Type version", "Enter Continue", "Esc Back", "q Quit"}
 	case UploadStateGitHash:
 		controls = []string{"Type hash", "Enter Continue", "Esc Back", "q Quit"}
+	case UploadStateTags:
+		controls = []string{"Type Key=Value", "Enter Add/Continue", "Ctrl+S Toggle Preset", "Esc Back"}
 	case UploadStateDryRun:
 		controls = []string{"↑/↓ Navigate", "Enter Select", "Esc Back", "q Quit"}
 	case UploadStateConfirmation:
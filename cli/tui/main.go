@@ -3,6 +3,7 @@ package tui
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -25,6 +26,10 @@ import (
 	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
 	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/debug"
 	luautils "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/lua_utils"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/i18n"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/plugin"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/registry"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/wallet"
 	components "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/tui/components"
 )
 
@@ -48,6 +53,7 @@ const (
 	ViewLuaUtilsRunning
 	ViewLuaUtilsSuccess
 	ViewLuaUtilsError
+	ViewUploadFastPath
 	ViewUploadWasmSelection
 	ViewUploadConfigSelection
 	ViewUploadWalletSelection
@@ -58,6 +64,11 @@ const (
 	ViewUploadRunning
 	ViewUploadSuccess
 	ViewUploadError
+	ViewModuleExplorer
+	ViewModuleActions
+	ViewProcessExplorer
+	ViewProcessActions
+	ViewProcessMessage
 )
 
 // Model represents the modernized TUI application state
@@ -101,6 +112,36 @@ type Model struct {
 	uploadConfirmSelector *components.ListSelectorComponent
 	uploadProgress        *components.ProgressComponent // Separate progress for uploads
 
+	// Async balance check state
+	balanceChecking    bool
+	balanceCheckCancel context.CancelFunc
+
+	// Cancels the in-flight build when the user backs out or quits
+	buildCancel context.CancelFunc
+
+	// Upload fast path: settings detected from the project
+	uploadFastPathSelector *components.ListSelectorComponent
+	detectedWasm           string
+	detectedConfig         string
+	detectedWallet         string // file path, or a note that WALLET env is set
+
+	// Module explorer state
+	moduleSelector       *components.ListSelectorComponent
+	moduleActionSelector *components.ListSelectorComponent
+	moduleEntries        []moduleEntry
+	selectedModule       *moduleEntry
+	moduleStatus         map[string]string // txID -> last fetched on-chain status
+	moduleActionNotice   string
+
+	// Process dashboard state
+	processSelector       *components.ListSelectorComponent
+	processActionSelector *components.ListSelectorComponent
+	processActionInput    *components.TextInputComponent
+	processEntries        []registry.Process
+	selectedProcess       *registry.Process
+	processLogs           map[string]string // process ID -> last fetched output
+	processActionNotice   string
+
 	// Init wizard component
 	initWizard *components.InitWizardComponent
 
@@ -119,6 +160,7 @@ type Model struct {
 	buildResult *BuildResult
 	luaUtilsResult *LuaUtilsResult
 	uploadResult *UploadResult
+	optimizeSizes string // before/after sizes reported by the OptimizeWasm step
 	program     *tea.Program
 }
 
@@ -127,6 +169,7 @@ type BuildFlow struct {
 	BuildType    string
 	SubType      string
 	Entrypoint   string
+	Entrypoints  []string // All selected entrypoints; multi-process workspaces build each
 	OutputDir    string
 	Config       *config.Config
 	ConfigEdited bool
@@ -138,6 +181,8 @@ type BuildResult struct {
 	Success bool
 	Error   error
 	Flow    *BuildFlow
+	OptimizeSizes string // e.g. "(2.1 MB → 1.4 MB)" when the optimize step ran
+	Cancelled bool // The user cancelled the build rather than it failing
 }
 
 // LuaUtilsFlow represents the lua-utils configuration flow
@@ -165,6 +210,7 @@ type UploadFlow struct {
 	Balance    string
 	EstimatedCost string
 	BalanceCheckError string  // Store balance check error for display
+	BudgetWinc int64 // Project max_upload_cost resolved to winc (0 = no budget)
 }
 
 // UploadResult holds the result of an upload operation
@@ -192,10 +238,18 @@ type LuaUtilsCompleteMsg struct{ Result *LuaUtilsResult }
 type UploadCompleteMsg struct{ Result *UploadResult }
 type TickMsg struct{}
 
+// BalanceCheckMsg carries the result of the async wallet balance check
+type BalanceCheckMsg struct {
+	Balance       string
+	EstimatedCost string
+	BudgetWinc    int64 // Project max_upload_cost resolved to winc (0 = no budget)
+	Err           string
+}
+
 // NewModel creates a new modernized TUI model
 func NewModel(ctx context.Context) *Model {
 	// Initialize components
-	keyMap := components.DefaultKeyMap()
+	keyMap := components.UserKeyMap()
 	helpModel := help.New()
 
 	// Create model with initial size
@@ -204,6 +258,8 @@ func NewModel(ctx context.Context) *Model {
 		flow:         &BuildFlow{},
 		luaUtilsFlow: &LuaUtilsFlow{},
 		uploadFlow:   &UploadFlow{},
+		moduleStatus: map[string]string{},
+		processLogs:  map[string]string{},
 		ctx:          ctx,
 		keyMap:       keyMap,
 		help:         helpModel,
@@ -220,6 +276,11 @@ func NewModel(ctx context.Context) *Model {
 	model.buildSelector = components.CreateBuildTypeSelector(panelWidth, panelHeight)
 	model.progress = components.NewProgressComponent(panelWidth, panelHeight)
 
+	// Seed step ETAs from previous builds of this project
+	if cwd, err := os.Getwd(); err == nil {
+		model.progress.SetStepEstimates(components.LoadStepTimings(cwd))
+	}
+
 	return model
 }
 
@@ -246,6 +307,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Global key bindings
 		switch {
 		case key.Matches(msg, m.keyMap.Quit):
+			// Stop any in-flight build before tearing the program down, so
+			// the Docker build does not keep running after the TUI exits
+			if m.buildCancel != nil {
+				m.buildCancel()
+			}
 			return m, tea.Quit
 		case key.Matches(msg, m.keyMap.Help):
 			// Toggle help - could be implemented later
@@ -284,6 +350,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateLuaUtilsRunning(msg)
 		case ViewLuaUtilsSuccess, ViewLuaUtilsError:
 			return m.updateLuaUtilsResult(msg)
+		case ViewUploadFastPath:
+			return m.updateUploadFastPath(msg)
 		case ViewUploadWasmSelection:
 			return m.updateUploadWasmSelection(msg)
 		case ViewUploadConfigSelection:
@@ -302,6 +370,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateUploadRunning(msg)
 		case ViewUploadSuccess, ViewUploadError:
 			return m.updateUploadResult(msg)
+		case ViewModuleExplorer:
+			return m.updateModuleExplorer(msg)
+		case ViewModuleActions:
+			return m.updateModuleActions(msg)
+		case ViewProcessExplorer:
+			return m.updateProcessExplorer(msg)
+		case ViewProcessActions:
+			return m.updateProcessActions(msg)
+		case ViewProcessMessage:
+			return m.updateProcessMessage(msg)
 		}
 
 	case BuildStepStartMsg:
@@ -346,8 +424,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case BuildCompleteMsg:
 		m.buildResult = msg.Result
+		m.buildCancel = nil
 		if msg.Result.Success {
 			m.state = ViewBuildSuccess
+
+			// Remember step durations so the next run can show ETAs
+			if m.progress != nil {
+				if cwd, err := os.Getwd(); err == nil {
+					components.SaveStepTimings(cwd, m.progress.StepDurations())
+				}
+			}
 		} else {
 			m.state = ViewBuildError
 		}
@@ -361,6 +447,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			0, // Height not used by content methods
 		)
 
+		// A cancelled build is not a failure; say so instead of showing the
+		// context error as if the build had broken
+		if msg.Result.Cancelled {
+			m.result.SetMessage("Build cancelled")
+			m.result.SetDetails("⚠️  Build cancelled by user\n\nNo output files were written.")
+		}
+
 		return m, nil
 
 	case LuaUtilsCompleteMsg:
@@ -385,6 +478,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.uploadResult = msg.Result
 		if msg.Result.Success {
 			m.state = ViewUploadSuccess
+
+			// Remember step durations so the next run can show ETAs
+			if m.uploadProgress != nil {
+				if cwd, err := os.Getwd(); err == nil {
+					components.SaveStepTimings(cwd, m.uploadProgress.StepDurations())
+				}
+			}
 		} else {
 			m.state = ViewUploadError
 		}
@@ -399,6 +499,47 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, nil
 
+	case BalanceCheckMsg:
+		// Ignore late results after the user cancelled the check
+		if !m.balanceChecking {
+			return m, nil
+		}
+		m.balanceChecking = false
+		m.balanceCheckCancel = nil
+		m.uploadFlow.Balance = msg.Balance
+		m.uploadFlow.EstimatedCost = msg.EstimatedCost
+		m.uploadFlow.BudgetWinc = msg.BudgetWinc
+		m.uploadFlow.BalanceCheckError = msg.Err
+
+		// Rebuild the confirmation selector with the new balance info
+		m.uploadConfirmSelector = nil
+		return m, nil
+
+	case moduleStatusMsg:
+		// Record the fetched on-chain status and refresh whichever module
+		// view is showing it
+		m.moduleStatus[msg.ID] = msg.Status
+		if m.state == ViewModuleExplorer {
+			m.buildModuleSelector()
+		}
+		if m.state == ViewModuleActions {
+			m.moduleActionNotice = "Status: " + msg.Status
+		}
+		return m, nil
+
+	case processLogsMsg:
+		// Record the fetched output and refresh whichever process view is
+		// showing it
+		if msg.Err != nil {
+			m.processLogs[msg.ProcessID] = fmt.Sprintf("Could not fetch results: %v", msg.Err)
+		} else {
+			m.processLogs[msg.ProcessID] = msg.Logs
+		}
+		if m.state == ViewProcessActions {
+			m.processActionNotice = "Results refreshed"
+		}
+		return m, nil
+
 	case TickMsg:
 		// Update progress animations during build
 		if m.state == ViewBuildRunning && m.progress != nil {
@@ -408,6 +549,13 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return TickMsg{}
 			})
 		}
+		// Keep upload spinners and ETA bars moving too
+		if m.state == ViewUploadRunning && m.uploadProgress != nil {
+			m.uploadProgress.UpdateAnimations()
+			return m, tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
+				return TickMsg{}
+			})
+		}
 	}
 
 	return m, tea.Batch(cmds...)
@@ -454,6 +602,8 @@ func (m *Model) View() string {
 		content = m.viewLuaUtilsRunning()
 	case ViewLuaUtilsSuccess, ViewLuaUtilsError:
 		content = m.viewLuaUtilsResult()
+	case ViewUploadFastPath:
+		content = m.viewUploadFastPath()
 	case ViewUploadWasmSelection:
 		content = m.viewUploadWasmSelection()
 	case ViewUploadConfigSelection:
@@ -472,6 +622,16 @@ func (m *Model) View() string {
 		content = m.viewUploadRunning()
 	case ViewUploadSuccess, ViewUploadError:
 		content = m.viewUploadResult()
+	case ViewModuleExplorer:
+		content = m.viewModuleExplorer()
+	case ViewModuleActions:
+		content = m.viewModuleActions()
+	case ViewProcessExplorer:
+		content = m.viewProcessExplorer()
+	case ViewProcessActions:
+		content = m.viewProcessActions()
+	case ViewProcessMessage:
+		content = m.viewProcessMessage()
 	}
 
 	// Create controls/help with proper width
@@ -646,6 +806,9 @@ func (m *Model) getViewTitle() string {
 	case ViewBuildSuccess:
 		return "Build Successful!"
 	case ViewBuildError:
+		if m.buildResult != nil && m.buildResult.Cancelled {
+			return "Build Cancelled"
+		}
 		return "Build Failed"
 	case ViewLuaUtilsSelection:
 		return "Select Lua Utils Command"
@@ -659,6 +822,8 @@ func (m *Model) getViewTitle() string {
 		return "Bundle Successful!"
 	case ViewLuaUtilsError:
 		return "Bundle Failed"
+	case ViewUploadFastPath:
+		return "Upload Module"
 	case ViewUploadWasmSelection:
 		return "Select WASM File"
 	case ViewUploadConfigSelection:
@@ -679,6 +844,16 @@ func (m *Model) getViewTitle() string {
 		return "Upload Successful!"
 	case ViewUploadError:
 		return "Upload Failed"
+	case ViewModuleExplorer:
+		return "Modules"
+	case ViewModuleActions:
+		return "Module Actions"
+	case ViewProcessExplorer:
+		return "Processes"
+	case ViewProcessActions:
+		return "Process Actions"
+	case ViewProcessMessage:
+		return "Quick Message"
 	}
 	return "Harlequin"
 }
@@ -693,17 +868,17 @@ func (m *Model) viewCommandSelection() string {
 
 	// Right panel with description
 	selected := m.commandSelector.GetSelected()
-	description := "Welcome to Harlequin! Choose a command to get started."
+	description := i18n.T("Welcome to Harlequin! Choose a command to get started.")
 	if selected != nil {
 		switch selected.Value() {
 		case "init":
-			description = "Create a new AO process project from template.\n\nThis will guide you through:\n• Project name selection\n• Template language choice (Lua, C, Rust, AssemblyScript)\n• Author and GitHub information\n• Project directory setup\n\nAvailable templates:\n• Lua - With C trampoline and LuaRocks\n• C - With Conan and CMake\n• Rust - With Cargo and wasm-pack\n• AssemblyScript - With custom JSON handling\n\nEach template includes comprehensive documentation, testing, and build systems."
+			description = i18n.T("Create a new AO process project from template.\n\nThis will guide you through:\n• Project name selection\n• Template language choice (Lua, C, Rust, AssemblyScript)\n• Author and GitHub information\n• Project directory setup\n\nAvailable templates:\n• Lua - With C trampoline and LuaRocks\n• C - With Conan and CMake\n• Rust - With Cargo and wasm-pack\n• AssemblyScript - With custom JSON handling\n\nEach template includes comprehensive documentation, testing, and build systems.")
 		case "build":
-			description = "Interactive project building with guided configuration.\n\nThis will take you through:\n• Build type selection\n• Entrypoint file selection\n• Output directory configuration\n• Build configuration review\n• Actual build process\n\nThe TUI will guide you step-by-step through the entire build process with helpful descriptions and validation."
+			description = i18n.T("Interactive project building with guided configuration.\n\nThis will take you through:\n• Build type selection\n• Entrypoint file selection\n• Output directory configuration\n• Build configuration review\n• Actual build process\n\nThe TUI will guide you step-by-step through the entire build process with helpful descriptions and validation.")
 		case "upload-module":
-			description = "Upload built WASM modules to Arweave with comprehensive metadata.\n\nThis will guide you through:\n• WASM file selection\n• Configuration file selection\n• Wallet file selection\n• Version and git hash configuration\n• Upload mode selection (dry run vs actual)\n\nFeatures:\n• Automatic WASM metadata extraction\n• JSON export analysis\n• Comprehensive tagging\n• Progress tracking\n• Dry run validation"
+			description = i18n.T("Upload built WASM modules to Arweave with comprehensive metadata.\n\nThis will guide you through:\n• WASM file selection\n• Configuration file selection\n• Wallet file selection\n• Version and git hash configuration\n• Upload mode selection (dry run vs actual)\n\nFeatures:\n• Automatic WASM metadata extraction\n• JSON export analysis\n• Comprehensive tagging\n• Progress tracking\n• Dry run validation")
 		case "lua-utils":
-			description = "Lua utilities for bundling and processing Lua files.\n\nCurrently available:\n• Bundle - Combine multiple Lua files into a single executable\n\nThe bundle command will:\n• Analyze require() statements in your main Lua file\n• Recursively resolve all dependencies\n• Create a single bundled file with all modules\n• Handle circular dependencies gracefully"
+			description = i18n.T("Lua utilities for bundling and processing Lua files.\n\nCurrently available:\n• Bundle - Combine multiple Lua files into a single executable\n\nThe bundle command will:\n• Analyze require() statements in your main Lua file\n• Recursively resolve all dependencies\n• Create a single bundled file with all modules\n• Handle circular dependencies gracefully")
 		default:
 			description = selected.Description()
 		}
@@ -729,7 +904,7 @@ func (m *Model) viewBuildTypeSelection() string {
 
 	// Right panel with description
 	selected := m.buildSelector.GetSelected()
-	description := "Select a build configuration type to continue."
+	description := i18n.T("Select a build configuration type to continue.")
 	if selected != nil {
 		description = selected.Description()
 	}
@@ -968,7 +1143,7 @@ func (m *Model) createControls() string {
 			controls = []string{"↑/↓ Navigate", "←/→ Edit Values", "Tab Buttons", "Esc Back", "q Quit"}
 		}
 	case ViewBuildRunning:
-		controls = []string{"Please wait...", "q Quit"}
+		controls = []string{"Esc Cancel Build", "q Quit"}
 	case ViewBuildSuccess, ViewBuildError:
 		controls = []string{"Enter Exit", "q Quit"}
 	case ViewLuaUtilsSelection:
@@ -985,6 +1160,8 @@ func (m *Model) createControls() string {
 		controls = []string{"Please wait...", "q Quit"}
 	case ViewLuaUtilsSuccess, ViewLuaUtilsError:
 		controls = []string{"Enter Exit", "q Quit"}
+	case ViewUploadFastPath:
+		controls = []string{"↑/↓ Navigate", "Enter Select", "Esc Back", "q Quit"}
 	case ViewUploadWasmSelection:
 		if m.useUploadWasmFilePicker {
 			controls = []string{"↑/↓ Navigate", "→ Enter Directory", "Enter Select", "l Auto-discover", "Esc Back", "q Quit"}
@@ -1069,13 +1246,38 @@ func (m *Model) updateCommandSelection(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.state = ViewBuildTypeSelection
 				return m, nil
 			case "upload-module":
-				// Go to upload WASM selection
+				// Offer the fast path when settings are resolvable from
+				// the project; otherwise walk the file pickers
+				if m.detectUploadSettings() {
+					m.state = ViewUploadFastPath
+					return m, nil
+				}
 				m.state = ViewUploadWasmSelection
 				return m, nil
 			case "lua-utils":
 				// Go to lua-utils selection
 				m.state = ViewLuaUtilsSelection
 				return m, nil
+			case "modules":
+				// Reload the lockfile each time the explorer opens
+				m.buildModuleSelector()
+				m.state = ViewModuleExplorer
+				return m, nil
+			case "processes":
+				// Reload the registry each time the dashboard opens
+				m.buildProcessSelector()
+				m.state = ViewProcessExplorer
+				return m, nil
+			default:
+				// External plugins run outside the TUI
+				if name, ok := strings.CutPrefix(selected.Value(), "plugin:"); ok {
+					if p, found := plugin.Find(name); found {
+						pluginCmd := exec.Command(p.Path)
+						return m, tea.ExecProcess(pluginCmd, func(error) tea.Msg {
+							return tea.QuitMsg{}
+						})
+					}
+				}
 			}
 		}
 	}
@@ -1122,6 +1324,7 @@ func (m *Model) updateEntrypointSelection(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	if key.Matches(msg, m.keyMap.Enter) {
 		var selectedFile string
+		var selectedFiles []string
 
 		if m.useFilePicker {
 			if m.filePicker != nil && m.filePicker.HasSelection() {
@@ -1132,11 +1335,20 @@ func (m *Model) updateEntrypointSelection(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				if selected := m.fileSelector.GetSelected(); selected != nil {
 					selectedFile = selected.Value()
 				}
+				// Space-toggled items build as multiple entrypoints
+				if choices := m.fileSelector.GetChoices(); len(choices) > 1 {
+					selectedFiles = choices
+					selectedFile = choices[0]
+				}
 			}
 		}
 
 		if selectedFile != "" && selectedFile != "No Lua files found" {
 			m.flow.Entrypoint = selectedFile
+			m.flow.Entrypoints = selectedFiles
+			if cwd, err := os.Getwd(); err == nil {
+				components.SaveLastEntrypoint(cwd, selectedFile)
+			}
 			m.state = ViewOutputDirectory
 			return m, nil
 		}
@@ -1174,8 +1386,11 @@ func (m *Model) updateOutputDirectory(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if value != "" {
 				m.flow.OutputDir = value
 
-				// Load or create config
+				// Load or create config, then apply the selected AOS flavour
 				m.flow.Config = m.loadOrCreateConfig()
+				if m.flow.BuildType != "" {
+					m.flow.Config.ApplyFlavour(m.flow.BuildType)
+				}
 				m.state = ViewConfigReview
 				return m, nil
 			}
@@ -1202,7 +1417,9 @@ func (m *Model) updateConfigReview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				case "use":
 					// Proceed with current config - go to build
 					m.state = ViewBuildRunning
-					go m.runBuild() // Run build in background
+					buildCtx, cancel := context.WithCancel(m.ctx)
+					m.buildCancel = cancel
+					go m.runBuild(buildCtx) // Run build in background
 					// Start progress animations
 					return m, tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
 						return TickMsg{}
@@ -1264,6 +1481,12 @@ func (m *Model) handleBack() (tea.Model, tea.Cmd) {
 	switch m.state {
 	case ViewInitWizard:
 		m.state = ViewCommandSelection
+	case ViewBuildRunning:
+		// Esc during a build cancels it; the cancelled result arrives as a
+		// BuildCompleteMsg like any other outcome
+		if m.buildCancel != nil {
+			m.buildCancel()
+		}
 	case ViewBuildTypeSelection:
 		m.state = ViewCommandSelection
 	case ViewEntrypointSelection:
@@ -1280,6 +1503,8 @@ func (m *Model) handleBack() (tea.Model, tea.Cmd) {
 		m.state = ViewLuaUtilsSelection
 	case ViewLuaUtilsOutput:
 		m.state = ViewLuaUtilsEntrypoint
+	case ViewUploadFastPath:
+		m.state = ViewCommandSelection
 	case ViewUploadWasmSelection:
 		m.state = ViewCommandSelection
 	case ViewUploadConfigSelection:
@@ -1294,6 +1519,16 @@ func (m *Model) handleBack() (tea.Model, tea.Cmd) {
 		m.state = ViewUploadVersion
 	case ViewUploadConfirmation:
 		m.state = ViewUploadDryRun
+	case ViewModuleExplorer:
+		m.state = ViewCommandSelection
+	case ViewModuleActions:
+		m.state = ViewModuleExplorer
+	case ViewProcessExplorer:
+		m.state = ViewCommandSelection
+	case ViewProcessActions:
+		m.state = ViewProcessExplorer
+	case ViewProcessMessage:
+		m.state = ViewProcessActions
 	}
 
 	return m, nil
@@ -1342,15 +1577,19 @@ func (m *Model) saveConfigFromForm() error {
 func (m *Model) startBuild() (tea.Model, tea.Cmd) {
 	m.state = ViewBuildRunning
 
+	// Run the build against a cancellable child context so esc can stop it
+	buildCtx, cancel := context.WithCancel(m.ctx)
+	m.buildCancel = cancel
+
 	// Start the build in a goroutine
 	return m, func() tea.Msg {
-		go m.runBuild()
+		go m.runBuild(buildCtx)
 		return nil
 	}
 }
 
 // runBuild executes the actual build process
-func (m *Model) runBuild() {
+func (m *Model) runBuild(ctx context.Context) {
 	debug.Printf("Starting build process")
 	debug.Printf("Build config: %+v", m.flow)
 
@@ -1358,7 +1597,7 @@ func (m *Model) runBuild() {
 	success := true
 
 	// Execute the build using AOSBuilder directly
-	buildErr = m.executeRealBuild()
+	buildErr = m.executeRealBuild(ctx)
 	if buildErr != nil {
 		debug.Printf("Build failed: %v", buildErr)
 		success = false
@@ -1371,6 +1610,8 @@ func (m *Model) runBuild() {
 		Success: success,
 		Error:   buildErr,
 		Flow:    m.flow,
+		OptimizeSizes: m.optimizeSizes,
+		Cancelled: errors.Is(buildErr, context.Canceled),
 	}
 
 	if m.program != nil {
@@ -1379,7 +1620,7 @@ func (m *Model) runBuild() {
 }
 
 // executeRealBuild runs the actual build process with progress updates
-func (m *Model) executeRealBuild() error {
+func (m *Model) executeRealBuild(ctx context.Context) error {
 	debug.Printf("Executing real build for entrypoint: %s", m.flow.Entrypoint)
 
 	// Debug: Print build configuration
@@ -1388,14 +1629,36 @@ func (m *Model) executeRealBuild() error {
 	debug.Printf("  OutputDir: %s", m.flow.OutputDir)
 	debug.Printf("  Config: %+v", m.flow.Config)
 
-	// Create AOSBuilder and execute the complete build process
-	builder := builders.NewAOSBuilder(builders.AOSBuilderParams{
-		Config:     m.flow.Config,
-		Entrypoint: m.flow.Entrypoint,
-		OutputDir:  m.flow.OutputDir,
-		Callbacks:  builders.NoOpCallbacks(), // Silent for now
-	})
-	debug.Printf("AOSBuilder created successfully")
+	// Capture the optimize step sizes for the result screen; other steps stay silent
+	callbacks := *builders.NoOpCallbacks()
+	callbacks.OnOptimizeWasm = func(ctx context.Context, info builders.BuildStepInfo) {
+		if info.Success {
+			m.optimizeSizes = builders.FormatOptimizationSizes(info)
+		}
+	}
+
+	// Build every selected entrypoint; multi-process selections get their own
+	// output subdirectory so artifacts never collide
+	entrypoints := m.flow.Entrypoints
+	if len(entrypoints) == 0 {
+		entrypoints = []string{m.flow.Entrypoint}
+	}
+
+	builders_ := make([]*builders.AOSBuilder, len(entrypoints))
+	for i, entrypoint := range entrypoints {
+		outputDir := m.flow.OutputDir
+		if len(entrypoints) > 1 {
+			base := strings.TrimSuffix(filepath.Base(entrypoint), filepath.Ext(entrypoint))
+			outputDir = filepath.Join(outputDir, base)
+		}
+		builders_[i] = builders.NewAOSBuilder(builders.AOSBuilderParams{
+			Config:     m.flow.Config,
+			Entrypoint: entrypoint,
+			OutputDir:  outputDir,
+			Callbacks:  &callbacks,
+		})
+	}
+	debug.Printf("Created %d AOSBuilder(s)", len(builders_))
 
 	// Define build steps to match the progress component expectations
 	steps := []struct {
@@ -1416,13 +1679,15 @@ func (m *Model) executeRealBuild() error {
 		}},
 		{"Build WASM", func() error {
 			debug.Printf("Step: Build WASM - executing full build process")
-			err := builder.Build(m.ctx)
-			if err != nil {
-				debug.Printf("AOSBuilder.Build() failed: %v", err)
-			} else {
-				debug.Printf("AOSBuilder.Build() completed successfully")
+			for i, builder := range builders_ {
+				debug.Printf("Building entrypoint %s", entrypoints[i])
+				if err := builder.Build(ctx); err != nil {
+					debug.Printf("AOSBuilder.Build() failed for %s: %v", entrypoints[i], err)
+					return fmt.Errorf("build failed for %s: %w", entrypoints[i], err)
+				}
 			}
-			return err
+			debug.Printf("AOSBuilder.Build() completed successfully")
+			return nil
 		}},
 		{"Copy Outputs", func() error {
 			debug.Printf("Step: Copy Outputs")
@@ -1475,11 +1740,11 @@ func (m *Model) viewLuaUtilsSelection() string {
 
 	// Right panel with description
 	selected := m.luaUtilsSelector.GetSelected()
-	description := "Select a Lua utility command to run."
+	description := i18n.T("Select a Lua utility command to run.")
 	if selected != nil {
 		switch selected.Value() {
 		case "bundle":
-			description = "Bundle multiple Lua files into a single executable.\n\nThis command will:\n• Analyze your main Lua file for require() statements\n• Recursively resolve all dependencies\n• Handle circular dependencies gracefully\n• Create a self-contained Lua script\n• Preserve module structure and functionality\n\nThe bundled output includes all required modules as local functions with package loading mappings for require() compatibility."
+			description = i18n.T("Bundle multiple Lua files into a single executable.\n\nThis command will:\n• Analyze your main Lua file for require() statements\n• Recursively resolve all dependencies\n• Handle circular dependencies gracefully\n• Create a self-contained Lua script\n• Preserve module structure and functionality\n\nThe bundled output includes all required modules as local functions with package loading mappings for require() compatibility.")
 		default:
 			description = selected.Description()
 		}
@@ -1794,6 +2059,104 @@ func (m *Model) runLuaUtilsBundle() {
 
 // Upload Module update handlers
 
+// detectUploadSettings checks whether the upload inputs are resolvable from
+// the project without any file pickers: a .harlequin.yaml, a wallet from the
+// WALLET/WALLET_PATH env vars, and a discoverable WASM file
+func (m *Model) detectUploadSettings() bool {
+	if _, err := os.Stat(".harlequin.yaml"); err != nil {
+		return false
+	}
+
+	walletFile := ""
+	walletDisplay := ""
+	if os.Getenv("WALLET") != "" {
+		walletDisplay = "WALLET environment variable"
+	} else if path := os.Getenv("WALLET_PATH"); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return false
+		}
+		walletFile = path
+		walletDisplay = path
+	} else {
+		return false
+	}
+
+	wasmFiles, err := components.FindWasmFilesQuick(".")
+	if err != nil || len(wasmFiles) == 0 {
+		return false
+	}
+
+	// Prefer the conventional build output when several WASM files exist
+	wasmFile := wasmFiles[0]
+	for _, file := range wasmFiles {
+		if file == "dist/process.wasm" || strings.HasSuffix(file, "/dist/process.wasm") {
+			wasmFile = file
+			break
+		}
+	}
+
+	m.detectedWasm = wasmFile
+	m.detectedConfig = ".harlequin.yaml"
+	m.detectedWallet = walletDisplay
+	m.uploadFlow.WalletFile = walletFile
+	return true
+}
+
+// updateUploadFastPath handles the detected-settings fast path selection
+func (m *Model) updateUploadFastPath(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.uploadFastPathSelector == nil {
+		return m, nil
+	}
+
+	model, cmd := m.uploadFastPathSelector.Update(tea.Msg(msg))
+	if newSelector, ok := model.(*components.ListSelectorComponent); ok {
+		m.uploadFastPathSelector = newSelector
+	}
+
+	// Check if enter was pressed after updating the component
+	if key.Matches(msg, m.keyMap.Enter) {
+		if selected := m.uploadFastPathSelector.GetSelected(); selected != nil {
+			switch selected.Value() {
+			case "detected":
+				// Adopt the detected settings and jump to version entry
+				m.uploadFlow.WasmFile = m.detectedWasm
+				m.uploadFlow.ConfigFile = m.detectedConfig
+				m.state = ViewUploadVersion
+				return m, nil
+			case "manual":
+				m.state = ViewUploadWasmSelection
+				return m, nil
+			}
+		}
+	}
+
+	return m, cmd
+}
+
+// viewUploadFastPath renders the detected-settings fast path view
+func (m *Model) viewUploadFastPath() string {
+	if m.uploadFastPathSelector == nil {
+		actualPanelWidth := m.getPanelWidth() - 2
+		m.uploadFastPathSelector = components.CreateUploadFastPathSelector(actualPanelWidth, m.getPanelHeight())
+	}
+
+	leftPanel := m.uploadFastPathSelector.View()
+
+	description := fmt.Sprintf("Settings resolved from this project:\n\nWASM File: %s\nConfig File: %s\nWallet: %s\n\nUse them to jump straight to version entry, or configure each value manually.",
+		m.detectedWasm,
+		m.detectedConfig,
+		m.detectedWallet,
+	)
+	rightPanel := components.CreateDescriptionPanel(
+		"Detected Settings",
+		description,
+		m.getPanelWidth()-2,
+		0,
+	)
+
+	return m.createTwoPanelLayout(leftPanel, rightPanel)
+}
+
 // updateUploadWasmSelection handles WASM file selection
 func (m *Model) updateUploadWasmSelection(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -1929,6 +2292,18 @@ func (m *Model) updateUploadWalletSelection(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 			m.uploadWalletSelector = nil // Reset to reinitialize
 			return m, nil
 		}
+	case "g":
+		// Generate a fresh wallet file in the working directory and select it
+		walletFile, err := generateWalletFile()
+		if err != nil {
+			m.uploadResult = &UploadResult{Success: false, Error: err, Flow: m.uploadFlow}
+			m.result = components.NewResultComponent(false, m.uploadResult, 0, 0)
+			m.state = ViewUploadError
+			return m, nil
+		}
+		m.uploadFlow.WalletFile = walletFile
+		m.state = ViewUploadVersion
+		return m, nil
 	}
 
 	if key.Matches(msg, m.keyMap.Enter) {
@@ -1971,6 +2346,32 @@ func (m *Model) updateUploadWalletSelection(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 	return m, nil
 }
 
+// generateWalletFile creates a fresh wallet JWK in the working directory
+// under a name that does not clobber an existing file
+func generateWalletFile() (string, error) {
+	jwk, err := wallet.GenerateJWK()
+	if err != nil {
+		return "", err
+	}
+	content, err := json.Marshal(jwk)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode wallet: %w", err)
+	}
+
+	walletFile := "wallet.json"
+	for i := 1; ; i++ {
+		if _, err := os.Stat(walletFile); os.IsNotExist(err) {
+			break
+		}
+		walletFile = fmt.Sprintf("wallet-%d.json", i)
+	}
+
+	if err := os.WriteFile(walletFile, content, 0600); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", walletFile, err)
+	}
+	return walletFile, nil
+}
+
 // updateUploadVersion handles version input
 func (m *Model) updateUploadVersion(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Pass all messages directly to the input first
@@ -2037,22 +2438,16 @@ func (m *Model) updateUploadDryRun(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if selected := m.uploadDryRunSelector.GetSelected(); selected != nil {
 				m.uploadFlow.DryRun = selected.Value() == "true"
 
-								// If user selected actual upload, check balance before proceeding
-				if !m.uploadFlow.DryRun {
-					err := m.checkBalanceAndCost()
-					if err != nil {
-						debug.Printf("Balance check failed: %v", err)
-						// Store the error for display in confirmation screen
-						m.uploadFlow.BalanceCheckError = err.Error()
-					} else {
-						// Clear any previous error
-						m.uploadFlow.BalanceCheckError = ""
-					}
-				}
-
 				// Reset confirmation selector to rebuild with new balance info
 				m.uploadConfirmSelector = nil
 				m.state = ViewUploadConfirmation
+
+				// If user selected actual upload, check balance in the
+				// background so slow networks don't freeze the UI
+				if !m.uploadFlow.DryRun {
+					m.uploadFlow.BalanceCheckError = ""
+					return m, m.checkBalanceAndCostCmd()
+				}
 				return m, nil
 			}
 		}
@@ -2065,6 +2460,20 @@ func (m *Model) updateUploadDryRun(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // updateUploadConfirmation handles upload confirmation
 func (m *Model) updateUploadConfirmation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// While the balance check is in flight only allow cancelling it
+	if m.balanceChecking {
+		if key.Matches(msg, m.keyMap.Back) {
+			if m.balanceCheckCancel != nil {
+				m.balanceCheckCancel()
+				m.balanceCheckCancel = nil
+			}
+			m.balanceChecking = false
+			m.uploadFlow.BalanceCheckError = "Balance check cancelled"
+			m.uploadConfirmSelector = nil
+		}
+		return m, nil
+	}
+
 	// Pass all messages directly to the selector first
 	if m.uploadConfirmSelector != nil {
 		model, cmd := m.uploadConfirmSelector.Update(tea.Msg(msg))
@@ -2084,10 +2493,20 @@ func (m *Model) updateUploadConfirmation(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			panelHeight := m.getPanelHeight()
 			m.uploadProgress = components.NewProgressComponent(panelWidth, panelHeight)
 
+			// Seed step ETAs from previous uploads of this project
+			if cwd, err := os.Getwd(); err == nil {
+				m.uploadProgress.SetStepEstimates(components.LoadStepTimings(cwd))
+			}
+
 			go m.runUpload() // Run upload in background
 			return m, tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
 				return TickMsg{}
 			})
+				case "retry":
+					// Run the balance check again in the background
+					m.uploadFlow.BalanceCheckError = ""
+					m.uploadConfirmSelector = nil
+					return m, m.checkBalanceAndCostCmd()
 				case "insufficient":
 					// Do nothing - insufficient balance prevents upload
 					// User can only cancel to go back and fix the issue
@@ -2244,15 +2663,32 @@ func (m *Model) extractDataItemID(output string) string {
 	return ""
 }
 
+// checkBalanceAndCostCmd runs the balance check as a background tea.Cmd so
+// slow networks never block the UI; the result arrives as a BalanceCheckMsg
+func (m *Model) checkBalanceAndCostCmd() tea.Cmd {
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.balanceChecking = true
+	m.balanceCheckCancel = cancel
+
+	// Capture inputs now so the goroutine never touches the model
+	wasmFile := m.uploadFlow.WasmFile
+	walletFile := m.uploadFlow.WalletFile
+	configFile := m.uploadFlow.ConfigFile
+
+	return func() tea.Msg {
+		return checkBalanceAndCost(ctx, wasmFile, walletFile, configFile)
+	}
+}
+
 // checkBalanceAndCost checks wallet balance and estimates upload cost
-func (m *Model) checkBalanceAndCost() error {
+func checkBalanceAndCost(ctx context.Context, wasmFile, walletFile, configFile string) BalanceCheckMsg {
 	debug.Printf("Checking wallet balance and upload cost")
 
 	// We need to load the wallet and WASM file to check balance and cost
 	// Read WASM file to get size
-	wasmData, err := os.ReadFile(m.uploadFlow.WasmFile)
+	wasmData, err := os.ReadFile(wasmFile)
 	if err != nil {
-		return fmt.Errorf("failed to read WASM file: %w", err)
+		return BalanceCheckMsg{Err: fmt.Sprintf("failed to read WASM file: %v", err)}
 	}
 
 	// Load wallet
@@ -2260,28 +2696,28 @@ func (m *Model) checkBalanceAndCost() error {
 	if os.Getenv("WALLET") != "" {
 		err = json.Unmarshal([]byte(os.Getenv("WALLET")), &jwk)
 		if err != nil {
-			return fmt.Errorf("failed to parse WALLET environment variable: %w", err)
+			return BalanceCheckMsg{Err: fmt.Sprintf("failed to parse WALLET environment variable: %v", err)}
 		}
 	} else {
-		walletContent, err := os.ReadFile(m.uploadFlow.WalletFile)
+		walletContent, err := os.ReadFile(walletFile)
 		if err != nil {
-			return fmt.Errorf("failed to read wallet file %s: %w", m.uploadFlow.WalletFile, err)
+			return BalanceCheckMsg{Err: fmt.Sprintf("failed to read wallet file %s: %v", walletFile, err)}
 		}
 		err = json.Unmarshal(walletContent, &jwk)
 		if err != nil {
-			return fmt.Errorf("failed to parse wallet file: %w", err)
+			return BalanceCheckMsg{Err: fmt.Sprintf("failed to parse wallet file: %v", err)}
 		}
 	}
 
 	// Create signer
 	signer, err := signers.NewArweaveSigner(jwk)
 	if err != nil {
-		return fmt.Errorf("failed to create Arweave signer: %w", err)
+		return BalanceCheckMsg{Err: fmt.Sprintf("failed to create Arweave signer: %v", err)}
 	}
 
 	// Create authenticated client and check balance
 	turboClient := turbo.Authenticated(nil, signer)
-	balance, err := turboClient.GetBalanceForSigner(m.ctx)
+	balance, err := turboClient.GetBalanceForSigner(ctx)
 	if err != nil {
 		// Check if it's a 404 User Not Found error - treat as 0 balance
 		if strings.Contains(err.Error(), "HTTP 404") || strings.Contains(err.Error(), "User Not Found") {
@@ -2292,7 +2728,7 @@ func (m *Model) checkBalanceAndCost() error {
 				Currency: "winston",
 			}
 		} else {
-			return fmt.Errorf("failed to check wallet balance: %w", err)
+			return BalanceCheckMsg{Err: fmt.Sprintf("failed to check wallet balance: %v", err)}
 		}
 	}
 
@@ -2301,7 +2737,7 @@ func (m *Model) checkBalanceAndCost() error {
 	fileSize := int64(len(wasmData))
 	debug.Printf("Requesting upload costs for file size: %d bytes", fileSize)
 
-	uploadCosts, err := unauthenticatedClient.GetUploadCosts(m.ctx, []int64{fileSize})
+	uploadCosts, err := unauthenticatedClient.GetUploadCosts(ctx, []int64{fileSize})
 	if err != nil {
 		debug.Printf("GetUploadCosts API error: %v", err)
 
@@ -2310,28 +2746,46 @@ func (m *Model) checkBalanceAndCost() error {
 			debug.Printf("Known issue: API returned object but expected array - continuing without cost estimate")
 
 			// Set a default estimated cost warning and continue
-			m.uploadFlow.Balance = balance.WinC
-			m.uploadFlow.EstimatedCost = "unknown"
-			m.uploadFlow.BalanceCheckError = "Unable to estimate upload cost due to API format issue. Upload may still proceed."
-
 			debug.Printf("Balance: %s, Estimated cost: unknown (API issue)", balance.WinC)
-			return nil
+			return BalanceCheckMsg{
+				Balance:       balance.WinC,
+				EstimatedCost: "unknown",
+				Err:           "Unable to estimate upload cost due to API format issue. Upload may still proceed.",
+			}
 		}
 
-		return fmt.Errorf("failed to estimate upload cost: %w", err)
+		return BalanceCheckMsg{Err: fmt.Sprintf("failed to estimate upload cost: %v", err)}
 	}
 
 	if len(uploadCosts) == 0 {
-		return fmt.Errorf("no upload cost estimate received")
+		return BalanceCheckMsg{Err: "no upload cost estimate received"}
 	}
 
-	// Store balance and cost in flow
-	m.uploadFlow.Balance = balance.WinC
-	m.uploadFlow.EstimatedCost = uploadCosts[0].Winc
-
 	debug.Printf("Balance: %s, Estimated cost: %s", balance.WinC, uploadCosts[0].Winc)
 
-	return nil
+	return BalanceCheckMsg{
+		Balance:       balance.WinC,
+		EstimatedCost: uploadCosts[0].Winc,
+		BudgetWinc:    resolveUploadBudget(ctx, configFile),
+	}
+}
+
+// resolveUploadBudget reads the project's max_upload_cost budget as winc.
+// Budget problems never block the balance check; they just disable the check.
+func resolveUploadBudget(ctx context.Context, configFile string) int64 {
+	if configFile == "" {
+		return 0
+	}
+	uploadConfig, err := config.ReadUploadConfig(configFile)
+	if err != nil || uploadConfig.MaxUploadCost == "" {
+		return 0
+	}
+	budgetWinc, err := config.ResolveMaxUploadCost(ctx, turbo.DefaultConfig().PaymentURL, uploadConfig.MaxUploadCost)
+	if err != nil {
+		debug.Printf("Failed to resolve max_upload_cost %q: %v", uploadConfig.MaxUploadCost, err)
+		return 0
+	}
+	return budgetWinc
 }
 
 // checkInsufficientBalance checks if wallet has sufficient balance for upload
@@ -2365,6 +2819,19 @@ func (m *Model) checkInsufficientBalance() error {
 	return nil
 }
 
+// uploadBudgetOverage returns the winc amount by which the estimated cost
+// exceeds the project's budget, or 0 when there is no budget or no overage
+func (m *Model) uploadBudgetOverage() int64 {
+	if m.uploadFlow.BudgetWinc <= 0 || m.uploadFlow.EstimatedCost == "" || m.uploadFlow.EstimatedCost == "unknown" {
+		return 0
+	}
+	costInt, err := strconv.ParseInt(m.uploadFlow.EstimatedCost, 10, 64)
+	if err != nil || costInt <= m.uploadFlow.BudgetWinc {
+		return 0
+	}
+	return costInt - m.uploadFlow.BudgetWinc
+}
+
 // winstonToCredits converts Winston Credits to Credits (AR denomination)
 func winstonToCredits(winston string) string {
 	if winston == "" || winston == "0" {
@@ -2575,7 +3042,7 @@ func (m *Model) viewUploadWalletSelection() string {
 			selectedFile = selected.Value()
 		}
 
-		description := "Select the Arweave wallet file.\n\nLooking for key.json, wallet.json, and similar files\n\nPress 'f' to switch to manual file picker"
+		description := "Select the Arweave wallet file.\n\nLooking for key.json, wallet.json, and similar files\n\nPress 'f' to switch to manual file picker\nPress 'g' to generate a fresh wallet"
 		if selectedFile != "" {
 			description = fmt.Sprintf("Selected: %s\n\n%s", selectedFile, description)
 		}
@@ -2671,6 +3138,18 @@ func (m *Model) viewUploadDryRun() string {
 
 // viewUploadConfirmation renders the upload confirmation view
 func (m *Model) viewUploadConfirmation() string {
+	// Show a loading panel while the balance check runs in the background
+	if m.balanceChecking {
+		leftPanel := m.formatUploadPreview()
+		rightPanel := components.CreateDescriptionPanel(
+			"Checking Balance",
+			"⏳ Checking wallet balance and upload cost...\n\nPress esc to cancel and continue without balance information.",
+			m.getPanelWidth()-2,
+			0,
+		)
+		return m.createTwoPanelLayout(leftPanel, rightPanel)
+	}
+
 	// Create confirmation selector if not exists or if balance info changed
 	if m.uploadConfirmSelector == nil {
 		actualPanelWidth := m.getPanelWidth() - 2
@@ -2678,9 +3157,8 @@ func (m *Model) viewUploadConfirmation() string {
 				// Check if we have balance information and if it's sufficient for actual uploads
 		if !m.uploadFlow.DryRun {
 						if m.uploadFlow.BalanceCheckError != "" {
-				// Balance check failed - show error state
-				// Use regular confirmation selector but with error context
-				m.uploadConfirmSelector = components.CreateUploadConfirmationSelector(actualPanelWidth, m.getPanelHeight())
+				// Balance check failed - offer a retry alongside the usual options
+				m.uploadConfirmSelector = components.CreateUploadConfirmationSelectorWithRetry(actualPanelWidth, m.getPanelHeight())
 			} else if m.uploadFlow.Balance != "" && m.uploadFlow.EstimatedCost != "" {
 				// Parse balance and cost to check sufficiency
 				hasSufficientBalance := true
@@ -2688,6 +3166,15 @@ func (m *Model) viewUploadConfirmation() string {
 					hasSufficientBalance = false
 				}
 
+				if overage := m.uploadBudgetOverage(); hasSufficientBalance && overage > 0 {
+					// Over the project budget - make the overage explicit
+					m.uploadConfirmSelector = components.CreateUploadConfirmationSelectorOverBudget(
+						actualPanelWidth,
+						m.getPanelHeight(),
+						formatCreditsDisplay(strconv.FormatInt(m.uploadFlow.BudgetWinc, 10)),
+						formatCreditsDisplay(strconv.FormatInt(overage, 10)),
+					)
+				} else {
 								m.uploadConfirmSelector = components.CreateUploadConfirmationSelectorWithBalance(
 					actualPanelWidth,
 					m.getPanelHeight(),
@@ -2695,6 +3182,7 @@ func (m *Model) viewUploadConfirmation() string {
 					formatCreditsDisplay(m.uploadFlow.Balance),
 					formatCreditsDisplay(m.uploadFlow.EstimatedCost),
 				)
+				}
 			} else {
 				// No balance info available
 				m.uploadConfirmSelector = components.CreateUploadConfirmationSelector(actualPanelWidth, m.getPanelHeight())
@@ -2805,6 +3293,19 @@ Shortfall: %s`, formatCreditsDisplay(shortfallStr))
 				}
 			}
 		}
+
+		// Show the project's cost budget when the config declares one
+		if m.uploadFlow.BudgetWinc > 0 {
+			budgetStatus := "✅ Within budget"
+			if overage := m.uploadBudgetOverage(); overage > 0 {
+				budgetStatus = fmt.Sprintf("⚠️  Over budget by %s", formatCreditsDisplay(strconv.FormatInt(overage, 10)))
+			}
+			preview += fmt.Sprintf(`
+Budget: %s
+Budget Status: %s`,
+				formatCreditsDisplay(strconv.FormatInt(m.uploadFlow.BudgetWinc, 10)),
+				budgetStatus)
+		}
 		}
 	}
 
@@ -2821,8 +3322,14 @@ func (m *Model) updateInitWizard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.initWizard == nil {
 		m.initWizard = components.NewInitWizardComponent()
 
+		// Detect conflicts with existing files before the wizard finishes
+		m.initWizard.TargetDirConflict = func(targetDir string) bool {
+			entries, err := os.ReadDir(targetDir)
+			return err == nil && len(entries) > 0
+		}
+
 		// Set up completion callback
-		m.initWizard.OnComplete = func(projectName, templateLang, authorName, githubUser, targetDir string) {
+		m.initWizard.OnComplete = func(projectName, templateLang, authorName, githubUser, targetDir, conflictMode string, variables map[string]string) {
 			// Create the project using the CLI command to avoid import cycle
 			args := []string{"init", templateLang, "--name", projectName}
 			if authorName != "" {
@@ -2834,6 +3341,12 @@ func (m *Model) updateInitWizard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if targetDir != "" && targetDir != projectName {
 				args = append(args, "--dir", targetDir)
 			}
+			if conflictMode != "" {
+				args = append(args, "--conflict", conflictMode)
+			}
+			for name, value := range variables {
+				args = append(args, "--var", name+"="+value)
+			}
 
 			cmd := exec.Command(os.Args[0], args...)
 			cmd.Stdout = os.Stdout
@@ -2864,8 +3377,14 @@ func (m *Model) viewInitWizard() string {
 	if m.initWizard == nil {
 		m.initWizard = components.NewInitWizardComponent()
 
+		// Detect conflicts with existing files before the wizard finishes
+		m.initWizard.TargetDirConflict = func(targetDir string) bool {
+			entries, err := os.ReadDir(targetDir)
+			return err == nil && len(entries) > 0
+		}
+
 		// Set up completion callback
-		m.initWizard.OnComplete = func(projectName, templateLang, authorName, githubUser, targetDir string) {
+		m.initWizard.OnComplete = func(projectName, templateLang, authorName, githubUser, targetDir, conflictMode string, variables map[string]string) {
 			// Create the project using the CLI command to avoid import cycle
 			args := []string{"init", templateLang, "--name", projectName}
 			if authorName != "" {
@@ -2877,6 +3396,12 @@ func (m *Model) viewInitWizard() string {
 			if targetDir != "" && targetDir != projectName {
 				args = append(args, "--dir", targetDir)
 			}
+			if conflictMode != "" {
+				args = append(args, "--conflict", conflictMode)
+			}
+			for name, value := range variables {
+				args = append(args, "--var", name+"="+value)
+			}
 
 			cmd := exec.Command(os.Args[0], args...)
 			cmd.Stdout = os.Stdout
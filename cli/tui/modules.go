@@ -0,0 +1,350 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/i18n"
+	components "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/tui/components"
+)
+
+// moduleLockFileName mirrors the upload lockfile the upload command maintains;
+// the explorer reads it directly so the TUI needs no dependency on cmd
+const moduleLockFileName = ".harlequin-uploads.json"
+
+// moduleEntry is one recorded module upload from the lockfile
+type moduleEntry struct {
+	TransactionID string            `json:"transaction_id"`
+	UploadedAt    time.Time         `json:"uploaded_at"`
+	SizeBytes     int               `json:"size_bytes"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+// Version returns the module version recorded at upload time
+func (e moduleEntry) Version() string {
+	if v := e.Tags["AO-Module-Version"]; v != "" {
+		return v
+	}
+	return "unknown"
+}
+
+// moduleStatusMsg carries the result of an on-demand gateway status check
+type moduleStatusMsg struct {
+	ID     string
+	Status string
+}
+
+// loadModuleEntries reads the upload lockfile, newest upload first
+func loadModuleEntries() ([]moduleEntry, error) {
+	content, err := os.ReadFile(moduleLockFileName)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", moduleLockFileName, err)
+	}
+
+	var lock struct {
+		Entries []moduleEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", moduleLockFileName, err)
+	}
+
+	// The lockfile appends newest last; the explorer shows newest first
+	entries := make([]moduleEntry, 0, len(lock.Entries))
+	for i := len(lock.Entries) - 1; i >= 0; i-- {
+		entries = append(entries, lock.Entries[i])
+	}
+	return entries, nil
+}
+
+// moduleGateway returns the gateway used for status checks and browser links
+func moduleGateway() string {
+	preset, _ := config.NetworkPreset(config.NetworkMainnet)
+	return preset.Gateway
+}
+
+// checkModuleStatusCmd probes the gateway for the module on demand
+func checkModuleStatusCmd(gateway, id string) tea.Cmd {
+	return func() tea.Msg {
+		client := &http.Client{Timeout: 15 * time.Second}
+		resp, err := client.Head(gateway + "/" + id)
+		if err != nil {
+			return moduleStatusMsg{ID: id, Status: "gateway unreachable"}
+		}
+		resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return moduleStatusMsg{ID: id, Status: "✅ seeded"}
+		case http.StatusNotFound:
+			return moduleStatusMsg{ID: id, Status: "❌ not found"}
+		default:
+			return moduleStatusMsg{ID: id, Status: fmt.Sprintf("HTTP %d", resp.StatusCode)}
+		}
+	}
+}
+
+// formatModuleSize renders a byte count for the explorer columns
+func formatModuleSize(size int) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+// shortModuleID abbreviates a transaction ID for list display
+func shortModuleID(id string) string {
+	if len(id) <= 12 {
+		return id
+	}
+	return id[:8] + "…" + id[len(id)-4:]
+}
+
+// buildModuleSelector lists the lockfile entries with version, txID, date,
+// and size columns
+func (m *Model) buildModuleSelector() {
+	entries, err := loadModuleEntries()
+	m.moduleEntries = entries
+
+	items := make([]components.ListItem, 0, len(entries))
+	if err != nil {
+		items = append(items, components.NewListItem("Could not read lockfile", err.Error(), ""))
+	} else if len(entries) == 0 {
+		items = append(items, components.NewListItem(
+			"No modules recorded",
+			fmt.Sprintf("Upload a module to populate %s", moduleLockFileName), ""))
+	} else {
+		for _, entry := range entries {
+			title := fmt.Sprintf("%-12s %s", entry.Version(), shortModuleID(entry.TransactionID))
+			description := fmt.Sprintf("%s  •  %s",
+				entry.UploadedAt.Format("2006-01-02 15:04"),
+				formatModuleSize(entry.SizeBytes))
+			if status := m.moduleStatus[entry.TransactionID]; status != "" {
+				description += "  •  " + status
+			}
+			items = append(items, components.NewListItem(title, description, entry.TransactionID))
+		}
+	}
+
+	m.moduleSelector = components.NewListSelector(
+		i18n.T("Recorded Modules"), items, m.getPanelWidth()-2, m.getPanelHeight())
+}
+
+// buildModuleActionSelector lists the actions for the selected module
+func (m *Model) buildModuleActionSelector() {
+	items := []components.ListItem{
+		components.NewListItem("Check On-Chain Status", "Probe the gateway for this module", "status"),
+		components.NewListItem("Open In Gateway", "Open the module in the default browser", "open"),
+		components.NewListItem("Copy Transaction ID", "Copy the module ID to the clipboard", "copy"),
+		components.NewListItem("Promote (Deploy)", "Point a release channel at this module", "promote"),
+		components.NewListItem("Rollback Channel", "Repoint a channel at its previous module", "rollback"),
+	}
+	m.moduleActionSelector = components.NewListSelector(
+		i18n.T("Module Actions"), items, m.getPanelWidth()-2, m.getPanelHeight())
+}
+
+// updateModuleExplorer handles keys in the module list view
+func (m *Model) updateModuleExplorer(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.moduleSelector == nil {
+		m.buildModuleSelector()
+	}
+
+	if msg.String() == "s" {
+		// Fetch on-chain status for the highlighted module
+		if selected := m.moduleSelector.GetSelected(); selected != nil && selected.Value() != "" {
+			id := selected.Value()
+			m.moduleStatus[id] = "checking…"
+			m.buildModuleSelector()
+			return m, checkModuleStatusCmd(moduleGateway(), id)
+		}
+	}
+
+	model, cmd := m.moduleSelector.Update(tea.Msg(msg))
+	if newSelector, ok := model.(*components.ListSelectorComponent); ok {
+		m.moduleSelector = newSelector
+	}
+
+	if key.Matches(msg, m.keyMap.Enter) {
+		if selected := m.moduleSelector.GetSelected(); selected != nil && selected.Value() != "" {
+			for i := range m.moduleEntries {
+				if m.moduleEntries[i].TransactionID == selected.Value() {
+					m.selectedModule = &m.moduleEntries[i]
+					break
+				}
+			}
+			m.moduleActionNotice = ""
+			m.buildModuleActionSelector()
+			m.state = ViewModuleActions
+			return m, nil
+		}
+	}
+
+	return m, cmd
+}
+
+// updateModuleActions handles keys in the module action view
+func (m *Model) updateModuleActions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.moduleActionSelector == nil {
+		m.buildModuleActionSelector()
+	}
+
+	model, cmd := m.moduleActionSelector.Update(tea.Msg(msg))
+	if newSelector, ok := model.(*components.ListSelectorComponent); ok {
+		m.moduleActionSelector = newSelector
+	}
+
+	if key.Matches(msg, m.keyMap.Enter) && m.selectedModule != nil {
+		if selected := m.moduleActionSelector.GetSelected(); selected != nil {
+			id := m.selectedModule.TransactionID
+			switch selected.Value() {
+			case "status":
+				m.moduleStatus[id] = "checking…"
+				m.moduleActionNotice = "Checking gateway…"
+				return m, checkModuleStatusCmd(moduleGateway(), id)
+			case "open":
+				if err := openInBrowser(moduleGateway() + "/" + id); err != nil {
+					m.moduleActionNotice = fmt.Sprintf("Could not open browser: %v", err)
+				} else {
+					m.moduleActionNotice = "Opened in browser"
+				}
+				return m, nil
+			case "copy":
+				if err := copyToClipboard(id); err != nil {
+					m.moduleActionNotice = fmt.Sprintf("No clipboard tool found - ID: %s", id)
+				} else {
+					m.moduleActionNotice = "Transaction ID copied"
+				}
+				return m, nil
+			case "promote", "rollback":
+				// Channel pointers need wallet signing; run the CLI command
+				// outside the TUI like external plugins do
+				cliArgs := []string{"modules", selected.Value()}
+				if selected.Value() == "promote" {
+					cliArgs = append(cliArgs, id)
+				}
+				cliCmd := exec.Command(os.Args[0], cliArgs...)
+				return m, tea.ExecProcess(cliCmd, func(error) tea.Msg {
+					return tea.QuitMsg{}
+				})
+			}
+		}
+	}
+
+	return m, cmd
+}
+
+// viewModuleExplorer renders the module list with a detail panel
+func (m *Model) viewModuleExplorer() string {
+	if m.moduleSelector == nil {
+		m.buildModuleSelector()
+	}
+
+	leftPanel := m.moduleSelector.View()
+
+	description := i18n.T("Modules recorded by harlequin upload.\n\nPress enter for actions, s to check on-chain status.")
+	if selected := m.moduleSelector.GetSelected(); selected != nil && selected.Value() != "" {
+		for _, entry := range m.moduleEntries {
+			if entry.TransactionID != selected.Value() {
+				continue
+			}
+			lines := []string{
+				fmt.Sprintf("Version:  %s", entry.Version()),
+				fmt.Sprintf("TX ID:    %s", entry.TransactionID),
+				fmt.Sprintf("Uploaded: %s", entry.UploadedAt.Format(time.RFC3339)),
+				fmt.Sprintf("Size:     %s", formatModuleSize(entry.SizeBytes)),
+			}
+			if status := m.moduleStatus[entry.TransactionID]; status != "" {
+				lines = append(lines, fmt.Sprintf("Status:   %s", status))
+			}
+			if format := entry.Tags["Module-Format"]; format != "" {
+				lines = append(lines, fmt.Sprintf("Format:   %s", format))
+			}
+			description = strings.Join(lines, "\n")
+			break
+		}
+	}
+
+	rightPanel := components.CreateDescriptionPanel(
+		"Module Details",
+		description,
+		m.getPanelWidth()-2,
+		0,
+	)
+
+	return m.createTwoPanelLayout(leftPanel, rightPanel)
+}
+
+// viewModuleActions renders the action list for the selected module
+func (m *Model) viewModuleActions() string {
+	if m.moduleActionSelector == nil {
+		m.buildModuleActionSelector()
+	}
+
+	leftPanel := m.moduleActionSelector.View()
+
+	description := i18n.T("Choose an action for this module.")
+	if m.selectedModule != nil {
+		description = fmt.Sprintf("%s (%s)", m.selectedModule.TransactionID, m.selectedModule.Version())
+		if status := m.moduleStatus[m.selectedModule.TransactionID]; status != "" {
+			description += "\n\nStatus: " + status
+		}
+		if m.moduleActionNotice != "" {
+			description += "\n\n" + m.moduleActionNotice
+		}
+	}
+
+	rightPanel := components.CreateDescriptionPanel(
+		"Module",
+		description,
+		m.getPanelWidth()-2,
+		0,
+	)
+
+	return m.createTwoPanelLayout(leftPanel, rightPanel)
+}
+
+// openInBrowser opens a URL with the platform's default browser
+func openInBrowser(url string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url).Start()
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url).Start()
+	default:
+		return exec.Command("xdg-open", url).Start()
+	}
+}
+
+// copyToClipboard copies text using whichever clipboard tool the platform has
+func copyToClipboard(text string) error {
+	candidates := [][]string{
+		{"pbcopy"},
+		{"wl-copy"},
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	}
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(candidate[0], candidate[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+	return fmt.Errorf("no clipboard tool available")
+}
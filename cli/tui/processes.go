@@ -0,0 +1,388 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/config"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/i18n"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/cli/pkg/registry"
+	components "github.com/the-permaweb-harlequin/harlequin-toolkit/cli/tui/components"
+)
+
+// processLogsMsg carries recent compute unit results for one process
+type processLogsMsg struct {
+	ProcessID string
+	Logs      string
+	Err       error
+}
+
+// processComputeURL returns the compute unit used for log tailing
+func processComputeURL() string {
+	preset, _ := config.NetworkPreset(config.NetworkMainnet)
+	return preset.CU
+}
+
+// fetchProcessLogsCmd pulls the newest results for a process from the
+// compute unit and flattens their Output into display lines
+func fetchProcessLogsCmd(cuURL, processID string) tea.Cmd {
+	return func() tea.Msg {
+		client := &http.Client{Timeout: 20 * time.Second}
+		url := fmt.Sprintf("%s/results/%s?sort=DESC&limit=5", cuURL, processID)
+
+		resp, err := client.Get(url)
+		if err != nil {
+			return processLogsMsg{ProcessID: processID, Err: err}
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return processLogsMsg{ProcessID: processID,
+				Err: fmt.Errorf("compute unit returned HTTP %d", resp.StatusCode)}
+		}
+
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		if err != nil {
+			return processLogsMsg{ProcessID: processID, Err: err}
+		}
+
+		return processLogsMsg{ProcessID: processID, Logs: flattenResultOutputs(body)}
+	}
+}
+
+// flattenResultOutputs extracts the Output data of each result edge,
+// newest first, tolerating string and structured payloads
+func flattenResultOutputs(body []byte) string {
+	var page struct {
+		Edges []struct {
+			Node struct {
+				Output json.RawMessage `json:"Output"`
+			} `json:"node"`
+		} `json:"edges"`
+	}
+	if err := json.Unmarshal(body, &page); err != nil {
+		return fmt.Sprintf("Could not parse results: %v", err)
+	}
+	if len(page.Edges) == 0 {
+		return "No results recorded for this process yet."
+	}
+
+	lines := make([]string, 0, len(page.Edges))
+	for _, edge := range page.Edges {
+		output := strings.TrimSpace(renderResultOutput(edge.Node.Output))
+		if output == "" {
+			output = "(no output)"
+		}
+		lines = append(lines, "• "+output)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderResultOutput renders one result Output, which the compute unit
+// returns either as a bare string or as an object with a data field
+func renderResultOutput(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+
+	var structured struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &structured); err == nil && len(structured.Data) > 0 {
+		if err := json.Unmarshal(structured.Data, &text); err == nil {
+			return text
+		}
+		return string(structured.Data)
+	}
+	return string(raw)
+}
+
+// buildProcessSelector lists the registered processes with name, version,
+// and last-message columns
+func (m *Model) buildProcessSelector() {
+	processes, err := registry.Load(".")
+	m.processEntries = processes
+
+	items := make([]components.ListItem, 0, len(processes))
+	if err != nil {
+		items = append(items, components.NewListItem("Could not read registry", err.Error(), ""))
+	} else if len(processes) == 0 {
+		items = append(items, components.NewListItem(
+			"No processes registered",
+			"Register one with harlequin processes add <PROCESS_ID>", ""))
+	} else {
+		for _, process := range processes {
+			name := process.Name
+			if name == "" {
+				name = shortModuleID(process.ProcessID)
+			}
+			title := fmt.Sprintf("%-16s %s", name, shortModuleID(process.ProcessID))
+			description := "last message: " + formatProcessAge(process.LastMessageAt)
+			if process.ModuleVersion != "" {
+				description = "module " + process.ModuleVersion + "  •  " + description
+			}
+			items = append(items, components.NewListItem(title, description, process.ProcessID))
+		}
+	}
+
+	m.processSelector = components.NewListSelector(
+		i18n.T("Registered Processes"), items, m.getPanelWidth()-2, m.getPanelHeight())
+}
+
+// formatProcessAge renders a last-message timestamp as a relative age
+func formatProcessAge(at time.Time) string {
+	if at.IsZero() {
+		return "never"
+	}
+
+	age := time.Since(at)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	}
+}
+
+// buildProcessActionSelector lists the actions for the selected process
+func (m *Model) buildProcessActionSelector() {
+	items := []components.ListItem{
+		components.NewListItem("Send Quick Message", "Send a message to this process", "message"),
+		components.NewListItem("Tail Logs", "Fetch the newest results from the compute unit", "logs"),
+		components.NewListItem("Copy Process ID", "Copy the process ID to the clipboard", "copy"),
+		components.NewListItem("Fetch State", "Download a state snapshot via harlequin state", "state"),
+	}
+	m.processActionSelector = components.NewListSelector(
+		i18n.T("Process Actions"), items, m.getPanelWidth()-2, m.getPanelHeight())
+}
+
+// updateProcessExplorer handles keys in the process list view
+func (m *Model) updateProcessExplorer(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.processSelector == nil {
+		m.buildProcessSelector()
+	}
+
+	if msg.String() == "l" {
+		// Tail logs for the highlighted process without leaving the list
+		if selected := m.processSelector.GetSelected(); selected != nil && selected.Value() != "" {
+			id := selected.Value()
+			m.processLogs[id] = "Fetching results…"
+			return m, fetchProcessLogsCmd(processComputeURL(), id)
+		}
+	}
+
+	model, cmd := m.processSelector.Update(tea.Msg(msg))
+	if newSelector, ok := model.(*components.ListSelectorComponent); ok {
+		m.processSelector = newSelector
+	}
+
+	if key.Matches(msg, m.keyMap.Enter) {
+		if selected := m.processSelector.GetSelected(); selected != nil && selected.Value() != "" {
+			for i := range m.processEntries {
+				if m.processEntries[i].ProcessID == selected.Value() {
+					m.selectedProcess = &m.processEntries[i]
+					break
+				}
+			}
+			m.processActionNotice = ""
+			m.buildProcessActionSelector()
+			m.state = ViewProcessActions
+			return m, nil
+		}
+	}
+
+	return m, cmd
+}
+
+// updateProcessActions handles keys in the process action view
+func (m *Model) updateProcessActions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.processActionSelector == nil {
+		m.buildProcessActionSelector()
+	}
+
+	model, cmd := m.processActionSelector.Update(tea.Msg(msg))
+	if newSelector, ok := model.(*components.ListSelectorComponent); ok {
+		m.processActionSelector = newSelector
+	}
+
+	if key.Matches(msg, m.keyMap.Enter) && m.selectedProcess != nil {
+		if selected := m.processActionSelector.GetSelected(); selected != nil {
+			id := m.selectedProcess.ProcessID
+			switch selected.Value() {
+			case "message":
+				m.processActionInput = components.NewTextInput(
+					"Action Tag", "Info", m.getPanelWidth()-2, m.getPanelHeight())
+				m.state = ViewProcessMessage
+				return m, m.processActionInput.Init()
+			case "logs":
+				m.processLogs[id] = "Fetching results…"
+				m.processActionNotice = "Fetching results…"
+				return m, fetchProcessLogsCmd(processComputeURL(), id)
+			case "copy":
+				if err := copyToClipboard(id); err != nil {
+					m.processActionNotice = fmt.Sprintf("No clipboard tool found - ID: %s", id)
+				} else {
+					m.processActionNotice = "Process ID copied"
+				}
+				return m, nil
+			case "state":
+				// State download streams progress to the terminal; run the
+				// CLI command outside the TUI like external plugins do
+				cliCmd := exec.Command(os.Args[0], "state", id)
+				return m, tea.ExecProcess(cliCmd, func(error) tea.Msg {
+					return tea.QuitMsg{}
+				})
+			}
+		}
+	}
+
+	return m, cmd
+}
+
+// updateProcessMessage handles keys in the quick message prompt
+func (m *Model) updateProcessMessage(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.processActionInput == nil || m.selectedProcess == nil {
+		m.state = ViewProcessActions
+		return m, nil
+	}
+
+	if key.Matches(msg, m.keyMap.Enter) {
+		action := strings.TrimSpace(m.processActionInput.GetValue())
+		if action == "" {
+			action = "Info"
+		}
+		// Messaging needs wallet signing and result polling; run the CLI
+		// command outside the TUI
+		cliCmd := exec.Command(os.Args[0], "message", m.selectedProcess.ProcessID, "--action", action)
+		return m, tea.ExecProcess(cliCmd, func(error) tea.Msg {
+			return tea.QuitMsg{}
+		})
+	}
+
+	model, cmd := m.processActionInput.Update(tea.Msg(msg))
+	if newInput, ok := model.(*components.TextInputComponent); ok {
+		m.processActionInput = newInput
+	}
+	return m, cmd
+}
+
+// viewProcessExplorer renders the process list with a detail panel
+func (m *Model) viewProcessExplorer() string {
+	if m.processSelector == nil {
+		m.buildProcessSelector()
+	}
+
+	leftPanel := m.processSelector.View()
+
+	description := i18n.T("Processes registered with harlequin processes.\n\nPress enter for actions, l to tail logs.")
+	if selected := m.processSelector.GetSelected(); selected != nil && selected.Value() != "" {
+		for _, process := range m.processEntries {
+			if process.ProcessID != selected.Value() {
+				continue
+			}
+			lines := []string{
+				fmt.Sprintf("Process:  %s", process.ProcessID),
+			}
+			if process.Name != "" {
+				lines = append(lines, fmt.Sprintf("Name:     %s", process.Name))
+			}
+			if process.ModuleID != "" {
+				lines = append(lines, fmt.Sprintf("Module:   %s", shortModuleID(process.ModuleID)))
+			}
+			if process.ModuleVersion != "" {
+				lines = append(lines, fmt.Sprintf("Version:  %s", process.ModuleVersion))
+			}
+			lines = append(lines, fmt.Sprintf("Last msg: %s", formatProcessAge(process.LastMessageAt)))
+			if logs := m.processLogs[process.ProcessID]; logs != "" {
+				lines = append(lines, "", "Recent output:", logs)
+			}
+			description = strings.Join(lines, "\n")
+			break
+		}
+	}
+
+	rightPanel := components.CreateDescriptionPanel(
+		"Process Details",
+		description,
+		m.getPanelWidth()-2,
+		0,
+	)
+
+	return m.createTwoPanelLayout(leftPanel, rightPanel)
+}
+
+// viewProcessActions renders the action list for the selected process
+func (m *Model) viewProcessActions() string {
+	if m.processActionSelector == nil {
+		m.buildProcessActionSelector()
+	}
+
+	leftPanel := m.processActionSelector.View()
+
+	description := i18n.T("Choose an action for this process.")
+	if m.selectedProcess != nil {
+		description = m.selectedProcess.ProcessID
+		if m.selectedProcess.Name != "" {
+			description = m.selectedProcess.Name + "\n" + description
+		}
+		if logs := m.processLogs[m.selectedProcess.ProcessID]; logs != "" {
+			description += "\n\nRecent output:\n" + logs
+		}
+		if m.processActionNotice != "" {
+			description += "\n\n" + m.processActionNotice
+		}
+	}
+
+	rightPanel := components.CreateDescriptionPanel(
+		"Process",
+		description,
+		m.getPanelWidth()-2,
+		0,
+	)
+
+	return m.createTwoPanelLayout(leftPanel, rightPanel)
+}
+
+// viewProcessMessage renders the quick message prompt
+func (m *Model) viewProcessMessage() string {
+	if m.processActionInput == nil {
+		return ""
+	}
+
+	leftPanel := components.CreateDescriptionPanel(
+		"Quick Message",
+		m.processActionInput.View(),
+		m.getPanelWidth()-2,
+		0,
+	)
+
+	description := i18n.T("Enter the Action tag for the message.\n\nThe TUI hands off to harlequin message, which signs with your wallet and polls the compute unit for the result.")
+	if m.selectedProcess != nil {
+		description = fmt.Sprintf("To: %s\n\n%s", m.selectedProcess.ProcessID, description)
+	}
+	rightPanel := components.CreateDescriptionPanel(
+		"Details",
+		description,
+		m.getPanelWidth()-2,
+		0,
+	)
+
+	return m.createTwoPanelLayout(leftPanel, rightPanel)
+}
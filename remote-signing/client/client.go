@@ -0,0 +1,200 @@
+// Package client provides a typed Go SDK for the Harlequin remote signing
+// server, so Go programs can submit data for signing and wait for results
+// without re-implementing the HTTP and SSE protocol.
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client talks to a remote signing server
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// New creates a client for the signing server at baseURL
+// (e.g. "http://localhost:8080")
+func New(baseURL string) *Client {
+	// No client-level timeout: SSE subscriptions are long-lived, callers
+	// bound individual calls with a context instead
+	return NewWithHTTPClient(baseURL, &http.Client{})
+}
+
+// NewWithHTTPClient creates a client using a caller-provided HTTP client
+func NewWithHTTPClient(baseURL string, httpClient *http.Client) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// SubmitOptions are optional parameters for SubmitData
+type SubmitOptions struct {
+	ClientID    string // Client identifier for tracking
+	CallbackURL string // Webhook URL notified when signing completes
+}
+
+// SubmitResponse is the server's response to a data submission
+type SubmitResponse struct {
+	UUID       string `json:"uuid"`
+	SigningURL string `json:"signing_url"`
+	Message    string `json:"message"`
+}
+
+// Event is a server-sent event emitted for a signing request
+type Event struct {
+	Type string          // Event type, e.g. "connected", "signed", "heartbeat"
+	Data json.RawMessage // Event payload as sent by the server
+}
+
+// SubmitData submits raw data for signing and returns the request UUID and
+// signing URL
+func (c *Client) SubmitData(ctx context.Context, data []byte, opts *SubmitOptions) (*SubmitResponse, error) {
+	endpoint := c.baseURL + "/"
+	if opts != nil {
+		params := url.Values{}
+		if opts.ClientID != "" {
+			params.Set("client_id", opts.ClientID)
+		}
+		if opts.CallbackURL != "" {
+			params.Set("callback_url", opts.CallbackURL)
+		}
+		if len(params) > 0 {
+			endpoint += "?" + params.Encode()
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create submit request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("server error (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+
+	var submitResp SubmitResponse
+	if err := json.NewDecoder(resp.Body).Decode(&submitResp); err != nil {
+		return nil, fmt.Errorf("failed to parse submit response: %w", err)
+	}
+	return &submitResp, nil
+}
+
+// SubscribeEvents opens the SSE stream for a signing request and delivers
+// events on the returned channel. The channel closes when the stream ends or
+// the context is cancelled.
+func (c *Client) SubscribeEvents(ctx context.Context, uuid string) (<-chan Event, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/events/"+uuid, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to event stream: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("server error (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		var currentEvent, currentData string
+
+		for scanner.Scan() {
+			line := scanner.Text()
+
+			switch {
+			case strings.HasPrefix(line, "event:"):
+				currentEvent = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			case strings.HasPrefix(line, "data:"):
+				currentData = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			case line == "" && currentEvent != "":
+				select {
+				case events <- Event{Type: currentEvent, Data: json.RawMessage(currentData)}:
+				case <-ctx.Done():
+					return
+				}
+				currentEvent, currentData = "", ""
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// WaitForEvent blocks until an event of the given type arrives for the signing
+// request, or the context is cancelled
+func (c *Client) WaitForEvent(ctx context.Context, uuid, eventType string) (*Event, error) {
+	events, err := c.SubscribeEvents(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil, fmt.Errorf("event stream closed before %q event", eventType)
+			}
+			if event.Type == eventType {
+				return &event, nil
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// WaitForSigned blocks until the signing request is signed, then returns the
+// signed data
+func (c *Client) WaitForSigned(ctx context.Context, uuid string) ([]byte, error) {
+	if _, err := c.WaitForEvent(ctx, uuid, "signed"); err != nil {
+		return nil, err
+	}
+	return c.FetchSigned(ctx, uuid)
+}
+
+// FetchSigned retrieves the signed data for a completed signing request
+func (c *Client) FetchSigned(ctx context.Context, uuid string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/signed/"+uuid, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fetch request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch signed data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("server error (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "test-client", r.URL.Query().Get("client_id"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"uuid":"test-uuid","signing_url":"http://example.com/sign/test-uuid","message":"ok"}`))
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	resp, err := c.SubmitData(context.Background(), []byte("test data"), &SubmitOptions{ClientID: "test-client"})
+	require.NoError(t, err)
+	assert.Equal(t, "test-uuid", resp.UUID)
+	assert.Equal(t, "http://example.com/sign/test-uuid", resp.SigningURL)
+}
+
+func TestWaitForSigned(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/events/test-uuid", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("event:connected\ndata:{\"uuid\":\"test-uuid\"}\n\n"))
+		w.Write([]byte("event:signed\ndata:{\"uuid\":\"test-uuid\",\"success\":true}\n\n"))
+	})
+	mux.HandleFunc("/signed/test-uuid", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("signed bytes"))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c := New(server.URL)
+	signedData, err := c.WaitForSigned(ctx, "test-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("signed bytes"), signedData)
+}
+
+func TestWaitForSignedContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("event:connected\ndata:{}\n\n"))
+		// Never send a signed event
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	c := New(server.URL)
+	_, err := c.WaitForSigned(ctx, "test-uuid")
+	assert.Error(t, err)
+}
+
+func TestFetchSignedNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"Signing request not found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	_, err := c.FetchSigned(context.Background(), "missing-uuid")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 404")
+}
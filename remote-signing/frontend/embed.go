@@ -0,0 +1,35 @@
+// Package frontend embeds the built signing UI so the server binary can serve
+// it without any files on disk. Run the frontend build (yarn build) in this
+// directory before building the Go binary to refresh the embedded assets; a
+// source checkout without a build falls back to disk paths or the external
+// frontend URL.
+package frontend
+
+import (
+	"embed"
+	"io/fs"
+	"strings"
+)
+
+//go:embed all:dist
+var dist embed.FS
+
+// Dist returns the embedded build output rooted at the dist directory
+func Dist() (fs.FS, error) {
+	return fs.Sub(dist, "dist")
+}
+
+// Available reports whether a built frontend is embedded in the binary, as
+// opposed to just the placeholder kept so source checkouts still compile
+func Available() bool {
+	entries, err := dist.ReadDir("dist/assets")
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".js") {
+			return true
+		}
+	}
+	return false
+}
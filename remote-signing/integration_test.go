@@ -9,6 +9,8 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -73,6 +75,14 @@ func TestIntegrationSuite(t *testing.T) {
 	t.Run("MultipleRequests", func(t *testing.T) {
 		testMultipleRequests(t, srv)
 	})
+
+	t.Run("WebSocketBroadcastLoad", func(t *testing.T) {
+		testWebSocketBroadcastLoad(t, srv)
+	})
+
+	t.Run("WebSocketSlowConsumer", func(t *testing.T) {
+		testWebSocketSlowConsumer(t, srv)
+	})
 }
 
 // setupTestServer creates and starts a test server
@@ -496,6 +506,161 @@ func testMultipleRequests(t *testing.T, srv *server.Server) {
 	t.Logf("Successfully handled %d concurrent requests", successCount)
 }
 
+// testWebSocketBroadcastLoad fans a burst of notifications out to many
+// concurrent WebSocket listeners and verifies every active reader sees the
+// full stream
+func testWebSocketBroadcastLoad(t *testing.T, srv *server.Server) {
+	const (
+		numClients  = 50
+		numMessages = 200
+		loadUUID    = "broadcast-load-test"
+	)
+
+	dialer := websocket.Dialer{
+		HandshakeTimeout: 5 * time.Second,
+	}
+
+	conns := make([]*websocket.Conn, 0, numClients)
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+
+	for i := 0; i < numClients; i++ {
+		conn, _, err := dialer.Dial(testWSURL, nil)
+		require.NoError(t, err, "Failed to connect client %d", i)
+		conns = append(conns, conn)
+
+		err = conn.WriteJSON(map[string]string{
+			"type": "subscribe",
+			"uuid": loadUUID,
+		})
+		require.NoError(t, err, "Failed to subscribe client %d", i)
+	}
+
+	// Give the read loops a moment to process the subscriptions
+	time.Sleep(200 * time.Millisecond)
+
+	// Count signed notifications per client while the broadcast runs
+	var received atomic.Int64
+	var wg sync.WaitGroup
+	for _, conn := range conns {
+		wg.Add(1)
+		go func(conn *websocket.Conn) {
+			defer wg.Done()
+			count := 0
+			for count < numMessages {
+				conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+				var message map[string]interface{}
+				if err := conn.ReadJSON(&message); err != nil {
+					return
+				}
+				if msgType, ok := message["type"].(string); ok && msgType == "signed" {
+					count++
+					received.Add(1)
+				}
+			}
+		}(conn)
+	}
+
+	hub := srv.GetWebSocketHub()
+	start := time.Now()
+	for i := 0; i < numMessages; i++ {
+		hub.BroadcastToUUID(loadUUID, server.WebSocketMessage{
+			Type:    server.MessageTypeSigned,
+			Payload: map[string]int{"seq": i},
+		})
+	}
+	broadcastTime := time.Since(start)
+
+	wg.Wait()
+
+	assert.Equal(t, int64(numClients*numMessages), received.Load(),
+		"Every active listener should receive the full stream")
+	assert.Less(t, broadcastTime, 5*time.Second, "Broadcast path should not block on listeners")
+	t.Logf("Broadcast %d messages to %d clients in %v", numMessages, numClients, broadcastTime)
+}
+
+// testWebSocketSlowConsumer floods a listener that never reads and verifies
+// the broadcast path keeps moving by dropping that client's oldest messages
+// instead of stalling everyone else
+func testWebSocketSlowConsumer(t *testing.T, srv *server.Server) {
+	const (
+		numMessages = 5000
+		slowUUID    = "slow-consumer-test"
+	)
+
+	dialer := websocket.Dialer{
+		HandshakeTimeout: 5 * time.Second,
+	}
+
+	// The slow client subscribes and then never reads
+	slowConn, _, err := dialer.Dial(testWSURL, nil)
+	require.NoError(t, err, "Failed to connect slow client")
+	defer slowConn.Close()
+
+	err = slowConn.WriteJSON(map[string]string{
+		"type": "subscribe",
+		"uuid": slowUUID,
+	})
+	require.NoError(t, err, "Failed to subscribe slow client")
+
+	// The responsive client reads continuously
+	fastConn, _, err := dialer.Dial(testWSURL, nil)
+	require.NoError(t, err, "Failed to connect responsive client")
+	defer fastConn.Close()
+
+	err = fastConn.WriteJSON(map[string]string{
+		"type": "subscribe",
+		"uuid": slowUUID,
+	})
+	require.NoError(t, err, "Failed to subscribe responsive client")
+
+	time.Sleep(200 * time.Millisecond)
+
+	// Flood with payloads large enough to fill the socket buffer and the
+	// per-client queue behind it
+	hub := srv.GetWebSocketHub()
+	payload := make([]byte, 1024)
+	start := time.Now()
+	for i := 0; i < numMessages; i++ {
+		hub.BroadcastToUUID(slowUUID, server.WebSocketMessage{
+			Type:    server.MessageTypeSigned,
+			Payload: map[string]interface{}{"seq": i, "data": payload},
+		})
+	}
+	broadcastTime := time.Since(start)
+
+	assert.Less(t, broadcastTime, 10*time.Second,
+		"Broadcast path should not block on a slow consumer")
+
+	// The responsive client still sees the tail of the stream
+	sawTail := false
+	for !sawTail {
+		fastConn.SetReadDeadline(time.Now().Add(10 * time.Second))
+		var message map[string]interface{}
+		if err := fastConn.ReadJSON(&message); err != nil {
+			break
+		}
+		if payload, ok := message["payload"].(map[string]interface{}); ok {
+			if seq, ok := payload["seq"].(float64); ok && int(seq) == numMessages-1 {
+				sawTail = true
+			}
+		}
+	}
+	assert.True(t, sawTail, "Responsive client should still receive the latest broadcast")
+
+	// The server itself stays healthy throughout
+	resp, err := http.Get(testServerURL + "/health")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	t.Logf("Broadcast %d messages past a stalled consumer in %v (%d dropped)",
+		numMessages, broadcastTime, hub.DroppedMessageCount())
+}
+
 // Benchmark tests
 func BenchmarkDataSubmission(b *testing.B) {
 	srv, cancel := setupTestServer(&testing.T{})
@@ -19,12 +19,22 @@ import (
 func main() {
 	// Parse command line flags
 	var (
-		port        = flag.Int("port", 8080, "Server port")
-		host        = flag.String("host", "localhost", "Server host")
-		timeout     = flag.Int("timeout", 30, "Signing timeout in minutes")
-		maxSize     = flag.Int64("max-size", 10*1024*1024, "Maximum data item size in bytes")
-		frontendURL = flag.String("frontend-url", "", "Frontend URL for development (e.g., http://localhost:5173)")
-		help        = flag.Bool("help", false, "Show help message")
+		port           = flag.Int("port", 8080, "Server port")
+		host           = flag.String("host", "localhost", "Server host")
+		timeout        = flag.Int("timeout", 30, "Signing timeout in minutes")
+		maxSize        = flag.Int64("max-size", 10*1024*1024, "Maximum data item size in bytes")
+		frontendURL    = flag.String("frontend-url", "", "Frontend URL for development (e.g., http://localhost:5173)")
+		storage        = flag.String("storage", "", "Path to the embedded storage file (default: in-memory)")
+		bundler        = flag.String("bundler", "", "Bundler target for direct submission: turbo, turbo-dev, or a custom gateway URL (default: disabled)")
+		callbackSecret = flag.String("callback-secret", "", "HMAC-SHA256 key for signing webhook payloads (default: unsigned)")
+		tlsCert        = flag.String("tls-cert", "", "Path to TLS certificate for serving HTTPS (requires -tls-key)")
+		tlsKey         = flag.String("tls-key", "", "Path to TLS private key for serving HTTPS (requires -tls-cert)")
+		publicURL      = flag.String("public-url", "", "Externally visible base URL for signing links when behind a proxy (e.g. https://sign.example.com)")
+		rateLimitIP     = flag.Int("rate-limit-ip", 0, "Maximum submissions per IP per minute (default: unlimited)")
+		rateLimitClient = flag.Int("rate-limit-client", 0, "Maximum submissions per client ID per minute (default: unlimited)")
+		maxPending      = flag.Int("max-pending", 0, "Cap on simultaneous pending signing requests (default: unlimited)")
+		pendingPolicy   = flag.String("pending-policy", server.PendingPolicyReject, "What to do at the pending cap: reject or evict-oldest")
+		help           = flag.Bool("help", false, "Show help message")
 	)
 	flag.Parse()
 
@@ -33,6 +43,14 @@ func main() {
 		return
 	}
 
+	if (*tlsCert == "") != (*tlsKey == "") {
+		log.Fatal("Both -tls-cert and -tls-key must be provided to enable HTTPS")
+	}
+
+	if *pendingPolicy != server.PendingPolicyReject && *pendingPolicy != server.PendingPolicyEvictOldest {
+		log.Fatalf("Invalid -pending-policy %q: must be %q or %q", *pendingPolicy, server.PendingPolicyReject, server.PendingPolicyEvictOldest)
+	}
+
 	// Create server configuration
 	config := server.DefaultConfig()
 	config.Port = *port
@@ -40,6 +58,16 @@ func main() {
 	config.SigningTimeout = time.Duration(*timeout) * time.Minute
 	config.MaxDataSize = *maxSize
 	config.FrontendURL = *frontendURL
+	config.StoragePath = *storage
+	config.BundlerURL = *bundler
+	config.CallbackSecret = *callbackSecret
+	config.TLSCertFile = *tlsCert
+	config.TLSKeyFile = *tlsKey
+	config.PublicURL = *publicURL
+	config.RateLimitPerIP = *rateLimitIP
+	config.RateLimitPerClient = *rateLimitClient
+	config.MaxPendingRequests = *maxPending
+	config.PendingOverflowPolicy = *pendingPolicy
 
 	// Create and start server
 	s := server.New(config)
@@ -59,16 +87,24 @@ func main() {
 	}()
 
 	// Print startup information
+	scheme, wsScheme := "http", "ws"
+	if config.TLSCertFile != "" && config.TLSKeyFile != "" {
+		scheme, wsScheme = "https", "wss"
+	}
+
 	log.Printf("🎭 Starting Harlequin Remote Signing Server...")
-	log.Printf("📡 Server will be available at: http://%s:%d", config.Host, config.Port)
+	log.Printf("📡 Server will be available at: %s://%s:%d", scheme, config.Host, config.Port)
+	if config.PublicURL != "" {
+		log.Printf("🌍 Public URL: %s", config.PublicURL)
+	}
 	if config.FrontendURL != "" {
 		log.Printf("🌐 Frontend URL: %s", config.FrontendURL)
 		log.Printf("📝 Signing interface: %s/sign/<uuid>", config.FrontendURL)
 	} else {
-		log.Printf("📝 Signing interface: http://%s:%d/sign/<uuid>", config.Host, config.Port)
+		log.Printf("📝 Signing interface: %s://%s:%d/sign/<uuid>", scheme, config.Host, config.Port)
 	}
-	log.Printf("🔌 WebSocket endpoint: ws://%s:%d/ws", config.Host, config.Port)
-	log.Printf("📚 API docs: http://%s:%d/api-docs", config.Host, config.Port)
+	log.Printf("🔌 WebSocket endpoint: %s://%s:%d/ws", wsScheme, config.Host, config.Port)
+	log.Printf("📚 API docs: %s://%s:%d/api-docs", scheme, config.Host, config.Port)
 	log.Println()
 
 	// Start the server
@@ -91,6 +127,16 @@ func printHelp() {
 	fmt.Println("  -timeout <minutes>     Signing timeout in minutes (default: 30)")
 	fmt.Println("  -max-size <bytes>      Maximum data item size in bytes (default: 10MB)")
 	fmt.Println("  -frontend-url <url>    Frontend URL for development (e.g., http://localhost:5173)")
+	fmt.Println("  -storage <path>        Path to the embedded storage file (default: in-memory)")
+	fmt.Println("  -bundler <target>      Bundler target for direct submission: turbo, turbo-dev, or a custom gateway URL (default: disabled)")
+	fmt.Println("  -callback-secret <key> HMAC-SHA256 key for signing webhook payloads (default: unsigned)")
+	fmt.Println("  -tls-cert <path>       Path to TLS certificate for serving HTTPS (requires -tls-key)")
+	fmt.Println("  -tls-key <path>        Path to TLS private key for serving HTTPS (requires -tls-cert)")
+	fmt.Println("  -public-url <url>      Externally visible base URL for signing links when behind a proxy")
+	fmt.Println("  -rate-limit-ip <n>     Maximum submissions per IP per minute (default: unlimited)")
+	fmt.Println("  -rate-limit-client <n> Maximum submissions per client ID per minute (default: unlimited)")
+	fmt.Println("  -max-pending <n>       Cap on simultaneous pending signing requests (default: unlimited)")
+	fmt.Println("  -pending-policy <p>    What to do at the pending cap: reject (429) or evict-oldest")
 	fmt.Println("  -help                  Show this help message")
 	fmt.Println()
 	fmt.Println("Examples:")
@@ -98,6 +144,8 @@ func printHelp() {
 	fmt.Println("  remote-signing -port 9000")
 	fmt.Println("  remote-signing -host 0.0.0.0 -port 8080")
 	fmt.Println("  remote-signing -frontend-url http://localhost:5173")
+	fmt.Println("  remote-signing -tls-cert cert.pem -tls-key key.pem")
+	fmt.Println("  remote-signing -host 0.0.0.0 -public-url https://sign.example.com")
 	fmt.Println()
 	fmt.Println("How it works:")
 	fmt.Println("  1. Submit data items via POST / to get a signing UUID")
@@ -1,5 +1,11 @@
 package server
 
+import (
+	"time"
+
+	"github.com/everFinance/goar/types"
+)
+
 // @title Harlequin Remote Signing Server API
 // @version 1.0.0
 // @description A secure HTTP/WebSocket service for remote transaction signing workflows. Submit raw data for signing via web interface with wallet extensions.
@@ -27,10 +33,94 @@ type SubmitDataResponse struct {
 	Message    string `json:"message" example:"Data submitted for signing"`                                 // Status message
 }
 
+// CreateDataItemRequest represents the request body for server-side ANS-104 construction
+// @Description Request structure for constructing an unsigned ANS-104 data item from raw data plus tags
+type CreateDataItemRequest struct {
+	Data          []byte     `json:"data" binding:"required" example:"SGVsbG8gV29ybGQ=" format:"byte"` // Raw payload data (base64 encoded in JSON)
+	Owner         string     `json:"owner,omitempty"`                                                  // Base64url-encoded public key of the signing wallet
+	SignatureType int        `json:"signature_type,omitempty" example:"1"`                             // ANS-104 signature type (default: 1, Arweave)
+	Target        string     `json:"target,omitempty"`                                                 // Optional target address (base64url, 32 bytes)
+	Anchor        string     `json:"anchor,omitempty"`                                                 // Optional anchor (base64url, 32 bytes; generated if empty)
+	Tags          []types.Tag `json:"tags,omitempty"`                                                  // Tags to attach to the data item
+	ClientID      string     `json:"client_id,omitempty" example:"client-app-v1.2.3"`                  // Client identifier for tracking
+	CallbackURL   string     `json:"callback_url,omitempty"`                                           // Optional webhook URL for completion notification
+}
+
+// CreateDataItemResponse represents the response after server-side construction
+// @Description Response structure containing the exact bytes the wallet must sign
+type CreateDataItemResponse struct {
+	UUID       string `json:"uuid" example:"123e4567-e89b-12d3-a456-426614174000"` // Unique identifier for the signing request
+	SigningURL string `json:"signing_url"`                                         // URL for the web signing interface
+	DataToSign []byte `json:"data_to_sign" format:"byte"`                          // Deep-hash signature data to sign (base64 encoded in JSON)
+	Anchor     string `json:"anchor"`                                              // Anchor used in the constructed item (generated if not provided)
+	Message    string `json:"message"`                                             // Status message
+}
+
+// SessionItemRequest represents a single data item within a session submission
+// @Description A named data item submitted as part of a signing session
+type SessionItemRequest struct {
+	Name string `json:"name" example:"process.wasm"`                        // Human-readable item name
+	Data []byte `json:"data" binding:"required" example:"SGVsbG8=" format:"byte"` // Raw data to be signed (base64 encoded in JSON)
+}
+
+// SubmitSessionRequest represents the request body for submitting a batch of items
+// @Description Request structure for submitting multiple data items under one signing session
+type SubmitSessionRequest struct {
+	Items       []SessionItemRequest `json:"items" binding:"required"`                                               // Data items to sign together
+	ClientID    string               `json:"client_id,omitempty" example:"client-app-v1.2.3"`                        // Client identifier for tracking
+	CallbackURL string               `json:"callback_url,omitempty" example:"https://your-app.com/webhook/signing-complete"` // Optional webhook URL for completion notification
+}
+
+// SessionItemStatus represents the signing status of one item in a session
+// @Description Status of a single item within a signing session
+type SessionItemStatus struct {
+	UUID     string `json:"uuid" example:"123e4567-e89b-12d3-a456-426614174000"` // Item signing request UUID
+	Name     string `json:"name" example:"process.wasm"`                         // Human-readable item name
+	IsSigned bool   `json:"is_signed" example:"false"`                           // Whether the item has been signed
+}
+
+// SubmitSessionResponse represents the response after submitting a session
+// @Description Response structure after successfully submitting a signing session
+type SubmitSessionResponse struct {
+	SessionUUID string              `json:"session_uuid" example:"123e4567-e89b-12d3-a456-426614174000"` // Unique identifier for the session
+	Items       []SessionItemStatus `json:"items"`                                                       // Per-item signing request UUIDs
+	SigningURL  string              `json:"signing_url"`                                                 // URL for the web signing interface
+	Message     string              `json:"message" example:"Session submitted for signing"`             // Status message
+}
+
+// SessionStatusResponse represents the status of a signing session
+// @Description Current signing status of a session and its items
+type SessionStatusResponse struct {
+	SessionUUID string              `json:"session_uuid" example:"123e4567-e89b-12d3-a456-426614174000"` // Unique identifier for the session
+	Items       []SessionItemStatus `json:"items"`                                                       // Per-item signing status
+	Total       int                 `json:"total" example:"3"`                                           // Total number of items
+	Signed      int                 `json:"signed" example:"1"`                                          // Number of signed items
+	Complete    bool                `json:"complete" example:"false"`                                    // Whether all items are signed
+}
+
 // SubmitSignedDataRequest represents the request body for submitting signed data
 // @Description Request structure for submitting signed data
 type SubmitSignedDataRequest struct {
-	SignedData []byte `json:"signed_data" binding:"required" example:"U2lnbmVkIEhlbGxvIFdvcmxk" format:"byte"` // Signed data (base64 encoded in JSON)
+	SignedData    []byte `json:"signed_data" binding:"required" example:"U2lnbmVkIEhlbGxvIFdvcmxk" format:"byte"` // Signed data (base64 encoded in JSON)
+	SignerAddress string `json:"signer_address,omitempty"`                                                        // Wallet address that signed the data, recorded for auditability
+}
+
+// PendingItem represents a pending signing request in the queue view
+// @Description A signing request awaiting signature
+type PendingItem struct {
+	UUID        string    `json:"uuid" example:"123e4567-e89b-12d3-a456-426614174000"` // Signing request UUID
+	CreatedAt   time.Time `json:"created_at"`                                          // When the request was submitted
+	Size        int       `json:"size" example:"1024"`                                 // Size of the data to sign in bytes
+	ClientID    string    `json:"client_id,omitempty"`                                 // Client identifier
+	SessionUUID string    `json:"session_uuid,omitempty"`                              // Session the item belongs to, if any
+	ItemName    string    `json:"item_name,omitempty"`                                 // Human-readable item name within a session
+}
+
+// RejectRequest represents a bulk rejection of pending signing requests
+// @Description Request structure for rejecting pending signing requests in bulk
+type RejectRequest struct {
+	UUIDs  []string `json:"uuids" binding:"required"` // Signing request UUIDs to reject
+	Reason string   `json:"reason,omitempty"`         // Optional rejection reason relayed to waiting clients
 }
 
 // HealthResponse represents the health check response
@@ -38,6 +128,7 @@ type SubmitSignedDataRequest struct {
 type HealthResponse struct {
 	Status    string `json:"status" example:"healthy"`     // Health status
 	Timestamp int64  `json:"timestamp" example:"1640995200"` // Unix timestamp
+	Uptime    string `json:"uptime" example:"2h30m45s"`    // Time since the server started
 	Version   string `json:"version" example:"1.0.0"`      // Server version
 }
 
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/project-kardeshev/go-ardrive-turbo/pkg/turbo"
+	turboTypes "github.com/project-kardeshev/go-ardrive-turbo/pkg/types"
+)
+
+// Named bundler targets resolvable via Config.BundlerURL
+var bundlerTargets = map[string]string{
+	"turbo":     "https://upload.ardrive.io",
+	"turbo-dev": "https://upload.ardrive.dev",
+}
+
+// resolveBundlerURL maps a named bundler target ("turbo", "turbo-dev") to its
+// upload URL; any other value is treated as a custom gateway URL
+func resolveBundlerURL(target string) string {
+	if url, ok := bundlerTargets[target]; ok {
+		return url
+	}
+	return target
+}
+
+// submitToBundler uploads a signed data item to the given bundler target via
+// the Turbo client and returns the upload receipt
+func submitToBundler(ctx context.Context, target string, signedData []byte) (*turboTypes.UploadResult, error) {
+	client := turbo.Unauthenticated(&turbo.TurboConfig{
+		PaymentURL: turbo.DefaultConfig().PaymentURL,
+		UploadURL:  resolveBundlerURL(target),
+	})
+
+	uploadCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	return client.UploadSignedDataItem(uploadCtx, &turboTypes.SignedDataItemUploadRequest{
+		DataItemStreamFactory: func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(signedData)), nil
+		},
+		DataItemSizeFactory: func() int64 {
+			return int64(len(signedData))
+		},
+		Context: uploadCtx,
+	})
+}
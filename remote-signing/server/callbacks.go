@@ -0,0 +1,214 @@
+package server
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Callback delivery statuses
+const (
+	CallbackStatusPending   = "pending"
+	CallbackStatusDelivered = "delivered"
+	CallbackStatusFailed    = "failed"
+)
+
+const (
+	callbackMaxAttempts = 5
+	callbackBaseBackoff = time.Second
+)
+
+// CallbackDelivery tracks the delivery of a signed-payload webhook
+type CallbackDelivery struct {
+	UUID        string    `json:"uuid"`
+	CallbackURL string    `json:"callback_url"`
+	Status      string    `json:"status"`
+	Attempts    int       `json:"attempts"`
+	LastError   string    `json:"last_error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// callbackTracker records webhook delivery status for the /callbacks endpoints
+type callbackTracker struct {
+	mutex      sync.RWMutex
+	deliveries map[string]*CallbackDelivery
+}
+
+// newCallbackTracker creates an empty callback tracker
+func newCallbackTracker() *callbackTracker {
+	return &callbackTracker{
+		deliveries: make(map[string]*CallbackDelivery),
+	}
+}
+
+// track registers a pending delivery for a signing request
+func (ct *callbackTracker) track(uuid, callbackURL string) {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+
+	now := time.Now()
+	ct.deliveries[uuid] = &CallbackDelivery{
+		UUID:        uuid,
+		CallbackURL: callbackURL,
+		Status:      CallbackStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// recordAttempt records the outcome of one delivery attempt
+func (ct *callbackTracker) recordAttempt(uuid string, err error) {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+
+	delivery, exists := ct.deliveries[uuid]
+	if !exists {
+		return
+	}
+
+	delivery.Attempts++
+	delivery.UpdatedAt = time.Now()
+	if err != nil {
+		delivery.LastError = err.Error()
+	} else {
+		delivery.LastError = ""
+		delivery.Status = CallbackStatusDelivered
+	}
+}
+
+// markFailed marks a delivery as dead after all attempts were exhausted
+func (ct *callbackTracker) markFailed(uuid string) {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+
+	if delivery, exists := ct.deliveries[uuid]; exists {
+		delivery.Status = CallbackStatusFailed
+		delivery.UpdatedAt = time.Now()
+	}
+}
+
+// list returns all tracked deliveries ordered by creation time
+func (ct *callbackTracker) list() []*CallbackDelivery {
+	ct.mutex.RLock()
+	defer ct.mutex.RUnlock()
+
+	result := make([]*CallbackDelivery, 0, len(ct.deliveries))
+	for _, delivery := range ct.deliveries {
+		copied := *delivery
+		result = append(result, &copied)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+// notifyCallback delivers the signed response to the callback URL with
+// exponential-backoff retries, signing the payload with HMAC-SHA256 when a
+// callback secret is configured
+func (s *Server) notifyCallback(callbackURL string, response *SignedResponse) {
+	s.callbacks.track(response.UUID, callbackURL)
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		s.callbacks.recordAttempt(response.UUID, fmt.Errorf("failed to marshal callback payload: %w", err))
+		s.callbacks.markFailed(response.UUID)
+		s.metrics.CallbackFailures.Inc()
+		return
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	for attempt := 1; attempt <= callbackMaxAttempts; attempt++ {
+		if attempt > 1 {
+			// Exponential backoff: 1s, 2s, 4s, 8s
+			time.Sleep(callbackBaseBackoff << (attempt - 2))
+		}
+
+		err := s.deliverCallback(client, callbackURL, response.UUID, body)
+		s.callbacks.recordAttempt(response.UUID, err)
+		if err == nil {
+			return
+		}
+	}
+
+	s.callbacks.markFailed(response.UUID)
+	s.metrics.CallbackFailures.Inc()
+	s.logger.Warn("callback delivery failed",
+		"uuid", response.UUID,
+		"callback_url", callbackURL,
+		"attempts", callbackMaxAttempts,
+	)
+}
+
+// deliverCallback performs a single webhook delivery attempt
+func (s *Server) deliverCallback(client *http.Client, callbackURL, uuid string, body []byte) error {
+	req, err := http.NewRequest("POST", callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create callback request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Harlequin-Delivery", uuid)
+	if s.config.CallbackSecret != "" {
+		mac := hmac.New(sha256.New, []byte(s.config.CallbackSecret))
+		mac.Write(body)
+		req.Header.Set("X-Harlequin-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("callback request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// HandleListCallbacks handles GET /callbacks - lists webhook delivery statuses
+// @Summary List callback deliveries
+// @Description Get the delivery status of all signed-payload webhooks
+// @Tags Callbacks
+// @Produce json
+// @Success 200 {array} CallbackDelivery "Callback delivery statuses"
+// @Router /callbacks [get]
+func (s *Server) HandleListCallbacks(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"callbacks": s.callbacks.list(),
+	})
+}
+
+// HandleListDeadCallbacks handles GET /callbacks/dead - lists deliveries that
+// exhausted all retry attempts
+// @Summary List dead callback deliveries
+// @Description Get webhooks that could not be delivered after all retries
+// @Tags Callbacks
+// @Produce json
+// @Success 200 {array} CallbackDelivery "Dead callback deliveries"
+// @Router /callbacks/dead [get]
+func (s *Server) HandleListDeadCallbacks(c *gin.Context) {
+	dead := make([]*CallbackDelivery, 0)
+	for _, delivery := range s.callbacks.list() {
+		if delivery.Status == CallbackStatusFailed {
+			dead = append(dead, delivery)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"callbacks": dead,
+	})
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"crypto/rand"
+	"net/http"
+	"time"
+
+	goarUtils "github.com/everFinance/goar/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// HandleCreateDataItem handles POST /data-item - constructs an unsigned
+// ANS-104 data item server-side from raw data plus tags/target/anchor, so
+// clients do not need to re-implement data item assembly and deep hashing
+// @Summary Create an unsigned ANS-104 data item
+// @Description Construct an unsigned ANS-104 data item from raw data, tags, target, and anchor. Returns the exact bytes the wallet must sign and a signing URL.
+// @Tags Signing
+// @Accept json
+// @Produce json
+// @Param request body CreateDataItemRequest true "Data item construction request"
+// @Success 201 {object} CreateDataItemResponse "Data item constructed successfully"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 413 {object} ErrorResponse "Data too large"
+// @Router /data-item [post]
+func (s *Server) HandleCreateDataItem(c *gin.Context) {
+	var req CreateDataItemRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid JSON payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if len(req.Data) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Empty data",
+		})
+		return
+	}
+
+	if int64(len(req.Data)) > s.config.MaxDataSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error":    "Data too large",
+			"max_size": s.config.MaxDataSize,
+		})
+		return
+	}
+
+	// Enforce the per-client rate limit and the pending request cap
+	if !s.allowClient(c, req.ClientID) || !s.reservePending(c, 1) {
+		return
+	}
+
+	// Default to an Arweave signature
+	signatureType := req.SignatureType
+	if signatureType == 0 {
+		signatureType = 1
+	}
+
+	// Generate a random anchor if the caller did not provide one
+	anchor := req.Anchor
+	if anchor == "" {
+		anchorBytes := make([]byte, 32)
+		if _, err := rand.Read(anchorBytes); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to generate anchor",
+			})
+			return
+		}
+		anchor = goarUtils.Base64Encode(anchorBytes)
+	}
+
+	item, err := goarUtils.NewBundleItem(req.Owner, signatureType, req.Target, anchor, req.Data, req.Tags)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Failed to construct data item",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// Compute the deep-hash signature data - the exact bytes the wallet signs
+	dataToSign, err := goarUtils.BundleItemSignData(*item)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to compute signature data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	itemUUID := uuid.New().String()
+
+	signingRequest := &SigningRequest{
+		UUID:        itemUUID,
+		Data:        dataToSign,
+		CreatedAt:   time.Now(),
+		IsSigned:    false,
+		RequestedAt: time.Now(),
+		ClientID:    req.ClientID,
+		CallbackURL: req.CallbackURL,
+	}
+
+	s.mutex.Lock()
+	err = s.store.SaveRequest(signingRequest)
+	s.mutex.Unlock()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to store signing request",
+		})
+		return
+	}
+
+	s.metrics.RequestsSubmitted.Inc()
+	s.logger.Info("data item constructed",
+		"uuid", itemUUID,
+		"size", len(req.Data),
+		"tags", len(req.Tags),
+		"client_id", req.ClientID,
+	)
+
+	go s.setExpirationTimer(itemUUID)
+
+	c.JSON(http.StatusCreated, CreateDataItemResponse{
+		UUID:       itemUUID,
+		SigningURL: s.generateSigningURL(c, itemUUID),
+		DataToSign: dataToSign,
+		Anchor:     anchor,
+		Message:    "Data item constructed. Sign the returned bytes via the signing URL.",
+	})
+}
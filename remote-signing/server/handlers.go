@@ -1,8 +1,10 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -10,8 +12,10 @@ import (
 	"strings"
 	"time"
 
+	goarUtils "github.com/everFinance/goar/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/remote-signing/frontend"
 )
 
 // Note: Request/Response types are defined in api.go
@@ -72,6 +76,11 @@ func (s *Server) HandleSubmitData(c *gin.Context) {
 		return
 	}
 
+	// Enforce the per-client rate limit and the pending request cap
+	if !s.allowClient(c, req.ClientID) || !s.reservePending(c, 1) {
+		return
+	}
+
 	// Generate UUID for this signing request
 	itemUUID := uuid.New().String()
 
@@ -88,8 +97,21 @@ func (s *Server) HandleSubmitData(c *gin.Context) {
 
 	// Store the signing request
 	s.mutex.Lock()
-	s.signingRequests[itemUUID] = signingRequest
+	err := s.store.SaveRequest(signingRequest)
 	s.mutex.Unlock()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to store signing request",
+		})
+		return
+	}
+
+	s.metrics.RequestsSubmitted.Inc()
+	s.logger.Info("signing request submitted",
+		"uuid", itemUUID,
+		"size", len(req.Data),
+		"client_id", req.ClientID,
+	)
 
 	// Notify WebSocket clients about new signing request
 	s.hub.BroadcastToUUID(itemUUID, WebSocketMessage{
@@ -103,7 +125,7 @@ func (s *Server) HandleSubmitData(c *gin.Context) {
 	})
 
 	// Generate signing URL
-	signingURL := s.generateSigningURL(itemUUID)
+	signingURL := s.generateSigningURL(c, itemUUID)
 
 	// Set expiration timer
 	go s.setExpirationTimer(itemUUID)
@@ -123,6 +145,7 @@ func (s *Server) HandleSubmitData(c *gin.Context) {
 // @Param uuid path string true "Signing request UUID"
 // @Success 200 {string} binary "Raw binary data"
 // @Failure 404 {object} ErrorResponse "Signing request not found"
+// @Failure 410 {object} ErrorResponse "Signing request expired"
 // @Router /{uuid} [get]
 func (s *Server) HandleGetData(c *gin.Context) {
 	itemUUID := c.Param("uuid")
@@ -136,10 +159,17 @@ func (s *Server) HandleGetData(c *gin.Context) {
 	}
 
 	s.mutex.RLock()
-	signingRequest, exists := s.signingRequests[itemUUID]
+	signingRequest, exists := s.store.GetRequest(itemUUID)
 	s.mutex.RUnlock()
 
 	if !exists {
+		if s.isExpired(itemUUID) {
+			c.JSON(http.StatusGone, gin.H{
+				"error": "Signing request expired",
+				"uuid":  itemUUID,
+			})
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Signing request not found",
 			"uuid": itemUUID,
@@ -185,6 +215,7 @@ func (s *Server) HandleSubmitSignedData(c *gin.Context) {
 
 	// Handle both JSON and raw binary data
 	var signedData []byte
+	var signerAddress string
 	contentType := c.GetHeader("Content-Type")
 
 	if contentType == "application/json" {
@@ -197,6 +228,7 @@ func (s *Server) HandleSubmitSignedData(c *gin.Context) {
 			return
 		}
 		signedData = req.SignedData
+		signerAddress = req.SignerAddress
 	} else {
 		// Handle raw binary data
 		body, err := io.ReadAll(c.Request.Body)
@@ -217,7 +249,7 @@ func (s *Server) HandleSubmitSignedData(c *gin.Context) {
 	}
 
 	s.mutex.Lock()
-	signingRequest, exists := s.signingRequests[itemUUID]
+	signingRequest, exists := s.store.GetRequest(itemUUID)
 	if !exists {
 		s.mutex.Unlock()
 		c.JSON(http.StatusNotFound, gin.H{
@@ -236,9 +268,28 @@ func (s *Server) HandleSubmitSignedData(c *gin.Context) {
 		return
 	}
 
+	// Derive the signer address from the signed item when not provided explicitly
+	if signerAddress == "" {
+		if item, err := goarUtils.DecodeBundleItem(signedData); err == nil {
+			if addr, err := goarUtils.ItemSignerAddr(*item); err == nil {
+				signerAddress = addr
+			}
+		}
+	}
+
 	// Update the signing request with signed data
 	signingRequest.IsSigned = true
 	signingRequest.SignedData = signedData
+	signingRequest.SignedAt = time.Now()
+	signingRequest.SignerAddress = signerAddress
+
+	if err := s.store.SaveRequest(signingRequest); err != nil {
+		s.mutex.Unlock()
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to store signed data",
+		})
+		return
+	}
 
 	s.mutex.Unlock()
 
@@ -246,30 +297,70 @@ func (s *Server) HandleSubmitSignedData(c *gin.Context) {
 	signedResponse := &SignedResponse{
 		UUID:       itemUUID,
 		SignedData: signedData,
-		SignedAt:   time.Now(),
+		SignedAt:   signingRequest.SignedAt,
 		Success:    true,
 	}
 
+	s.metrics.RequestsSigned.Inc()
+	s.metrics.SigningLatency.Observe(signingRequest.SignedAt.Sub(signingRequest.CreatedAt).Seconds())
+	s.logger.Info("signing request completed",
+		"uuid", itemUUID,
+		"latency", signingRequest.SignedAt.Sub(signingRequest.CreatedAt).String(),
+		"signer", signerAddress,
+	)
+
+	signedPayload := map[string]interface{}{
+		"uuid":      itemUUID,
+		"signed_at": signedResponse.SignedAt,
+		"success":   true,
+	}
+
+	// Submit directly to the configured bundler and include the receipt in the
+	// signed-event payload
+	if s.config.BundlerURL != "" {
+		receipt, err := submitToBundler(c.Request.Context(), s.config.BundlerURL, signedData)
+		if err != nil {
+			signedPayload["bundler_error"] = err.Error()
+		} else {
+			signedPayload["bundler"] = receipt
+			if receiptJSON, err := json.Marshal(receipt); err == nil {
+				signingRequest.BundlerResponse = receiptJSON
+				s.mutex.Lock()
+				s.store.SaveRequest(signingRequest)
+				s.mutex.Unlock()
+			}
+		}
+	}
+
 	// Notify SSE clients about successful signing (metadata only)
 	s.hub.BroadcastSSEToUUID(itemUUID, SSEEvent{
 		Type: "signed",
-		Data: map[string]interface{}{
-			"uuid":      itemUUID,
-			"signed_at": signedResponse.SignedAt,
-			"success":   true,
-		},
+		Data: signedPayload,
 	})
 
+	// If the item belongs to a session, notify session-level listeners too
+	if signingRequest.SessionUUID != "" {
+		s.notifySessionProgress(signingRequest.SessionUUID, signingRequest)
+	}
+
 	// If there's a callback URL, notify the original client
 	if signingRequest.CallbackURL != "" {
 		go s.notifyCallback(signingRequest.CallbackURL, signedResponse)
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"message": "Data signed successfully",
 		"uuid": itemUUID,
 		"signed_at": signedResponse.SignedAt,
-	})
+	}
+	if bundler, ok := signedPayload["bundler"]; ok {
+		response["bundler"] = bundler
+	}
+	if bundlerErr, ok := signedPayload["bundler_error"]; ok {
+		response["bundler_error"] = bundlerErr
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // HandleGetSignedData serves the signed binary data for a specific signing request
@@ -293,10 +384,17 @@ func (s *Server) HandleGetSignedData(c *gin.Context) {
 	}
 
 	s.mutex.RLock()
-	signingRequest, exists := s.signingRequests[itemUUID]
+	signingRequest, exists := s.store.GetRequest(itemUUID)
 	s.mutex.RUnlock()
 
 	if !exists {
+		if s.isExpired(itemUUID) {
+			c.JSON(http.StatusGone, gin.H{
+				"error": "Signing request expired",
+				"uuid":  itemUUID,
+			})
+			return
+		}
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Signing request not found",
 		})
@@ -344,10 +442,16 @@ func (s *Server) HandleGetSigningForm(c *gin.Context) {
 	}
 
 	s.mutex.RLock()
-	signingRequest, exists := s.signingRequests[itemUUID]
+	signingRequest, exists := s.store.GetRequest(itemUUID)
 	s.mutex.RUnlock()
 
 	if !exists {
+		// The UUID may identify a multi-item session rather than a single item
+		if len(s.sessionItems(itemUUID)) > 0 {
+			serveReactApp(c, "Harlequin Remote Signing")
+			return
+		}
+
 		c.HTML(http.StatusNotFound, "error.html", gin.H{
 			"error": "Signing request not found or expired",
 			"uuid": itemUUID,
@@ -375,18 +479,21 @@ func (s *Server) HandleGetSigningForm(c *gin.Context) {
 // @Router /status [get]
 func (s *Server) HandleGetStatus(c *gin.Context) {
 	s.mutex.RLock()
-	totalRequests := len(s.signingRequests)
+	requests := s.store.ListRequests()
+	expiredRequests := len(s.expired)
+	s.mutex.RUnlock()
+
+	totalRequests := len(requests)
 	signedRequests := 0
 	pendingRequests := 0
 
-	for _, req := range s.signingRequests {
+	for _, req := range requests {
 		if req.IsSigned {
 			signedRequests++
 		} else {
 			pendingRequests++
 		}
 	}
-	s.mutex.RUnlock()
 
 	connectedClients := s.hub.GetClientCount()
 
@@ -394,63 +501,232 @@ func (s *Server) HandleGetStatus(c *gin.Context) {
 		"server": gin.H{
 			"status": "running",
 			"uptime": time.Since(s.startTime).String(),
-			"version": "1.0.0",
+			"started_at": s.startTime.UTC().Format(time.RFC3339),
+			"version": Version,
 		},
 		"requests": gin.H{
 			"total": totalRequests,
 			"signed": signedRequests,
 			"pending": pendingRequests,
+			"expired": expiredRequests,
 		},
 		"websockets": gin.H{
 			"connected_clients": connectedClients,
 		},
+		"storage": gin.H{
+			"backend": StorageBackendName(s.store),
+			"path": s.config.StoragePath,
+		},
 		"config": gin.H{
 			"host": s.config.Host,
 			"port": s.config.Port,
 			"max_data_size": s.config.MaxDataSize,
 			"signing_timeout": s.config.SigningTimeout.String(),
+			"max_ws_clients": s.config.MaxWSClients,
+			"max_sse_clients": s.config.MaxSSEClients,
+			"rate_limit_per_ip": s.config.RateLimitPerIP,
+			"rate_limit_per_client": s.config.RateLimitPerClient,
+			"max_pending_requests": s.config.MaxPendingRequests,
+			"pending_overflow_policy": s.config.PendingOverflowPolicy,
+			"bundler_url": s.config.BundlerURL,
 		},
 	})
 }
 
 // Helper methods
 
-func (s *Server) generateSigningURL(uuid string) string {
+func (s *Server) generateSigningURL(c *gin.Context, uuid string) string {
 	// If a custom frontend URL is configured, use it as the host
-	// Otherwise, use the server URL as the host
-	hostURL := s.getServerURL()
+	// Otherwise, use the externally-visible server URL as the host
+	serverURL := s.requestBaseURL(c)
+	hostURL := serverURL
 	if s.config.FrontendURL != "" {
 		hostURL = s.config.FrontendURL
 	}
 
 	// Always include the server parameter so the frontend knows where to make API calls
-	return fmt.Sprintf("%s/sign/%s?server=%s", hostURL, uuid, s.getServerURL())
+	return fmt.Sprintf("%s/sign/%s?server=%s", hostURL, uuid, serverURL)
+}
+
+// requestBaseURL resolves the base URL clients should use to reach this
+// server: the configured public URL if set, then the X-Forwarded-* headers a
+// reverse proxy adds, and finally the listen host/port.
+func (s *Server) requestBaseURL(c *gin.Context) string {
+	if s.config.PublicURL != "" {
+		return strings.TrimRight(s.config.PublicURL, "/")
+	}
+	if c != nil {
+		if host := c.GetHeader("X-Forwarded-Host"); host != "" {
+			scheme := c.GetHeader("X-Forwarded-Proto")
+			if scheme == "" {
+				scheme = "http"
+			}
+			return scheme + "://" + host
+		}
+	}
+	return s.getServerURL()
 }
 
 func (s *Server) getServerURL() string {
-	return fmt.Sprintf("http://%s:%d", s.config.Host, s.config.Port)
+	scheme := "http"
+	if s.config.TLSCertFile != "" && s.config.TLSKeyFile != "" {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, s.config.Host, s.config.Port)
 }
 
 func (s *Server) getWebSocketURL() string {
-	return fmt.Sprintf("ws://%s:%d/ws", s.config.Host, s.config.Port)
+	serverURL := s.getServerURL()
+	if strings.HasPrefix(serverURL, "https://") {
+		return "wss://" + strings.TrimPrefix(serverURL, "https://") + "/ws"
+	}
+	return "ws://" + strings.TrimPrefix(serverURL, "http://") + "/ws"
 }
 
+// expiredRetention bounds how long expired UUIDs are remembered for 410
+// responses before they fall back to plain 404s
+const expiredRetention = 24 * time.Hour
+
 func (s *Server) setExpirationTimer(uuid string) {
 	time.Sleep(s.config.SigningTimeout)
 
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	signingRequest, exists := s.store.GetRequest(uuid)
+	if !exists || signingRequest.IsSigned {
+		s.mutex.Unlock()
+		return
+	}
+	if err := s.store.DeleteRequest(uuid); err != nil {
+		s.mutex.Unlock()
+		return
+	}
+	s.markExpiredLocked(uuid)
+	s.mutex.Unlock()
+
+	s.metrics.RequestsExpired.Inc()
+	s.logger.Info("signing request expired", "uuid", uuid)
+
+	// Notify WebSocket and SSE clients about expiration
+	expiredAt := time.Now()
+	s.hub.BroadcastToUUID(uuid, WebSocketMessage{
+		Type: MessageTypeExpired,
+		UUID: uuid,
+		Payload: map[string]interface{}{
+			"status":     "expired",
+			"message":    "Signing request expired",
+			"expired_at": expiredAt,
+		},
+	})
+	s.hub.BroadcastSSEToUUID(uuid, SSEEvent{
+		Type: "expired",
+		Data: map[string]interface{}{
+			"uuid":       uuid,
+			"expired_at": expiredAt,
+		},
+	})
+}
+
+// markExpiredLocked records an expired UUID and prunes stale entries. The
+// caller must hold s.mutex.
+func (s *Server) markExpiredLocked(uuid string) {
+	cutoff := time.Now().Add(-expiredRetention)
+	for expiredUUID, expiredAt := range s.expired {
+		if expiredAt.Before(cutoff) {
+			delete(s.expired, expiredUUID)
+		}
+	}
+	s.expired[uuid] = time.Now()
+}
+
+// isExpired reports whether a UUID belonged to a signing request that expired
+// unsigned within the retention window
+func (s *Server) isExpired(uuid string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	_, expired := s.expired[uuid]
+	return expired
+}
+
+// HandleCancelRequest handles DELETE /<uuid> - cancels a pending signing request
+// @Summary Cancel a signing request
+// @Description Cancel a pending signing request that is no longer needed. Signed requests cannot be cancelled.
+// @Tags Signing
+// @Produce json
+// @Param uuid path string true "Signing request UUID"
+// @Success 200 {object} SuccessResponse "Signing request cancelled"
+// @Failure 404 {object} ErrorResponse "Signing request not found"
+// @Failure 409 {object} ErrorResponse "Request already signed"
+// @Router /{uuid} [delete]
+func (s *Server) HandleCancelRequest(c *gin.Context) {
+	itemUUID := c.Param("uuid")
+
+	// Validate UUID format
+	if _, err := uuid.Parse(itemUUID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid UUID format",
+		})
+		return
+	}
+
+	s.mutex.Lock()
+	signingRequest, exists := s.store.GetRequest(itemUUID)
+	if !exists {
+		s.mutex.Unlock()
+		if s.isExpired(itemUUID) {
+			c.JSON(http.StatusGone, gin.H{
+				"error": "Signing request expired",
+				"uuid":  itemUUID,
+			})
+			return
+		}
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Signing request not found",
+			"uuid":  itemUUID,
+		})
+		return
+	}
 
-	if signingRequest, exists := s.signingRequests[uuid]; exists && !signingRequest.IsSigned {
-		delete(s.signingRequests, uuid)
+	if signingRequest.IsSigned {
+		s.mutex.Unlock()
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Data already signed",
+			"uuid":  itemUUID,
+		})
+		return
+	}
 
-		// Notify WebSocket clients about expiration
-		s.hub.BroadcastToUUID(uuid, WebSocketMessage{
-			Type: MessageTypeError,
-			UUID: uuid,
-			Error: "Signing request expired",
+	if err := s.store.DeleteRequest(itemUUID); err != nil {
+		s.mutex.Unlock()
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to cancel signing request",
 		})
+		return
 	}
+	s.mutex.Unlock()
+
+	s.metrics.RequestsCancelled.Inc()
+	s.logger.Info("signing request cancelled", "uuid", itemUUID)
+
+	// Notify listeners so any open signing page can stop waiting
+	s.hub.BroadcastToUUID(itemUUID, WebSocketMessage{
+		Type: MessageTypeCancelled,
+		UUID: itemUUID,
+		Payload: map[string]interface{}{
+			"status":  "cancelled",
+			"message": "Signing request cancelled by the client",
+		},
+	})
+	s.hub.BroadcastSSEToUUID(itemUUID, SSEEvent{
+		Type: "cancelled",
+		Data: map[string]interface{}{
+			"uuid": itemUUID,
+		},
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Signing request cancelled",
+		"uuid":    itemUUID,
+	})
 }
 
 // HandleSSE handles GET /events/:uuid - Server-Sent Events for real-time updates
@@ -472,6 +748,14 @@ func (s *Server) HandleSSE(c *gin.Context) {
 		return
 	}
 
+	// Refuse new streams once the configured limit is reached
+	if s.config.MaxSSEClients > 0 && s.hub.GetSSEClientCount() >= s.config.MaxSSEClients {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Too many concurrent event streams",
+		})
+		return
+	}
+
 	// Set headers for SSE
 	c.Header("Content-Type", "text/event-stream")
 	c.Header("Cache-Control", "no-cache")
@@ -480,7 +764,7 @@ func (s *Server) HandleSSE(c *gin.Context) {
 	c.Header("Access-Control-Allow-Headers", "Cache-Control")
 
 	// Create a channel for this client
-	clientChan := make(chan SSEEvent, 10)
+	clientChan := make(chan SSEEvent, sseClientQueueSize)
 	clientID := uuid.New().String()
 
 	// Register this client
@@ -489,7 +773,7 @@ func (s *Server) HandleSSE(c *gin.Context) {
 
 	// Check if signing is already complete
 	s.mutex.RLock()
-	signingRequest, exists := s.signingRequests[itemUUID]
+	signingRequest, exists := s.store.GetRequest(itemUUID)
 	isSigned := exists && signingRequest.IsSigned
 	s.mutex.RUnlock()
 
@@ -526,8 +810,8 @@ func (s *Server) HandleSSE(c *gin.Context) {
 			c.SSEvent(event.Type, event.Data)
 			c.Writer.Flush()
 
-			// If it's a signed event, close the connection
-			if event.Type == "signed" {
+			// Terminal events end the stream
+			if event.Type == "signed" || event.Type == "expired" || event.Type == "cancelled" {
 				return
 			}
 		case <-ticker.C:
@@ -554,40 +838,37 @@ func (s *Server) HandleHealth(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"status":    "healthy",
 		"timestamp": time.Now().Unix(),
-		"version":   "1.0.0",
+		"uptime":    time.Since(s.startTime).String(),
+		"version":   Version,
 	})
 }
 
-func (s *Server) notifyCallback(callbackURL string, response *SignedResponse) {
-	// TODO: Implement HTTP callback notification
-	// This would make an HTTP POST request to the callback URL with the signed response
-}
-
 // serveReactApp is a helper function to serve the React app with the correct assets
 func serveReactApp(c *gin.Context, title string) {
 	c.Header("Content-Type", "text/html")
 
-	// Get the frontend path
-	_, filename, _, _ := runtime.Caller(0)
-	serverDir := filepath.Dir(filename)
-	projectRoot := filepath.Join(serverDir, "..")
-	frontendPath := filepath.Join(projectRoot, "frontend/dist")
-	assetsPath := filepath.Join(frontendPath, "assets")
-
-	// Find the current JS and CSS files
+	// Find the current JS and CSS files, preferring the assets embedded in the
+	// binary over a dist directory on disk
 	var jsFile, cssFile string
-	if entries, err := os.ReadDir(assetsPath); err == nil {
-		for _, entry := range entries {
-			if !entry.IsDir() {
-				name := entry.Name()
-				if strings.HasSuffix(name, ".js") && strings.HasPrefix(name, "index-") {
-					jsFile = name
-				} else if strings.HasSuffix(name, ".css") && strings.HasPrefix(name, "index-") {
-					cssFile = name
-				}
+	if frontend.Available() {
+		if distFS, err := frontend.Dist(); err == nil {
+			if entries, err := fs.ReadDir(distFS, "assets"); err == nil {
+				jsFile, cssFile = findIndexAssets(entries)
 			}
 		}
 	}
+	if jsFile == "" && cssFile == "" {
+		// Get the frontend path of a source checkout
+		_, filename, _, _ := runtime.Caller(0)
+		serverDir := filepath.Dir(filename)
+		projectRoot := filepath.Join(serverDir, "..")
+		frontendPath := filepath.Join(projectRoot, "frontend/dist")
+		assetsPath := filepath.Join(frontendPath, "assets")
+
+		if entries, err := os.ReadDir(assetsPath); err == nil {
+			jsFile, cssFile = findIndexAssets(entries)
+		}
+	}
 
 	// Fallback to default names if not found
 	if jsFile == "" {
@@ -613,3 +894,20 @@ func serveReactApp(c *gin.Context, title string) {
 </html>`, title, jsFile, cssFile)
 	c.Data(http.StatusOK, "text/html", []byte(indexHTML))
 }
+
+// findIndexAssets picks the hashed index-*.js and index-*.css files out of a
+// build output directory listing
+func findIndexAssets(entries []fs.DirEntry) (jsFile, cssFile string) {
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if strings.HasSuffix(name, ".js") && strings.HasPrefix(name, "index-") {
+			jsFile = name
+		} else if strings.HasSuffix(name, ".css") && strings.HasPrefix(name, "index-") {
+			cssFile = name
+		}
+	}
+	return jsFile, cssFile
+}
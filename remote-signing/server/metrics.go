@@ -0,0 +1,130 @@
+package server
+
+import (
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the Prometheus collectors exported under /metrics. Operators
+// embedding the server can provide their own registry via Config.MetricsRegistry
+// to scrape these alongside their own collectors.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	RequestsSubmitted   prometheus.Counter
+	RequestsSigned      prometheus.Counter
+	RequestsExpired     prometheus.Counter
+	RequestsCancelled   prometheus.Counter
+	RequestsRateLimited prometheus.Counter
+	RequestsEvicted     prometheus.Counter
+	CallbackFailures    prometheus.Counter
+	SigningLatency      prometheus.Histogram
+}
+
+// newMetrics creates and registers the server's collectors on the given
+// registry, including gauges tracking live WebSocket and SSE connections
+func newMetrics(registry *prometheus.Registry, hub *WebSocketHub) *Metrics {
+	m := &Metrics{
+		registry: registry,
+		RequestsSubmitted: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "harlequin_signing_requests_submitted_total",
+			Help: "Total number of signing requests submitted",
+		}),
+		RequestsSigned: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "harlequin_signing_requests_signed_total",
+			Help: "Total number of signing requests completed",
+		}),
+		RequestsExpired: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "harlequin_signing_requests_expired_total",
+			Help: "Total number of signing requests that expired unsigned",
+		}),
+		RequestsCancelled: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "harlequin_signing_requests_cancelled_total",
+			Help: "Total number of signing requests cancelled by clients before signing",
+		}),
+		RequestsRateLimited: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "harlequin_signing_requests_rate_limited_total",
+			Help: "Total number of submissions rejected by the per-IP or per-client rate limits",
+		}),
+		RequestsEvicted: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "harlequin_signing_requests_evicted_total",
+			Help: "Total number of pending requests evicted to stay under the pending cap",
+		}),
+		CallbackFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "harlequin_callback_failures_total",
+			Help: "Total number of webhook deliveries that exhausted all retries",
+		}),
+		SigningLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "harlequin_signing_latency_seconds",
+			Help:    "Time from submission to signature in seconds",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 12), // 1s .. ~1h
+		}),
+	}
+
+	registry.MustRegister(
+		m.RequestsSubmitted,
+		m.RequestsSigned,
+		m.RequestsExpired,
+		m.RequestsCancelled,
+		m.RequestsRateLimited,
+		m.RequestsEvicted,
+		m.CallbackFailures,
+		m.SigningLatency,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "harlequin_websocket_connections",
+			Help: "Number of currently connected WebSocket clients",
+		}, func() float64 { return float64(hub.GetClientCount()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "harlequin_sse_connections",
+			Help: "Number of currently connected SSE clients",
+		}, func() float64 { return float64(hub.GetSSEClientCount()) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "harlequin_notifications_dropped_total",
+			Help: "Notifications dropped because a client queue was full",
+		}, func() float64 { return float64(hub.DroppedMessageCount()) }),
+	)
+
+	return m
+}
+
+// Handler returns the HTTP handler serving the /metrics endpoint
+func (m *Metrics) Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+}
+
+// defaultLogger returns the structured JSON logger used when the embedding
+// application does not provide one via Config.Logger
+func defaultLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, nil))
+}
+
+// requestLogger is a gin middleware that assigns each request an ID and emits
+// a structured log line per request
+func (s *Server) requestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader("X-Request-ID")
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+		c.Set("request_id", requestID)
+		c.Header("X-Request-ID", requestID)
+
+		start := time.Now()
+		c.Next()
+
+		s.logger.Info("request",
+			"request_id", requestID,
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", c.Writer.Status(),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"client_ip", c.ClientIP(),
+		)
+	}
+}
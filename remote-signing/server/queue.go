@@ -0,0 +1,132 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HandleListPending handles GET /pending - lists signing requests awaiting
+// signature so the frontend can present a queue instead of one UUID at a time
+// @Summary List pending signing requests
+// @Description Get all signing requests awaiting signature, optionally filtered by client ID
+// @Tags Signing
+// @Produce json
+// @Param client_id query string false "Only return requests submitted by this client"
+// @Success 200 {array} PendingItem "Pending signing requests"
+// @Router /pending [get]
+func (s *Server) HandleListPending(c *gin.Context) {
+	clientID := c.Query("client_id")
+
+	s.mutex.RLock()
+	requests := s.store.ListRequests()
+	s.mutex.RUnlock()
+
+	pending := make([]PendingItem, 0)
+	for _, req := range requests {
+		if req.IsSigned {
+			continue
+		}
+		if clientID != "" && req.ClientID != clientID {
+			continue
+		}
+		pending = append(pending, PendingItem{
+			UUID:        req.UUID,
+			CreatedAt:   req.CreatedAt,
+			Size:        len(req.Data),
+			ClientID:    req.ClientID,
+			SessionUUID: req.SessionUUID,
+			ItemName:    req.ItemName,
+		})
+	}
+
+	// Oldest first, so the queue is processed in submission order
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"pending": pending,
+		"total":   len(pending),
+	})
+}
+
+// HandleRejectPending handles POST /pending/reject - rejects pending signing
+// requests in bulk, removing them and notifying waiting clients
+// @Summary Reject pending signing requests
+// @Description Reject one or more pending signing requests in bulk. Signed requests cannot be rejected.
+// @Tags Signing
+// @Accept json
+// @Produce json
+// @Param request body RejectRequest true "UUIDs to reject"
+// @Success 200 {object} SuccessResponse "Rejection results"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Router /pending/reject [post]
+func (s *Server) HandleRejectPending(c *gin.Context) {
+	var req RejectRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid JSON payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if len(req.UUIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "No UUIDs provided",
+		})
+		return
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = "Signing request rejected"
+	}
+
+	rejected := make([]string, 0, len(req.UUIDs))
+	skipped := make([]string, 0)
+
+	for _, itemUUID := range req.UUIDs {
+		s.mutex.Lock()
+		signingRequest, exists := s.store.GetRequest(itemUUID)
+		if !exists || signingRequest.IsSigned {
+			s.mutex.Unlock()
+			skipped = append(skipped, itemUUID)
+			continue
+		}
+		err := s.store.DeleteRequest(itemUUID)
+		s.mutex.Unlock()
+		if err != nil {
+			skipped = append(skipped, itemUUID)
+			continue
+		}
+
+		rejected = append(rejected, itemUUID)
+		s.logger.Info("signing request rejected", "uuid", itemUUID, "reason", reason)
+
+		// Notify waiting clients on both channels
+		s.hub.BroadcastToUUID(itemUUID, WebSocketMessage{
+			Type:  MessageTypeError,
+			UUID:  itemUUID,
+			Error: reason,
+		})
+		s.hub.BroadcastSSEToUUID(itemUUID, SSEEvent{
+			Type: "rejected",
+			Data: map[string]interface{}{
+				"uuid":        itemUUID,
+				"reason":      reason,
+				"rejected_at": time.Now(),
+			},
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Rejection processed",
+		"rejected": rejected,
+		"skipped":  skipped,
+	})
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitWindow is the sliding window over which the per-IP and
+// per-client-ID submission limits are counted
+const rateLimitWindow = time.Minute
+
+// Policies applied when a submission would exceed MaxPendingRequests
+const (
+	// PendingPolicyReject refuses the submission with HTTP 429 (the default)
+	PendingPolicyReject = "reject"
+	// PendingPolicyEvictOldest drops the oldest pending requests to make room
+	PendingPolicyEvictOldest = "evict-oldest"
+)
+
+// rateLimiter counts events per key over a sliding window. A nil limiter or
+// one with no limit allows everything.
+type rateLimiter struct {
+	mutex  sync.Mutex
+	limit  int
+	window time.Duration
+	hits   map[string][]time.Time
+}
+
+// newRateLimiter creates a limiter allowing limit events per key per window
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:  limit,
+		window: window,
+		hits:   make(map[string][]time.Time),
+	}
+}
+
+// Allow records an event for the key and reports whether it stays within the
+// limit
+func (rl *rateLimiter) Allow(key string) bool {
+	if rl == nil || rl.limit <= 0 {
+		return true
+	}
+
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	// Drop events that have slid out of the window
+	cutoff := time.Now().Add(-rl.window)
+	recent := rl.hits[key][:0]
+	for _, hit := range rl.hits[key] {
+		if hit.After(cutoff) {
+			recent = append(recent, hit)
+		}
+	}
+
+	if len(recent) >= rl.limit {
+		rl.hits[key] = recent
+		return false
+	}
+	rl.hits[key] = append(recent, time.Now())
+	return true
+}
+
+// rateLimitMiddleware rejects submissions from IPs that exceed the configured
+// per-IP rate with HTTP 429. The per-client-ID limit is enforced by the
+// handlers via allowClient once the submission has been parsed.
+func (s *Server) rateLimitMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !s.ipLimiter.Allow(c.ClientIP()) {
+			s.metrics.RequestsRateLimited.Inc()
+			s.logger.Warn("submission rate limit exceeded", "client_ip", c.ClientIP())
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":          "Rate limit exceeded for this IP",
+				"limit":          s.config.RateLimitPerIP,
+				"window_seconds": int(rateLimitWindow.Seconds()),
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// allowClient enforces the per-client-ID rate limit, responding with HTTP 429
+// and returning false when the client has exceeded it. Submissions without a
+// client ID are only subject to the per-IP limit.
+func (s *Server) allowClient(c *gin.Context, clientID string) bool {
+	if clientID == "" || s.clientLimiter.Allow(clientID) {
+		return true
+	}
+
+	s.metrics.RequestsRateLimited.Inc()
+	s.logger.Warn("client submission rate limit exceeded", "client_id", clientID)
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error":          "Rate limit exceeded for this client ID",
+		"limit":          s.config.RateLimitPerClient,
+		"window_seconds": int(rateLimitWindow.Seconds()),
+	})
+	return false
+}
+
+// reservePending enforces MaxPendingRequests before incoming new signing
+// requests are stored. Under the evict-oldest policy the oldest pending
+// requests are dropped to make room; otherwise the submission is refused with
+// HTTP 429 and false is returned.
+func (s *Server) reservePending(c *gin.Context, incoming int) bool {
+	if s.config.MaxPendingRequests <= 0 {
+		return true
+	}
+
+	s.mutex.Lock()
+	var pending []*SigningRequest
+	for _, req := range s.store.ListRequests() {
+		if !req.IsSigned {
+			pending = append(pending, req)
+		}
+	}
+
+	overflow := len(pending) + incoming - s.config.MaxPendingRequests
+	if overflow <= 0 {
+		s.mutex.Unlock()
+		return true
+	}
+
+	if s.config.PendingOverflowPolicy != PendingPolicyEvictOldest {
+		s.mutex.Unlock()
+		s.logger.Warn("pending request cap reached",
+			"pending", len(pending),
+			"max_pending", s.config.MaxPendingRequests,
+		)
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error":       "Too many pending signing requests",
+			"max_pending": s.config.MaxPendingRequests,
+		})
+		return false
+	}
+
+	// Evict the oldest pending requests to make room for the new submission
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+	if overflow > len(pending) {
+		overflow = len(pending)
+	}
+	evicted := pending[:overflow]
+	for _, req := range evicted {
+		if err := s.store.DeleteRequest(req.UUID); err != nil {
+			s.logger.Error("failed to evict pending request", "uuid", req.UUID, "error", err)
+		}
+	}
+	s.mutex.Unlock()
+
+	for _, req := range evicted {
+		s.metrics.RequestsEvicted.Inc()
+		s.logger.Info("pending signing request evicted", "uuid", req.UUID)
+		s.hub.BroadcastToUUID(req.UUID, WebSocketMessage{
+			Type:  MessageTypeError,
+			UUID:  req.UUID,
+			Error: "Signing request evicted to make room for new submissions",
+		})
+	}
+	return true
+}
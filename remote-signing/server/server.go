@@ -3,7 +3,8 @@ package server
 import (
 	"context"
 	"fmt"
-	"log"
+	"io/fs"
+	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -13,33 +14,84 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	_ "github.com/the-permaweb-harlequin/harlequin-toolkit/remote-signing/docs"
+	"github.com/the-permaweb-harlequin/harlequin-toolkit/remote-signing/frontend"
 )
 
+// Version is the server version reported by the /health and /status
+// endpoints. Embedding applications may overwrite it at startup.
+var Version = "1.0.0"
+
 // Server represents the remote signing server
 type Server struct {
-	config          *Config
-	server          *http.Server
-	hub             *WebSocketHub
-	signingRequests map[string]*SigningRequest
-	mutex           sync.RWMutex
-	isRunning       bool
-	startTime       time.Time
+	config    *Config
+	server    *http.Server
+	hub       *WebSocketHub
+	store     Storage
+	callbacks *callbackTracker
+	metrics   *Metrics
+	logger    *slog.Logger
+	ipLimiter     *rateLimiter
+	clientLimiter *rateLimiter
+	expired   map[string]time.Time // Recently expired request UUIDs, so fetches can return 410 instead of 404
+	mutex     sync.RWMutex // Serializes read-modify-write cycles on signing requests
+	isRunning bool
+	startTime time.Time
 }
 
-// New creates a new remote signing server
+// New creates a new remote signing server using the storage backend selected
+// by the configuration. If the configured storage cannot be opened the server
+// falls back to in-memory storage so it stays usable.
 func New(config *Config) *Server {
 	if config == nil {
 		config = DefaultConfig()
 	}
 
+	store, err := NewStorage(config)
+	if err != nil {
+		defaultLogger().Warn("failed to open storage, falling back to in-memory storage", "error", err)
+		store = NewMemoryStorage()
+	}
+
+	return NewWithStorage(config, store)
+}
+
+// NewWithStorage creates a new remote signing server with an explicit storage
+// backend, for callers that provide their own Storage implementation
+func NewWithStorage(config *Config, store Storage) *Server {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	if store == nil {
+		store = NewMemoryStorage()
+	}
+
+	logger := config.Logger
+	if logger == nil {
+		logger = defaultLogger()
+	}
+
+	registry := config.MetricsRegistry
+	if registry == nil {
+		registry = prometheus.NewRegistry()
+	}
+
+	hub := NewWebSocketHub()
+
 	return &Server{
-		config:          config,
-		hub:             NewWebSocketHub(),
-		signingRequests: make(map[string]*SigningRequest),
-		isRunning:       false,
+		config:    config,
+		hub:       hub,
+		store:     store,
+		callbacks: newCallbackTracker(),
+		metrics:   newMetrics(registry, hub),
+		logger:    logger,
+		ipLimiter:     newRateLimiter(config.RateLimitPerIP, rateLimitWindow),
+		clientLimiter: newRateLimiter(config.RateLimitPerClient, rateLimitWindow),
+		expired:   make(map[string]time.Time),
+		isRunning: false,
 	}
 }
 
@@ -59,13 +111,20 @@ func (s *Server) StartWithTemplates(ctx context.Context, templatePath string) er
 	// Start WebSocket hub
 	go s.hub.Run()
 
+	// Re-arm expiration timers for pending requests restored from storage
+	for uuid, req := range s.store.ListRequests() {
+		if !req.IsSigned {
+			go s.setExpirationTimer(uuid)
+		}
+	}
+
 	// Configure Gin
 	if gin.Mode() != gin.DebugMode {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	router := gin.New()
-	router.Use(gin.Logger())
+	router.Use(s.requestLogger())
 	router.Use(gin.Recovery())
 	router.Use(s.corsMiddleware())
 
@@ -74,10 +133,29 @@ func (s *Server) StartWithTemplates(ctx context.Context, templatePath string) er
 		router.LoadHTMLGlob(templatePath + "/*")
 	}
 
+	// Submission endpoints share the per-IP rate limit
+	submitLimit := s.rateLimitMiddleware()
+
 	// API routes
-	router.POST("/", s.HandleSubmitData)
+	router.POST("/", submitLimit, s.HandleSubmitData)
 	router.GET("/:uuid", s.HandleGetData)
 	router.POST("/:uuid", s.HandleSubmitSignedData)
+	router.DELETE("/:uuid", s.HandleCancelRequest)
+
+	// Server-side ANS-104 construction
+	router.POST("/data-item", submitLimit, s.HandleCreateDataItem)
+
+	// Session routes for multi-item signing
+	router.POST("/session", submitLimit, s.HandleSubmitSession)
+	router.GET("/session/:uuid", s.HandleGetSession)
+
+	// Pending queue routes
+	router.GET("/pending", s.HandleListPending)
+	router.POST("/pending/reject", s.HandleRejectPending)
+
+	// Callback delivery status routes
+	router.GET("/callbacks", s.HandleListCallbacks)
+	router.GET("/callbacks/dead", s.HandleListDeadCallbacks)
 
 	// WebSocket endpoint
 	router.GET("/ws", s.HandleWebSocket)
@@ -85,39 +163,53 @@ func (s *Server) StartWithTemplates(ctx context.Context, templatePath string) er
 	// SSE endpoint for real-time updates
 	router.GET("/events/:uuid", s.HandleSSE)
 
-	// Serve static frontend files
-	frontendPath := s.config.FrontendPath
-	if frontendPath == "" {
-		// Auto-detect frontend path relative to server package
-		_, filename, _, _ := runtime.Caller(0)
-		serverDir := filepath.Dir(filename)
-		projectRoot := filepath.Join(serverDir, "..")
-		frontendPath = filepath.Join(projectRoot, "frontend/dist")
-	}
-
-	// Check if frontend assets exist
-	assetsPath := filepath.Join(frontendPath, "assets")
-	if _, err := os.Stat(assetsPath); err == nil {
-		router.Static("/static", assetsPath)
+	// Serve static frontend files: a configured path on disk wins, then the
+	// assets embedded in the binary, then the dist directory of a source
+	// checkout
+	if s.config.FrontendPath == "" && frontend.Available() {
+		distFS, err := frontend.Dist()
+		if err == nil {
+			s.logger.Info("serving embedded frontend assets")
+			assetsFS, _ := fs.Sub(distFS, "assets")
+			router.StaticFS("/static", http.FS(assetsFS))
+			router.StaticFileFS("/favicon.ico", "vite.svg", http.FS(distFS))
+			router.StaticFileFS("/harlequin_mascot.png", "harlequin_mascot.png", http.FS(distFS))
+			router.StaticFileFS("/harlequin_mascot_dark.png", "harlequin_mascot_dark.png", http.FS(distFS))
+		}
 	} else {
-		log.Printf("⚠️  Frontend assets not found at %s, static files will not be served", assetsPath)
-	}
+		frontendPath := s.config.FrontendPath
+		if frontendPath == "" {
+			// Auto-detect frontend path relative to server package
+			_, filename, _, _ := runtime.Caller(0)
+			serverDir := filepath.Dir(filename)
+			projectRoot := filepath.Join(serverDir, "..")
+			frontendPath = filepath.Join(projectRoot, "frontend/dist")
+		}
 
-	// Serve favicon
-	faviconPath := filepath.Join(frontendPath, "vite.svg")
-	if _, err := os.Stat(faviconPath); err == nil {
-		router.StaticFile("/favicon.ico", faviconPath)
-	}
+		// Check if frontend assets exist
+		assetsPath := filepath.Join(frontendPath, "assets")
+		if _, err := os.Stat(assetsPath); err == nil {
+			router.Static("/static", assetsPath)
+		} else {
+			s.logger.Warn("frontend assets not found, static files will not be served", "path", assetsPath)
+		}
 
-	// Serve Harlequin mascot images
-	mascotPath := filepath.Join(frontendPath, "harlequin_mascot.png")
-	if _, err := os.Stat(mascotPath); err == nil {
-		router.StaticFile("/harlequin_mascot.png", mascotPath)
-	}
+		// Serve favicon
+		faviconPath := filepath.Join(frontendPath, "vite.svg")
+		if _, err := os.Stat(faviconPath); err == nil {
+			router.StaticFile("/favicon.ico", faviconPath)
+		}
 
-	mascotDarkPath := filepath.Join(frontendPath, "harlequin_mascot_dark.png")
-	if _, err := os.Stat(mascotDarkPath); err == nil {
-		router.StaticFile("/harlequin_mascot_dark.png", mascotDarkPath)
+		// Serve Harlequin mascot images
+		mascotPath := filepath.Join(frontendPath, "harlequin_mascot.png")
+		if _, err := os.Stat(mascotPath); err == nil {
+			router.StaticFile("/harlequin_mascot.png", mascotPath)
+		}
+
+		mascotDarkPath := filepath.Join(frontendPath, "harlequin_mascot_dark.png")
+		if _, err := os.Stat(mascotDarkPath); err == nil {
+			router.StaticFile("/harlequin_mascot_dark.png", mascotDarkPath)
+		}
 	}
 
 	// Test page route - serve React app directly
@@ -135,6 +227,9 @@ func (s *Server) StartWithTemplates(ctx context.Context, templatePath string) er
 	// Status endpoint
 	router.GET("/status", s.HandleGetStatus)
 
+	// Prometheus metrics
+	router.GET("/metrics", s.metrics.Handler())
+
 	// API documentation
 	router.GET("/api-docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
@@ -147,16 +242,28 @@ func (s *Server) StartWithTemplates(ctx context.Context, templatePath string) er
 
 	s.isRunning = true
 
-	log.Printf("🎭 Remote Signing Server starting on %s", addr)
+	useTLS := s.config.TLSCertFile != "" && s.config.TLSKeyFile != ""
+	scheme, wsScheme := "http", "ws"
+	if useTLS {
+		scheme, wsScheme = "https", "wss"
+	}
+
+	s.logger.Info("remote signing server starting", "addr", addr, "tls", useTLS)
 	if templatePath != "" {
-		log.Printf("📝 Signing interface available at: http://%s/sign/<uuid>", addr)
+		s.logger.Info("signing interface available", "url", fmt.Sprintf("%s://%s/sign/<uuid>", scheme, addr))
 	}
-	log.Printf("🔌 WebSocket endpoint: ws://%s/ws", addr)
+	s.logger.Info("websocket endpoint ready", "url", fmt.Sprintf("%s://%s/ws", wsScheme, addr))
 
 	// Start server in goroutine
 	go func() {
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Printf("Server failed to start: %v", err)
+		var err error
+		if useTLS {
+			err = s.server.ListenAndServeTLS(s.config.TLSCertFile, s.config.TLSKeyFile)
+		} else {
+			err = s.server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			s.logger.Error("server failed to start", "error", err)
 		}
 	}()
 
@@ -171,7 +278,7 @@ func (s *Server) Stop() error {
 		return fmt.Errorf("server is not running")
 	}
 
-	log.Println("🛑 Shutting down Remote Signing Server...")
+	s.logger.Info("shutting down remote signing server")
 
 	// Create a timeout context for graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -179,12 +286,17 @@ func (s *Server) Stop() error {
 
 	// Gracefully shutdown the HTTP server
 	if err := s.server.Shutdown(ctx); err != nil {
-		log.Printf("Server forced to shutdown: %v", err)
+		s.logger.Error("server forced to shutdown", "error", err)
 		return err
 	}
 
+	// Close the storage backend
+	if err := s.store.Close(); err != nil {
+		s.logger.Error("failed to close storage", "error", err)
+	}
+
 	s.isRunning = false
-	log.Println("✅ Remote Signing Server stopped")
+	s.logger.Info("remote signing server stopped")
 	return nil
 }
 
@@ -200,23 +312,17 @@ func (s *Server) GetConfig() *Config {
 
 // GetSigningRequest returns a signing request by UUID
 func (s *Server) GetSigningRequest(uuid string) (*SigningRequest, bool) {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-	req, exists := s.signingRequests[uuid]
-	return req, exists
+	return s.store.GetRequest(uuid)
 }
 
 // ListSigningRequests returns all signing requests
 func (s *Server) ListSigningRequests() map[string]*SigningRequest {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
+	return s.store.ListRequests()
+}
 
-	// Return a copy to avoid concurrent access issues
-	result := make(map[string]*SigningRequest)
-	for k, v := range s.signingRequests {
-		result[k] = v
-	}
-	return result
+// GetStorage returns the storage backend for external use
+func (s *Server) GetStorage() Storage {
+	return s.store
 }
 
 // GetWebSocketHub returns the WebSocket hub for external use
@@ -1,11 +1,15 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -129,3 +133,281 @@ func TestConfig(t *testing.T) {
 		// Note: We could add validation if needed
 	})
 }
+
+func TestStatusAndHealthPayloads(t *testing.T) {
+	config := DefaultConfig()
+	config.BundlerURL = "turbo"
+	srv := New(config)
+	srv.startTime = time.Now().Add(-time.Minute)
+
+	t.Run("StatusReportsStorageAndLimits", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Request = httptest.NewRequest(http.MethodGet, "/status", nil)
+
+		srv.HandleGetStatus(c)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var status struct {
+			Server struct {
+				Status  string `json:"status"`
+				Uptime  string `json:"uptime"`
+				Version string `json:"version"`
+			} `json:"server"`
+			Storage struct {
+				Backend string `json:"backend"`
+			} `json:"storage"`
+			Config struct {
+				MaxDataSize    int64  `json:"max_data_size"`
+				SigningTimeout string `json:"signing_timeout"`
+				MaxWSClients   int    `json:"max_ws_clients"`
+				MaxSSEClients  int    `json:"max_sse_clients"`
+				BundlerURL     string `json:"bundler_url"`
+			} `json:"config"`
+		}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+
+		assert.Equal(t, "running", status.Server.Status)
+		assert.Equal(t, Version, status.Server.Version)
+		assert.NotEmpty(t, status.Server.Uptime)
+		assert.Equal(t, "in-memory", status.Storage.Backend)
+		assert.Equal(t, int64(10*1024*1024), status.Config.MaxDataSize)
+		assert.Equal(t, "30m0s", status.Config.SigningTimeout)
+		assert.Equal(t, 1024, status.Config.MaxWSClients)
+		assert.Equal(t, 1024, status.Config.MaxSSEClients)
+		assert.Equal(t, "turbo", status.Config.BundlerURL)
+	})
+
+	t.Run("HealthReportsVersionAndUptime", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Request = httptest.NewRequest(http.MethodGet, "/health", nil)
+
+		srv.HandleHealth(c)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var health struct {
+			Status  string `json:"status"`
+			Uptime  string `json:"uptime"`
+			Version string `json:"version"`
+		}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &health))
+
+		assert.Equal(t, "healthy", health.Status)
+		assert.Equal(t, Version, health.Version)
+		assert.NotEmpty(t, health.Uptime)
+	})
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	limiter := newRateLimiter(2, time.Minute)
+	assert.True(t, limiter.Allow("a"))
+	assert.True(t, limiter.Allow("a"))
+	assert.False(t, limiter.Allow("a"), "third event within the window should be rejected")
+	assert.True(t, limiter.Allow("b"), "limits are tracked per key")
+
+	unlimited := newRateLimiter(0, time.Minute)
+	for i := 0; i < 100; i++ {
+		assert.True(t, unlimited.Allow("a"))
+	}
+}
+
+// submitTestData drives HandleSubmitData with a raw binary submission
+func submitTestData(srv *Server, clientID string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	target := "/"
+	if clientID != "" {
+		target = "/?client_id=" + clientID
+	}
+	c.Request = httptest.NewRequest(http.MethodPost, target, bytes.NewReader([]byte("data")))
+	srv.HandleSubmitData(c)
+	return recorder
+}
+
+func TestPerIPRateLimit(t *testing.T) {
+	config := DefaultConfig()
+	config.RateLimitPerIP = 1
+	srv := New(config)
+
+	middleware := srv.rateLimitMiddleware()
+	for i, expected := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+		middleware(c)
+		assert.Equal(t, expected, recorder.Code, "request %d", i+1)
+	}
+}
+
+func TestPerClientRateLimit(t *testing.T) {
+	config := DefaultConfig()
+	config.RateLimitPerClient = 1
+	srv := New(config)
+
+	assert.Equal(t, http.StatusCreated, submitTestData(srv, "builder-1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, submitTestData(srv, "builder-1").Code)
+	assert.Equal(t, http.StatusCreated, submitTestData(srv, "builder-2").Code,
+		"other client IDs are not affected")
+	assert.Equal(t, http.StatusCreated, submitTestData(srv, "").Code,
+		"submissions without a client ID are not rate limited per client")
+}
+
+func TestMaxPendingRequests(t *testing.T) {
+	t.Run("RejectPolicy", func(t *testing.T) {
+		config := DefaultConfig()
+		config.MaxPendingRequests = 2
+		srv := New(config)
+
+		assert.Equal(t, http.StatusCreated, submitTestData(srv, "").Code)
+		assert.Equal(t, http.StatusCreated, submitTestData(srv, "").Code)
+
+		recorder := submitTestData(srv, "")
+		assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "Too many pending signing requests")
+		assert.Len(t, srv.ListSigningRequests(), 2)
+	})
+
+	t.Run("EvictOldestPolicy", func(t *testing.T) {
+		config := DefaultConfig()
+		config.MaxPendingRequests = 2
+		config.PendingOverflowPolicy = PendingPolicyEvictOldest
+		srv := New(config)
+
+		var first struct {
+			UUID string `json:"uuid"`
+		}
+		require.NoError(t, json.Unmarshal(submitTestData(srv, "").Body.Bytes(), &first))
+		time.Sleep(5 * time.Millisecond) // Ensure a distinct CreatedAt ordering
+		assert.Equal(t, http.StatusCreated, submitTestData(srv, "").Code)
+
+		assert.Equal(t, http.StatusCreated, submitTestData(srv, "").Code,
+			"submissions at the cap succeed under evict-oldest")
+		assert.Len(t, srv.ListSigningRequests(), 2)
+
+		_, exists := srv.GetSigningRequest(first.UUID)
+		assert.False(t, exists, "the oldest pending request should have been evicted")
+	})
+}
+
+// requestWithUUID drives a UUID-parameterised handler against a test context
+func requestWithUUID(srv *Server, handler func(*gin.Context), method, uuid string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(method, "/"+uuid, nil)
+	c.Params = gin.Params{{Key: "uuid", Value: uuid}}
+	handler(c)
+	return recorder
+}
+
+func TestCancelSigningRequest(t *testing.T) {
+	srv := New(nil)
+
+	var submitted struct {
+		UUID string `json:"uuid"`
+	}
+	require.NoError(t, json.Unmarshal(submitTestData(srv, "").Body.Bytes(), &submitted))
+
+	recorder := requestWithUUID(srv, srv.HandleCancelRequest, http.MethodDelete, submitted.UUID)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	_, exists := srv.GetSigningRequest(submitted.UUID)
+	assert.False(t, exists, "cancelled request should be removed")
+
+	recorder = requestWithUUID(srv, srv.HandleCancelRequest, http.MethodDelete, submitted.UUID)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "cancelling twice reports not found")
+
+	t.Run("SignedRequestsCannotBeCancelled", func(t *testing.T) {
+		require.NoError(t, json.Unmarshal(submitTestData(srv, "").Body.Bytes(), &submitted))
+
+		request, exists := srv.GetSigningRequest(submitted.UUID)
+		require.True(t, exists)
+		request.IsSigned = true
+		require.NoError(t, srv.store.SaveRequest(request))
+
+		recorder := requestWithUUID(srv, srv.HandleCancelRequest, http.MethodDelete, submitted.UUID)
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+	})
+}
+
+func TestExpiredRequestsReturnGone(t *testing.T) {
+	config := DefaultConfig()
+	config.SigningTimeout = 30 * time.Millisecond
+	srv := New(config)
+	srv.startTime = time.Now()
+
+	var submitted struct {
+		UUID string `json:"uuid"`
+	}
+	require.NoError(t, json.Unmarshal(submitTestData(srv, "").Body.Bytes(), &submitted))
+
+	// Wait for the expiration timer to fire
+	require.Eventually(t, func() bool {
+		return srv.isExpired(submitted.UUID)
+	}, time.Second, 10*time.Millisecond)
+
+	recorder := requestWithUUID(srv, srv.HandleGetData, http.MethodGet, submitted.UUID)
+	assert.Equal(t, http.StatusGone, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "expired")
+
+	recorder = requestWithUUID(srv, srv.HandleGetSignedData, http.MethodGet, submitted.UUID)
+	assert.Equal(t, http.StatusGone, recorder.Code)
+
+	// Expired requests are counted in the status payload
+	statusRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(statusRecorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/status", nil)
+	srv.HandleGetStatus(c)
+
+	var status struct {
+		Requests struct {
+			Pending int `json:"pending"`
+			Expired int `json:"expired"`
+		} `json:"requests"`
+	}
+	require.NoError(t, json.Unmarshal(statusRecorder.Body.Bytes(), &status))
+	assert.Equal(t, 0, status.Requests.Pending)
+	assert.Equal(t, 1, status.Requests.Expired)
+}
+
+func TestStorageBackendName(t *testing.T) {
+	assert.Equal(t, "in-memory", StorageBackendName(NewMemoryStorage()))
+	assert.Equal(t, "boltdb", StorageBackendName(&BoltStorage{}))
+}
+
+func TestRequestBaseURL(t *testing.T) {
+	t.Run("ListenAddress", func(t *testing.T) {
+		srv := New(nil)
+		assert.Equal(t, "http://localhost:8080", srv.requestBaseURL(nil))
+	})
+
+	t.Run("TLSUsesHTTPS", func(t *testing.T) {
+		config := DefaultConfig()
+		config.TLSCertFile = "cert.pem"
+		config.TLSKeyFile = "key.pem"
+		srv := New(config)
+		assert.Equal(t, "https://localhost:8080", srv.requestBaseURL(nil))
+		assert.Equal(t, "wss://localhost:8080/ws", srv.getWebSocketURL())
+	})
+
+	t.Run("PublicURLOverrides", func(t *testing.T) {
+		config := DefaultConfig()
+		config.PublicURL = "https://sign.example.com/"
+		srv := New(config)
+		assert.Equal(t, "https://sign.example.com", srv.requestBaseURL(nil))
+	})
+
+	t.Run("ForwardedHeaders", func(t *testing.T) {
+		srv := New(nil)
+
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Request.Header.Set("X-Forwarded-Host", "sign.example.com")
+		c.Request.Header.Set("X-Forwarded-Proto", "https")
+		assert.Equal(t, "https://sign.example.com", srv.requestBaseURL(c))
+
+		// Proto defaults to http when the proxy only forwards the host
+		c.Request.Header.Del("X-Forwarded-Proto")
+		assert.Equal(t, "http://sign.example.com", srv.requestBaseURL(c))
+	})
+}
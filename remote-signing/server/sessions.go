@@ -0,0 +1,245 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// HandleSubmitSession handles POST /session - submits a batch of data items
+// under one session UUID so they can all be signed in a single browser visit
+// @Summary Submit a signing session
+// @Description Submit multiple data items for signing under one session UUID. Returns per-item UUIDs and a single signing URL.
+// @Tags Signing
+// @Accept json
+// @Produce json
+// @Param request body SubmitSessionRequest true "Session submission request"
+// @Success 201 {object} SubmitSessionResponse "Session submitted successfully"
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 413 {object} ErrorResponse "Data too large"
+// @Router /session [post]
+func (s *Server) HandleSubmitSession(c *gin.Context) {
+	var req SubmitSessionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid JSON payload",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if len(req.Items) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Session must contain at least one item",
+		})
+		return
+	}
+
+	// Validate each item before storing anything
+	for _, item := range req.Items {
+		if len(item.Data) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Session item has empty data",
+				"name":  item.Name,
+			})
+			return
+		}
+		if int64(len(item.Data)) > s.config.MaxDataSize {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error":    "Data too large",
+				"name":     item.Name,
+				"max_size": s.config.MaxDataSize,
+			})
+			return
+		}
+	}
+
+	// Enforce the per-client rate limit and the pending request cap for the
+	// whole session before storing any item
+	if !s.allowClient(c, req.ClientID) || !s.reservePending(c, len(req.Items)) {
+		return
+	}
+
+	sessionUUID := uuid.New().String()
+	now := time.Now()
+	itemStatuses := make([]SessionItemStatus, 0, len(req.Items))
+
+	for _, item := range req.Items {
+		itemUUID := uuid.New().String()
+
+		signingRequest := &SigningRequest{
+			UUID:        itemUUID,
+			Data:        item.Data,
+			CreatedAt:   now,
+			IsSigned:    false,
+			RequestedAt: now,
+			ClientID:    req.ClientID,
+			CallbackURL: req.CallbackURL,
+			SessionUUID: sessionUUID,
+			ItemName:    item.Name,
+		}
+
+		s.mutex.Lock()
+		err := s.store.SaveRequest(signingRequest)
+		s.mutex.Unlock()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to store signing request",
+			})
+			return
+		}
+
+		go s.setExpirationTimer(itemUUID)
+
+		s.metrics.RequestsSubmitted.Inc()
+
+		itemStatuses = append(itemStatuses, SessionItemStatus{
+			UUID:     itemUUID,
+			Name:     item.Name,
+			IsSigned: false,
+		})
+	}
+
+	// Notify listeners on the session UUID that the session is pending
+	s.hub.BroadcastToUUID(sessionUUID, WebSocketMessage{
+		Type: MessageTypeStatus,
+		UUID: sessionUUID,
+		Payload: map[string]interface{}{
+			"status":     "pending",
+			"message":    "Session submitted for signing",
+			"created_at": now,
+			"total":      len(itemStatuses),
+		},
+	})
+
+	c.JSON(http.StatusCreated, SubmitSessionResponse{
+		SessionUUID: sessionUUID,
+		Items:       itemStatuses,
+		SigningURL:  s.generateSessionSigningURL(c, sessionUUID),
+		Message:     "Session submitted successfully. Use the signing URL to sign all items.",
+	})
+}
+
+// HandleGetSession handles GET /session/<uuid> - returns the signing status of
+// a session so the frontend can fetch and sign each item in turn
+// @Summary Get session status
+// @Description Get the signing status of all items in a session
+// @Tags Signing
+// @Produce json
+// @Param uuid path string true "Session UUID"
+// @Success 200 {object} SessionStatusResponse "Current session status"
+// @Failure 404 {object} ErrorResponse "Session not found"
+// @Router /session/{uuid} [get]
+func (s *Server) HandleGetSession(c *gin.Context) {
+	sessionUUID := c.Param("uuid")
+
+	// Validate UUID format
+	if _, err := uuid.Parse(sessionUUID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid UUID format",
+		})
+		return
+	}
+
+	items := s.sessionItems(sessionUUID)
+	if len(items) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Session not found",
+			"uuid":  sessionUUID,
+		})
+		return
+	}
+
+	itemStatuses := make([]SessionItemStatus, 0, len(items))
+	signed := 0
+	for _, item := range items {
+		if item.IsSigned {
+			signed++
+		}
+		itemStatuses = append(itemStatuses, SessionItemStatus{
+			UUID:     item.UUID,
+			Name:     item.ItemName,
+			IsSigned: item.IsSigned,
+		})
+	}
+
+	c.JSON(http.StatusOK, SessionStatusResponse{
+		SessionUUID: sessionUUID,
+		Items:       itemStatuses,
+		Total:       len(items),
+		Signed:      signed,
+		Complete:    signed == len(items),
+	})
+}
+
+// sessionItems returns all signing requests belonging to a session, ordered by
+// item name for deterministic output
+func (s *Server) sessionItems(sessionUUID string) []*SigningRequest {
+	s.mutex.RLock()
+	requests := s.store.ListRequests()
+	s.mutex.RUnlock()
+
+	items := make([]*SigningRequest, 0)
+	for _, req := range requests {
+		if req.SessionUUID == sessionUUID {
+			items = append(items, req)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ItemName < items[j].ItemName
+	})
+	return items
+}
+
+// notifySessionProgress broadcasts session-level SSE events after one of the
+// session's items has been signed, including a final "session_signed" event
+// once every item is signed
+func (s *Server) notifySessionProgress(sessionUUID string, signedItem *SigningRequest) {
+	items := s.sessionItems(sessionUUID)
+	signed := 0
+	for _, item := range items {
+		if item.IsSigned {
+			signed++
+		}
+	}
+
+	s.hub.BroadcastSSEToUUID(sessionUUID, SSEEvent{
+		Type: "item_signed",
+		Data: map[string]interface{}{
+			"session_uuid": sessionUUID,
+			"uuid":         signedItem.UUID,
+			"name":         signedItem.ItemName,
+			"signed":       signed,
+			"total":        len(items),
+		},
+	})
+
+	if signed == len(items) {
+		s.hub.BroadcastSSEToUUID(sessionUUID, SSEEvent{
+			Type: "signed",
+			Data: map[string]interface{}{
+				"session_uuid": sessionUUID,
+				"signed_at":    time.Now(),
+				"success":      true,
+				"total":        len(items),
+			},
+		})
+	}
+}
+
+// generateSessionSigningURL builds the signing URL for a session, flagged so
+// the frontend loads the multi-item signing flow
+func (s *Server) generateSessionSigningURL(c *gin.Context, sessionUUID string) string {
+	serverURL := s.requestBaseURL(c)
+	hostURL := serverURL
+	if s.config.FrontendURL != "" {
+		hostURL = s.config.FrontendURL
+	}
+
+	return hostURL + "/sign/" + sessionUUID + "?server=" + serverURL + "&session=true"
+}
@@ -1,7 +1,6 @@
 package server
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"crypto/rand"
@@ -11,12 +10,12 @@ import (
 	"net/http"
 	"os/exec"
 	"runtime"
-	"strings"
 	"time"
 
 	"github.com/everFinance/goar/types"
 	"github.com/everFinance/goar/utils"
 	"github.com/gin-gonic/gin"
+	signingclient "github.com/the-permaweb-harlequin/harlequin-toolkit/remote-signing/client"
 )
 
 // UploadRequest represents a request to upload and sign data
@@ -61,8 +60,20 @@ func NewSigningServer(config *Config) *SigningServer {
 	}
 }
 
-// Upload uploads data for signing and waits for completion
-func (ss *SigningServer) Upload(req *UploadRequest) (*UploadResult, error) {
+// SignResult represents the outcome of a signing flow before any bundler upload
+type SignResult struct {
+	UUID            string    // Request UUID
+	DataItemID      string    // Arweave DataItem ID
+	SigningURL      string    // URL for signing interface
+	SignedData      []byte    // Raw signed DataItem bytes
+	SignedAt        time.Time // When the item was signed
+	BundlerResponse string    // Receipt from the server's direct bundler submission, if any
+}
+
+// Sign uploads data for signing and waits for the wallet signature, returning
+// the signed DataItem bytes without uploading them anywhere (unless the server
+// is configured to submit to a bundler directly)
+func (ss *SigningServer) Sign(req *UploadRequest) (*SignResult, error) {
 	// Ensure server is running
 	if err := ss.ensureServerRunning(); err != nil {
 		return nil, fmt.Errorf("failed to start server: %w", err)
@@ -88,7 +99,7 @@ func (ss *SigningServer) Upload(req *UploadRequest) (*UploadResult, error) {
 	}
 
 	// Wait for signing completion
-	signedData, err := ss.waitForSigning(uploadResp.UUID)
+	signedData, bundlerResponse, err := ss.waitForSigning(uploadResp.UUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to wait for signing: %w", err)
 	}
@@ -99,22 +110,42 @@ func (ss *SigningServer) Upload(req *UploadRequest) (*UploadResult, error) {
 		return nil, fmt.Errorf("failed to extract DataItem ID: %w", err)
 	}
 
-	// Upload to bundler
-	bundlerResponse, err := ss.uploadToBundler(signedData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to upload to bundler: %w", err)
-	}
-
-	return &UploadResult{
+	return &SignResult{
 		UUID:            uploadResp.UUID,
 		DataItemID:      dataItemID,
 		SigningURL:      uploadResp.SigningURL,
-		TransactionID:   dataItemID, // Same as DataItem ID for now
+		SignedData:      signedData,
 		SignedAt:        time.Now(),
 		BundlerResponse: bundlerResponse,
 	}, nil
 }
 
+// Upload uploads data for signing and waits for completion
+func (ss *SigningServer) Upload(req *UploadRequest) (*UploadResult, error) {
+	signResult, err := ss.Sign(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Upload to bundler unless the server already submitted it directly
+	bundlerResponse := signResult.BundlerResponse
+	if bundlerResponse == "" {
+		bundlerResponse, err = ss.uploadToBundler(signResult.SignedData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload to bundler: %w", err)
+		}
+	}
+
+	return &UploadResult{
+		UUID:            signResult.UUID,
+		DataItemID:      signResult.DataItemID,
+		SigningURL:      signResult.SigningURL,
+		TransactionID:   signResult.DataItemID, // Same as DataItem ID for now
+		SignedAt:        signResult.SignedAt,
+		BundlerResponse: bundlerResponse,
+	}, nil
+}
+
 // Close stops the signing server
 func (ss *SigningServer) Close() error {
 	if ss.cancel != nil {
@@ -255,53 +286,35 @@ func (ss *SigningServer) openBrowser(url string) error {
 }
 
 // waitForSigning waits for the signing to complete and returns the signed data
-func (ss *SigningServer) waitForSigning(uuid string) ([]byte, error) {
+// along with the bundler receipt from the signed-event payload, if the server
+// submitted the item directly
+func (ss *SigningServer) waitForSigning(uuid string) ([]byte, string, error) {
 	serverURL := fmt.Sprintf("http://%s:%d", ss.config.Host, ss.config.Port)
+	sdk := signingclient.New(serverURL)
 
-	// Connect to SSE stream
-	sseURL := fmt.Sprintf("%s/events/%s", serverURL, uuid)
-	resp, err := http.Get(sseURL)
+	ctx, cancel := context.WithTimeout(ss.ctx, ss.config.SigningTimeout)
+	defer cancel()
+
+	event, err := sdk.WaitForEvent(ctx, uuid, "signed")
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to SSE stream: %w", err)
+		return nil, "", fmt.Errorf("signing timeout or connection lost: %w", err)
 	}
-	defer resp.Body.Close()
 
-	// Parse SSE stream
-	scanner := bufio.NewScanner(resp.Body)
-	var currentEvent, currentData string
-
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		if strings.HasPrefix(line, "event:") {
-			currentEvent = strings.TrimPrefix(line, "event:")
-		} else if strings.HasPrefix(line, "data:") {
-			currentData = strings.TrimPrefix(line, "data:")
-		} else if line == "" && currentEvent != "" && currentData != "" {
-			// Process complete event
-			if currentEvent == "signed" {
-				// Fetch signed data from separate endpoint
-				signedDataURL := fmt.Sprintf("%s/signed/%s", serverURL, uuid)
-				signedResp, err := http.Get(signedDataURL)
-				if err != nil {
-					return nil, fmt.Errorf("failed to fetch signed data: %w", err)
-				}
-				defer signedResp.Body.Close()
-
-				signedData, err := io.ReadAll(signedResp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read signed data: %w", err)
-				}
-
-				return signedData, nil
-			}
-
-			currentEvent = ""
-			currentData = ""
-		}
+	// Extract the bundler receipt if the server submitted directly
+	var payload struct {
+		Bundler json.RawMessage `json:"bundler"`
+	}
+	bundlerResponse := ""
+	if err := json.Unmarshal(event.Data, &payload); err == nil && len(payload.Bundler) > 0 {
+		bundlerResponse = string(payload.Bundler)
 	}
 
-	return nil, fmt.Errorf("signing timeout or connection lost")
+	signedData, err := sdk.FetchSigned(ctx, uuid)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch signed data: %w", err)
+	}
+
+	return signedData, bundlerResponse, nil
 }
 
 // extractDataItemID extracts the DataItem ID from signed binary data
@@ -318,30 +331,23 @@ func (ss *SigningServer) extractDataItemID(signedData []byte) (string, error) {
 	return dataItem.Id, nil
 }
 
-// uploadToBundler uploads the signed DataItem to the ArDrive bundler
+// uploadToBundler uploads the signed DataItem to the configured bundler via
+// the Turbo client and returns the receipt as JSON
 func (ss *SigningServer) uploadToBundler(signedData []byte) (string, error) {
-	bundlerURL := "https://upload.ardrive.io/tx"
-
-	req, err := http.NewRequest("POST", bundlerURL, bytes.NewReader(signedData))
-	if err != nil {
-		return "", fmt.Errorf("failed to create bundler request: %w", err)
+	target := ss.config.BundlerURL
+	if target == "" {
+		target = "turbo"
 	}
 
-	req.Header.Set("Content-Type", "application/octet-stream")
-	req.Header.Set("Content-Length", fmt.Sprintf("%d", len(signedData)))
-
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	receipt, err := submitToBundler(ss.ctx, target, signedData)
 	if err != nil {
 		return "", fmt.Errorf("failed to upload to bundler: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("bundler upload failed (HTTP %d): %s", resp.StatusCode, string(body))
+	receiptJSON, err := json.Marshal(receipt)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal bundler receipt: %w", err)
 	}
 
-	return string(body), nil
+	return string(receiptJSON), nil
 }
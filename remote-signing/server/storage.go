@@ -0,0 +1,189 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// signingRequestsBucket is the BoltDB bucket holding signing requests keyed by UUID
+var signingRequestsBucket = []byte("signing_requests")
+
+// Storage persists signing requests, signed blobs, and audit timestamps.
+// Implementations must be safe for concurrent use.
+type Storage interface {
+	// SaveRequest creates or updates a signing request
+	SaveRequest(req *SigningRequest) error
+	// GetRequest returns a signing request by UUID
+	GetRequest(uuid string) (*SigningRequest, bool)
+	// ListRequests returns all signing requests keyed by UUID
+	ListRequests() map[string]*SigningRequest
+	// DeleteRequest removes a signing request
+	DeleteRequest(uuid string) error
+	// Close releases any resources held by the storage backend
+	Close() error
+}
+
+// StorageBackendName returns the human-readable name of a storage backend,
+// as reported by the /status endpoint
+func StorageBackendName(store Storage) string {
+	switch store.(type) {
+	case *MemoryStorage:
+		return "in-memory"
+	case *BoltStorage:
+		return "boltdb"
+	default:
+		return fmt.Sprintf("%T", store)
+	}
+}
+
+// NewStorage creates the storage backend selected by the configuration:
+// a Bolt-backed store when StoragePath is set, in-memory otherwise.
+func NewStorage(config *Config) (Storage, error) {
+	if config.StoragePath == "" {
+		return NewMemoryStorage(), nil
+	}
+	return NewBoltStorage(config.StoragePath)
+}
+
+// MemoryStorage keeps signing requests in memory; a server restart loses them
+type MemoryStorage struct {
+	mutex    sync.RWMutex
+	requests map[string]*SigningRequest
+}
+
+// NewMemoryStorage creates an empty in-memory storage backend
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		requests: make(map[string]*SigningRequest),
+	}
+}
+
+func (ms *MemoryStorage) SaveRequest(req *SigningRequest) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+	ms.requests[req.UUID] = req
+	return nil
+}
+
+func (ms *MemoryStorage) GetRequest(uuid string) (*SigningRequest, bool) {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+	req, exists := ms.requests[uuid]
+	return req, exists
+}
+
+func (ms *MemoryStorage) ListRequests() map[string]*SigningRequest {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+
+	// Return a copy to avoid concurrent access issues
+	result := make(map[string]*SigningRequest, len(ms.requests))
+	for k, v := range ms.requests {
+		result[k] = v
+	}
+	return result
+}
+
+func (ms *MemoryStorage) DeleteRequest(uuid string) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+	delete(ms.requests, uuid)
+	return nil
+}
+
+func (ms *MemoryStorage) Close() error {
+	return nil
+}
+
+// BoltStorage persists signing requests in an embedded BoltDB file so pending
+// UUIDs survive a server restart
+type BoltStorage struct {
+	db *bolt.DB
+}
+
+// NewBoltStorage opens (or creates) a BoltDB file at the given path
+func NewBoltStorage(path string) (*BoltStorage, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open storage at %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(signingRequestsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create storage bucket: %w", err)
+	}
+
+	return &BoltStorage{db: db}, nil
+}
+
+func (bs *BoltStorage) SaveRequest(req *SigningRequest) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal signing request: %w", err)
+	}
+
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(signingRequestsBucket).Put([]byte(req.UUID), data)
+	})
+}
+
+func (bs *BoltStorage) GetRequest(uuid string) (*SigningRequest, bool) {
+	var req *SigningRequest
+
+	_ = bs.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(signingRequestsBucket).Get([]byte(uuid))
+		if data == nil {
+			return nil
+		}
+
+		var decoded SigningRequest
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return err
+		}
+		req = &decoded
+		return nil
+	})
+
+	return req, req != nil
+}
+
+func (bs *BoltStorage) ListRequests() map[string]*SigningRequest {
+	result := make(map[string]*SigningRequest)
+
+	_ = bs.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(signingRequestsBucket).ForEach(func(k, v []byte) error {
+			var decoded SigningRequest
+			if err := json.Unmarshal(v, &decoded); err != nil {
+				return nil // Skip corrupt entries rather than failing the listing
+			}
+			result[string(k)] = &decoded
+			return nil
+		})
+	})
+
+	return result
+}
+
+func (bs *BoltStorage) DeleteRequest(uuid string) error {
+	return bs.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(signingRequestsBucket).Delete([]byte(uuid))
+	})
+}
+
+func (bs *BoltStorage) Close() error {
+	return bs.db.Close()
+}
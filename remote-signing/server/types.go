@@ -1,9 +1,12 @@
 package server
 
 import (
+	"encoding/json"
+	"log/slog"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // SigningRequest represents a request to sign raw data
@@ -13,9 +16,15 @@ type SigningRequest struct {
 	CreatedAt   time.Time `json:"created_at"`
 	IsSigned    bool      `json:"is_signed"`
 	SignedData  []byte    `json:"signed_data,omitempty"`
+	SignedAt    time.Time `json:"signed_at,omitempty"`
 	RequestedAt time.Time `json:"requested_at"`
 	ClientID    string    `json:"client_id"`
 	CallbackURL string    `json:"callback_url,omitempty"`
+	SessionUUID string    `json:"session_uuid,omitempty"` // Session this item belongs to (empty for standalone requests)
+	ItemName    string    `json:"item_name,omitempty"`    // Human-readable item name within a session (e.g. "process.wasm")
+	SignerAddress string  `json:"signer_address,omitempty"` // Wallet address that signed the item, for auditability
+
+	BundlerResponse json.RawMessage `json:"bundler_response,omitempty"` // Receipt from the bundler after direct submission
 }
 
 // SignedResponse represents a completed signing response
@@ -48,6 +57,8 @@ type WebSocketClient struct {
 const (
 	MessageTypeStatus    = "status"
 	MessageTypeSigned    = "signed"
+	MessageTypeExpired   = "expired"
+	MessageTypeCancelled = "cancelled"
 	MessageTypeError     = "error"
 	MessageTypeSubscribe = "subscribe"
 	MessageTypeHeartbeat = "heartbeat"
@@ -69,6 +80,22 @@ type Config struct {
 	SigningTimeout time.Duration `json:"signing_timeout"`
 	FrontendPath   string        `json:"frontend_path"` // Path to frontend assets
 	FrontendURL    string        `json:"frontend_url"`  // URL for the frontend (for static deployment)
+	StoragePath    string        `json:"storage_path"`  // Path to the embedded storage file (empty = in-memory)
+	BundlerURL     string        `json:"bundler_url"`   // Bundler target for direct submission: "turbo", "turbo-dev", or a custom gateway URL (empty = no direct submission)
+	CallbackSecret string        `json:"callback_secret"` // HMAC-SHA256 key for signing webhook payloads (empty = unsigned)
+	MaxWSClients   int           `json:"max_ws_clients"`  // Maximum concurrent WebSocket clients (0 = unlimited)
+	MaxSSEClients  int           `json:"max_sse_clients"` // Maximum concurrent SSE clients (0 = unlimited)
+	RateLimitPerIP     int    `json:"rate_limit_per_ip"`     // Maximum submissions per IP per minute (0 = unlimited)
+	RateLimitPerClient int    `json:"rate_limit_per_client"` // Maximum submissions per client ID per minute (0 = unlimited)
+	MaxPendingRequests int    `json:"max_pending_requests"`  // Cap on simultaneous pending signing requests (0 = unlimited)
+	PendingOverflowPolicy string `json:"pending_overflow_policy"` // What to do at the pending cap: "reject" (429, default) or "evict-oldest"
+	TLSCertFile    string        `json:"tls_cert_file"`   // TLS certificate for serving HTTPS directly (requires tls_key_file)
+	TLSKeyFile     string        `json:"tls_key_file"`    // TLS private key for serving HTTPS directly
+	PublicURL      string        `json:"public_url"`      // Externally visible base URL for generated links when behind a proxy (e.g. https://sign.example.com)
+
+	// Observability hooks for embedding applications
+	Logger          *slog.Logger         `json:"-"` // Structured logger (default: JSON to stdout)
+	MetricsRegistry *prometheus.Registry `json:"-"` // Prometheus registry for the /metrics endpoint (default: a fresh registry)
 }
 
 // DefaultConfig returns the default server configuration
@@ -81,5 +108,17 @@ func DefaultConfig() *Config {
 		SigningTimeout: 30 * time.Minute,
 		FrontendPath:   "", // Will be auto-detected
 		FrontendURL:    "", // Empty by default (uses same host)
+		StoragePath:    "", // Empty by default (in-memory storage)
+		BundlerURL:     "", // Empty by default (clients submit to the bundler themselves)
+		CallbackSecret: "", // Empty by default (webhook payloads are unsigned)
+		MaxWSClients:   1024,
+		MaxSSEClients:  1024,
+		RateLimitPerIP:     0, // Unlimited by default
+		RateLimitPerClient: 0, // Unlimited by default
+		MaxPendingRequests: 0, // Unlimited by default
+		PendingOverflowPolicy: PendingPolicyReject,
+		TLSCertFile:    "", // Empty by default (plain HTTP)
+		TLSKeyFile:     "",
+		PublicURL:      "", // Empty by default (links use the listen host/port or proxy headers)
 	}
 }
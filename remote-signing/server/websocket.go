@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +13,20 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+// Connection timing and queue sizing for the notification hub
+const (
+	// writeWait is the deadline for a single write to a client
+	writeWait = 10 * time.Second
+	// pongWait is how long a client may stay silent before the read loop gives up
+	pongWait = 60 * time.Second
+	// pingPeriod is how often pings are sent; must be shorter than pongWait
+	pingPeriod = 54 * time.Second
+	// wsClientQueueSize is the per-client WebSocket message queue depth
+	wsClientQueueSize = 256
+	// sseClientQueueSize is the per-client SSE event queue depth
+	sseClientQueueSize = 64
+)
+
 // WebSocketHub manages WebSocket connections and message broadcasting
 type WebSocketHub struct {
 	clients    map[string]*WebSocketClient
@@ -21,6 +36,7 @@ type WebSocketHub struct {
 	broadcast  chan WebSocketMessage
 	sseEvents  chan SSEEvent
 	mutex      sync.RWMutex
+	dropped    atomic.Int64 // Messages dropped because a client queue was full
 }
 
 // NewWebSocketHub creates a new WebSocket hub
@@ -71,7 +87,7 @@ func (h *WebSocketHub) Run() {
 			h.mutex.Unlock()
 
 		case message := <-h.broadcast:
-			h.mutex.RLock()
+			h.mutex.Lock()
 			for _, client := range h.clients {
 				// Check if client is interested in this UUID
 				if message.UUID != "" {
@@ -80,34 +96,85 @@ func (h *WebSocketHub) Run() {
 					}
 				}
 
-				select {
-				case client.Send <- message:
-				default:
+				if !h.enqueue(client.Send, message) {
+					// Queue not draining at all: the writer is gone, drop the client
 					close(client.Send)
 					delete(h.clients, client.ID)
 				}
 			}
-			h.mutex.RUnlock()
+			h.mutex.Unlock()
 
 		case event := <-h.sseEvents:
-			h.mutex.RLock()
+			h.mutex.Lock()
 			if clients, ok := h.sseClients[event.UUID]; ok {
 				for clientID, clientChan := range clients {
-					select {
-					case clientChan <- event:
-						// Event sent successfully
-					default:
-						// Client channel is full or closed, remove it
+					if !h.enqueueSSE(clientChan, event) {
+						// Client channel is not draining, remove it
 						delete(clients, clientID)
 						close(clientChan)
 					}
 				}
 			}
-			h.mutex.RUnlock()
+			h.mutex.Unlock()
 		}
 	}
 }
 
+// enqueue queues a message for one client without blocking the hub loop. When
+// the queue is full the oldest pending message is dropped in favor of the new
+// one, so a slow reader degrades to losing intermediate updates instead of
+// stalling broadcasts for everyone. It returns false only when the queue cannot
+// accept the message even after dropping, meaning the writer stopped draining.
+func (h *WebSocketHub) enqueue(send chan WebSocketMessage, message WebSocketMessage) bool {
+	select {
+	case send <- message:
+		return true
+	default:
+	}
+
+	select {
+	case <-send:
+		h.dropped.Add(1)
+	default:
+	}
+
+	select {
+	case send <- message:
+		return true
+	default:
+		return false
+	}
+}
+
+// enqueueSSE queues an event for one SSE client with the same drop-oldest
+// policy as enqueue
+func (h *WebSocketHub) enqueueSSE(send chan SSEEvent, event SSEEvent) bool {
+	select {
+	case send <- event:
+		return true
+	default:
+	}
+
+	select {
+	case <-send:
+		h.dropped.Add(1)
+	default:
+	}
+
+	select {
+	case send <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+// DroppedMessageCount returns how many notifications have been dropped because
+// a client queue was full
+func (h *WebSocketHub) DroppedMessageCount() int64 {
+	return h.dropped.Load()
+}
+
 // BroadcastToUUID sends a message to all clients subscribed to a specific UUID
 func (h *WebSocketHub) BroadcastToUUID(uuid string, message WebSocketMessage) {
 	message.UUID = uuid
@@ -153,13 +220,11 @@ func (h *WebSocketHub) UnregisterSSEClient(uuid, clientID string) {
 
 // BroadcastToClient sends a message to a specific client
 func (h *WebSocketHub) BroadcastToClient(clientID string, message WebSocketMessage) {
-	h.mutex.RLock()
-	defer h.mutex.RUnlock()
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
 
 	if client, ok := h.clients[clientID]; ok {
-		select {
-		case client.Send <- message:
-		default:
+		if !h.enqueue(client.Send, message) {
 			close(client.Send)
 			delete(h.clients, clientID)
 		}
@@ -173,6 +238,18 @@ func (h *WebSocketHub) GetClientCount() int {
 	return len(h.clients)
 }
 
+// GetSSEClientCount returns the number of connected SSE clients
+func (h *WebSocketHub) GetSSEClientCount() int {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	count := 0
+	for _, clients := range h.sseClients {
+		count += len(clients)
+	}
+	return count
+}
+
 // Upgrader configures the WebSocket upgrader
 var upgrader = websocket.Upgrader{
 	CheckOrigin: func(r *http.Request) bool {
@@ -190,6 +267,15 @@ var upgrader = websocket.Upgrader{
 // @Success 101 "WebSocket connection established"
 // @Router /ws [get]
 func (s *Server) HandleWebSocket(c *gin.Context) {
+	// Refuse new connections once the configured limit is reached, before the
+	// upgrade so the client gets an HTTP error it can act on
+	if s.config.MaxWSClients > 0 && s.hub.GetClientCount() >= s.config.MaxWSClients {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Too many concurrent WebSocket connections",
+		})
+		return
+	}
+
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		log.Printf("WebSocket upgrade failed: %v", err)
@@ -200,7 +286,7 @@ func (s *Server) HandleWebSocket(c *gin.Context) {
 	client := &WebSocketClient{
 		ID:       clientID,
 		Conn:     conn,
-		Send:     make(chan WebSocketMessage, 256),
+		Send:     make(chan WebSocketMessage, wsClientQueueSize),
 		ClientID: c.Query("client_id"),
 		UUIDs:    make(map[string]bool),
 	}
@@ -220,9 +306,9 @@ func (s *Server) readWebSocket(client *WebSocketClient) {
 	}()
 
 	client.Conn.SetReadLimit(1024 * 1024) // 1MB limit
-	client.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+	client.Conn.SetReadDeadline(time.Now().Add(pongWait))
 	client.Conn.SetPongHandler(func(string) error {
-		client.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
+		client.Conn.SetReadDeadline(time.Now().Add(pongWait))
 		return nil
 	})
 
@@ -245,20 +331,20 @@ func (s *Server) readWebSocket(client *WebSocketClient) {
 				log.Printf("Client %s subscribed to UUID %s", client.ID, message.UUID)
 			}
 		case MessageTypeHeartbeat:
-			// Respond to heartbeat
-			client.Send <- WebSocketMessage{
+			// Respond to heartbeat without blocking the read loop
+			s.hub.enqueue(client.Send, WebSocketMessage{
 				Type: MessageTypeHeartbeat,
 				Payload: map[string]string{
 					"status": "alive",
 				},
-			}
+			})
 		}
 	}
 }
 
 // writeWebSocket handles writing messages to WebSocket client
 func (s *Server) writeWebSocket(client *WebSocketClient) {
-	ticker := time.NewTicker(54 * time.Second)
+	ticker := time.NewTicker(pingPeriod)
 	defer func() {
 		ticker.Stop()
 		client.Conn.Close()
@@ -267,7 +353,7 @@ func (s *Server) writeWebSocket(client *WebSocketClient) {
 	for {
 		select {
 		case message, ok := <-client.Send:
-			client.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			client.Conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
 				client.Conn.WriteMessage(websocket.CloseMessage, []byte{})
 				return
@@ -290,7 +376,7 @@ func (s *Server) writeWebSocket(client *WebSocketClient) {
 			}
 
 		case <-ticker.C:
-			client.Conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+			client.Conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if err := client.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 				return
 			}